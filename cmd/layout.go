@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/vamika-digital/wms-api-server/config"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+var (
+	layoutSpecFile string
+	layoutDryRun   bool
+	layoutPreview  bool
+)
+
+var layoutCmd = &cobra.Command{
+	Use:   "layout",
+	Short: "Create the racks and bins of a store from a layout spec",
+	Long: `Create the racks and bins of a store from a JSON layout spec in one transaction.
+Racks and bins created by an earlier run are kept, so the command can be run
+again after the spec was extended.`,
+	Run: runLayout,
+}
+
+func init() {
+	layoutCmd.Flags().StringVarP(&layoutSpecFile, "spec", "s", "", "path of the JSON layout spec")
+	layoutCmd.Flags().BoolVar(&layoutDryRun, "dry-run", false, "check the layout against the database without writing anything")
+	layoutCmd.Flags().BoolVar(&layoutPreview, "preview", false, "only list the codes the spec expands to")
+	layoutCmd.MarkFlagRequired("spec")
+	rootCmd.AddCommand(layoutCmd)
+}
+
+func runLayout(cmd *cobra.Command, args []string) {
+	data, err := os.ReadFile(layoutSpecFile)
+	if err != nil {
+		log.Fatalf("Failed to read layout spec: %s", err)
+	}
+	var spec domain.LayoutSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		log.Fatalf("Invalid layout spec: %s", err)
+	}
+
+	var plan *domain.LayoutPlan
+	if layoutPreview {
+		if plan, err = spec.Plan(); err != nil {
+			log.Fatalf("Invalid layout spec: %s", err)
+		}
+	} else {
+		dbConn, err := database.NewMySQLConnection(config.AppConfig)
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %s", err)
+		}
+		containerUsecase := usecase.NewContainerUseCase(repository.NewContainerRepository(dbConn))
+		if plan, err = containerUsecase.ApplyLayout(&spec, layoutDryRun); err != nil {
+			log.Fatalf("Failed to apply layout: %s", err)
+		}
+	}
+
+	for _, rack := range plan.Racks {
+		printLayoutNode(rack, "")
+		for _, bin := range rack.Bins {
+			printLayoutNode(bin, "  ")
+		}
+	}
+	switch {
+	case layoutPreview:
+		fmt.Printf("%d racks, %d containers in total\n", len(plan.Racks), plan.Total)
+	case layoutDryRun:
+		fmt.Printf("Dry run: %d containers would be created, %d already exist\n", plan.Created, plan.Existing)
+	default:
+		fmt.Printf("%d containers created, %d already existed\n", plan.Created, plan.Existing)
+	}
+}
+
+func printLayoutNode(node *domain.LayoutNode, indent string) {
+	if node.Action == "" {
+		fmt.Printf("%s%s %s\n", indent, node.Type, node.Code)
+		return
+	}
+	fmt.Printf("%s%s %s %s\n", indent, node.Action, node.Type, node.Code)
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS inventory_import_rows;
+DROP TABLE IF EXISTS inventory_imports;
@@ -0,0 +1,37 @@
+CREATE TABLE inventory_imports (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    file_name VARCHAR(255) NOT NULL,
+    format VARCHAR(8) NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    total_rows INT NOT NULL DEFAULT 0,
+    validated_rows INT NOT NULL DEFAULT 0,
+    error_count INT NOT NULL DEFAULT 0,
+    message TEXT NOT NULL DEFAULT (''),
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP NULL,
+    KEY idx_inventory_imports_status (status)
+);
+
+CREATE TABLE inventory_import_rows (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    import_id BIGINT NOT NULL,
+    row_num INT NOT NULL,
+    product_code VARCHAR(255) NOT NULL DEFAULT '',
+    container_code VARCHAR(255) NOT NULL DEFAULT '',
+    container_type VARCHAR(32) NOT NULL DEFAULT '',
+    store VARCHAR(255) NOT NULL DEFAULT '',
+    batch VARCHAR(255) NOT NULL DEFAULT '',
+    quantity VARCHAR(64) NOT NULL DEFAULT '',
+    unit VARCHAR(32) NOT NULL DEFAULT '',
+    validated BOOLEAN NOT NULL DEFAULT FALSE,
+    error VARCHAR(512) NOT NULL DEFAULT '',
+    product_id BIGINT NULL,
+    store_id BIGINT NULL,
+    container_id BIGINT NULL,
+    qc_status VARCHAR(32) NOT NULL DEFAULT '',
+    UNIQUE KEY uq_inventory_import_rows_row (import_id, row_num),
+    KEY idx_inventory_import_rows_validated (import_id, validated, row_num),
+    CONSTRAINT fk_inventory_import_rows_import FOREIGN KEY (import_id) REFERENCES inventory_imports (id) ON DELETE CASCADE
+);
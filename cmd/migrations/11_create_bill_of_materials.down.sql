@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS production_consumptions;
+DROP TABLE IF EXISTS bom_lines;
@@ -0,0 +1,30 @@
+CREATE TABLE bom_lines (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    product_id BIGINT NOT NULL,
+    component_id BIGINT NOT NULL,
+    quantity_per DECIMAL(18,6) NOT NULL,
+    unit VARCHAR(32) NOT NULL DEFAULT '',
+    scrap_factor DECIMAL(18,6) NOT NULL DEFAULT 0,
+    effective_from DATETIME NULL,
+    effective_to DATETIME NULL,
+    KEY idx_bom_lines_product (product_id),
+    KEY idx_bom_lines_component (component_id)
+);
+
+CREATE TABLE production_consumptions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    produced_inventory_id BIGINT NOT NULL,
+    produced_batch VARCHAR(255) NOT NULL DEFAULT '',
+    component_id BIGINT NOT NULL,
+    inventory_id BIGINT NOT NULL,
+    batch VARCHAR(255) NOT NULL DEFAULT '',
+    store_id BIGINT NULL,
+    quantity DECIMAL(18,6) NOT NULL,
+    unit VARCHAR(32) NOT NULL DEFAULT '',
+    consumed_at DATETIME NOT NULL,
+    consumed_by VARCHAR(255) NOT NULL DEFAULT '',
+    KEY idx_production_consumptions_produced (produced_inventory_id),
+    KEY idx_production_consumptions_batch (produced_batch),
+    KEY idx_production_consumptions_component (component_id, consumed_at),
+    KEY idx_production_consumptions_inventory (inventory_id)
+);
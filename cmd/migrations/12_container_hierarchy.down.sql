@@ -0,0 +1,11 @@
+ALTER TABLE inventories
+    DROP KEY idx_inventories_container,
+    DROP COLUMN container_id;
+
+ALTER TABLE containers
+    DROP KEY idx_containers_path,
+    DROP KEY idx_containers_store,
+    DROP KEY idx_containers_parent,
+    DROP COLUMN path,
+    DROP COLUMN store_id,
+    DROP COLUMN parent_id;
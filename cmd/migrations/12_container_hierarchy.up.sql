@@ -0,0 +1,17 @@
+-- type is widened so that ZONE fits; the down migration keeps the wider column
+ALTER TABLE containers
+    MODIFY COLUMN type VARCHAR(32) NOT NULL,
+    ADD COLUMN parent_id BIGINT NULL AFTER type,
+    ADD COLUMN store_id BIGINT NULL AFTER parent_id,
+    ADD COLUMN path VARCHAR(255) NOT NULL DEFAULT '' AFTER store_id,
+    ADD KEY idx_containers_parent (parent_id),
+    ADD KEY idx_containers_store (store_id),
+    ADD KEY idx_containers_path (path);
+
+UPDATE containers SET path = CONCAT('/', id, '/');
+
+ALTER TABLE inventories
+    ADD COLUMN container_id BIGINT NULL AFTER qc_status,
+    ADD KEY idx_inventories_container (container_id);
+
+UPDATE inventories SET container_id = COALESCE(pallet_id, bin_id, rack_id);
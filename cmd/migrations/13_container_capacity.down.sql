@@ -0,0 +1,8 @@
+ALTER TABLE containers
+    DROP COLUMN max_units,
+    DROP COLUMN max_volume,
+    DROP COLUMN max_weight;
+
+ALTER TABLE products
+    DROP COLUMN volume,
+    DROP COLUMN weight;
@@ -0,0 +1,8 @@
+ALTER TABLE products
+    ADD COLUMN weight DECIMAL(18,6) NOT NULL DEFAULT 0 AFTER serial_pattern,
+    ADD COLUMN volume DECIMAL(18,6) NOT NULL DEFAULT 0 AFTER weight;
+
+ALTER TABLE containers
+    ADD COLUMN max_weight DECIMAL(18,6) NOT NULL DEFAULT 0 AFTER status,
+    ADD COLUMN max_volume DECIMAL(18,6) NOT NULL DEFAULT 0 AFTER max_weight,
+    ADD COLUMN max_units DECIMAL(18,6) NOT NULL DEFAULT 0 AFTER max_volume;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS number_series_counters;
+DROP TABLE IF EXISTS number_series;
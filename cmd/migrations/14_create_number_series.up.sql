@@ -0,0 +1,22 @@
+CREATE TABLE number_series (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    container_type VARCHAR(32) NOT NULL,
+    pattern VARCHAR(255) NOT NULL,
+    reset VARCHAR(16) NOT NULL DEFAULT 'NEVER',
+    status VARCHAR(32) NOT NULL DEFAULT 'active',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    last_updated_by VARCHAR(255),
+    version BIGINT NOT NULL DEFAULT 1,
+    UNIQUE KEY uq_number_series_type (container_type)
+);
+
+-- period is '' for series that never reset, 'YYYY' or 'YYYY-MM' otherwise;
+-- store_id is 0 unless the pattern numbers each store separately
+CREATE TABLE number_series_counters (
+    series_id BIGINT NOT NULL,
+    period VARCHAR(16) NOT NULL,
+    store_id BIGINT NOT NULL DEFAULT 0,
+    last_value BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (series_id, period, store_id)
+);
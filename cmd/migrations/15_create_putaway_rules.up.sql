@@ -0,0 +1,19 @@
+CREATE TABLE putaway_rules (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    priority INT NOT NULL DEFAULT 100,
+    product_id BIGINT NULL,
+    product_type VARCHAR(64) NULL,
+    store_id BIGINT NULL,
+    zone_id BIGINT NULL,
+    target_type VARCHAR(32) NOT NULL DEFAULT 'BIN',
+    allow_mixed_products BOOLEAN NOT NULL DEFAULT FALSE,
+    allow_mixed_batches BOOLEAN NOT NULL DEFAULT FALSE,
+    prefer_same_product BOOLEAN NOT NULL DEFAULT TRUE,
+    status VARCHAR(32) NOT NULL DEFAULT 'active',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    last_updated_by VARCHAR(255),
+    version BIGINT NOT NULL DEFAULT 1,
+    KEY idx_putaway_rules_status_priority (status, priority)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS container_status_history;
+
+ALTER TABLE containers MODIFY COLUMN status VARCHAR(32) NULL;
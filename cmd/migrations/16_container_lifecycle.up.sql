@@ -0,0 +1,20 @@
+-- Free-form statuses are mapped onto the lifecycle; anything unknown is
+-- taken to be in use
+UPDATE containers SET status = CASE
+    WHEN LOWER(status) IN ('active', 'damaged', 'under repair', 'retired') THEN LOWER(status)
+    WHEN LOWER(status) = 'inactive' THEN 'retired'
+    ELSE 'active'
+END;
+
+ALTER TABLE containers MODIFY COLUMN status VARCHAR(32) NOT NULL DEFAULT 'active';
+
+CREATE TABLE container_status_history (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    container_id BIGINT NOT NULL,
+    from_status VARCHAR(32) NOT NULL,
+    to_status VARCHAR(32) NOT NULL,
+    reason VARCHAR(512),
+    changed_by VARCHAR(255),
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_container_status_history_container (container_id, changed_at)
+);
@@ -0,0 +1,17 @@
+CREATE TABLE container_status_history (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    container_id BIGINT NOT NULL,
+    from_status VARCHAR(32) NOT NULL,
+    to_status VARCHAR(32) NOT NULL,
+    reason VARCHAR(512),
+    changed_by VARCHAR(255),
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_container_status_history_container (container_id, changed_at)
+);
+
+INSERT INTO container_status_history (container_id, from_status, to_status, reason, changed_by, changed_at)
+SELECT container_id, from_status, to_status, note, actor, occurred_at FROM container_events WHERE event = 'STATUS_CHANGED' ORDER BY occurred_at, id;
+
+DROP TABLE IF EXISTS container_events;
+
+ALTER TABLE inventory_movements DROP COLUMN container_id;
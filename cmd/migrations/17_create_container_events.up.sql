@@ -0,0 +1,39 @@
+ALTER TABLE inventory_movements ADD COLUMN container_id BIGINT NULL AFTER store_id;
+
+-- Only the current container of a lot is known, so it is set on the latest
+-- ledger entry, which later entries are compared with
+UPDATE inventory_movements m
+    JOIN (SELECT inventory_id, MAX(id) AS id FROM inventory_movements GROUP BY inventory_id) latest ON latest.id = m.id
+    JOIN inventories i ON i.id = m.inventory_id
+SET m.container_id = i.container_id;
+
+CREATE TABLE container_events (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    container_id BIGINT NOT NULL,
+    event VARCHAR(32) NOT NULL,
+    store_id BIGINT NULL,
+    from_container_id BIGINT NULL,
+    to_container_id BIGINT NULL,
+    inventory_id BIGINT NULL,
+    product_id BIGINT NULL,
+    batch VARCHAR(255) NULL,
+    quantity DECIMAL(18,6) NOT NULL DEFAULT 0,
+    unit VARCHAR(32) NULL,
+    from_status VARCHAR(32) NULL,
+    to_status VARCHAR(32) NULL,
+    note VARCHAR(512) NULL,
+    actor VARCHAR(255) NULL,
+    occurred_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_container_events_container (container_id, occurred_at, id)
+);
+
+-- The timeline starts with the creation of every container and the status
+-- changes recorded so far
+INSERT INTO container_events (container_id, event, store_id, to_container_id, actor, occurred_at)
+SELECT id, 'CREATED', store_id, parent_id, last_updated_by, created_at FROM containers ORDER BY created_at, id;
+
+INSERT INTO container_events (container_id, event, store_id, from_status, to_status, note, actor, occurred_at)
+SELECT h.container_id, 'STATUS_CHANGED', c.store_id, h.from_status, h.to_status, h.reason, h.changed_by, h.changed_at
+FROM container_status_history h JOIN containers c ON c.id = h.container_id ORDER BY h.changed_at, h.id;
+
+DROP TABLE container_status_history;
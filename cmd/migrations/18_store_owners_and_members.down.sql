@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS store_owner_history;
+DROP TABLE IF EXISTS store_members;
@@ -0,0 +1,23 @@
+-- Owners that are not in the user directory cannot be shown nor checked
+UPDATE stores SET owner_id = NULL WHERE owner_id IS NOT NULL AND owner_id NOT IN (SELECT id FROM users);
+
+CREATE TABLE store_members (
+    store_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    role VARCHAR(16) NOT NULL,
+    assigned_by VARCHAR(255),
+    assigned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (store_id, user_id),
+    KEY idx_store_members_user (user_id)
+);
+
+CREATE TABLE store_owner_history (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    store_id BIGINT NOT NULL,
+    from_owner_id BIGINT NULL,
+    to_owner_id BIGINT NULL,
+    reason VARCHAR(512),
+    changed_by VARCHAR(255),
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_store_owner_history_store (store_id, changed_at)
+);
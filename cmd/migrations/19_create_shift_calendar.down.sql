@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS shift_overrides;
+DROP TABLE IF EXISTS shift_assignments;
+DROP TABLE IF EXISTS shift_holidays;
+DROP TABLE IF EXISTS shifts;
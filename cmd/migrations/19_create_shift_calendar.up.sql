@@ -0,0 +1,50 @@
+CREATE TABLE shifts (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    store_id BIGINT NOT NULL,
+    code VARCHAR(32) NOT NULL,
+    name VARCHAR(255),
+    start_time CHAR(5) NOT NULL,
+    end_time CHAR(5) NOT NULL,
+    days VARCHAR(32),
+    supervisor VARCHAR(255),
+    status VARCHAR(32) NOT NULL DEFAULT 'active',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    last_updated_by VARCHAR(255),
+    version BIGINT NOT NULL DEFAULT 1,
+    UNIQUE KEY uk_shifts_store_code (store_id, code)
+);
+
+CREATE TABLE shift_holidays (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    store_id BIGINT NOT NULL,
+    holiday_date DATE NOT NULL,
+    name VARCHAR(255),
+    UNIQUE KEY uk_shift_holidays_store_date (store_id, holiday_date)
+);
+
+CREATE TABLE shift_assignments (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    shift_id BIGINT NOT NULL,
+    shift_date DATE NOT NULL,
+    supervisor VARCHAR(255) NOT NULL,
+    assigned_by VARCHAR(255),
+    assigned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uk_shift_assignments_shift_date (shift_id, shift_date)
+);
+
+-- Lots booked to another shift or supervisor than the calendar gave
+CREATE TABLE shift_overrides (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    inventory_id BIGINT NOT NULL,
+    store_id BIGINT NOT NULL,
+    calendar_shift VARCHAR(32),
+    calendar_supervisor VARCHAR(255),
+    shift VARCHAR(32),
+    supervisor VARCHAR(255),
+    approved_by BIGINT NOT NULL,
+    reason VARCHAR(512),
+    overridden_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_shift_overrides_inventory (inventory_id),
+    KEY idx_shift_overrides_store (store_id, overridden_at)
+);
@@ -0,0 +1,43 @@
+-- Lots in transit carry the status 'STOCK IN TRANSIT'
+ALTER TABLE inventories MODIFY COLUMN status VARCHAR(32) NOT NULL DEFAULT 'STOCK IN';
+
+CREATE TABLE transfer_orders (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    from_store_id BIGINT NOT NULL,
+    to_store_id BIGINT NOT NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'DRAFT',
+    vehicle VARCHAR(255),
+    remarks VARCHAR(512),
+    dispatched_at TIMESTAMP NULL,
+    dispatched_by VARCHAR(255),
+    received_at TIMESTAMP NULL,
+    cancelled_at TIMESTAMP NULL,
+    cancel_reason VARCHAR(512),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    last_updated_by VARCHAR(255),
+    version BIGINT NOT NULL DEFAULT 1,
+    KEY idx_transfer_orders_status (status, created_at),
+    KEY idx_transfer_orders_from_store (from_store_id, status),
+    KEY idx_transfer_orders_to_store (to_store_id, status)
+);
+
+CREATE TABLE transfer_order_lines (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    transfer_id BIGINT NOT NULL,
+    inventory_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    batch VARCHAR(255) NOT NULL DEFAULT '',
+    quantity DECIMAL(18,6) NOT NULL,
+    unit VARCHAR(32) NOT NULL DEFAULT '',
+    source_container_id BIGINT NULL,
+    transit_inventory_id BIGINT NULL,
+    received_quantity DECIMAL(18,6) NULL,
+    to_container_id BIGINT NULL,
+    discrepancy DECIMAL(18,6) NOT NULL DEFAULT 0,
+    discrepancy_reason VARCHAR(512),
+    received_at TIMESTAMP NULL,
+    received_by VARCHAR(255),
+    KEY idx_transfer_order_lines_transfer (transfer_id),
+    KEY idx_transfer_order_lines_transit (transit_inventory_id)
+);
@@ -0,0 +1,4 @@
+DROP INDEX idx_stock_alerts_store_status ON stock_alerts;
+DROP INDEX idx_inventory_movements_store_occurred ON inventory_movements;
+DROP INDEX idx_inventories_store_stockout_at ON inventories;
+DROP INDEX idx_inventories_store_status_expires ON inventories;
@@ -0,0 +1,4 @@
+CREATE INDEX idx_inventories_store_status_expires ON inventories (store_id, status, expires_at);
+CREATE INDEX idx_inventories_store_stockout_at ON inventories (store_id, stockout_at);
+CREATE INDEX idx_inventory_movements_store_occurred ON inventory_movements (store_id, occurred_at);
+CREATE INDEX idx_stock_alerts_store_status ON stock_alerts (store_id, status);
@@ -0,0 +1,32 @@
+CREATE TABLE product_attributes (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    product_type VARCHAR(64) NOT NULL,
+    code VARCHAR(64) NOT NULL,
+    name VARCHAR(255),
+    data_type VARCHAR(16) NOT NULL,
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    unit VARCHAR(32),
+    min_value DECIMAL(18,6) NULL,
+    max_value DECIMAL(18,6) NULL,
+    options TEXT,
+    max_length INT NOT NULL DEFAULT 0,
+    pattern VARCHAR(255),
+    position INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    last_updated_by VARCHAR(255),
+    version BIGINT NOT NULL DEFAULT 1,
+    UNIQUE KEY uk_product_attributes_type_code (product_type, code)
+);
+
+-- number_value repeats numeric values so that filters compare them as numbers
+CREATE TABLE product_attribute_values (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    product_id BIGINT NOT NULL,
+    attribute_id BIGINT NOT NULL,
+    value VARCHAR(1024) NOT NULL,
+    number_value DECIMAL(18,6) NULL,
+    UNIQUE KEY uk_product_attribute_values (product_id, attribute_id),
+    KEY idx_product_attribute_values_attribute (attribute_id, value(191)),
+    KEY idx_product_attribute_values_number (attribute_id, number_value)
+);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS product_categories;
+DROP INDEX idx_products_category ON products;
+ALTER TABLE products DROP COLUMN category_id;
+DROP TABLE IF EXISTS categories;
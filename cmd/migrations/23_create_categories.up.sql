@@ -0,0 +1,27 @@
+CREATE TABLE categories (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    parent_id BIGINT NULL,
+    path VARCHAR(512) NOT NULL DEFAULT '',
+    code VARCHAR(64) NOT NULL,
+    name VARCHAR(255),
+    description TEXT,
+    status VARCHAR(32) NOT NULL DEFAULT 'active',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    last_updated_by VARCHAR(255),
+    version BIGINT NOT NULL DEFAULT 1,
+    UNIQUE KEY uk_categories_code (code),
+    KEY idx_categories_parent (parent_id),
+    KEY idx_categories_path (path)
+);
+
+ALTER TABLE products ADD COLUMN category_id BIGINT NULL AFTER description;
+CREATE INDEX idx_products_category ON products (category_id);
+
+-- Secondary categories; the primary one is products.category_id
+CREATE TABLE product_categories (
+    product_id BIGINT NOT NULL,
+    category_id BIGINT NOT NULL,
+    PRIMARY KEY (product_id, category_id),
+    KEY idx_product_categories_category (category_id)
+);
@@ -0,0 +1,2 @@
+DROP INDEX idx_stock_alerts_level_recovered ON stock_alerts;
+ALTER TABLE stock_alerts DROP COLUMN recovered_at;
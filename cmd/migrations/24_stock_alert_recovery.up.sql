@@ -0,0 +1,3 @@
+ALTER TABLE stock_alerts ADD COLUMN recovered_at TIMESTAMP NULL AFTER resolved_at;
+UPDATE stock_alerts SET recovered_at = resolved_at WHERE status = 'RESOLVED';
+CREATE INDEX idx_stock_alerts_level_recovered ON stock_alerts (stock_level_id, recovered_at);
@@ -0,0 +1 @@
+ALTER TABLE containers DROP INDEX uk_containers_code;
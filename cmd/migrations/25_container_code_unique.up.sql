@@ -0,0 +1,3 @@
+-- Codes were only checked before insert; concurrent requests could still
+-- create the same code twice. Duplicates must be renamed before this runs.
+ALTER TABLE containers ADD UNIQUE KEY uk_containers_code (code);
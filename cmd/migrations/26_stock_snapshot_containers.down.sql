@@ -0,0 +1,2 @@
+DELETE FROM stock_snapshots;
+ALTER TABLE stock_snapshots DROP COLUMN container_id;
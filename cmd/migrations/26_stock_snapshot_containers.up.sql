@@ -0,0 +1,6 @@
+ALTER TABLE stock_snapshots ADD COLUMN container_id BIGINT NULL AFTER store_id;
+
+-- Positions are now kept per container. Snapshots taken without it would not
+-- line up with the ledger replayed on top of them, so they are dropped and
+-- taken again by the snapshot job.
+DELETE FROM stock_snapshots;
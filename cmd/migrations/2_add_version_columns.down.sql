@@ -0,0 +1,7 @@
+ALTER TABLE inventories DROP COLUMN version;
+ALTER TABLE stores DROP COLUMN version;
+ALTER TABLE containers DROP COLUMN version;
+ALTER TABLE customers DROP COLUMN version;
+ALTER TABLE machines DROP COLUMN version;
+ALTER TABLE products DROP COLUMN version;
+ALTER TABLE users DROP COLUMN version;
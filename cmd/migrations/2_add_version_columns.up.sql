@@ -0,0 +1,7 @@
+ALTER TABLE users ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE products ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE machines ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE customers ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE containers ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE stores ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE inventories ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1 @@
+ALTER TABLE inventories MODIFY COLUMN quantity DOUBLE NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE inventories MODIFY COLUMN quantity DECIMAL(18,6) NOT NULL DEFAULT 0;
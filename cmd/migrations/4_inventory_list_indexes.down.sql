@@ -0,0 +1,4 @@
+DROP INDEX idx_inventories_batch ON inventories;
+DROP INDEX idx_inventories_product_status ON inventories;
+DROP INDEX idx_inventories_stockout_at_id ON inventories;
+DROP INDEX idx_inventories_stockin_at_id ON inventories;
@@ -0,0 +1,4 @@
+CREATE INDEX idx_inventories_stockin_at_id ON inventories (stockin_at, id);
+CREATE INDEX idx_inventories_stockout_at_id ON inventories (stockout_at, id);
+CREATE INDEX idx_inventories_product_status ON inventories (product_id, status);
+CREATE INDEX idx_inventories_batch ON inventories (batch);
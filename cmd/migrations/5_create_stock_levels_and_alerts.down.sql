@@ -0,0 +1,2 @@
+DROP TABLE stock_alerts;
+DROP TABLE stock_levels;
@@ -0,0 +1,36 @@
+CREATE TABLE stock_levels (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    product_id BIGINT NOT NULL,
+    store_id BIGINT NOT NULL,
+    min_quantity DECIMAL(18,6) NOT NULL DEFAULT 0,
+    max_quantity DECIMAL(18,6) NOT NULL DEFAULT 0,
+    reorder_point DECIMAL(18,6) NOT NULL DEFAULT 0,
+    unit VARCHAR(32) NOT NULL DEFAULT '',
+    status VARCHAR(32) NOT NULL DEFAULT 'active',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    last_updated_by VARCHAR(255),
+    version BIGINT NOT NULL DEFAULT 1,
+    UNIQUE KEY uq_stock_levels_product_store (product_id, store_id)
+);
+
+CREATE TABLE stock_alerts (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    stock_level_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    store_id BIGINT NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    on_hand DECIMAL(18,6) NOT NULL DEFAULT 0,
+    reserved DECIMAL(18,6) NOT NULL DEFAULT 0,
+    available DECIMAL(18,6) NOT NULL DEFAULT 0,
+    reorder_point DECIMAL(18,6) NOT NULL DEFAULT 0,
+    suggested_quantity DECIMAL(18,6) NOT NULL DEFAULT 0,
+    unit VARCHAR(32) NOT NULL DEFAULT '',
+    raised_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    acknowledged_at TIMESTAMP NULL,
+    acknowledged_by VARCHAR(255),
+    resolved_at TIMESTAMP NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    KEY idx_stock_alerts_level_status (stock_level_id, status),
+    KEY idx_stock_alerts_status (status)
+);
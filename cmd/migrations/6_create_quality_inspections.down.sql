@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS inspection_results;
+DROP TABLE IF EXISTS inspections;
+DROP TABLE IF EXISTS inspection_parameters;
+DROP INDEX idx_inventories_qc_status ON inventories;
+ALTER TABLE inventories DROP COLUMN qc_status;
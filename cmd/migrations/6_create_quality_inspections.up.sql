@@ -0,0 +1,45 @@
+ALTER TABLE inventories ADD COLUMN qc_status VARCHAR(32) NOT NULL DEFAULT 'RELEASED' AFTER status;
+CREATE INDEX idx_inventories_qc_status ON inventories (qc_status);
+
+CREATE TABLE inspection_parameters (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    product_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    unit VARCHAR(32),
+    min_value DECIMAL(18,6) NULL,
+    max_value DECIMAL(18,6) NULL,
+    expected_value VARCHAR(255),
+    mandatory BOOLEAN NOT NULL DEFAULT TRUE,
+    status VARCHAR(32) NOT NULL DEFAULT 'active',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    last_updated_by VARCHAR(255),
+    version BIGINT NOT NULL DEFAULT 1,
+    KEY idx_inspection_parameters_product (product_id, status)
+);
+
+CREATE TABLE inspections (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    inventory_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    batch VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(32) NOT NULL,
+    inspector VARCHAR(255),
+    remarks TEXT,
+    decided_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_inspections_inventory_status (inventory_id, status),
+    KEY idx_inspections_product (product_id)
+);
+
+CREATE TABLE inspection_results (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    inspection_id BIGINT NOT NULL,
+    parameter_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    value VARCHAR(255) NOT NULL,
+    passed BOOLEAN NOT NULL,
+    recorded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    recorded_by VARCHAR(255) NOT NULL,
+    KEY idx_inspection_results_inspection (inspection_id)
+);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS serial_events;
+DROP TABLE IF EXISTS serial_numbers;
+DROP TABLE IF EXISTS serial_sequences;
+ALTER TABLE products DROP COLUMN serial_pattern, DROP COLUMN serialized;
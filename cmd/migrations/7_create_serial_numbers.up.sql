@@ -0,0 +1,32 @@
+ALTER TABLE products ADD COLUMN serialized BOOLEAN NOT NULL DEFAULT FALSE, ADD COLUMN serial_pattern VARCHAR(255) NULL;
+
+CREATE TABLE serial_sequences (
+    product_id BIGINT PRIMARY KEY,
+    last_value BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE serial_numbers (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    serial VARCHAR(255) NOT NULL,
+    product_id BIGINT NOT NULL,
+    inventory_id BIGINT NOT NULL,
+    batch VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_serial_numbers_serial (serial),
+    KEY idx_serial_numbers_inventory (inventory_id),
+    KEY idx_serial_numbers_product_status (product_id, status)
+);
+
+CREATE TABLE serial_events (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    serial_id BIGINT NOT NULL,
+    event VARCHAR(32) NOT NULL,
+    inventory_id BIGINT NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    store_id BIGINT NULL,
+    occurred_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    actor VARCHAR(255),
+    KEY idx_serial_events_serial (serial_id, occurred_at)
+);
@@ -0,0 +1,39 @@
+CREATE TABLE inventory_movements (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    inventory_id BIGINT NOT NULL,
+    movement VARCHAR(16) NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    qc_status VARCHAR(32) NOT NULL,
+    product_id BIGINT NOT NULL,
+    store_id BIGINT NULL,
+    rack_id BIGINT NULL,
+    bin_id BIGINT NULL,
+    pallet_id BIGINT NULL,
+    batch VARCHAR(255) NOT NULL DEFAULT '',
+    quantity DECIMAL(18,6) NOT NULL DEFAULT 0,
+    unit VARCHAR(32) NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_inventory_movements_occurred (occurred_at, inventory_id),
+    KEY idx_inventory_movements_inventory (inventory_id, occurred_at)
+);
+
+-- The ledger starts with the current state of every lot. History before this
+-- migration is unknown, so each lot is assumed to have been in its current
+-- state since it was stocked in.
+INSERT INTO inventory_movements (inventory_id, movement, status, qc_status, product_id, store_id, rack_id, bin_id, pallet_id, batch, quantity, unit, occurred_at)
+SELECT id, 'CREATED', status, qc_status, product_id, store_id, rack_id, bin_id, pallet_id, batch, quantity, COALESCE(unit, ''), stockin_at FROM inventories ORDER BY stockin_at, id;
+
+CREATE TABLE stock_snapshots (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    snapshot_at TIMESTAMP NOT NULL,
+    product_id BIGINT NOT NULL,
+    store_id BIGINT NULL,
+    rack_id BIGINT NULL,
+    bin_id BIGINT NULL,
+    pallet_id BIGINT NULL,
+    unit VARCHAR(32) NOT NULL DEFAULT '',
+    on_hand DECIMAL(18,6) NOT NULL DEFAULT 0,
+    reserved DECIMAL(18,6) NOT NULL DEFAULT 0,
+    held DECIMAL(18,6) NOT NULL DEFAULT 0,
+    KEY idx_stock_snapshots_at (snapshot_at, product_id, store_id)
+);
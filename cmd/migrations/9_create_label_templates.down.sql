@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS label_templates;
+ALTER TABLE inventories DROP COLUMN expires_at;
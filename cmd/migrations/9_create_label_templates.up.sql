@@ -0,0 +1,18 @@
+ALTER TABLE inventories ADD COLUMN expires_at DATETIME NULL AFTER stockin_at;
+
+CREATE TABLE label_templates (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    width DECIMAL(8,2) NOT NULL,
+    height DECIMAL(8,2) NOT NULL,
+    dpi INT NOT NULL DEFAULT 203,
+    elements JSON NOT NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'active',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    last_updated_by VARCHAR(255),
+    version BIGINT NOT NULL DEFAULT 1,
+    UNIQUE KEY uq_label_templates_name (name),
+    KEY idx_label_templates_kind (kind, status)
+);
@@ -5,6 +5,8 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/vamika-digital/wms-api-server/config"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
 	"github.com/vamika-digital/wms-api-server/pkg/database"
 	"github.com/vamika-digital/wms-api-server/pkg/server"
 )
@@ -13,6 +15,14 @@ var serverCmd = &cobra.Command{
 	Use:   "server",
 	Short: "Start the web application server",
 	Run: func(cmd *cobra.Command, args []string) {
+		// Apply the configured precision for each unit of measure
+		for unit, scale := range config.AppConfig.Units {
+			customtypes.SetUnitScale(unit, scale)
+		}
+		// Apply the container capacity defaults
+		if err := warehouse.ApplyCapacityConfig(); err != nil {
+			log.Fatalf("Invalid capacity configuration: %s", err)
+		}
 		// Create a database connection
 		dbConn, err := database.NewMySQLConnection(config.AppConfig)
 		if err != nil {
@@ -25,6 +25,7 @@ type Customer struct {
 	CreatedAt       time.Time                  `json:"created_at"`
 	UpdatedAt       time.Time                  `json:"updated_at"`
 	LastUpdatedBy   customtypes.NullableString `json:"last_updated_by"`
+	Version         int64                      `json:"version"`
 }
 
 func NewCustomerWithDefaults() *Customer {
@@ -10,6 +10,7 @@ import (
 	"github.com/vamika-digital/wms-api-server/internal/app/customer/domain"
 	"github.com/vamika-digital/wms-api-server/internal/app/customer/repository"
 	"github.com/vamika-digital/wms-api-server/internal/app/customer/usecase"
+	"github.com/vamika-digital/wms-api-server/internal/utility/etag"
 	"github.com/vamika-digital/wms-api-server/internal/utility/valueobjects"
 )
 
@@ -62,11 +63,20 @@ func (handler *CustomerHandler) UpdateCustomer(w http.ResponseWriter, r *http.Re
 	}
 
 	customer.ID = int64(id)
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if version > 0 {
+		customer.Version = version
+	}
 	if err := handler.UseCase.UpdateCustomer(customer); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		handler.handleWriteError(w, customer.ID, err)
 		return
 	}
 
+	etag.Set(w, customer.Version)
 	w.WriteHeader(http.StatusOK)
 }
 
@@ -78,8 +88,14 @@ func (handler *CustomerHandler) DeleteCustomer(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	if err := handler.UseCase.DeleteCustomer(id); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.DeleteCustomer(id, version); err != nil {
+		handler.handleWriteError(w, id, err)
 		return
 	}
 
@@ -100,6 +116,7 @@ func (handler *CustomerHandler) GetCustomerByID(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	etag.Set(w, customer.Version)
 	if err := json.NewEncoder(w).Encode(customer); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
@@ -155,6 +172,17 @@ func (handler *CustomerHandler) GetAllCustomers(w http.ResponseWriter, r *http.R
 	}
 }
 
+func (handler *CustomerHandler) handleWriteError(w http.ResponseWriter, customerID int64, err error) {
+	current := func() (int64, interface{}, error) {
+		customer, err := handler.UseCase.GetCustomerByID(customerID)
+		if err != nil {
+			return 0, nil, err
+		}
+		return customer.Version, customer, nil
+	}
+	etag.WriteError(w, err, current, nil)
+}
+
 func validateCustomer(customer *domain.Customer) error {
 	if customer.Name == "" {
 		return errors.New("name is required")
@@ -25,22 +25,32 @@ func (r *MySqlCustomerRepository) Create(customer *domain.Customer) error {
 }
 
 func (r *MySqlCustomerRepository) Update(customer *domain.Customer) error {
-	query := "UPDATE customers SET code=?, name=?, contact_person=?, billing_address_address1=?, billing_address_address2=?, billing_address_state=?, billing_address_country=?, billing_address_pincode=?, shipping_address_address1=?, shipping_address_address2=?, shipping_address_state=?, shipping_address_country=?, shipping_address_pincode=?, status=?, last_updated_by=? WHERE id=?"
-	_, err := r.conn.GetDB().Exec(query, customer.Code, customer.Name, customer.ContactPerson, customer.BillingAddress.Address1, customer.BillingAddress.Address2, customer.BillingAddress.State, customer.BillingAddress.Country, customer.BillingAddress.Pincode, customer.ShippingAddress.Address1, customer.ShippingAddress.Address2, customer.ShippingAddress.State, customer.ShippingAddress.Country, customer.ShippingAddress.Pincode, customer.Status, customer.LastUpdatedBy, customer.ID)
-	return err
+	query := "UPDATE customers SET code=?, name=?, contact_person=?, billing_address_address1=?, billing_address_address2=?, billing_address_state=?, billing_address_country=?, billing_address_pincode=?, shipping_address_address1=?, shipping_address_address2=?, shipping_address_state=?, shipping_address_country=?, shipping_address_pincode=?, status=?, last_updated_by=?, version=LAST_INSERT_ID(version+1) WHERE id=? AND (? = 0 OR version=?)"
+	result, err := r.conn.GetDB().Exec(query, customer.Code, customer.Name, customer.ContactPerson, customer.BillingAddress.Address1, customer.BillingAddress.Address2, customer.BillingAddress.State, customer.BillingAddress.Country, customer.BillingAddress.Pincode, customer.ShippingAddress.Address1, customer.ShippingAddress.Address2, customer.ShippingAddress.State, customer.ShippingAddress.Country, customer.ShippingAddress.Pincode, customer.Status, customer.LastUpdatedBy, customer.ID, customer.Version, customer.Version)
+	if err != nil {
+		return err
+	}
+	if err := database.CheckVersionedWrite(result, "customer", customer.ID, customer.Version); err != nil {
+		return err
+	}
+	customer.Version = database.NextVersion(result, customer.Version)
+	return nil
 }
 
-func (r *MySqlCustomerRepository) Delete(customerID int64) error {
-	query := "DELETE FROM customers WHERE id=?"
-	_, err := r.conn.GetDB().Exec(query, customerID)
-	return err
+func (r *MySqlCustomerRepository) Delete(customerID int64, version int64) error {
+	query := "DELETE FROM customers WHERE id=? AND (? = 0 OR version=?)"
+	result, err := r.conn.GetDB().Exec(query, customerID, version, version)
+	if err != nil {
+		return err
+	}
+	return database.CheckVersionedWrite(result, "customer", customerID, version)
 }
 
 func (r *MySqlCustomerRepository) GetById(customerID int64) (*domain.Customer, error) {
-	query := "SELECT id, code, name, contact_person, billing_address_address1, billing_address_address2, billing_address_state, billing_address_country, billing_address_pincode, shipping_address_address1, shipping_address_address2, shipping_address_state, shipping_address_country, shipping_address_pincode, status, created_at, updated_at, last_updated_by FROM customers WHERE id = ?"
+	query := "SELECT id, code, name, contact_person, billing_address_address1, billing_address_address2, billing_address_state, billing_address_country, billing_address_pincode, shipping_address_address1, shipping_address_address2, shipping_address_state, shipping_address_country, shipping_address_pincode, status, created_at, updated_at, last_updated_by, version FROM customers WHERE id = ?"
 	row := r.conn.GetDB().QueryRow(query, customerID)
 	customer := domain.NewCustomerWithDefaults()
-	err := row.Scan(&customer.ID, &customer.Code, &customer.Name, &customer.ContactPerson, &customer.BillingAddress.Address1, &customer.BillingAddress.Address2, &customer.BillingAddress.State, &customer.BillingAddress.Country, &customer.BillingAddress.Pincode, &customer.ShippingAddress.Address1, &customer.ShippingAddress.Address2, &customer.ShippingAddress.State, &customer.ShippingAddress.Country, &customer.ShippingAddress.Pincode, &customer.Status, &customer.CreatedAt, &customer.UpdatedAt, &customer.LastUpdatedBy)
+	err := row.Scan(&customer.ID, &customer.Code, &customer.Name, &customer.ContactPerson, &customer.BillingAddress.Address1, &customer.BillingAddress.Address2, &customer.BillingAddress.State, &customer.BillingAddress.Country, &customer.BillingAddress.Pincode, &customer.ShippingAddress.Address1, &customer.ShippingAddress.Address2, &customer.ShippingAddress.State, &customer.ShippingAddress.Country, &customer.ShippingAddress.Pincode, &customer.Status, &customer.CreatedAt, &customer.UpdatedAt, &customer.LastUpdatedBy, &customer.Version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, customerrors.ErrResourceNotFound
@@ -62,7 +72,7 @@ func (r *MySqlCustomerRepository) GetTotalCount(filter CustomerFilterOptions) (i
 }
 
 func (r *MySqlCustomerRepository) GetAll(page int, pageSize int, sort string, filter CustomerFilterOptions) ([]*domain.Customer, error) {
-	query, args := r.buildFilterQuery("SELECT id, code, name, contact_person, billing_address_address1, billing_address_address2, billing_address_state, billing_address_country, billing_address_pincode, shipping_address_address1, shipping_address_address2, shipping_address_state, shipping_address_country, shipping_address_pincode, status, created_at, updated_at, last_updated_by FROM customers", filter)
+	query, args := r.buildFilterQuery("SELECT id, code, name, contact_person, billing_address_address1, billing_address_address2, billing_address_state, billing_address_country, billing_address_pincode, shipping_address_address1, shipping_address_address2, shipping_address_state, shipping_address_country, shipping_address_pincode, status, created_at, updated_at, last_updated_by, version FROM customers", filter)
 	var allowedSortOrders = map[string]bool{
 		"code ASC":            true,
 		"code DESC":           true,
@@ -94,7 +104,7 @@ func (r *MySqlCustomerRepository) GetAll(page int, pageSize int, sort string, fi
 	var customers []*domain.Customer
 	for rows.Next() {
 		customer := domain.NewCustomerWithDefaults()
-		if err := rows.Scan(&customer.ID, &customer.Code, &customer.Name, &customer.ContactPerson, &customer.BillingAddress.Address1, &customer.BillingAddress.Address2, &customer.BillingAddress.State, &customer.BillingAddress.Country, &customer.BillingAddress.Pincode, &customer.ShippingAddress.Address1, &customer.ShippingAddress.Address2, &customer.ShippingAddress.State, &customer.ShippingAddress.Country, &customer.ShippingAddress.Pincode, &customer.Status, &customer.CreatedAt, &customer.UpdatedAt, &customer.LastUpdatedBy); err != nil {
+		if err := rows.Scan(&customer.ID, &customer.Code, &customer.Name, &customer.ContactPerson, &customer.BillingAddress.Address1, &customer.BillingAddress.Address2, &customer.BillingAddress.State, &customer.BillingAddress.Country, &customer.BillingAddress.Pincode, &customer.ShippingAddress.Address1, &customer.ShippingAddress.Address2, &customer.ShippingAddress.State, &customer.ShippingAddress.Country, &customer.ShippingAddress.Pincode, &customer.Status, &customer.CreatedAt, &customer.UpdatedAt, &customer.LastUpdatedBy, &customer.Version); err != nil {
 			return nil, err
 		}
 		customers = append(customers, customer)
@@ -8,7 +8,7 @@ import (
 type CustomerUseCase interface {
 	CreateCustomer(store *domain.Customer) error
 	UpdateCustomer(store *domain.Customer) error
-	DeleteCustomer(storeID int64) error
+	DeleteCustomer(customerID int64, version int64) error
 	GetCustomerByID(storeID int64) (*domain.Customer, error)
 	GetAllCustomers(page int, pageSize int, sort string, filter repository.CustomerFilterOptions) ([]*domain.Customer, int, error)
 }
@@ -25,8 +25,8 @@ func (u *CustomerUseCaseImpl) UpdateCustomer(customer *domain.Customer) error {
 	return u.Repo.Update(customer)
 }
 
-func (u *CustomerUseCaseImpl) DeleteCustomer(customerID int64) error {
-	return u.Repo.Delete(customerID)
+func (u *CustomerUseCaseImpl) DeleteCustomer(customerID int64, version int64) error {
+	return u.Repo.Delete(customerID, version)
 }
 
 func (u *CustomerUseCaseImpl) GetCustomerByID(customerID int64) (*domain.Customer, error) {
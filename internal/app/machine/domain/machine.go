@@ -14,6 +14,7 @@ type Machine struct {
 	CreatedAt     time.Time                  `json:"created_at"`
 	UpdatedAt     time.Time                  `json:"updated_at"`
 	LastUpdatedBy customtypes.NullableString `json:"last_updated_by"`
+	Version       int64                      `json:"version"`
 }
 
 func NewMachineWithDefaults() *Machine {
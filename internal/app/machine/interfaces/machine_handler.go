@@ -10,6 +10,7 @@ import (
 	"github.com/vamika-digital/wms-api-server/internal/app/machine/domain"
 	"github.com/vamika-digital/wms-api-server/internal/app/machine/repository"
 	"github.com/vamika-digital/wms-api-server/internal/app/machine/usecase"
+	"github.com/vamika-digital/wms-api-server/internal/utility/etag"
 	"github.com/vamika-digital/wms-api-server/internal/utility/valueobjects"
 )
 
@@ -62,11 +63,20 @@ func (handler *MachineHandler) UpdateMachine(w http.ResponseWriter, r *http.Requ
 	}
 
 	machine.ID = int64(id)
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if version > 0 {
+		machine.Version = version
+	}
 	if err := handler.UseCase.UpdateMachine(machine); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		handler.handleWriteError(w, machine.ID, err)
 		return
 	}
 
+	etag.Set(w, machine.Version)
 	w.WriteHeader(http.StatusOK)
 }
 
@@ -78,8 +88,14 @@ func (handler *MachineHandler) DeleteMachine(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	if err := handler.UseCase.DeleteMachine(id); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.DeleteMachine(id, version); err != nil {
+		handler.handleWriteError(w, id, err)
 		return
 	}
 
@@ -100,6 +116,7 @@ func (handler *MachineHandler) GetMachineByID(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	etag.Set(w, machine.Version)
 	if err := json.NewEncoder(w).Encode(machine); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
@@ -155,6 +172,17 @@ func (handler *MachineHandler) GetAllMachines(w http.ResponseWriter, r *http.Req
 	}
 }
 
+func (handler *MachineHandler) handleWriteError(w http.ResponseWriter, machineID int64, err error) {
+	current := func() (int64, interface{}, error) {
+		machine, err := handler.UseCase.GetMachineByID(machineID)
+		if err != nil {
+			return 0, nil, err
+		}
+		return machine.Version, machine, nil
+	}
+	etag.WriteError(w, err, current, nil)
+}
+
 func validateMachine(machine *domain.Machine) error {
 	if machine.Name == "" {
 		return errors.New("name is required")
@@ -25,22 +25,32 @@ func (r *MySqlMachineRepository) Create(machine *domain.Machine) error {
 }
 
 func (r *MySqlMachineRepository) Update(machine *domain.Machine) error {
-	query := "UPDATE machines SET name=?, code=?, status=?, last_updated_by=? WHERE id=?"
-	_, err := r.conn.GetDB().Exec(query, machine.Name, machine.Code, machine.Status, machine.LastUpdatedBy, machine.ID)
-	return err
+	query := "UPDATE machines SET name=?, code=?, status=?, last_updated_by=?, version=LAST_INSERT_ID(version+1) WHERE id=? AND (? = 0 OR version=?)"
+	result, err := r.conn.GetDB().Exec(query, machine.Name, machine.Code, machine.Status, machine.LastUpdatedBy, machine.ID, machine.Version, machine.Version)
+	if err != nil {
+		return err
+	}
+	if err := database.CheckVersionedWrite(result, "machine", machine.ID, machine.Version); err != nil {
+		return err
+	}
+	machine.Version = database.NextVersion(result, machine.Version)
+	return nil
 }
 
-func (r *MySqlMachineRepository) Delete(machineID int64) error {
-	query := "DELETE FROM machines WHERE id=?"
-	_, err := r.conn.GetDB().Exec(query, machineID)
-	return err
+func (r *MySqlMachineRepository) Delete(machineID int64, version int64) error {
+	query := "DELETE FROM machines WHERE id=? AND (? = 0 OR version=?)"
+	result, err := r.conn.GetDB().Exec(query, machineID, version, version)
+	if err != nil {
+		return err
+	}
+	return database.CheckVersionedWrite(result, "machine", machineID, version)
 }
 
 func (r *MySqlMachineRepository) GetById(machineID int64) (*domain.Machine, error) {
-	query := "SELECT id, name, code, status, created_at, updated_at, last_updated_by FROM machines WHERE id = ?"
+	query := "SELECT id, name, code, status, created_at, updated_at, last_updated_by, version FROM machines WHERE id = ?"
 	row := r.conn.GetDB().QueryRow(query, machineID)
 	machine := domain.NewMachineWithDefaults()
-	err := row.Scan(&machine.ID, &machine.Name, &machine.Code, &machine.Status, &machine.CreatedAt, &machine.UpdatedAt, &machine.LastUpdatedBy)
+	err := row.Scan(&machine.ID, &machine.Name, &machine.Code, &machine.Status, &machine.CreatedAt, &machine.UpdatedAt, &machine.LastUpdatedBy, &machine.Version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, customerrors.ErrResourceNotFound
@@ -62,7 +72,7 @@ func (r *MySqlMachineRepository) GetTotalCount(filter MachineFilterOptions) (int
 }
 
 func (r *MySqlMachineRepository) GetAll(page int, pageSize int, sort string, filter MachineFilterOptions) ([]*domain.Machine, error) {
-	query, args := r.buildFilterQuery("SELECT id, name, code, status, created_at, updated_at, last_updated_by FROM machines", filter)
+	query, args := r.buildFilterQuery("SELECT id, name, code, status, created_at, updated_at, last_updated_by, version FROM machines", filter)
 	var allowedSortOrders = map[string]bool{
 		"code ASC":    true,
 		"code DESC":   true,
@@ -92,7 +102,7 @@ func (r *MySqlMachineRepository) GetAll(page int, pageSize int, sort string, fil
 	var machines []*domain.Machine
 	for rows.Next() {
 		machine := domain.NewMachineWithDefaults()
-		if err := rows.Scan(&machine.ID, &machine.Name, &machine.Code, &machine.Status, &machine.CreatedAt, &machine.UpdatedAt, &machine.LastUpdatedBy); err != nil {
+		if err := rows.Scan(&machine.ID, &machine.Name, &machine.Code, &machine.Status, &machine.CreatedAt, &machine.UpdatedAt, &machine.LastUpdatedBy, &machine.Version); err != nil {
 			return nil, err
 		}
 		machines = append(machines, machine)
@@ -7,7 +7,7 @@ import (
 type MachineRepository interface {
 	Create(machine *domain.Machine) error
 	Update(machine *domain.Machine) error
-	Delete(machineID int64) error
+	Delete(machineID int64, version int64) error
 	GetById(machineID int64) (*domain.Machine, error)
 	GetTotalCount(filter MachineFilterOptions) (int, error)
 	GetAll(page int, pageSize int, sort string, filter MachineFilterOptions) ([]*domain.Machine, error)
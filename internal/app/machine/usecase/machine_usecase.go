@@ -8,7 +8,7 @@ import (
 type MachineUseCase interface {
 	CreateMachine(store *domain.Machine) error
 	UpdateMachine(store *domain.Machine) error
-	DeleteMachine(storeID int64) error
+	DeleteMachine(machineID int64, version int64) error
 	GetMachineByID(storeID int64) (*domain.Machine, error)
 	GetAllMachines(page int, pageSize int, sort string, filter repository.MachineFilterOptions) ([]*domain.Machine, int, error)
 }
@@ -25,8 +25,8 @@ func (u *MachineUseCaseImpl) UpdateMachine(machine *domain.Machine) error {
 	return u.Repo.Update(machine)
 }
 
-func (u *MachineUseCaseImpl) DeleteMachine(machineID int64) error {
-	return u.Repo.Delete(machineID)
+func (u *MachineUseCaseImpl) DeleteMachine(machineID int64, version int64) error {
+	return u.Repo.Delete(machineID, version)
 }
 
 func (u *MachineUseCaseImpl) GetMachineByID(machineID int64) (*domain.Machine, error) {
@@ -0,0 +1,308 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+// AttributeDataType is the kind of value a product attribute holds.
+type AttributeDataType string
+
+const (
+	ATTRIBUTE_STRING  AttributeDataType = "string"
+	ATTRIBUTE_NUMBER  AttributeDataType = "number"
+	ATTRIBUTE_ENUM    AttributeDataType = "enum"
+	ATTRIBUTE_BOOLEAN AttributeDataType = "boolean"
+	ATTRIBUTE_DATE    AttributeDataType = "date"
+)
+
+// AttributeDateLayout is the layout of date attribute values.
+const AttributeDateLayout = "2006-01-02"
+
+var (
+	ErrInvalidAttributeDefinition = errors.New("invalid attribute definition")
+	ErrDuplicateAttribute         = errors.New("product type already has an attribute with that code")
+	ErrAttributeDefinitionLocked  = errors.New("code, product type and data type of an attribute cannot change")
+	ErrInvalidAttributeValue      = errors.New("invalid product attribute")
+)
+
+var attributeCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+func invalidAttributeDefinition(message string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidAttributeDefinition, fmt.Sprintf(message, args...))
+}
+
+// AttributeDefinition is a custom field of every product of a type, such as
+// the grade, colour, GSM or thickness of a raw material. Unit, MinValue and
+// MaxValue apply to numbers, Options to enums and MaxLength and Pattern to
+// strings.
+type AttributeDefinition struct {
+	ID            int64                      `json:"id"`
+	ProductType   ProductType                `json:"product_type"`
+	Code          string                     `json:"code"`
+	Name          customtypes.NullableString `json:"name"`
+	DataType      AttributeDataType          `json:"data_type"`
+	Required      bool                       `json:"required"`
+	Unit          customtypes.NullableString `json:"unit"`
+	MinValue      *customtypes.Decimal       `json:"min_value"`
+	MaxValue      *customtypes.Decimal       `json:"max_value"`
+	Options       []string                   `json:"options"`
+	MaxLength     int                        `json:"max_length"`
+	Pattern       customtypes.NullableString `json:"pattern"`
+	Position      int                        `json:"position"`
+	CreatedAt     time.Time                  `json:"created_at"`
+	UpdatedAt     time.Time                  `json:"updated_at"`
+	LastUpdatedBy customtypes.NullableString `json:"last_updated_by"`
+	Version       int64                      `json:"version"`
+}
+
+func (d *AttributeDefinition) Validate() error {
+	product := Product{Type: d.ProductType}
+	if err := product.ValidateType(); err != nil {
+		return invalidAttributeDefinition("product type should be valid")
+	}
+	d.Code = strings.ToLower(strings.TrimSpace(d.Code))
+	if !attributeCodePattern.MatchString(d.Code) {
+		return invalidAttributeDefinition("code must start with a letter and hold only lowercase letters, digits and underscores")
+	}
+	if d.Name == "" {
+		d.Name = customtypes.NullableString(d.Code)
+	}
+
+	switch d.DataType {
+	case ATTRIBUTE_STRING, ATTRIBUTE_NUMBER, ATTRIBUTE_ENUM, ATTRIBUTE_BOOLEAN, ATTRIBUTE_DATE:
+	default:
+		return invalidAttributeDefinition("data type must be string, number, enum, boolean or date")
+	}
+	if d.DataType != ATTRIBUTE_NUMBER && (d.Unit != "" || d.MinValue != nil || d.MaxValue != nil) {
+		return invalidAttributeDefinition("unit, min value and max value only apply to numbers")
+	}
+	if d.MinValue != nil && d.MaxValue != nil && d.MinValue.Cmp(*d.MaxValue) > 0 {
+		return invalidAttributeDefinition("min value cannot be greater than max value")
+	}
+	if d.DataType != ATTRIBUTE_STRING && (d.MaxLength != 0 || d.Pattern != "") {
+		return invalidAttributeDefinition("max length and pattern only apply to strings")
+	}
+	if d.MaxLength < 0 {
+		return invalidAttributeDefinition("max length cannot be negative")
+	}
+	if d.Pattern != "" {
+		if _, err := regexp.Compile(string(d.Pattern)); err != nil {
+			return invalidAttributeDefinition("pattern is not a valid regular expression")
+		}
+	}
+
+	if d.DataType != ATTRIBUTE_ENUM {
+		if len(d.Options) > 0 {
+			return invalidAttributeDefinition("options only apply to enums")
+		}
+		return nil
+	}
+	seen := make(map[string]bool)
+	options := make([]string, 0, len(d.Options))
+	for _, option := range d.Options {
+		option = strings.TrimSpace(option)
+		if option == "" || seen[strings.ToLower(option)] {
+			return invalidAttributeDefinition("options must be distinct and not blank")
+		}
+		seen[strings.ToLower(option)] = true
+		options = append(options, option)
+	}
+	if len(options) == 0 {
+		return invalidAttributeDefinition("an enum needs at least one option")
+	}
+	d.Options = options
+	return nil
+}
+
+// Label is the column heading of the attribute in exports.
+func (d *AttributeDefinition) Label() string {
+	if d.Unit != "" {
+		return fmt.Sprintf("%s (%s)", d.Name, d.Unit)
+	}
+	return string(d.Name)
+}
+
+// Normalize checks text against the rules of the attribute and returns it in
+// its canonical form: numbers without superfluous zeros, booleans as true or
+// false, enums spelt as the option and dates as YYYY-MM-DD.
+func (d *AttributeDefinition) Normalize(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	invalid := func(message string, args ...interface{}) (string, error) {
+		return "", fmt.Errorf("%w: %s %s", ErrInvalidAttributeValue, d.Code, fmt.Sprintf(message, args...))
+	}
+
+	switch d.DataType {
+	case ATTRIBUTE_NUMBER:
+		value, err := customtypes.ParseDecimal(text)
+		if errors.Is(err, customtypes.ErrDecimalOverflow) {
+			return invalid("must have at most %d digits before the decimal point", customtypes.MaxDecimalIntegerDigits)
+		}
+		if err != nil {
+			return invalid("must be a number")
+		}
+		if d.MinValue != nil && value.Cmp(*d.MinValue) < 0 {
+			return invalid("must be at least %s", d.MinValue)
+		}
+		if d.MaxValue != nil && value.Cmp(*d.MaxValue) > 0 {
+			return invalid("must be at most %s", d.MaxValue)
+		}
+		return value.String(), nil
+	case ATTRIBUTE_BOOLEAN:
+		value, err := strconv.ParseBool(text)
+		if err != nil {
+			return invalid("must be true or false")
+		}
+		return strconv.FormatBool(value), nil
+	case ATTRIBUTE_DATE:
+		value, err := time.Parse(AttributeDateLayout, text)
+		if err != nil {
+			return invalid("must be a date as YYYY-MM-DD")
+		}
+		return value.Format(AttributeDateLayout), nil
+	case ATTRIBUTE_ENUM:
+		for _, option := range d.Options {
+			if strings.EqualFold(option, text) {
+				return option, nil
+			}
+		}
+		return invalid("must be one of %s", strings.Join(d.Options, ", "))
+	default:
+		if d.MaxLength > 0 && len([]rune(text)) > d.MaxLength {
+			return invalid("cannot be longer than %d characters", d.MaxLength)
+		}
+		if d.Pattern != "" && !regexp.MustCompile(string(d.Pattern)).MatchString(text) {
+			return invalid("does not match %s", d.Pattern)
+		}
+		return text, nil
+	}
+}
+
+// AttributeValue is the value of one attribute of a product. It is kept as
+// text and written out in JSON as a number, boolean or string according to
+// Type.
+type AttributeValue struct {
+	Text string
+	Type AttributeDataType
+}
+
+func (v *AttributeValue) UnmarshalJSON(data []byte) error {
+	var text string
+	switch {
+	case string(data) == "null":
+	case len(data) > 0 && data[0] == '"':
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+	case len(data) > 0 && (data[0] == '{' || data[0] == '['):
+		return fmt.Errorf("%w: values must be strings, numbers or booleans", ErrInvalidAttributeValue)
+	default:
+		text = string(data)
+	}
+	v.Text = text
+	return nil
+}
+
+func (v AttributeValue) MarshalJSON() ([]byte, error) {
+	switch {
+	case v.Text == "" && (v.Type == ATTRIBUTE_NUMBER || v.Type == ATTRIBUTE_BOOLEAN):
+		return []byte("null"), nil
+	case v.Type == ATTRIBUTE_NUMBER || v.Type == ATTRIBUTE_BOOLEAN:
+		return []byte(v.Text), nil
+	default:
+		return json.Marshal(v.Text)
+	}
+}
+
+// Cell returns the value as a typed spreadsheet cell.
+func (v *AttributeValue) Cell() interface{} {
+	if v == nil {
+		return nil
+	}
+	switch v.Type {
+	case ATTRIBUTE_NUMBER:
+		if value, err := customtypes.ParseDecimal(v.Text); err == nil {
+			return value
+		}
+	case ATTRIBUTE_BOOLEAN:
+		return v.Text == "true"
+	}
+	return v.Text
+}
+
+// NormalizeAttributes checks the attribute values of the product against the
+// definitions of its type and brings them to their canonical form. Blank
+// values are dropped, so that a required attribute cannot be left blank.
+func (p *Product) NormalizeAttributes(definitions []*AttributeDefinition) error {
+	known := make(map[string]*AttributeDefinition, len(definitions))
+	for _, definition := range definitions {
+		known[definition.Code] = definition
+	}
+	values := make(map[string]*AttributeValue, len(p.Attributes))
+	for code, value := range p.Attributes {
+		definition, ok := known[code]
+		if !ok {
+			return fmt.Errorf("%w: %s is not an attribute of %s", ErrInvalidAttributeValue, code, p.Type)
+		}
+		if value == nil || strings.TrimSpace(value.Text) == "" {
+			continue
+		}
+		text, err := definition.Normalize(value.Text)
+		if err != nil {
+			return err
+		}
+		values[code] = &AttributeValue{Text: text, Type: definition.DataType}
+	}
+	for _, definition := range definitions {
+		if _, ok := values[definition.Code]; definition.Required && !ok {
+			return fmt.Errorf("%w: %s is required", ErrInvalidAttributeValue, definition.Code)
+		}
+	}
+	p.Attributes = values
+	return nil
+}
+
+// AttributeOperator compares an attribute with the value of a filter.
+type AttributeOperator string
+
+const (
+	ATTRIBUTE_EQ  AttributeOperator = "eq"
+	ATTRIBUTE_NE  AttributeOperator = "ne"
+	ATTRIBUTE_GT  AttributeOperator = "gt"
+	ATTRIBUTE_GTE AttributeOperator = "gte"
+	ATTRIBUTE_LT  AttributeOperator = "lt"
+	ATTRIBUTE_LTE AttributeOperator = "lte"
+)
+
+// AttributeFilter narrows products to those whose attribute Code compares
+// with Value. Comparisons other than eq and ne apply to numbers and dates.
+type AttributeFilter struct {
+	Code     string
+	Operator AttributeOperator
+	Value    string
+}
+
+// ParseAttributeFilter reads a query parameter such as attr.grade=A or
+// attr.thickness_gt=2. ok is false for parameters that are not attribute
+// filters.
+func ParseAttributeFilter(key string, value string) (filter AttributeFilter, ok bool) {
+	code := strings.TrimPrefix(key, "attr.")
+	if code == key || code == "" {
+		return AttributeFilter{}, false
+	}
+	filter = AttributeFilter{Code: strings.ToLower(code), Operator: ATTRIBUTE_EQ, Value: strings.TrimSpace(value)}
+	for _, operator := range []AttributeOperator{ATTRIBUTE_NE, ATTRIBUTE_GTE, ATTRIBUTE_GT, ATTRIBUTE_LTE, ATTRIBUTE_LT} {
+		if base := strings.TrimSuffix(filter.Code, "_"+string(operator)); base != filter.Code && base != "" {
+			filter.Code, filter.Operator = base, operator
+			break
+		}
+	}
+	return filter, true
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+func TestParseAttributeFilter(t *testing.T) {
+	tests := []struct {
+		key    string
+		value  string
+		filter AttributeFilter
+		ok     bool
+	}{
+		{key: "attr.grade", value: "A", filter: AttributeFilter{Code: "grade", Operator: ATTRIBUTE_EQ, Value: "A"}, ok: true},
+		{key: "attr.Grade", value: " A ", filter: AttributeFilter{Code: "grade", Operator: ATTRIBUTE_EQ, Value: "A"}, ok: true},
+		{key: "attr.thickness_gt", value: "2", filter: AttributeFilter{Code: "thickness", Operator: ATTRIBUTE_GT, Value: "2"}, ok: true},
+		{key: "attr.thickness_gte", value: "2", filter: AttributeFilter{Code: "thickness", Operator: ATTRIBUTE_GTE, Value: "2"}, ok: true},
+		{key: "attr.made_on_lt", value: "2024-01-01", filter: AttributeFilter{Code: "made_on", Operator: ATTRIBUTE_LT, Value: "2024-01-01"}, ok: true},
+		{key: "attr.made_on_lte", value: "2024-01-01", filter: AttributeFilter{Code: "made_on", Operator: ATTRIBUTE_LTE, Value: "2024-01-01"}, ok: true},
+		{key: "attr.colour_ne", value: "red", filter: AttributeFilter{Code: "colour", Operator: ATTRIBUTE_NE, Value: "red"}, ok: true},
+		{key: "attr.gt", value: "1", filter: AttributeFilter{Code: "gt", Operator: ATTRIBUTE_EQ, Value: "1"}, ok: true},
+		{key: "attr._gt", value: "1", filter: AttributeFilter{Code: "_gt", Operator: ATTRIBUTE_EQ, Value: "1"}, ok: true},
+		{key: "attr.", value: "1"},
+		{key: "grade", value: "A"},
+	}
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			filter, ok := ParseAttributeFilter(test.key, test.value)
+			if ok != test.ok || filter != test.filter {
+				t.Errorf("ParseAttributeFilter(%q, %q) = %+v, %v, want %+v, %v", test.key, test.value, filter, ok, test.filter, test.ok)
+			}
+		})
+	}
+}
+
+func TestAttributeDefinitionNormalize(t *testing.T) {
+	min, max := customtypes.MustParseDecimal("0.5"), customtypes.MustParseDecimal("10")
+	number := &AttributeDefinition{Code: "thickness", DataType: ATTRIBUTE_NUMBER}
+	bounded := &AttributeDefinition{Code: "thickness", DataType: ATTRIBUTE_NUMBER, MinValue: &min, MaxValue: &max}
+	enum := &AttributeDefinition{Code: "grade", DataType: ATTRIBUTE_ENUM, Options: []string{"A", "B"}}
+	text := &AttributeDefinition{Code: "lot", DataType: ATTRIBUTE_STRING, MaxLength: 4, Pattern: "^[A-Z]+$"}
+	tests := []struct {
+		name       string
+		definition *AttributeDefinition
+		text       string
+		want       string
+		invalid    bool
+	}{
+		{name: "number", definition: number, text: " 2.500 ", want: "2.5"},
+		{name: "largest number", definition: number, text: "-999999999999.999999", want: "-999999999999.999999"},
+		{name: "too many digits", definition: number, text: "1000000000000", invalid: true},
+		{name: "too many fractional digits", definition: number, text: "1.0000001", invalid: true},
+		{name: "not a number", definition: number, text: "2mm", invalid: true},
+		{name: "below min", definition: bounded, text: "0.4", invalid: true},
+		{name: "above max", definition: bounded, text: "10.01", invalid: true},
+		{name: "boolean", definition: &AttributeDefinition{DataType: ATTRIBUTE_BOOLEAN}, text: "1", want: "true"},
+		{name: "date", definition: &AttributeDefinition{DataType: ATTRIBUTE_DATE}, text: "2024-02-30", invalid: true},
+		{name: "enum", definition: enum, text: "b", want: "B"},
+		{name: "unknown option", definition: enum, text: "C", invalid: true},
+		{name: "string", definition: text, text: "ABCD", want: "ABCD"},
+		{name: "too long", definition: text, text: "ABCDE", invalid: true},
+		{name: "pattern", definition: text, text: "ab", invalid: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.definition.Normalize(test.text)
+			if test.invalid {
+				if !errors.Is(err, ErrInvalidAttributeValue) {
+					t.Fatalf("Normalize(%q) = %q, %v, want ErrInvalidAttributeValue", test.text, got, err)
+				}
+				return
+			}
+			if err != nil || got != test.want {
+				t.Errorf("Normalize(%q) = %q, %v, want %q", test.text, got, err, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+var (
+	ErrInvalidBOM       = errors.New("invalid bom line")
+	ErrBOMCycle         = errors.New("bill of materials would contain its own product")
+	ErrBOMComponentUnit = errors.New("bom unit must match the component unit")
+	ErrBOMChanged       = errors.New("a component bill of materials changed at the same time; try again")
+)
+
+// MaxBOMDepth bounds BOM traversal. Real structures are a handful of levels
+// deep; anything beyond this is treated as a cycle.
+const MaxBOMDepth = 20
+
+func invalidBOM(message string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidBOM, message)
+}
+
+// BOMLine is one component of a product's bill of materials. QuantityPer is
+// needed for each unit of the parent; ScrapFactor is the extra fraction lost
+// in production, e.g. 0.02 for 2%.
+type BOMLine struct {
+	ID            int64                      `json:"id"`
+	ProductID     int64                      `json:"product_id"`
+	ComponentID   int64                      `json:"component_id"`
+	ComponentCode customtypes.NullableString `json:"component_code"`
+	ComponentName customtypes.NullableString `json:"component_name"`
+	QuantityPer   customtypes.Decimal        `json:"quantity_per"`
+	Unit          string                     `json:"unit"`
+	ScrapFactor   customtypes.Decimal        `json:"scrap_factor"`
+	EffectiveFrom *time.Time                 `json:"effective_from"`
+	EffectiveTo   *time.Time                 `json:"effective_to"`
+}
+
+func (l *BOMLine) Validate() error {
+	if l.ComponentID <= 0 {
+		return invalidBOM("component is required")
+	}
+	if l.ComponentID == l.ProductID {
+		return ErrBOMCycle
+	}
+	if l.QuantityPer.Sign() <= 0 {
+		return invalidBOM("quantity per must be greater than zero")
+	}
+	if l.Unit == "" {
+		return invalidBOM("unit is required")
+	}
+	if l.ScrapFactor.Sign() < 0 || l.ScrapFactor.Cmp(customtypes.NewDecimalFromInt(1)) >= 0 {
+		return invalidBOM("scrap factor must be at least 0 and less than 1")
+	}
+	if l.EffectiveFrom != nil && l.EffectiveTo != nil && l.EffectiveTo.Before(*l.EffectiveFrom) {
+		return invalidBOM("effective to cannot be before effective from")
+	}
+	return nil
+}
+
+// IsEffective reports whether the line applies to production at time at. The
+// effective to date is inclusive.
+func (l *BOMLine) IsEffective(at time.Time) bool {
+	if l.EffectiveFrom != nil && at.Before(*l.EffectiveFrom) {
+		return false
+	}
+	return l.EffectiveTo == nil || !at.After(*l.EffectiveTo)
+}
+
+// Required returns the component quantity consumed to make quantity units of
+// the parent, including scrap, rounded up to the precision of the unit.
+func (l *BOMLine) Required(quantity customtypes.Decimal) (customtypes.Decimal, error) {
+	return customtypes.RequiredQuantity(quantity, l.QuantityPer, l.ScrapFactor, l.Unit)
+}
+
+// CheckBOMCycle searches the BOMs below components for productID, reading
+// the components of each product with children. Shared sub-assemblies are
+// read once.
+func CheckBOMCycle(productID int64, components []int64, children func(int64) ([]int64, error)) error {
+	visited := make(map[int64]bool)
+	var search func(componentID int64, depth int) error
+	search = func(componentID int64, depth int) error {
+		if componentID == productID || depth > MaxBOMDepth {
+			return ErrBOMCycle
+		}
+		if visited[componentID] {
+			return nil
+		}
+		visited[componentID] = true
+		below, err := children(componentID)
+		if err != nil {
+			return err
+		}
+		for _, id := range below {
+			if err := search(id, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, id := range components {
+		if err := search(id, 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BOMRequirement is a node of an exploded bill of materials.
+type BOMRequirement struct {
+	Level         int                        `json:"level"`
+	ComponentID   int64                      `json:"component_id"`
+	ComponentCode customtypes.NullableString `json:"component_code"`
+	ComponentName customtypes.NullableString `json:"component_name"`
+	Quantity      customtypes.Decimal        `json:"quantity"`
+	Unit          string                     `json:"unit"`
+	Components    []*BOMRequirement          `json:"components,omitempty"`
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckBOMCycle(t *testing.T) {
+	graph := map[int64][]int64{
+		2: {3, 4},
+		3: {4},
+		4: {},
+		5: {1},
+		6: {7},
+		7: {6},
+	}
+	children := func(id int64) ([]int64, error) {
+		return graph[id], nil
+	}
+
+	tests := []struct {
+		name       string
+		components []int64
+		want       error
+	}{
+		{"no components", nil, nil},
+		{"shared sub-assembly", []int64{2, 3}, nil},
+		{"direct cycle", []int64{1}, ErrBOMCycle},
+		{"cycle below a component", []int64{4, 5}, ErrBOMCycle},
+		{"cycle not through the product", []int64{6}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckBOMCycle(1, tt.components, children); !errors.Is(err, tt.want) {
+				t.Errorf("CheckBOMCycle(%v) = %v, want %v", tt.components, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckBOMCycleDepth(t *testing.T) {
+	children := func(id int64) ([]int64, error) {
+		return []int64{id + 1}, nil
+	}
+	if err := CheckBOMCycle(0, []int64{1}, children); !errors.Is(err, ErrBOMCycle) {
+		t.Errorf("CheckBOMCycle on an unbounded chain = %v, want %v", err, ErrBOMCycle)
+	}
+}
+
+func TestCheckBOMCycleReadError(t *testing.T) {
+	failed := errors.New("read failed")
+	children := func(int64) ([]int64, error) {
+		return nil, failed
+	}
+	if err := CheckBOMCycle(1, []int64{2}, children); !errors.Is(err, failed) {
+		t.Errorf("CheckBOMCycle = %v, want %v", err, failed)
+	}
+}
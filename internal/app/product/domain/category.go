@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+var (
+	ErrInvalidCategory       = errors.New("invalid category")
+	ErrCategoryCycle         = errors.New("category cannot be moved below itself")
+	ErrDuplicateCategoryCode = errors.New("category code is already in use")
+	ErrCategoryNotEmpty      = errors.New("category still has subcategories or products")
+	ErrCategoryTooDeep       = fmt.Errorf("category tree cannot be more than %d levels deep", MaxCategoryDepth)
+)
+
+// MaxCategoryDepth bounds the levels of the category tree, which keeps the
+// path of the deepest category within its column.
+const MaxCategoryDepth = 20
+
+// Category is a node of the product classification tree, of any depth. Path
+// lists the ids from the root down to the category itself, e.g. "/3/17/42/",
+// so that a subtree can be selected with a prefix match.
+type Category struct {
+	ID            int64                      `json:"id"`
+	ParentID      *int64                     `json:"parent_id"`
+	Path          string                     `json:"path"`
+	Code          string                     `json:"code"`
+	Name          customtypes.NullableString `json:"name"`
+	Description   customtypes.NullableString `json:"description"`
+	Status        customtypes.NullableString `json:"status"`
+	CreatedAt     time.Time                  `json:"created_at"`
+	UpdatedAt     time.Time                  `json:"updated_at"`
+	LastUpdatedBy customtypes.NullableString `json:"last_updated_by"`
+	Version       int64                      `json:"version"`
+	Children      []*Category                `json:"children,omitempty"`
+}
+
+func NewCategoryWithDefaults() *Category {
+	return &Category{Status: "active"}
+}
+
+func (c *Category) Validate() error {
+	c.Code = strings.ToUpper(strings.TrimSpace(c.Code))
+	if c.Code == "" {
+		return fmt.Errorf("%w: code is required", ErrInvalidCategory)
+	}
+	if c.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidCategory)
+	}
+	if c.Status == "" {
+		return fmt.Errorf("%w: status is required", ErrInvalidCategory)
+	}
+	return nil
+}
+
+// ValidateParent checks that the category may be placed in parent, or at the
+// root when parent is nil: a category cannot be placed in itself or anywhere
+// below it, and its subtree, height levels deep counting the category itself,
+// must not take the tree past MaxCategoryDepth.
+func (c *Category) ValidateParent(parent *Category, height int) error {
+	depth := height
+	if parent != nil {
+		if parent.ID == c.ID || c.IsAncestorOf(parent) {
+			return ErrCategoryCycle
+		}
+		depth += parent.Depth()
+	}
+	if depth > MaxCategoryDepth {
+		return ErrCategoryTooDeep
+	}
+	return nil
+}
+
+// IsAncestorOf reports whether other is somewhere below the category.
+func (c *Category) IsAncestorOf(other *Category) bool {
+	return c.Path != "" && other.Path != c.Path && strings.HasPrefix(other.Path, c.Path)
+}
+
+// Depth is the level of the category in the tree, 1 for a root category.
+func (c *Category) Depth() int {
+	return strings.Count(c.Path, "/") - 1
+}
+
+// CategoryChildPath returns the path of a category with the given id placed
+// in parent, or at the root when parent is nil.
+func CategoryChildPath(parent *Category, id int64) string {
+	prefix := "/"
+	if parent != nil {
+		prefix = parent.Path
+	}
+	return prefix + strconv.FormatInt(id, 10) + "/"
+}
+
+// BuildCategoryTree nests categories, ordered by path, under their parents
+// and returns the roots. A category whose parent is not among categories is
+// returned as a root, so that a subtree can be built as well.
+func BuildCategoryTree(categories []*Category) []*Category {
+	byID := make(map[int64]*Category, len(categories))
+	roots := []*Category{}
+	for _, category := range categories {
+		byID[category.ID] = category
+		if category.ParentID != nil {
+			if parent, ok := byID[*category.ParentID]; ok {
+				parent.Children = append(parent.Children, category)
+				continue
+			}
+		}
+		roots = append(roots, category)
+	}
+	return roots
+}
+
+// CategoryMoveForm places a category under ParentID, or at the root when it
+// is nil.
+type CategoryMoveForm struct {
+	ParentID *int64 `json:"parent_id"`
+}
+
+// SetCategories checks the primary and secondary categories of the product.
+// The primary category is not repeated among the secondary ones.
+func (p *Product) SetCategories() error {
+	if p.CategoryID != nil && *p.CategoryID <= 0 {
+		p.CategoryID = nil
+	}
+	if p.SecondaryCategoryIDs == nil {
+		return nil
+	}
+	if len(p.SecondaryCategoryIDs) > 0 && p.CategoryID == nil {
+		return fmt.Errorf("%w: secondary categories need a primary category", ErrInvalidCategory)
+	}
+	seen := make(map[int64]bool)
+	ids := []int64{}
+	for _, id := range p.SecondaryCategoryIDs {
+		if id <= 0 || seen[id] || id == *p.CategoryID {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	p.SecondaryCategoryIDs = ids
+	return nil
+}
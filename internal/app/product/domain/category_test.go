@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCategoryValidateParent(t *testing.T) {
+	category := func(path string) *Category {
+		ids := strings.Split(strings.Trim(path, "/"), "/")
+		id, _ := strconv.ParseInt(ids[len(ids)-1], 10, 64)
+		return &Category{ID: id, Path: path}
+	}
+	deepest := "/"
+	for id := 1; id < MaxCategoryDepth; id++ {
+		deepest += strconv.Itoa(id) + "/"
+	}
+	tests := []struct {
+		name     string
+		category *Category
+		parent   *Category
+		height   int
+		err      error
+	}{
+		{name: "to the root", category: category("/1/2/"), height: 1},
+		{name: "under a sibling", category: category("/1/2/"), parent: category("/1/3/"), height: 1},
+		{name: "under its parent", category: category("/1/2/"), parent: category("/1/"), height: 3},
+		{name: "new category", category: &Category{}, parent: category("/1/"), height: 1},
+		{name: "under itself", category: category("/1/2/"), parent: category("/1/2/"), height: 1, err: ErrCategoryCycle},
+		{name: "under a descendant", category: category("/1/2/"), parent: category("/1/2/5/"), height: 2, err: ErrCategoryCycle},
+		{name: "id that is a prefix of another", category: category("/1/"), parent: category("/12/"), height: 1},
+		{name: "down to the last level", category: category("/99/"), parent: category(deepest), height: 1},
+		{name: "past the last level", category: category("/99/"), parent: category(deepest), height: 2, err: ErrCategoryTooDeep},
+		{name: "subtree too deep for the root", category: category("/99/"), height: MaxCategoryDepth + 1, err: ErrCategoryTooDeep},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.category.ValidateParent(test.parent, test.height); !errors.Is(err, test.err) {
+				t.Errorf("ValidateParent() = %v, want %v", err, test.err)
+			}
+		})
+	}
+}
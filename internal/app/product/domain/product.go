@@ -2,8 +2,10 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
+	"github.com/vamika-digital/wms-api-server/internal/utility/codepattern"
 	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
 )
 
@@ -23,10 +25,27 @@ type Product struct {
 	Name          customtypes.NullableString `json:"name"`
 	Description   customtypes.NullableString `json:"description"`
 	Unit          customtypes.NullableString `json:"unit"`
+	Serialized    bool                       `json:"serialized"`
+	SerialPattern customtypes.NullableString `json:"serial_pattern"`
+	// Weight in kg and Volume in m³ are per unit of the product. Zero means
+	// not known, and the product is then ignored by capacity checks.
+	Weight customtypes.Decimal `json:"weight"`
+	Volume customtypes.Decimal `json:"volume"`
+	BOM    []*BOMLine          `json:"bom,omitempty"`
+	// Attributes holds the custom attributes defined for the type of the
+	// product, by code. Left out of an update, the stored values are kept.
+	Attributes    map[string]*AttributeValue `json:"attributes"`
 	Status        customtypes.NullableString `json:"status"`
 	CreatedAt     time.Time                  `json:"created_at"`
 	UpdatedAt     time.Time                  `json:"updated_at"`
 	LastUpdatedBy customtypes.NullableString `json:"last_updated_by"`
+	Version       int64                      `json:"version"`
+	// CategoryID is the primary category of the product;
+	// SecondaryCategoryIDs lists the other categories it is found under. Left
+	// out of an update, the stored categories are kept; a CategoryID of 0
+	// clears the primary category.
+	CategoryID           *int64  `json:"category_id"`
+	SecondaryCategoryIDs []int64 `json:"secondary_category_ids"`
 }
 
 func NewProductWithDefaults() Product {
@@ -36,6 +55,32 @@ func NewProductWithDefaults() Product {
 	}
 }
 
+// ValidateSerialPattern checks that generated serials can be unique. A
+// pattern such as "FG-{YYYY}{MM}-{SEQ:6}" must contain a {SEQ} token padded
+// to at most codepattern.MaxPadWidth digits.
+func (p *Product) ValidateSerialPattern() error {
+	if p.SerialPattern == "" {
+		return nil
+	}
+	if !p.Serialized {
+		return errors.New("serial pattern is only allowed on serialized products")
+	}
+	if err := codepattern.ValidateSequence(string(p.SerialPattern)); err != nil {
+		return fmt.Errorf("serial pattern: %w", err)
+	}
+	return nil
+}
+
+func (p *Product) ValidateDimensions() error {
+	if p.Weight.Sign() < 0 {
+		return errors.New("weight cannot be negative")
+	}
+	if p.Volume.Sign() < 0 {
+		return errors.New("volume cannot be negative")
+	}
+	return nil
+}
+
 func (p *Product) ValidateType() error {
 	switch p.Type {
 	case RAW_MATERIAL_TYPE, SEMI_FINISHED_GOODS_TYPE, FINISHED_GOODS_TYPE:
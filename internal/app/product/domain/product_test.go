@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+func TestValidateSerialPattern(t *testing.T) {
+	tests := []struct {
+		pattern    customtypes.NullableString
+		serialized bool
+		valid      bool
+	}{
+		{pattern: "", valid: true},
+		{pattern: "FG-{YYYY}{MM}-{SEQ:6}", serialized: true, valid: true},
+		{pattern: "FG-{SEQ:6}", serialized: false, valid: false},
+		{pattern: "FG-{YYYY}", serialized: true, valid: false},
+		{pattern: "FG-{SEQ:abc}", serialized: true, valid: false},
+		{pattern: "FG-{SEQ:999999999}", serialized: true, valid: false},
+	}
+	for _, test := range tests {
+		product := Product{SerialPattern: test.pattern, Serialized: test.serialized}
+		if err := product.ValidateSerialPattern(); (err == nil) != test.valid {
+			t.Errorf("ValidateSerialPattern(%q, serialized %v) = %v, want valid %v", test.pattern, test.serialized, err, test.valid)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/internal/app/product/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/product/repository"
+	"github.com/vamika-digital/wms-api-server/internal/utility/etag"
+)
+
+func (handler *ProductHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	category := domain.NewCategoryWithDefaults()
+	if err := json.NewDecoder(r.Body).Decode(category); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.CreateCategory(category); err != nil {
+		handler.handleCategoryError(w, 0, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(category); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// UpdateCategory changes the code, name, description and status of a
+// category. It is moved in the tree with MoveCategory.
+func (handler *ProductHandler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Category ID", http.StatusBadRequest)
+		return
+	}
+
+	var category domain.Category
+	if err := json.NewDecoder(r.Body).Decode(&category); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	category.ID = id
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if version > 0 {
+		category.Version = version
+	}
+
+	if err := handler.UseCase.UpdateCategory(&category); err != nil {
+		handler.handleCategoryError(w, id, err)
+		return
+	}
+
+	etag.Set(w, category.Version)
+	w.WriteHeader(http.StatusOK)
+}
+
+// MoveCategory places the category, with everything below it, under the
+// parent_id of the body, or at the root when it is null.
+func (handler *ProductHandler) MoveCategory(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Category ID", http.StatusBadRequest)
+		return
+	}
+
+	var form domain.CategoryMoveForm
+	if err := json.NewDecoder(r.Body).Decode(&form); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	category, err := handler.UseCase.MoveCategory(id, form.ParentID, version)
+	if err != nil {
+		handler.handleCategoryError(w, id, err)
+		return
+	}
+
+	etag.Set(w, category.Version)
+	if err := json.NewEncoder(w).Encode(category); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *ProductHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Category ID", http.StatusBadRequest)
+		return
+	}
+
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.DeleteCategory(id, version); err != nil {
+		handler.handleCategoryError(w, id, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (handler *ProductHandler) GetCategoryByID(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Category ID", http.StatusBadRequest)
+		return
+	}
+
+	category, err := handler.UseCase.GetCategoryByID(id)
+	if err != nil {
+		handler.handleCategoryError(w, id, err)
+		return
+	}
+
+	etag.Set(w, category.Version)
+	if err := json.NewEncoder(w).Encode(category); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// GetCategories lists categories by path, limited to the subtree of ?root_id=
+// when given. ?tree=true nests them under their parents.
+func (handler *ProductHandler) GetCategories(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := repository.CategoryFilterOptions{
+		Code:   query.Get("code"),
+		Name:   query.Get("name"),
+		Status: query.Get("status"),
+	}
+	filter.RootID, _ = strconv.ParseInt(query.Get("root_id"), 10, 64)
+	asTree, _ := strconv.ParseBool(query.Get("tree"))
+
+	categories, err := handler.UseCase.GetCategories(filter, asTree)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(categories); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *ProductHandler) handleCategoryError(w http.ResponseWriter, categoryID int64, err error) {
+	current := func() (int64, interface{}, error) {
+		category, err := handler.UseCase.GetCategoryByID(categoryID)
+		if err != nil {
+			return 0, nil, err
+		}
+		return category.Version, category, nil
+	}
+	etag.WriteError(w, err, current, map[error]int{
+		domain.ErrCategoryCycle:         http.StatusConflict,
+		domain.ErrDuplicateCategoryCode: http.StatusConflict,
+		domain.ErrCategoryNotEmpty:      http.StatusConflict,
+		domain.ErrCategoryTooDeep:       http.StatusConflict,
+		domain.ErrInvalidCategory:       http.StatusBadRequest,
+	})
+}
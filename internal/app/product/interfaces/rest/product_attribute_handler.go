@@ -0,0 +1,132 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/internal/app/product/domain"
+	"github.com/vamika-digital/wms-api-server/internal/utility/etag"
+)
+
+func (handler *ProductHandler) CreateAttribute(w http.ResponseWriter, r *http.Request) {
+	var definition domain.AttributeDefinition
+	if err := json.NewDecoder(r.Body).Decode(&definition); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.CreateAttribute(&definition); err != nil {
+		handler.handleAttributeError(w, 0, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(definition); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *ProductHandler) UpdateAttribute(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Attribute ID", http.StatusBadRequest)
+		return
+	}
+
+	var definition domain.AttributeDefinition
+	if err := json.NewDecoder(r.Body).Decode(&definition); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	definition.ID = id
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if version > 0 {
+		definition.Version = version
+	}
+
+	if err := handler.UseCase.UpdateAttribute(&definition); err != nil {
+		handler.handleAttributeError(w, id, err)
+		return
+	}
+
+	etag.Set(w, definition.Version)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (handler *ProductHandler) DeleteAttribute(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Attribute ID", http.StatusBadRequest)
+		return
+	}
+
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.DeleteAttribute(id, version); err != nil {
+		handler.handleAttributeError(w, id, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (handler *ProductHandler) GetAttributeByID(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Attribute ID", http.StatusBadRequest)
+		return
+	}
+
+	definition, err := handler.UseCase.GetAttributeByID(id)
+	if err != nil {
+		handler.handleAttributeError(w, id, err)
+		return
+	}
+
+	etag.Set(w, definition.Version)
+	if err := json.NewEncoder(w).Encode(definition); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// GetAttributes lists the attribute definitions, of one ?product_type= or of
+// all types.
+func (handler *ProductHandler) GetAttributes(w http.ResponseWriter, r *http.Request) {
+	definitions, err := handler.UseCase.GetAttributes(r.URL.Query().Get("product_type"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(definitions); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *ProductHandler) handleAttributeError(w http.ResponseWriter, definitionID int64, err error) {
+	current := func() (int64, interface{}, error) {
+		definition, err := handler.UseCase.GetAttributeByID(definitionID)
+		if err != nil {
+			return 0, nil, err
+		}
+		return definition.Version, definition, nil
+	}
+	etag.WriteError(w, err, current, map[error]int{
+		domain.ErrDuplicateAttribute:         http.StatusConflict,
+		domain.ErrAttributeDefinitionLocked:  http.StatusConflict,
+		domain.ErrInvalidAttributeDefinition: http.StatusBadRequest,
+	})
+}
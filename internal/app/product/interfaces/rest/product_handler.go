@@ -3,13 +3,21 @@ package rest
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/vamika-digital/wms-api-server/internal/app/product/domain"
 	"github.com/vamika-digital/wms-api-server/internal/app/product/repository"
 	"github.com/vamika-digital/wms-api-server/internal/app/product/usecase"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+	"github.com/vamika-digital/wms-api-server/internal/utility/etag"
+	"github.com/vamika-digital/wms-api-server/internal/utility/spreadsheet"
 	"github.com/vamika-digital/wms-api-server/internal/utility/valueobjects"
 )
 
@@ -35,7 +43,7 @@ func (handler *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Requ
 	}
 
 	if err := handler.UseCase.CreateProduct(&product); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		handler.handleWriteError(w, product.ID, err)
 		return
 	}
 
@@ -62,11 +70,20 @@ func (handler *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Requ
 	}
 
 	product.ID = int64(id)
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if version > 0 {
+		product.Version = version
+	}
 	if err := handler.UseCase.UpdateProduct(&product); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		handler.handleWriteError(w, product.ID, err)
 		return
 	}
 
+	etag.Set(w, product.Version)
 	w.WriteHeader(http.StatusOK)
 }
 
@@ -78,8 +95,14 @@ func (handler *ProductHandler) DeleteProduct(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	if err := handler.UseCase.DeleteProduct(id); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.DeleteProduct(id, version); err != nil {
+		handler.handleWriteError(w, id, err)
 		return
 	}
 
@@ -100,6 +123,7 @@ func (handler *ProductHandler) GetProductByID(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	etag.Set(w, product.Version)
 	if err := json.NewEncoder(w).Encode(product); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
@@ -122,13 +146,7 @@ func (handler *ProductHandler) GetAllProducts(w http.ResponseWriter, r *http.Req
 	}
 
 	// Extract filter parameters
-	filterOptions := repository.ProductFilterOptions{
-		Type:    r.URL.Query().Get("type"),
-		Code:    r.URL.Query().Get("code"),
-		RawCode: r.URL.Query().Get("raw_code"),
-		Name:    r.URL.Query().Get("name"),
-		Status:  r.URL.Query().Get("status"),
-	}
+	filterOptions := productFilterOptions(r)
 
 	products, totalProducts, err := handler.UseCase.GetAllProducts(page, pageSize, sort, filterOptions)
 	if err != nil {
@@ -157,6 +175,195 @@ func (handler *ProductHandler) GetAllProducts(w http.ResponseWriter, r *http.Req
 	}
 }
 
+// ExportProducts downloads the products matching the list filters as a csv
+// or xlsx (?format=, default csv), with a column for every custom attribute.
+func (handler *ProductHandler) ExportProducts(w http.ResponseWriter, r *http.Request) {
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		format = spreadsheet.FormatCSV
+	}
+	if format != spreadsheet.FormatCSV && format != spreadsheet.FormatXLSX {
+		http.Error(w, "format must be csv or xlsx", http.StatusBadRequest)
+		return
+	}
+
+	products, definitions, err := handler.UseCase.ExportProducts(r.URL.Query().Get("sort"), productFilterOptions(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	filename := fmt.Sprintf("products-%s.%s", time.Now().Format("2006-01-02"), format)
+	w.Header().Set("Content-Type", spreadsheet.ContentType(format))
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	// Part of the export may be sent already, so the status cannot change.
+	if err := spreadsheet.Write(w, format, productSheet(products, definitions)); err != nil {
+		log.Printf("failed to write %s export: %v", format, err)
+	}
+}
+
+// productSheet lays out products with their attributes after the fixed
+// columns. Attributes sharing a code across product types share a column.
+func productSheet(products []*domain.Product, definitions []*domain.AttributeDefinition) *spreadsheet.Sheet {
+	sheet := &spreadsheet.Sheet{Name: "Products"}
+	sheet.Headers = []string{"ID", "Type", "Code", "Raw Code", "Name", "Description", "Category ID", "Unit", "Serialized", "Weight (kg)", "Volume (m³)", "Status", "Updated At"}
+	var codes []string
+	seen := make(map[string]bool)
+	for _, definition := range definitions {
+		if seen[definition.Code] {
+			continue
+		}
+		seen[definition.Code] = true
+		codes = append(codes, definition.Code)
+		sheet.Headers = append(sheet.Headers, definition.Label())
+	}
+
+	for _, product := range products {
+		var categoryID interface{}
+		if product.CategoryID != nil {
+			categoryID = *product.CategoryID
+		}
+		row := []interface{}{product.ID, string(product.Type), string(product.Code), string(product.RawCode), string(product.Name), string(product.Description),
+			categoryID, string(product.Unit), product.Serialized, product.Weight, product.Volume, string(product.Status), product.UpdatedAt}
+		for _, code := range codes {
+			row = append(row, product.Attributes[code].Cell())
+		}
+		sheet.Rows = append(sheet.Rows, row)
+	}
+	return sheet
+}
+
+// productFilterOptions reads the list filters, including ?category_id=, which
+// takes in the categories below it, and attribute filters such as
+// ?attr.grade=A&attr.thickness_gt=2.
+func productFilterOptions(r *http.Request) repository.ProductFilterOptions {
+	query := r.URL.Query()
+	filter := repository.ProductFilterOptions{
+		Type:    query.Get("type"),
+		Code:    query.Get("code"),
+		RawCode: query.Get("raw_code"),
+		Name:    query.Get("name"),
+		Status:  query.Get("status"),
+	}
+	filter.CategoryID, _ = strconv.ParseInt(query.Get("category_id"), 10, 64)
+	for key, values := range query {
+		for _, value := range values {
+			if attribute, ok := domain.ParseAttributeFilter(key, value); ok {
+				filter.Attributes = append(filter.Attributes, attribute)
+			}
+		}
+	}
+	return filter
+}
+
+func (handler *ProductHandler) GetBOM(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Product ID", http.StatusBadRequest)
+		return
+	}
+
+	lines, err := handler.UseCase.GetBOM(id)
+	if err != nil {
+		handler.handleBOMError(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(lines); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// SetBOM replaces the bill of materials with the lines in the body.
+func (handler *ProductHandler) SetBOM(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Product ID", http.StatusBadRequest)
+		return
+	}
+
+	var lines []*domain.BOMLine
+	if err := json.NewDecoder(r.Body).Decode(&lines); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	lines, err = handler.UseCase.SetBOM(id, lines)
+	if err != nil {
+		handler.handleBOMError(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(lines); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// ExplodeBOM returns the multi-level requirements for ?quantity= units of the
+// product, using the lines effective at ?at= (default now).
+func (handler *ProductHandler) ExplodeBOM(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Product ID", http.StatusBadRequest)
+		return
+	}
+
+	quantity := customtypes.NewDecimalFromInt(1)
+	if value := r.URL.Query().Get("quantity"); value != "" {
+		if quantity, err = customtypes.ParseDecimal(value); err != nil || quantity.Sign() <= 0 {
+			http.Error(w, "invalid quantity", http.StatusBadRequest)
+			return
+		}
+	}
+	at := time.Now()
+	if value := r.URL.Query().Get("at"); value != "" {
+		if at, err = time.Parse(time.RFC3339, value); err != nil {
+			http.Error(w, "invalid at, expected RFC 3339", http.StatusBadRequest)
+			return
+		}
+	}
+
+	requirement, err := handler.UseCase.ExplodeBOM(id, quantity, at)
+	if err != nil {
+		handler.handleBOMError(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(requirement); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *ProductHandler) handleBOMError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, customerrors.ErrResourceNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, domain.ErrBOMCycle), errors.Is(err, domain.ErrBOMChanged):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, domain.ErrInvalidBOM), errors.Is(err, domain.ErrBOMComponentUnit), errors.Is(err, customtypes.ErrDecimalOverflow):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *ProductHandler) handleWriteError(w http.ResponseWriter, productID int64, err error) {
+	current := func() (int64, interface{}, error) {
+		product, err := handler.UseCase.GetProductByID(productID)
+		if err != nil {
+			return 0, nil, err
+		}
+		return product.Version, product, nil
+	}
+	etag.WriteError(w, err, current, map[error]int{
+		domain.ErrInvalidAttributeValue: http.StatusBadRequest,
+		domain.ErrInvalidCategory:       http.StatusBadRequest,
+	})
+}
+
 func validateProduct(product *domain.Product) error {
 	typeErr := product.ValidateType()
 	if typeErr != nil {
@@ -177,5 +384,11 @@ func validateProduct(product *domain.Product) error {
 	if product.Status == "" {
 		return errors.New("status is required")
 	}
+	if err := product.ValidateSerialPattern(); err != nil {
+		return err
+	}
+	if err := product.ValidateDimensions(); err != nil {
+		return err
+	}
 	return nil
 }
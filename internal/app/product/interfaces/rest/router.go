@@ -15,7 +15,7 @@ type ProductModule struct {
 
 func NewProductModule(db database.Connection) *ProductModule {
 	productRepo := repository.NewProductRepository(db)
-	productUsecase := usecase.NewProductUseCase(productRepo)
+	productUsecase := usecase.NewProductUseCase(productRepo, repository.NewProductAttributeRepository(db), repository.NewCategoryRepository(db))
 	productHandler := NewProductHandler(productUsecase)
 
 	return &ProductModule{Handler: productHandler}
@@ -25,7 +25,26 @@ func (u *ProductModule) RegisterRoutes(r *mux.Router) {
 	subRouter := r.PathPrefix("/products").Subrouter()
 	subRouter.HandleFunc("", u.Handler.CreateProduct).Methods(http.MethodPost)
 	subRouter.HandleFunc("", u.Handler.GetAllProducts).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/export", u.Handler.ExportProducts).Methods(http.MethodGet, http.MethodOptions)
 	subRouter.HandleFunc("/{id}", u.Handler.GetProductByID).Methods(http.MethodGet, http.MethodOptions)
 	subRouter.HandleFunc("/{id}", u.Handler.UpdateProduct).Methods(http.MethodPut)
 	subRouter.HandleFunc("/{id}", u.Handler.DeleteProduct).Methods(http.MethodDelete)
+	subRouter.HandleFunc("/{id}/bom", u.Handler.GetBOM).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/{id}/bom", u.Handler.SetBOM).Methods(http.MethodPut)
+	subRouter.HandleFunc("/{id}/bom/explode", u.Handler.ExplodeBOM).Methods(http.MethodGet, http.MethodOptions)
+
+	attributeRouter := r.PathPrefix("/product-attributes").Subrouter()
+	attributeRouter.HandleFunc("", u.Handler.CreateAttribute).Methods(http.MethodPost)
+	attributeRouter.HandleFunc("", u.Handler.GetAttributes).Methods(http.MethodGet, http.MethodOptions)
+	attributeRouter.HandleFunc("/{id}", u.Handler.GetAttributeByID).Methods(http.MethodGet, http.MethodOptions)
+	attributeRouter.HandleFunc("/{id}", u.Handler.UpdateAttribute).Methods(http.MethodPut)
+	attributeRouter.HandleFunc("/{id}", u.Handler.DeleteAttribute).Methods(http.MethodDelete)
+
+	categoryRouter := r.PathPrefix("/categories").Subrouter()
+	categoryRouter.HandleFunc("", u.Handler.CreateCategory).Methods(http.MethodPost)
+	categoryRouter.HandleFunc("", u.Handler.GetCategories).Methods(http.MethodGet, http.MethodOptions)
+	categoryRouter.HandleFunc("/{id}", u.Handler.GetCategoryByID).Methods(http.MethodGet, http.MethodOptions)
+	categoryRouter.HandleFunc("/{id}", u.Handler.UpdateCategory).Methods(http.MethodPut)
+	categoryRouter.HandleFunc("/{id}", u.Handler.DeleteCategory).Methods(http.MethodDelete)
+	categoryRouter.HandleFunc("/{id}/move", u.Handler.MoveCategory).Methods(http.MethodPost)
 }
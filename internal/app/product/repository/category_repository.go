@@ -0,0 +1,21 @@
+package repository
+
+import "github.com/vamika-digital/wms-api-server/internal/app/product/domain"
+
+type CategoryRepository interface {
+	Create(category *domain.Category) error
+	Update(category *domain.Category) error
+	Move(categoryID int64, parentID *int64, version int64) error
+	Delete(categoryID int64, version int64) error
+	GetById(categoryID int64) (*domain.Category, error)
+	GetAll(filter CategoryFilterOptions) ([]*domain.Category, error)
+}
+
+// CategoryFilterOptions selects categories. RootID limits the list to a
+// category and everything below it.
+type CategoryFilterOptions struct {
+	RootID int64
+	Code   string
+	Name   string
+	Status string
+}
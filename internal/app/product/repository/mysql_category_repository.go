@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/vamika-digital/wms-api-server/internal/app/product/domain"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+const categoryColumns = "id, parent_id, path, code, name, description, status, created_at, updated_at, last_updated_by, version"
+
+type MySqlCategoryRepository struct {
+	conn database.Connection
+}
+
+func NewCategoryRepository(conn database.Connection) CategoryRepository {
+	return &MySqlCategoryRepository{conn: conn}
+}
+
+// Create adds the category under its parent. Its path is only known once the
+// id is assigned.
+func (r *MySqlCategoryRepository) Create(category *domain.Category) error {
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		var parent *domain.Category
+		if category.ParentID != nil {
+			var err error
+			if parent, err = lockCategory(tx, *category.ParentID); err != nil {
+				return err
+			}
+		}
+		if err := category.ValidateParent(parent, 1); err != nil {
+			return err
+		}
+
+		query := "INSERT INTO categories (parent_id, code, name, description, status, last_updated_by) VALUES (?, ?, ?, ?, ?, ?)"
+		result, err := tx.Exec(query, category.ParentID, category.Code, category.Name, category.Description, category.Status, category.LastUpdatedBy)
+		if err != nil {
+			return duplicateCategoryError(err)
+		}
+		if category.ID, err = result.LastInsertId(); err != nil {
+			return err
+		}
+		category.Path = domain.CategoryChildPath(parent, category.ID)
+		_, err = tx.Exec("UPDATE categories SET path = ? WHERE id = ?", category.Path, category.ID)
+		return err
+	})
+}
+
+// Update changes the details of a category. Its place in the tree only
+// changes through Move.
+func (r *MySqlCategoryRepository) Update(category *domain.Category) error {
+	query := "UPDATE categories SET code=?, name=?, description=?, status=?, last_updated_by=?, version=LAST_INSERT_ID(version+1) WHERE id=? AND (? = 0 OR version=?)"
+	result, err := r.conn.GetDB().Exec(query, category.Code, category.Name, category.Description, category.Status, category.LastUpdatedBy, category.ID, category.Version, category.Version)
+	if err != nil {
+		return duplicateCategoryError(err)
+	}
+	if err := database.CheckVersionedWrite(result, "category", category.ID, category.Version); err != nil {
+		return err
+	}
+	category.Version = database.NextVersion(result, category.Version)
+	return nil
+}
+
+// Move places the category and everything below it under parentID, or at the
+// root when parentID is nil. Both categories are locked first, so that two
+// moves cannot together close a cycle.
+func (r *MySqlCategoryRepository) Move(categoryID int64, parentID *int64, version int64) error {
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		category, err := lockCategory(tx, categoryID)
+		if err != nil {
+			return err
+		}
+		var parent *domain.Category
+		if parentID != nil {
+			if parent, err = lockCategory(tx, *parentID); err != nil {
+				return err
+			}
+		}
+		height, err := categoryHeight(tx, category)
+		if err != nil {
+			return err
+		}
+		if err := category.ValidateParent(parent, height); err != nil {
+			return err
+		}
+
+		query := "UPDATE categories SET parent_id=?, version=version+1 WHERE id=? AND (? = 0 OR version=?)"
+		result, err := tx.Exec(query, parentID, categoryID, version, version)
+		if err != nil {
+			return err
+		}
+		if err := database.CheckVersionedWrite(result, "category", categoryID, version); err != nil {
+			return err
+		}
+
+		oldPath := category.Path
+		newPath := domain.CategoryChildPath(parent, categoryID)
+		query = "UPDATE categories SET path = CONCAT(?, SUBSTRING(path, ?)) WHERE path LIKE ?"
+		_, err = tx.Exec(query, newPath, len(oldPath)+1, oldPath+"%")
+		return err
+	})
+}
+
+// Delete removes a category that has no subcategories and no products, as
+// primary or secondary category.
+func (r *MySqlCategoryRepository) Delete(categoryID int64, version int64) error {
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		if _, err := lockCategory(tx, categoryID); err != nil {
+			return err
+		}
+		var inUse bool
+		query := `SELECT EXISTS (SELECT 1 FROM categories WHERE parent_id = ?)
+			OR EXISTS (SELECT 1 FROM products WHERE category_id = ?)
+			OR EXISTS (SELECT 1 FROM product_categories WHERE category_id = ?)`
+		if err := tx.QueryRow(query, categoryID, categoryID, categoryID).Scan(&inUse); err != nil {
+			return err
+		}
+		if inUse {
+			return domain.ErrCategoryNotEmpty
+		}
+
+		result, err := tx.Exec("DELETE FROM categories WHERE id=? AND (? = 0 OR version=?)", categoryID, version, version)
+		if err != nil {
+			return err
+		}
+		return database.CheckVersionedWrite(result, "category", categoryID, version)
+	})
+}
+
+func (r *MySqlCategoryRepository) GetById(categoryID int64) (*domain.Category, error) {
+	query := "SELECT " + categoryColumns + " FROM categories WHERE id = ?"
+	category, err := scanCategory(r.conn.GetDB().QueryRow(query, categoryID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, customerrors.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return category, nil
+}
+
+// GetAll lists categories ordered by path, so that parents come before their
+// children. The tree is small enough not to be paginated.
+func (r *MySqlCategoryRepository) GetAll(filter CategoryFilterOptions) ([]*domain.Category, error) {
+	var filters []string
+	var args []interface{}
+	if filter.RootID > 0 {
+		filters = append(filters, "path LIKE CONCAT((SELECT root.path FROM categories root WHERE root.id = ?), '%')")
+		args = append(args, filter.RootID)
+	}
+	if filter.Code != "" {
+		filters = append(filters, "code LIKE ?")
+		args = append(args, "%"+filter.Code+"%")
+	}
+	if filter.Name != "" {
+		filters = append(filters, "name LIKE ?")
+		args = append(args, "%"+filter.Name+"%")
+	}
+	if filter.Status != "" {
+		filters = append(filters, "status = ?")
+		args = append(args, filter.Status)
+	}
+	query := "SELECT " + categoryColumns + " FROM categories"
+	if len(filters) > 0 {
+		query += " WHERE " + strings.Join(filters, " AND ")
+	}
+	query += " ORDER BY path"
+
+	rows, err := r.conn.GetDB().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := []*domain.Category{}
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+	return categories, rows.Err()
+}
+
+func lockCategory(tx *sql.Tx, categoryID int64) (*domain.Category, error) {
+	query := "SELECT " + categoryColumns + " FROM categories WHERE id = ? FOR UPDATE"
+	category, err := scanCategory(tx.QueryRow(query, categoryID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, customerrors.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return category, nil
+}
+
+// categoryHeight counts the levels from the category down to its deepest
+// descendant, locking the subtree so that it cannot grow meanwhile.
+func categoryHeight(tx *sql.Tx, category *domain.Category) (int, error) {
+	var deepest int
+	query := "SELECT MAX(LENGTH(path) - LENGTH(REPLACE(path, '/', ''))) - 1 FROM categories WHERE path LIKE ? FOR UPDATE"
+	if err := tx.QueryRow(query, category.Path+"%").Scan(&deepest); err != nil {
+		return 0, err
+	}
+	return deepest - category.Depth() + 1, nil
+}
+
+func scanCategory(row rowScanner) (*domain.Category, error) {
+	category := &domain.Category{}
+	err := row.Scan(&category.ID, &category.ParentID, &category.Path, &category.Code, &category.Name, &category.Description, &category.Status, &category.CreatedAt, &category.UpdatedAt, &category.LastUpdatedBy, &category.Version)
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func duplicateCategoryError(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+		return domain.ErrDuplicateCategoryCode
+	}
+	return err
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/vamika-digital/wms-api-server/internal/app/product/domain"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+// mysqlDuplicateEntry is the server error number for a unique key violation.
+const mysqlDuplicateEntry = 1062
+
+const attributeColumns = "id, product_type, code, name, data_type, required, unit, min_value, max_value, options, max_length, pattern, position, created_at, updated_at, last_updated_by, version"
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+type MySqlProductAttributeRepository struct {
+	conn database.Connection
+}
+
+func NewProductAttributeRepository(conn database.Connection) ProductAttributeRepository {
+	return &MySqlProductAttributeRepository{conn: conn}
+}
+
+func (r *MySqlProductAttributeRepository) Create(definition *domain.AttributeDefinition) error {
+	options, err := attributeOptions(definition)
+	if err != nil {
+		return err
+	}
+	query := "INSERT INTO product_attributes (product_type, code, name, data_type, required, unit, min_value, max_value, options, max_length, pattern, position, last_updated_by) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := r.conn.GetDB().Exec(query, definition.ProductType, definition.Code, definition.Name, definition.DataType, definition.Required, definition.Unit, definition.MinValue, definition.MaxValue, options, definition.MaxLength, definition.Pattern, definition.Position, definition.LastUpdatedBy)
+	if err != nil {
+		return duplicateAttributeError(err)
+	}
+	definition.ID, err = result.LastInsertId()
+	return err
+}
+
+// Update changes the rules of an attribute. Its code, product type and data
+// type stay as they were created, so stored values remain valid.
+func (r *MySqlProductAttributeRepository) Update(definition *domain.AttributeDefinition) error {
+	options, err := attributeOptions(definition)
+	if err != nil {
+		return err
+	}
+	query := "UPDATE product_attributes SET name=?, required=?, unit=?, min_value=?, max_value=?, options=?, max_length=?, pattern=?, position=?, last_updated_by=?, version=LAST_INSERT_ID(version+1) WHERE id=? AND (? = 0 OR version=?)"
+	result, err := r.conn.GetDB().Exec(query, definition.Name, definition.Required, definition.Unit, definition.MinValue, definition.MaxValue, options, definition.MaxLength, definition.Pattern, definition.Position, definition.LastUpdatedBy, definition.ID, definition.Version, definition.Version)
+	if err != nil {
+		return err
+	}
+	if err := database.CheckVersionedWrite(result, "product attribute", definition.ID, definition.Version); err != nil {
+		return err
+	}
+	definition.Version = database.NextVersion(result, definition.Version)
+	return nil
+}
+
+// Delete removes the attribute together with its value on every product.
+func (r *MySqlProductAttributeRepository) Delete(definitionID int64, version int64) error {
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		query := "DELETE FROM product_attributes WHERE id=? AND (? = 0 OR version=?)"
+		result, err := tx.Exec(query, definitionID, version, version)
+		if err != nil {
+			return err
+		}
+		if err := database.CheckVersionedWrite(result, "product attribute", definitionID, version); err != nil {
+			return err
+		}
+		_, err = tx.Exec("DELETE FROM product_attribute_values WHERE attribute_id = ?", definitionID)
+		return err
+	})
+}
+
+func (r *MySqlProductAttributeRepository) GetById(definitionID int64) (*domain.AttributeDefinition, error) {
+	query := "SELECT " + attributeColumns + " FROM product_attributes WHERE id = ?"
+	definition, err := scanAttributeDefinition(r.conn.GetDB().QueryRow(query, definitionID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, customerrors.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return definition, nil
+}
+
+// GetAll lists the attributes of a product type, or of every type when
+// productType is empty, in the order they are shown in.
+func (r *MySqlProductAttributeRepository) GetAll(productType string) ([]*domain.AttributeDefinition, error) {
+	query := "SELECT " + attributeColumns + " FROM product_attributes"
+	var args []interface{}
+	if productType != "" {
+		query += " WHERE product_type = ?"
+		args = append(args, productType)
+	}
+	query += " ORDER BY product_type, position, id"
+
+	rows, err := r.conn.GetDB().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	definitions := []*domain.AttributeDefinition{}
+	for rows.Next() {
+		definition, err := scanAttributeDefinition(rows)
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions, rows.Err()
+}
+
+func scanAttributeDefinition(row rowScanner) (*domain.AttributeDefinition, error) {
+	definition := &domain.AttributeDefinition{}
+	var options sql.NullString
+	err := row.Scan(&definition.ID, &definition.ProductType, &definition.Code, &definition.Name, &definition.DataType, &definition.Required, &definition.Unit, &definition.MinValue, &definition.MaxValue, &options, &definition.MaxLength, &definition.Pattern, &definition.Position, &definition.CreatedAt, &definition.UpdatedAt, &definition.LastUpdatedBy, &definition.Version)
+	if err != nil {
+		return nil, err
+	}
+	if options.Valid && options.String != "" {
+		if err := json.Unmarshal([]byte(options.String), &definition.Options); err != nil {
+			return nil, err
+		}
+	}
+	return definition, nil
+}
+
+// attributeOptions encodes the options of an enum as a JSON array, which
+// keeps options containing commas intact.
+func attributeOptions(definition *domain.AttributeDefinition) (interface{}, error) {
+	if len(definition.Options) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(definition.Options)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func duplicateAttributeError(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+		return domain.ErrDuplicateAttribute
+	}
+	return err
+}
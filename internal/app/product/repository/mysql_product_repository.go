@@ -3,10 +3,14 @@ package repository
 import (
 	"database/sql"
 	"errors"
+	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/vamika-digital/wms-api-server/internal/app/product/domain"
 	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
 	"github.com/vamika-digital/wms-api-server/pkg/database"
 )
 
@@ -19,34 +23,84 @@ func NewProductRepository(conn database.Connection) ProductRepository {
 }
 
 func (r *MySqlProductRepository) Create(product *domain.Product) error {
-	query := "INSERT INTO products (type, code, raw_code, name, description, unit, status, last_updated_by) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
-	_, err := r.conn.GetDB().Exec(query, product.Type, product.Code, product.RawCode, product.Name, product.Description, product.Unit, product.Status, product.LastUpdatedBy)
-	return err
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		if err := lockProductCategories(tx, product); err != nil {
+			return err
+		}
+		query := "INSERT INTO products (type, code, raw_code, name, description, category_id, unit, serialized, serial_pattern, weight, volume, status, last_updated_by) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+		result, err := tx.Exec(query, product.Type, product.Code, product.RawCode, product.Name, product.Description, product.CategoryID, product.Unit, product.Serialized, product.SerialPattern, product.Weight, product.Volume, product.Status, product.LastUpdatedBy)
+		if err != nil {
+			return err
+		}
+		if product.ID, err = result.LastInsertId(); err != nil {
+			return err
+		}
+		if err := saveSecondaryCategories(tx, product); err != nil {
+			return err
+		}
+		return saveAttributeValues(tx, product)
+	})
 }
 
 func (r *MySqlProductRepository) Update(product *domain.Product) error {
-	query := "UPDATE products SET type=?, code=?, raw_code=?, name=?, description=?, unit=?, status=?, last_updated_by=? WHERE id=?"
-	_, err := r.conn.GetDB().Exec(query, product.Type, product.Code, product.RawCode, product.Name, product.Description, product.Unit, product.Status, product.LastUpdatedBy, product.ID)
-	return err
+	version := product.Version
+	err := database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		if err := lockProductCategories(tx, product); err != nil {
+			return err
+		}
+		query := "UPDATE products SET type=?, code=?, raw_code=?, name=?, description=?, category_id=?, unit=?, serialized=?, serial_pattern=?, weight=?, volume=?, status=?, last_updated_by=?, version=LAST_INSERT_ID(version+1) WHERE id=? AND (? = 0 OR version=?)"
+		result, err := tx.Exec(query, product.Type, product.Code, product.RawCode, product.Name, product.Description, product.CategoryID, product.Unit, product.Serialized, product.SerialPattern, product.Weight, product.Volume, product.Status, product.LastUpdatedBy, product.ID, product.Version, product.Version)
+		if err != nil {
+			return err
+		}
+		if err := database.CheckVersionedWrite(result, "product", product.ID, product.Version); err != nil {
+			return err
+		}
+		version = database.NextVersion(result, product.Version)
+		if err := saveSecondaryCategories(tx, product); err != nil {
+			return err
+		}
+		return saveAttributeValues(tx, product)
+	})
+	if err != nil {
+		return err
+	}
+	product.Version = version
+	return nil
 }
 
-func (r *MySqlProductRepository) Delete(productID int64) error {
-	query := "DELETE FROM products WHERE id=?"
-	_, err := r.conn.GetDB().Exec(query, productID)
-	return err
+func (r *MySqlProductRepository) Delete(productID int64, version int64) error {
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		query := "DELETE FROM products WHERE id=? AND (? = 0 OR version=?)"
+		result, err := tx.Exec(query, productID, version, version)
+		if err != nil {
+			return err
+		}
+		if err := database.CheckVersionedWrite(result, "product", productID, version); err != nil {
+			return err
+		}
+		if _, err := tx.Exec("DELETE FROM product_categories WHERE product_id = ?", productID); err != nil {
+			return err
+		}
+		_, err = tx.Exec("DELETE FROM product_attribute_values WHERE product_id = ?", productID)
+		return err
+	})
 }
 
 func (r *MySqlProductRepository) GetById(productID int64) (*domain.Product, error) {
-	query := "SELECT id, type, code, raw_code, name, description, unit, status, created_at, updated_at, last_updated_by FROM products WHERE id = ?"
+	query := "SELECT id, type, code, raw_code, name, description, category_id, unit, serialized, serial_pattern, weight, volume, status, created_at, updated_at, last_updated_by, version FROM products WHERE id = ?"
 	row := r.conn.GetDB().QueryRow(query, productID)
 	product := &domain.Product{}
-	err := row.Scan(&product.ID, &product.Type, &product.Code, &product.RawCode, &product.Name, &product.Description, &product.Unit, &product.Status, &product.CreatedAt, &product.UpdatedAt, &product.LastUpdatedBy)
+	err := row.Scan(&product.ID, &product.Type, &product.Code, &product.RawCode, &product.Name, &product.Description, &product.CategoryID, &product.Unit, &product.Serialized, &product.SerialPattern, &product.Weight, &product.Volume, &product.Status, &product.CreatedAt, &product.UpdatedAt, &product.LastUpdatedBy, &product.Version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, customerrors.ErrResourceNotFound
 		}
 		return nil, err
 	}
+	if err := r.loadDetails([]*domain.Product{product}); err != nil {
+		return nil, err
+	}
 	return product, nil
 }
 
@@ -62,7 +116,7 @@ func (r *MySqlProductRepository) GetTotalCount(filter ProductFilterOptions) (int
 }
 
 func (r *MySqlProductRepository) GetAll(page int, pageSize int, sort string, filter ProductFilterOptions) ([]*domain.Product, error) {
-	query, args := r.buildFilterQuery("SELECT id, type, code, raw_code, name, description, unit, status, created_at, updated_at, last_updated_by FROM products", filter)
+	query, args := r.buildFilterQuery("SELECT id, type, code, raw_code, name, description, category_id, unit, serialized, serial_pattern, weight, volume, status, created_at, updated_at, last_updated_by, version FROM products", filter)
 	var allowedSortOrders = map[string]bool{
 		"name ASC":        true,
 		"name DESC":       true,
@@ -94,14 +148,117 @@ func (r *MySqlProductRepository) GetAll(page int, pageSize int, sort string, fil
 	var products []*domain.Product
 	for rows.Next() {
 		product := &domain.Product{}
-		if err := rows.Scan(&product.ID, &product.Type, &product.Code, &product.RawCode, &product.Name, &product.Description, &product.Unit, &product.Status, &product.CreatedAt, &product.UpdatedAt, &product.LastUpdatedBy); err != nil {
+		if err := rows.Scan(&product.ID, &product.Type, &product.Code, &product.RawCode, &product.Name, &product.Description, &product.CategoryID, &product.Unit, &product.Serialized, &product.SerialPattern, &product.Weight, &product.Volume, &product.Status, &product.CreatedAt, &product.UpdatedAt, &product.LastUpdatedBy, &product.Version); err != nil {
 			return nil, err
 		}
 		products = append(products, product)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := r.loadDetails(products); err != nil {
+		return nil, err
+	}
 	return products, nil
 }
 
+func (r *MySqlProductRepository) GetBOM(productID int64) ([]*domain.BOMLine, error) {
+	query := `SELECT b.id, b.product_id, b.component_id, p.code, p.name, b.quantity_per, b.unit, b.scrap_factor, b.effective_from, b.effective_to
+		FROM bom_lines b JOIN products p ON p.id = b.component_id
+		WHERE b.product_id = ? ORDER BY b.id`
+	rows, err := r.conn.GetDB().Query(query, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lines := []*domain.BOMLine{}
+	for rows.Next() {
+		line := &domain.BOMLine{}
+		if err := rows.Scan(&line.ID, &line.ProductID, &line.ComponentID, &line.ComponentCode, &line.ComponentName, &line.QuantityPer, &line.Unit, &line.ScrapFactor, &line.EffectiveFrom, &line.EffectiveTo); err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+	return lines, rows.Err()
+}
+
+// mysqlDeadlock is the server error number for a transaction rolled back to
+// break a lock wait cycle.
+const mysqlDeadlock = 1213
+
+// ReplaceBOM swaps the whole bill of materials of a product at once. The
+// product row is locked and every BOM below the new components is read with
+// shared locks before the cycle check, so a concurrent save that would close
+// a cycle waits for this one or deadlocks and is rolled back.
+func (r *MySqlProductRepository) ReplaceBOM(productID int64, lines []*domain.BOMLine) error {
+	err := database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		var id int64
+		if err := tx.QueryRow("SELECT id FROM products WHERE id = ? FOR UPDATE", productID).Scan(&id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return customerrors.ErrResourceNotFound
+			}
+			return err
+		}
+		components := make([]int64, 0, len(lines))
+		for _, line := range lines {
+			components = append(components, line.ComponentID)
+		}
+		if err := domain.CheckBOMCycle(productID, components, func(componentID int64) ([]int64, error) {
+			return lockBOMComponents(tx, componentID)
+		}); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec("DELETE FROM bom_lines WHERE product_id = ?", productID); err != nil {
+			return err
+		}
+		query := "INSERT INTO bom_lines (product_id, component_id, quantity_per, unit, scrap_factor, effective_from, effective_to) VALUES (?, ?, ?, ?, ?, ?, ?)"
+		for _, line := range lines {
+			result, err := tx.Exec(query, productID, line.ComponentID, line.QuantityPer, line.Unit, line.ScrapFactor, line.EffectiveFrom, line.EffectiveTo)
+			if err != nil {
+				return err
+			}
+			if line.ID, err = result.LastInsertId(); err != nil {
+				return err
+			}
+			line.ProductID = productID
+		}
+		return nil
+	})
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDeadlock {
+		return domain.ErrBOMChanged
+	}
+	return err
+}
+
+// lockBOMComponents returns the components of productID, holding shared locks
+// on its product row and BOM lines until the transaction ends.
+func lockBOMComponents(tx *sql.Tx, productID int64) ([]int64, error) {
+	var id int64
+	if err := tx.QueryRow("SELECT id FROM products WHERE id = ? FOR SHARE", productID).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	rows, err := tx.Query("SELECT component_id FROM bom_lines WHERE product_id = ? FOR SHARE", productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var components []int64
+	for rows.Next() {
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		components = append(components, id)
+	}
+	return components, rows.Err()
+}
+
 func (r *MySqlProductRepository) buildFilterQuery(baseQuery string, filter ProductFilterOptions) (string, []interface{}) {
 	var filters []string
 	var args []interface{}
@@ -126,6 +283,16 @@ func (r *MySqlProductRepository) buildFilterQuery(baseQuery string, filter Produ
 		filters = append(filters, "status = ?")
 		args = append(args, filter.Status)
 	}
+	if filter.CategoryID > 0 {
+		filters = append(filters, `EXISTS (SELECT 1 FROM categories c JOIN categories f ON c.path LIKE CONCAT(f.path, '%')
+			WHERE f.id = ? AND (c.id = products.category_id OR c.id IN (SELECT pc.category_id FROM product_categories pc WHERE pc.product_id = products.id)))`)
+		args = append(args, filter.CategoryID)
+	}
+	for _, attribute := range filter.Attributes {
+		condition, conditionArgs := attributeCondition(attribute)
+		filters = append(filters, condition)
+		args = append(args, conditionArgs...)
+	}
 
 	if len(filters) > 0 {
 		return baseQuery + " WHERE " + strings.Join(filters, " AND "), args
@@ -133,3 +300,178 @@ func (r *MySqlProductRepository) buildFilterQuery(baseQuery string, filter Produ
 
 	return baseQuery, args
 }
+
+const attributeValueExists = `EXISTS (SELECT 1 FROM product_attribute_values v JOIN product_attributes a ON a.id = v.attribute_id
+	WHERE v.product_id = products.id AND a.product_type = products.type AND a.code = ? AND `
+
+// attributeCondition matches a filter against the stored values. A value that
+// reads as a number is compared with numeric attributes by value, so that
+// thickness_gt=2 finds 2.5 and grade=A still compares text; other ordering
+// comparisons apply to dates, which sort as text.
+func attributeCondition(filter domain.AttributeFilter) (string, []interface{}) {
+	number, err := customtypes.ParseDecimal(filter.Value)
+	numeric := err == nil
+	args := []interface{}{filter.Code}
+
+	var comparison string
+	switch filter.Operator {
+	case domain.ATTRIBUTE_GT, domain.ATTRIBUTE_GTE, domain.ATTRIBUTE_LT, domain.ATTRIBUTE_LTE:
+		operator := map[domain.AttributeOperator]string{domain.ATTRIBUTE_GT: ">", domain.ATTRIBUTE_GTE: ">=", domain.ATTRIBUTE_LT: "<", domain.ATTRIBUTE_LTE: "<="}[filter.Operator]
+		if numeric {
+			comparison = "v.number_value " + operator + " ?"
+			args = append(args, number)
+		} else {
+			comparison = "a.data_type = ? AND v.value " + operator + " ?"
+			args = append(args, domain.ATTRIBUTE_DATE, filter.Value)
+		}
+	default:
+		if numeric {
+			comparison = "(v.value = ? OR v.number_value = ?)"
+			args = append(args, filter.Value, number)
+		} else {
+			comparison = "v.value = ?"
+			args = append(args, filter.Value)
+		}
+	}
+
+	if filter.Operator == domain.ATTRIBUTE_NE {
+		return "NOT " + attributeValueExists + comparison + ")", args
+	}
+	return attributeValueExists + comparison + ")", args
+}
+
+// saveAttributeValues replaces the attribute values of the product. A product
+// sent without attributes keeps the values it has.
+func saveAttributeValues(tx *sql.Tx, product *domain.Product) error {
+	if product.Attributes == nil {
+		return nil
+	}
+	if _, err := tx.Exec("DELETE FROM product_attribute_values WHERE product_id = ?", product.ID); err != nil {
+		return err
+	}
+	query := `INSERT INTO product_attribute_values (product_id, attribute_id, value, number_value)
+		SELECT ?, id, ?, ? FROM product_attributes WHERE product_type = ? AND code = ?`
+	for code, value := range product.Attributes {
+		var number *customtypes.Decimal
+		if value.Type == domain.ATTRIBUTE_NUMBER {
+			parsed, err := customtypes.ParseDecimal(value.Text)
+			if err != nil {
+				return err
+			}
+			number = &parsed
+		}
+		if _, err := tx.Exec(query, product.ID, value.Text, number, product.Type, code); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadDetails fills in the secondary categories and attributes of products.
+func (r *MySqlProductRepository) loadDetails(products []*domain.Product) error {
+	if err := r.loadSecondaryCategories(products); err != nil {
+		return err
+	}
+	return r.loadAttributeValues(products)
+}
+
+// lockProductCategories makes sure the categories of the product exist and
+// keeps them from being deleted until the product is saved. They are locked in
+// id order so that two saves cannot deadlock.
+func lockProductCategories(tx *sql.Tx, product *domain.Product) error {
+	ids := append([]int64{}, product.SecondaryCategoryIDs...)
+	if product.CategoryID != nil {
+		ids = append(ids, *product.CategoryID)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		if _, err := lockCategory(tx, id); err != nil {
+			if errors.Is(err, customerrors.ErrResourceNotFound) {
+				return fmt.Errorf("%w: category %d does not exist", domain.ErrInvalidCategory, id)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// saveSecondaryCategories replaces the secondary categories of the product.
+// A product sent without them keeps the ones it has.
+func saveSecondaryCategories(tx *sql.Tx, product *domain.Product) error {
+	if product.SecondaryCategoryIDs == nil {
+		return nil
+	}
+	if _, err := tx.Exec("DELETE FROM product_categories WHERE product_id = ?", product.ID); err != nil {
+		return err
+	}
+	for _, categoryID := range product.SecondaryCategoryIDs {
+		if _, err := tx.Exec("INSERT INTO product_categories (product_id, category_id) VALUES (?, ?)", product.ID, categoryID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MySqlProductRepository) loadSecondaryCategories(products []*domain.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+	byID := make(map[int64]*domain.Product, len(products))
+	args := make([]interface{}, 0, len(products))
+	for _, product := range products {
+		product.SecondaryCategoryIDs = []int64{}
+		byID[product.ID] = product
+		args = append(args, product.ID)
+	}
+
+	query := "SELECT product_id, category_id FROM product_categories WHERE product_id IN (?" + strings.Repeat(", ?", len(args)-1) + ") ORDER BY product_id, category_id"
+	rows, err := r.conn.GetDB().Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var productID, categoryID int64
+		if err := rows.Scan(&productID, &categoryID); err != nil {
+			return err
+		}
+		byID[productID].SecondaryCategoryIDs = append(byID[productID].SecondaryCategoryIDs, categoryID)
+	}
+	return rows.Err()
+}
+
+// loadAttributeValues fills in the attributes of products with one query.
+// Values left over from a former type of a product are not returned.
+func (r *MySqlProductRepository) loadAttributeValues(products []*domain.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+	byID := make(map[int64]*domain.Product, len(products))
+	args := make([]interface{}, 0, len(products))
+	for _, product := range products {
+		product.Attributes = map[string]*domain.AttributeValue{}
+		byID[product.ID] = product
+		args = append(args, product.ID)
+	}
+
+	query := `SELECT v.product_id, a.code, a.data_type, v.value FROM product_attribute_values v
+		JOIN product_attributes a ON a.id = v.attribute_id JOIN products p ON p.id = v.product_id AND p.type = a.product_type
+		WHERE v.product_id IN (?` + strings.Repeat(", ?", len(args)-1) + `)`
+	rows, err := r.conn.GetDB().Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var productID int64
+		var code string
+		value := &domain.AttributeValue{}
+		if err := rows.Scan(&productID, &code, &value.Type, &value.Text); err != nil {
+			return err
+		}
+		byID[productID].Attributes[code] = value
+	}
+	return rows.Err()
+}
@@ -0,0 +1,11 @@
+package repository
+
+import "github.com/vamika-digital/wms-api-server/internal/app/product/domain"
+
+type ProductAttributeRepository interface {
+	Create(definition *domain.AttributeDefinition) error
+	Update(definition *domain.AttributeDefinition) error
+	Delete(definitionID int64, version int64) error
+	GetById(definitionID int64) (*domain.AttributeDefinition, error)
+	GetAll(productType string) ([]*domain.AttributeDefinition, error)
+}
@@ -5,10 +5,12 @@ import "github.com/vamika-digital/wms-api-server/internal/app/product/domain"
 type ProductRepository interface {
 	Create(product *domain.Product) error
 	Update(product *domain.Product) error
-	Delete(productID int64) error
+	Delete(productID int64, version int64) error
 	GetById(productID int64) (*domain.Product, error)
 	GetTotalCount(filter ProductFilterOptions) (int, error)
 	GetAll(page int, pageSize int, sort string, filter ProductFilterOptions) ([]*domain.Product, error)
+	GetBOM(productID int64) ([]*domain.BOMLine, error)
+	ReplaceBOM(productID int64, lines []*domain.BOMLine) error
 }
 
 type ProductFilterOptions struct {
@@ -17,4 +19,10 @@ type ProductFilterOptions struct {
 	RawCode string
 	Name    string
 	Status  string
+	// CategoryID narrows the list to products filed, as primary or secondary
+	// category, under the category or any category below it.
+	CategoryID int64
+	// Attributes narrows the list to products whose custom attributes match
+	// every filter.
+	Attributes []domain.AttributeFilter
 }
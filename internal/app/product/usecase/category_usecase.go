@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/product/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/product/repository"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+)
+
+// existingCategory loads a category referred to by another record, which
+// makes a missing one a bad request rather than a missing resource.
+func (u *ProductUseCaseImpl) existingCategory(categoryID int64) (*domain.Category, error) {
+	category, err := u.CategoryRepo.GetById(categoryID)
+	if errors.Is(err, customerrors.ErrResourceNotFound) {
+		return nil, fmt.Errorf("%w: category %d does not exist", domain.ErrInvalidCategory, categoryID)
+	}
+	return category, err
+}
+
+func (u *ProductUseCaseImpl) CreateCategory(category *domain.Category) error {
+	if err := category.Validate(); err != nil {
+		return err
+	}
+	if category.ParentID != nil {
+		if _, err := u.existingCategory(*category.ParentID); err != nil {
+			return err
+		}
+	}
+	return u.CategoryRepo.Create(category)
+}
+
+func (u *ProductUseCaseImpl) UpdateCategory(category *domain.Category) error {
+	if err := category.Validate(); err != nil {
+		return err
+	}
+	return u.CategoryRepo.Update(category)
+}
+
+// MoveCategory places a category and its subtree under parentID, or at the
+// root when parentID is nil.
+func (u *ProductUseCaseImpl) MoveCategory(categoryID int64, parentID *int64, version int64) (*domain.Category, error) {
+	if _, err := u.CategoryRepo.GetById(categoryID); err != nil {
+		return nil, err
+	}
+	if parentID != nil {
+		if _, err := u.existingCategory(*parentID); err != nil {
+			return nil, err
+		}
+	}
+	if err := u.CategoryRepo.Move(categoryID, parentID, version); err != nil {
+		return nil, err
+	}
+	return u.CategoryRepo.GetById(categoryID)
+}
+
+func (u *ProductUseCaseImpl) DeleteCategory(categoryID int64, version int64) error {
+	return u.CategoryRepo.Delete(categoryID, version)
+}
+
+func (u *ProductUseCaseImpl) GetCategoryByID(categoryID int64) (*domain.Category, error) {
+	return u.CategoryRepo.GetById(categoryID)
+}
+
+// GetCategories returns the matching categories as a flat list ordered by
+// path, or nested under their parents when asTree is set.
+func (u *ProductUseCaseImpl) GetCategories(filter repository.CategoryFilterOptions, asTree bool) ([]*domain.Category, error) {
+	categories, err := u.CategoryRepo.GetAll(filter)
+	if err != nil {
+		return nil, err
+	}
+	if asTree {
+		return domain.BuildCategoryTree(categories), nil
+	}
+	return categories, nil
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"github.com/vamika-digital/wms-api-server/internal/app/product/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/product/repository"
+)
+
+// maxProductExportRows bounds a product export to what a spreadsheet is
+// comfortably opened with.
+const maxProductExportRows = 10000
+
+func (u *ProductUseCaseImpl) normalizeAttributes(product *domain.Product) error {
+	definitions, err := u.AttributeRepo.GetAll(string(product.Type))
+	if err != nil {
+		return err
+	}
+	return product.NormalizeAttributes(definitions)
+}
+
+// ExportProducts returns the products matching filter with the attribute
+// definitions that become columns of the export: those of the filtered type,
+// or of every type.
+func (u *ProductUseCaseImpl) ExportProducts(sort string, filter repository.ProductFilterOptions) ([]*domain.Product, []*domain.AttributeDefinition, error) {
+	products, err := u.Repo.GetAll(0, maxProductExportRows, sort, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	definitions, err := u.AttributeRepo.GetAll(filter.Type)
+	if err != nil {
+		return nil, nil, err
+	}
+	return products, definitions, nil
+}
+
+func (u *ProductUseCaseImpl) CreateAttribute(definition *domain.AttributeDefinition) error {
+	if err := definition.Validate(); err != nil {
+		return err
+	}
+	return u.AttributeRepo.Create(definition)
+}
+
+// UpdateAttribute changes the name, rules and position of an attribute.
+// Values already stored are not checked again; they are when their product
+// is next saved.
+func (u *ProductUseCaseImpl) UpdateAttribute(definition *domain.AttributeDefinition) error {
+	existing, err := u.AttributeRepo.GetById(definition.ID)
+	if err != nil {
+		return err
+	}
+	if err := definition.Validate(); err != nil {
+		return err
+	}
+	if definition.Code != existing.Code || definition.ProductType != existing.ProductType || definition.DataType != existing.DataType {
+		return domain.ErrAttributeDefinitionLocked
+	}
+	return u.AttributeRepo.Update(definition)
+}
+
+func (u *ProductUseCaseImpl) DeleteAttribute(definitionID int64, version int64) error {
+	return u.AttributeRepo.Delete(definitionID, version)
+}
+
+func (u *ProductUseCaseImpl) GetAttributeByID(definitionID int64) (*domain.AttributeDefinition, error) {
+	return u.AttributeRepo.GetById(definitionID)
+}
+
+func (u *ProductUseCaseImpl) GetAttributes(productType string) ([]*domain.AttributeDefinition, error) {
+	return u.AttributeRepo.GetAll(productType)
+}
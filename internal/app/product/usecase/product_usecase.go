@@ -1,14 +1,32 @@
 package usecase
 
 import (
+	"time"
+
 	"github.com/vamika-digital/wms-api-server/internal/app/product/domain"
 	"github.com/vamika-digital/wms-api-server/internal/app/product/repository"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
 )
 
 type ProductUseCase interface {
 	CreateProduct(product *domain.Product) error
 	UpdateProduct(product *domain.Product) error
-	DeleteProduct(productID int64) error
+	DeleteProduct(productID int64, version int64) error
 	GetProductByID(productID int64) (*domain.Product, error)
 	GetAllProducts(page int, pageSize int, sort string, filter repository.ProductFilterOptions) ([]*domain.Product, int, error)
+	GetBOM(productID int64) ([]*domain.BOMLine, error)
+	SetBOM(productID int64, lines []*domain.BOMLine) ([]*domain.BOMLine, error)
+	ExplodeBOM(productID int64, quantity customtypes.Decimal, at time.Time) (*domain.BOMRequirement, error)
+	ExportProducts(sort string, filter repository.ProductFilterOptions) ([]*domain.Product, []*domain.AttributeDefinition, error)
+	CreateAttribute(definition *domain.AttributeDefinition) error
+	UpdateAttribute(definition *domain.AttributeDefinition) error
+	DeleteAttribute(definitionID int64, version int64) error
+	GetAttributeByID(definitionID int64) (*domain.AttributeDefinition, error)
+	GetAttributes(productType string) ([]*domain.AttributeDefinition, error)
+	CreateCategory(category *domain.Category) error
+	UpdateCategory(category *domain.Category) error
+	MoveCategory(categoryID int64, parentID *int64, version int64) (*domain.Category, error)
+	DeleteCategory(categoryID int64, version int64) error
+	GetCategoryByID(categoryID int64) (*domain.Category, error)
+	GetCategories(filter repository.CategoryFilterOptions, asTree bool) ([]*domain.Category, error)
 }
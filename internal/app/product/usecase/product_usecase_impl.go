@@ -1,38 +1,85 @@
 package usecase
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/vamika-digital/wms-api-server/internal/app/product/domain"
 	"github.com/vamika-digital/wms-api-server/internal/app/product/repository"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
 )
 
 type ProductUseCaseImpl struct {
-	Repo repository.ProductRepository
+	Repo          repository.ProductRepository
+	AttributeRepo repository.ProductAttributeRepository
+	CategoryRepo  repository.CategoryRepository
 }
 
-func NewProductUseCase(repo repository.ProductRepository) ProductUseCase {
-	return &ProductUseCaseImpl{Repo: repo}
+func NewProductUseCase(repo repository.ProductRepository, attributeRepo repository.ProductAttributeRepository, categoryRepo repository.CategoryRepository) ProductUseCase {
+	return &ProductUseCaseImpl{Repo: repo, AttributeRepo: attributeRepo, CategoryRepo: categoryRepo}
 }
 
 func (u *ProductUseCaseImpl) CreateProduct(product *domain.Product) error {
+	if product.Attributes == nil {
+		product.Attributes = map[string]*domain.AttributeValue{}
+	}
+	if err := u.normalizeAttributes(product); err != nil {
+		return err
+	}
+	if err := product.SetCategories(); err != nil {
+		return err
+	}
 	return u.Repo.Create(product)
 }
 
 func (u *ProductUseCaseImpl) UpdateProduct(product *domain.Product) error {
 	// Check for an existing product with the specified ID
-	// existingProduct, err := u.Repo.GetById(product.ID)
-	_, err := u.Repo.GetById(product.ID)
+	existingProduct, err := u.Repo.GetById(product.ID)
 	if err != nil {
 		return err
 	}
+	// A product sent without attributes keeps its stored values, which are
+	// checked against its type all the same: a new type may require
+	// attributes the product lacks. Values of a former type do not carry over.
+	if product.Attributes == nil {
+		product.Attributes = map[string]*domain.AttributeValue{}
+		if product.Type == existingProduct.Type {
+			product.Attributes = existingProduct.Attributes
+		}
+	}
+	if err := u.normalizeAttributes(product); err != nil {
+		return err
+	}
+	// Categories left out are kept as well; a category_id of 0 clears the
+	// primary category, which is then refused while secondaries remain.
+	if product.CategoryID == nil {
+		product.CategoryID = existingProduct.CategoryID
+	}
+	if product.SecondaryCategoryIDs == nil {
+		product.SecondaryCategoryIDs = existingProduct.SecondaryCategoryIDs
+	}
+	if err := product.SetCategories(); err != nil {
+		return err
+	}
 	return u.Repo.Update(product)
 }
 
-func (u *ProductUseCaseImpl) DeleteProduct(productID int64) error {
-	return u.Repo.Delete(productID)
+func (u *ProductUseCaseImpl) DeleteProduct(productID int64, version int64) error {
+	return u.Repo.Delete(productID, version)
 }
 
 func (u *ProductUseCaseImpl) GetProductByID(productID int64) (*domain.Product, error) {
-	return u.Repo.GetById(productID)
+	product, err := u.Repo.GetById(productID)
+	if err != nil {
+		return nil, err
+	}
+	if product.BOM, err = u.Repo.GetBOM(productID); err != nil {
+		return nil, err
+	}
+	return product, nil
 }
 
 func (u *ProductUseCaseImpl) GetAllProducts(page int, pageSize int, sort string, filter repository.ProductFilterOptions) ([]*domain.Product, int, error) {
@@ -56,3 +103,91 @@ func (u *ProductUseCaseImpl) GetAllProducts(page int, pageSize int, sort string,
 
 	return products, total, nil
 }
+
+func (u *ProductUseCaseImpl) GetBOM(productID int64) ([]*domain.BOMLine, error) {
+	if _, err := u.Repo.GetById(productID); err != nil {
+		return nil, err
+	}
+	return u.Repo.GetBOM(productID)
+}
+
+// SetBOM replaces the components of a product. Every component must exist
+// and use its own unit, and no component may contain the product at any
+// level below it. The cycle check runs in the repository, inside the
+// transaction that stores the lines.
+func (u *ProductUseCaseImpl) SetBOM(productID int64, lines []*domain.BOMLine) ([]*domain.BOMLine, error) {
+	if _, err := u.Repo.GetById(productID); err != nil {
+		return nil, err
+	}
+
+	for _, line := range lines {
+		line.ProductID = productID
+		if err := line.Validate(); err != nil {
+			return nil, err
+		}
+		component, err := u.Repo.GetById(line.ComponentID)
+		if err != nil {
+			if errors.Is(err, customerrors.ErrResourceNotFound) {
+				return nil, fmt.Errorf("%w: component %d does not exist", domain.ErrInvalidBOM, line.ComponentID)
+			}
+			return nil, err
+		}
+		if !strings.EqualFold(line.Unit, string(component.Unit)) {
+			return nil, fmt.Errorf("%w: %s is measured in %s", domain.ErrBOMComponentUnit, component.Code, component.Unit)
+		}
+	}
+
+	if err := u.Repo.ReplaceBOM(productID, lines); err != nil {
+		return nil, err
+	}
+	return u.Repo.GetBOM(productID)
+}
+
+// ExplodeBOM lists the components needed at every level to make quantity
+// units of a product with the BOM lines effective at time at.
+func (u *ProductUseCaseImpl) ExplodeBOM(productID int64, quantity customtypes.Decimal, at time.Time) (*domain.BOMRequirement, error) {
+	product, err := u.Repo.GetById(productID)
+	if err != nil {
+		return nil, err
+	}
+	root := &domain.BOMRequirement{
+		ComponentID:   product.ID,
+		ComponentCode: product.Code,
+		ComponentName: product.Name,
+		Quantity:      quantity,
+		Unit:          string(product.Unit),
+	}
+	return root, u.explode(root, at)
+}
+
+func (u *ProductUseCaseImpl) explode(parent *domain.BOMRequirement, at time.Time) error {
+	if parent.Level >= domain.MaxBOMDepth {
+		return domain.ErrBOMCycle
+	}
+	lines, err := u.Repo.GetBOM(parent.ComponentID)
+	if err != nil {
+		return err
+	}
+	for _, line := range lines {
+		if !line.IsEffective(at) {
+			continue
+		}
+		required, err := line.Required(parent.Quantity)
+		if err != nil {
+			return err
+		}
+		requirement := &domain.BOMRequirement{
+			Level:         parent.Level + 1,
+			ComponentID:   line.ComponentID,
+			ComponentCode: line.ComponentCode,
+			ComponentName: line.ComponentName,
+			Quantity:      required,
+			Unit:          line.Unit,
+		}
+		if err := u.explode(requirement, at); err != nil {
+			return err
+		}
+		parent.Components = append(parent.Components, requirement)
+	}
+	return nil
+}
@@ -20,6 +20,7 @@ type User struct {
 	CreatedAt         time.Time `json:"created_at"`
 	UpdatedAt         time.Time `json:"updated_at"`
 	LastUpdatedBy     string    `json:"last_updated_by"`
+	Version           int64     `json:"version"`
 }
 
 func NewUserWithDefaults() User {
@@ -10,6 +10,7 @@ import (
 	"github.com/vamika-digital/wms-api-server/internal/app/user/domain"
 	"github.com/vamika-digital/wms-api-server/internal/app/user/repository"
 	"github.com/vamika-digital/wms-api-server/internal/app/user/usecase"
+	"github.com/vamika-digital/wms-api-server/internal/utility/etag"
 	"github.com/vamika-digital/wms-api-server/internal/utility/valueobjects"
 )
 
@@ -62,11 +63,20 @@ func (handler *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	user.ID = int64(id)
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if version > 0 {
+		user.Version = version
+	}
 	if err := handler.UseCase.UpdateUser(&user); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		handler.handleWriteError(w, user.ID, err)
 		return
 	}
 
+	etag.Set(w, user.Version)
 	w.WriteHeader(http.StatusOK)
 }
 
@@ -78,8 +88,14 @@ func (handler *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := handler.UseCase.DeleteUser(id); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.DeleteUser(id, version); err != nil {
+		handler.handleWriteError(w, id, err)
 		return
 	}
 
@@ -100,6 +116,7 @@ func (handler *UserHandler) GetUserByID(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	etag.Set(w, user.Version)
 	if err := json.NewEncoder(w).Encode(user); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
@@ -151,6 +168,17 @@ func (handler *UserHandler) GetAllUsers(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+func (handler *UserHandler) handleWriteError(w http.ResponseWriter, userID int64, err error) {
+	current := func() (int64, interface{}, error) {
+		user, err := handler.UseCase.GetUserByID(userID)
+		if err != nil {
+			return 0, nil, err
+		}
+		return user.Version, user, nil
+	}
+	etag.WriteError(w, err, current, nil)
+}
+
 func validateUser(user *domain.User) error {
 	if user.Username == "" {
 		return errors.New("username is required")
@@ -25,22 +25,32 @@ func (r *MySqlUserRepository) Create(user *domain.User) error {
 }
 
 func (r *MySqlUserRepository) Update(user *domain.User) error {
-	query := "UPDATE users SET username=?, password_hash=?, name=?, staff_id=?, email=?, email_confirmation=?, status=?, last_updated_by=? WHERE id=?"
-	_, err := r.conn.GetDB().Exec(query, user.Username, user.PasswordHash, user.Name, user.StaffID, user.Email, user.EmailConfirmation, user.Status, user.LastUpdatedBy, user.ID)
-	return err
+	query := "UPDATE users SET username=?, password_hash=?, name=?, staff_id=?, email=?, email_confirmation=?, status=?, last_updated_by=?, version=LAST_INSERT_ID(version+1) WHERE id=? AND (? = 0 OR version=?)"
+	result, err := r.conn.GetDB().Exec(query, user.Username, user.PasswordHash, user.Name, user.StaffID, user.Email, user.EmailConfirmation, user.Status, user.LastUpdatedBy, user.ID, user.Version, user.Version)
+	if err != nil {
+		return err
+	}
+	if err := database.CheckVersionedWrite(result, "user", user.ID, user.Version); err != nil {
+		return err
+	}
+	user.Version = database.NextVersion(result, user.Version)
+	return nil
 }
 
-func (r *MySqlUserRepository) Delete(userID int64) error {
-	query := "DELETE FROM users WHERE id=?"
-	_, err := r.conn.GetDB().Exec(query, userID)
-	return err
+func (r *MySqlUserRepository) Delete(userID int64, version int64) error {
+	query := "DELETE FROM users WHERE id=? AND (? = 0 OR version=?)"
+	result, err := r.conn.GetDB().Exec(query, userID, version, version)
+	if err != nil {
+		return err
+	}
+	return database.CheckVersionedWrite(result, "user", userID, version)
 }
 
 func (r *MySqlUserRepository) GetById(userID int64) (*domain.User, error) {
-	query := "SELECT id, username, password_hash, name, staff_id, email, email_confirmation, status, created_at, updated_at, last_updated_by FROM users WHERE id = ?"
+	query := "SELECT id, username, password_hash, name, staff_id, email, email_confirmation, status, created_at, updated_at, last_updated_by, version FROM users WHERE id = ?"
 	row := r.conn.GetDB().QueryRow(query, userID)
 	user := &domain.User{}
-	err := row.Scan(&user.ID, &user.Username, &user.PasswordHash, &user.Name, &user.StaffID, &user.Email, &user.EmailConfirmation, &user.Status, &user.CreatedAt, &user.UpdatedAt, &user.LastUpdatedBy)
+	err := row.Scan(&user.ID, &user.Username, &user.PasswordHash, &user.Name, &user.StaffID, &user.Email, &user.EmailConfirmation, &user.Status, &user.CreatedAt, &user.UpdatedAt, &user.LastUpdatedBy, &user.Version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, customerrors.ErrResourceNotFound
@@ -62,7 +72,7 @@ func (r *MySqlUserRepository) GetTotalCount(filter UserFilterOptions) (int, erro
 }
 
 func (r *MySqlUserRepository) GetAll(page int, pageSize int, sort string, filter UserFilterOptions) ([]*domain.User, error) {
-	query, args := r.buildFilterQuery("SELECT id, username, password_hash, name, staff_id, email, email_confirmation, status, created_at, updated_at, last_updated_by FROM users", filter)
+	query, args := r.buildFilterQuery("SELECT id, username, password_hash, name, staff_id, email, email_confirmation, status, created_at, updated_at, last_updated_by, version FROM users", filter)
 
 	var allowedSortOrders = map[string]bool{
 		"username ASC":  true,
@@ -89,7 +99,7 @@ func (r *MySqlUserRepository) GetAll(page int, pageSize int, sort string, filter
 	var users []*domain.User
 	for rows.Next() {
 		user := &domain.User{}
-		if err := rows.Scan(&user.ID, &user.Username, &user.PasswordHash, &user.Name, &user.StaffID, &user.Email, &user.EmailConfirmation, &user.Status, &user.CreatedAt, &user.UpdatedAt, &user.LastUpdatedBy); err != nil {
+		if err := rows.Scan(&user.ID, &user.Username, &user.PasswordHash, &user.Name, &user.StaffID, &user.Email, &user.EmailConfirmation, &user.Status, &user.CreatedAt, &user.UpdatedAt, &user.LastUpdatedBy, &user.Version); err != nil {
 			return nil, err
 		}
 		users = append(users, user)
@@ -98,10 +108,10 @@ func (r *MySqlUserRepository) GetAll(page int, pageSize int, sort string, filter
 }
 
 func (r *MySqlUserRepository) FindByUsername(username string) (*domain.User, error) {
-	query := "SELECT id, username, password_hash, name, staff_id, email, email_confirmation, status, created_at, updated_at, last_updated_by FROM users WHERE username = ?"
+	query := "SELECT id, username, password_hash, name, staff_id, email, email_confirmation, status, created_at, updated_at, last_updated_by, version FROM users WHERE username = ?"
 	row := r.conn.GetDB().QueryRow(query, username)
 	user := &domain.User{}
-	err := row.Scan(&user.ID, &user.Username, &user.PasswordHash, &user.Name, &user.StaffID, &user.Email, &user.EmailConfirmation, &user.Status, &user.CreatedAt, &user.UpdatedAt, &user.LastUpdatedBy)
+	err := row.Scan(&user.ID, &user.Username, &user.PasswordHash, &user.Name, &user.StaffID, &user.Email, &user.EmailConfirmation, &user.Status, &user.CreatedAt, &user.UpdatedAt, &user.LastUpdatedBy, &user.Version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, customerrors.ErrResourceNotFound
@@ -112,10 +122,10 @@ func (r *MySqlUserRepository) FindByUsername(username string) (*domain.User, err
 }
 
 func (r *MySqlUserRepository) FindByEmail(email string) (*domain.User, error) {
-	query := "SELECT id, username, password_hash, name, staff_id, email, email_confirmation, status, created_at, updated_at, last_updated_by FROM users WHERE email = ?"
+	query := "SELECT id, username, password_hash, name, staff_id, email, email_confirmation, status, created_at, updated_at, last_updated_by, version FROM users WHERE email = ?"
 	row := r.conn.GetDB().QueryRow(query, email)
 	user := &domain.User{}
-	err := row.Scan(&user.ID, &user.Username, &user.PasswordHash, &user.Name, &user.StaffID, &user.Email, &user.EmailConfirmation, &user.Status, &user.CreatedAt, &user.UpdatedAt, &user.LastUpdatedBy)
+	err := row.Scan(&user.ID, &user.Username, &user.PasswordHash, &user.Name, &user.StaffID, &user.Email, &user.EmailConfirmation, &user.Status, &user.CreatedAt, &user.UpdatedAt, &user.LastUpdatedBy, &user.Version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, customerrors.ErrResourceNotFound
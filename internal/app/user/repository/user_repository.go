@@ -5,7 +5,7 @@ import "github.com/vamika-digital/wms-api-server/internal/app/user/domain"
 type UserRepository interface {
 	Create(user *domain.User) error
 	Update(user *domain.User) error
-	Delete(userID int64) error
+	Delete(userID int64, version int64) error
 	GetById(userID int64) (*domain.User, error)
 	GetTotalCount(filter UserFilterOptions) (int, error)
 	GetAll(page int, pageSize int, sort string, filter UserFilterOptions) ([]*domain.User, error)
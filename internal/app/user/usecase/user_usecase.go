@@ -8,7 +8,7 @@ import (
 type UserUseCase interface {
 	CreateUser(user *domain.User) error
 	UpdateUser(user *domain.User) error
-	DeleteUser(userID int64) error
+	DeleteUser(userID int64, version int64) error
 	GetUserByID(userID int64) (*domain.User, error)
 	GetAllUsers(page int, pageSize int, sort string, filter repository.UserFilterOptions) ([]*domain.User, int, error)
 	GetUserByUsername(username string) (*domain.User, error)
@@ -42,8 +42,8 @@ func (u *UserUseCaseImpl) UpdateUser(user *domain.User) error {
 	return u.Repo.Update(user)
 }
 
-func (u *UserUseCaseImpl) DeleteUser(userID int64) error {
-	return u.Repo.Delete(userID)
+func (u *UserUseCaseImpl) DeleteUser(userID int64, version int64) error {
+	return u.Repo.Delete(userID, version)
 }
 
 func (u *UserUseCaseImpl) GetUserByID(userID int64) (*domain.User, error) {
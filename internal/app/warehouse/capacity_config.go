@@ -0,0 +1,47 @@
+package warehouse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vamika-digital/wms-api-server/config"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+// ApplyCapacityConfig sets the capacity enforcement and the per type
+// container capacities from the configuration.
+func ApplyCapacityConfig() error {
+	if err := domain.SetCapacityEnforcement(config.AppConfig.Capacity.Enforcement); err != nil {
+		return err
+	}
+	if len(config.AppConfig.Capacity.CountUnits) > 0 {
+		domain.SetCountUnits(config.AppConfig.Capacity.CountUnits)
+	}
+	for containerType, limits := range config.AppConfig.Capacity.Defaults {
+		var capacity domain.ContainerCapacity
+		for _, limit := range []struct {
+			value  string
+			target *customtypes.Decimal
+		}{
+			{limits.MaxWeight, &capacity.MaxWeight},
+			{limits.MaxVolume, &capacity.MaxVolume},
+			{limits.MaxUnits, &capacity.MaxUnits},
+		} {
+			if limit.value == "" {
+				continue
+			}
+			value, err := customtypes.ParseDecimal(limit.value)
+			if err != nil {
+				return fmt.Errorf("container type %s: %w", containerType, err)
+			}
+			*limit.target = value
+		}
+		if err := capacity.Validate(); err != nil {
+			return fmt.Errorf("container type %s: %w", containerType, err)
+		}
+		// Configuration keys are case insensitive
+		domain.SetCapacityDefault(domain.ContainerType(strings.ToUpper(containerType)), capacity)
+	}
+	return nil
+}
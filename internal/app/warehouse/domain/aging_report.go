@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+const (
+	AGING_GROUP_PRODUCT      = "product"
+	AGING_GROUP_STORE        = "store"
+	AGING_GROUP_PRODUCT_TYPE = "product_type"
+	// AGING_GROUP_CATEGORY groups by the primary category of the product, or
+	// by its ancestor at the category level of the report.
+	AGING_GROUP_CATEGORY = "category"
+)
+
+// AgingBucket covers stock aged between MinDays and MaxDays inclusive. A
+// MaxDays of -1 leaves the bucket open ended.
+type AgingBucket struct {
+	Label   string `json:"label"`
+	MinDays int    `json:"min_days"`
+	MaxDays int    `json:"max_days"`
+}
+
+func (b AgingBucket) Contains(days int) bool {
+	return days >= b.MinDays && (b.MaxDays < 0 || days <= b.MaxDays)
+}
+
+// ParseAgingBuckets turns upper bounds such as "30,60,90" into the buckets
+// 0-30, 31-60, 61-90 and 90+.
+func ParseAgingBuckets(bounds string) ([]AgingBucket, error) {
+	if strings.TrimSpace(bounds) == "" {
+		bounds = "30,60,90"
+	}
+	var buckets []AgingBucket
+	min := 0
+	for _, part := range strings.Split(bounds, ",") {
+		max, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || max < min {
+			return nil, errors.New("buckets must be increasing day counts such as 30,60,90")
+		}
+		buckets = append(buckets, AgingBucket{
+			Label:   strconv.Itoa(min) + "-" + strconv.Itoa(max),
+			MinDays: min,
+			MaxDays: max,
+		})
+		min = max + 1
+	}
+	buckets = append(buckets, AgingBucket{Label: strconv.Itoa(min-1) + "+", MinDays: min, MaxDays: -1})
+	return buckets, nil
+}
+
+// AgingLot is an on-hand inventory row with the attributes used for grouping.
+type AgingLot struct {
+	InventoryID    int64
+	ProductID      int64
+	ProductCode    string
+	ProductName    string
+	ProductType    string
+	CategoryID     *int64
+	StoreID        int64
+	StoreName      string
+	Quantity       customtypes.Decimal
+	Unit           string
+	StockInAt      time.Time
+	LastMovementAt time.Time
+}
+
+type AgingReportRow struct {
+	ProductID       *int64                `json:"product_id,omitempty"`
+	ProductCode     string                `json:"product_code,omitempty"`
+	ProductName     string                `json:"product_name,omitempty"`
+	ProductType     string                `json:"product_type,omitempty"`
+	CategoryID      *int64                `json:"category_id,omitempty"`
+	CategoryName    string                `json:"category_name,omitempty"`
+	StoreID         *int64                `json:"store_id,omitempty"`
+	StoreName       string                `json:"store_name,omitempty"`
+	Unit            string                `json:"unit"`
+	TotalQuantity   customtypes.Decimal   `json:"total_quantity"`
+	Buckets         []customtypes.Decimal `json:"buckets"`
+	LotCount        int                   `json:"lot_count"`
+	OldestStockInAt time.Time             `json:"oldest_stockin_at"`
+	LastMovementAt  time.Time             `json:"last_movement_at"`
+	SlowMoving      bool                  `json:"slow_moving"`
+}
+
+type AgingReport struct {
+	AsOf           time.Time         `json:"as_of"`
+	GroupBy        []string          `json:"group_by"`
+	CategoryLevel  int               `json:"category_level,omitempty"`
+	Buckets        []AgingBucket     `json:"buckets"`
+	SlowMovingDays int               `json:"slow_moving_days"`
+	Rows           []*AgingReportRow `json:"rows"`
+}
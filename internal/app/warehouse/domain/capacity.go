@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+const (
+	CAPACITY_REJECT = "reject"
+	CAPACITY_WARN   = "warn"
+)
+
+var ErrCapacityExceeded = errors.New("container capacity would be exceeded")
+
+// ContainerCapacity limits what a container and everything below it may
+// hold. Weight is in kg, volume in m³ and units are the quantities of lots
+// counted in pieces (see IsCountUnit). A zero limit is unlimited.
+type ContainerCapacity struct {
+	MaxWeight customtypes.Decimal `json:"max_weight"`
+	MaxVolume customtypes.Decimal `json:"max_volume"`
+	MaxUnits  customtypes.Decimal `json:"max_units"`
+}
+
+func (c ContainerCapacity) Validate() error {
+	if c.MaxWeight.Sign() < 0 || c.MaxVolume.Sign() < 0 || c.MaxUnits.Sign() < 0 {
+		return errors.New("capacity cannot be negative")
+	}
+	return nil
+}
+
+func (c ContainerCapacity) IsUnlimited() bool {
+	return c.MaxWeight.IsZero() && c.MaxVolume.IsZero() && c.MaxUnits.IsZero()
+}
+
+// Or fills the limits c does not set from defaults.
+func (c ContainerCapacity) Or(defaults ContainerCapacity) ContainerCapacity {
+	if c.MaxWeight.IsZero() {
+		c.MaxWeight = defaults.MaxWeight
+	}
+	if c.MaxVolume.IsZero() {
+		c.MaxVolume = defaults.MaxVolume
+	}
+	if c.MaxUnits.IsZero() {
+		c.MaxUnits = defaults.MaxUnits
+	}
+	return c
+}
+
+// ContainerLoad is what a container holds, including everything below it.
+type ContainerLoad struct {
+	Weight customtypes.Decimal `json:"weight"`
+	Volume customtypes.Decimal `json:"volume"`
+	Units  customtypes.Decimal `json:"units"`
+}
+
+func (l ContainerLoad) Add(other ContainerLoad) ContainerLoad {
+	return ContainerLoad{
+		Weight: l.Weight.Add(other.Weight),
+		Volume: l.Volume.Add(other.Volume),
+		Units:  l.Units.Add(other.Units),
+	}
+}
+
+// Exceeds reports whether l is larger than other in any dimension.
+func (l ContainerLoad) Exceeds(other ContainerLoad) bool {
+	return l.Weight.Cmp(other.Weight) > 0 || l.Volume.Cmp(other.Volume) > 0 || l.Units.Cmp(other.Units) > 0
+}
+
+// Violations describes each limit of c that load goes over.
+func (c ContainerCapacity) Violations(load ContainerLoad) []string {
+	var violations []string
+	if !c.MaxWeight.IsZero() && load.Weight.Cmp(c.MaxWeight) > 0 {
+		violations = append(violations, fmt.Sprintf("weight %s kg exceeds %s kg", load.Weight, c.MaxWeight))
+	}
+	if !c.MaxVolume.IsZero() && load.Volume.Cmp(c.MaxVolume) > 0 {
+		violations = append(violations, fmt.Sprintf("volume %s m³ exceeds %s m³", load.Volume, c.MaxVolume))
+	}
+	if !c.MaxUnits.IsZero() && load.Units.Cmp(c.MaxUnits) > 0 {
+		violations = append(violations, fmt.Sprintf("%s units exceed %s units", load.Units, c.MaxUnits))
+	}
+	return violations
+}
+
+// LotLoad is the load quantity of a product, held in unit and weighing weight
+// and taking volume per unit, adds to a container. Only lots in count units
+// add units.
+func LotLoad(quantity customtypes.Decimal, unit string, weight, volume customtypes.Decimal) (ContainerLoad, error) {
+	var load ContainerLoad
+	if IsCountUnit(unit) {
+		load.Units = quantity
+	}
+	var err error
+	if load.Weight, err = quantity.Mul(weight); err != nil {
+		return ContainerLoad{}, err
+	}
+	if load.Volume, err = quantity.Mul(volume); err != nil {
+		return ContainerLoad{}, err
+	}
+	return load, nil
+}
+
+// EffectiveCapacity is the capacity of the container, falling back to the
+// default of its type for limits it does not set.
+func (p *Container) EffectiveCapacity() ContainerCapacity {
+	return p.Capacity.Or(CapacityDefault(p.Type))
+}
+
+// CapacityViolations describes the limits that load goes over, prefixed with
+// the container they belong to.
+func (p *Container) CapacityViolations(load ContainerLoad) []string {
+	violations := p.EffectiveCapacity().Violations(load)
+	for i, violation := range violations {
+		violations[i] = fmt.Sprintf("%s %s: %s", strings.ToLower(string(p.Type)), p.Code, violation)
+	}
+	return violations
+}
+
+var (
+	capacityMu          sync.RWMutex
+	capacityDefaults    = map[ContainerType]ContainerCapacity{}
+	capacityEnforcement = CAPACITY_REJECT
+	// countUnits are the units of measure whose quantities count towards
+	// MaxUnits; 25 kg of a lot says nothing about how many items it is.
+	countUnits = map[string]bool{"pcs": true, "nos": true}
+)
+
+// SetCapacityDefault configures the capacity of containers of type
+// containerType that do not set their own.
+func SetCapacityDefault(containerType ContainerType, capacity ContainerCapacity) {
+	capacityMu.Lock()
+	defer capacityMu.Unlock()
+	capacityDefaults[containerType] = capacity
+}
+
+func CapacityDefault(containerType ContainerType) ContainerCapacity {
+	capacityMu.RLock()
+	defer capacityMu.RUnlock()
+	return capacityDefaults[containerType]
+}
+
+// SetCapacityEnforcement selects whether overfilling a container is rejected
+// or accepted with a warning.
+func SetCapacityEnforcement(enforcement string) error {
+	switch enforcement {
+	case "":
+		enforcement = CAPACITY_REJECT
+	case CAPACITY_REJECT, CAPACITY_WARN:
+	default:
+		return fmt.Errorf("invalid capacity enforcement %q", enforcement)
+	}
+	capacityMu.Lock()
+	defer capacityMu.Unlock()
+	capacityEnforcement = enforcement
+	return nil
+}
+
+func CapacityEnforcement() string {
+	capacityMu.RLock()
+	defer capacityMu.RUnlock()
+	return capacityEnforcement
+}
+
+// SetCountUnits configures the units of measure whose quantities count
+// towards the unit capacity of containers.
+func SetCountUnits(units []string) {
+	capacityMu.Lock()
+	defer capacityMu.Unlock()
+	countUnits = make(map[string]bool, len(units))
+	for _, unit := range units {
+		countUnits[strings.ToLower(strings.TrimSpace(unit))] = true
+	}
+}
+
+// CountUnits lists the units configured with SetCountUnits, sorted.
+func CountUnits() []string {
+	capacityMu.RLock()
+	defer capacityMu.RUnlock()
+	units := make([]string, 0, len(countUnits))
+	for unit := range countUnits {
+		units = append(units, unit)
+	}
+	sort.Strings(units)
+	return units
+}
+
+func IsCountUnit(unit string) bool {
+	capacityMu.RLock()
+	defer capacityMu.RUnlock()
+	return countUnits[strings.ToLower(strings.TrimSpace(unit))]
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+func TestContainerLoadExceeds(t *testing.T) {
+	load := func(weight, volume, units string) ContainerLoad {
+		return ContainerLoad{
+			Weight: customtypes.MustParseDecimal(weight),
+			Volume: customtypes.MustParseDecimal(volume),
+			Units:  customtypes.MustParseDecimal(units),
+		}
+	}
+	previous := load("10", "0.5", "4")
+	tests := []struct {
+		name    string
+		load    ContainerLoad
+		exceeds bool
+	}{
+		{name: "same load", load: load("10", "0.5", "4"), exceeds: false},
+		{name: "smaller in every dimension", load: load("9", "0.4", "3"), exceeds: false},
+		{name: "heavier with fewer units", load: load("12", "0.5", "3"), exceeds: true},
+		{name: "bulkier", load: load("10", "0.6", "4"), exceeds: true},
+		{name: "more units", load: load("10", "0.5", "5"), exceeds: true},
+	}
+	for _, test := range tests {
+		if got := test.load.Exceeds(previous); got != test.exceeds {
+			t.Errorf("%s: Exceeds = %v, want %v", test.name, got, test.exceeds)
+		}
+	}
+}
+
+func TestCountUnits(t *testing.T) {
+	defer SetCountUnits(CountUnits())
+	SetCountUnits([]string{" PCS ", "box"})
+	tests := []struct {
+		unit  string
+		count bool
+	}{
+		{unit: "pcs", count: true},
+		{unit: "Box", count: true},
+		{unit: "nos", count: false},
+		{unit: "kg", count: false},
+	}
+	for _, test := range tests {
+		if got := IsCountUnit(test.unit); got != test.count {
+			t.Errorf("IsCountUnit(%q) = %v, want %v", test.unit, got, test.count)
+		}
+	}
+}
+
+func TestLotLoadIntoFullBin(t *testing.T) {
+	defer SetCountUnits(CountUnits())
+	SetCountUnits([]string{"pcs"})
+	bin := &Container{Type: BIN_TYPE, Code: "B-01", Capacity: ContainerCapacity{MaxUnits: customtypes.NewDecimalFromInt(10)}}
+	current := ContainerLoad{Units: customtypes.NewDecimalFromInt(10)}
+
+	// A lot received in pieces counts towards the unit limit of the bin
+	load, err := LotLoad(customtypes.NewDecimalFromInt(5), "PCS", customtypes.Decimal{}, customtypes.Decimal{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if violations := bin.CapacityViolations(current.Add(load)); len(violations) != 1 {
+		t.Errorf("receiving 5 pcs into a full bin: violations = %q, want one", violations)
+	}
+
+	// A lot whose unit is not known adds no units and is let in
+	load, err = LotLoad(customtypes.NewDecimalFromInt(5), "", customtypes.Decimal{}, customtypes.Decimal{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if violations := bin.CapacityViolations(current.Add(load)); len(violations) != 0 {
+		t.Errorf("receiving 5 of no unit: violations = %q, want none", violations)
+	}
+}
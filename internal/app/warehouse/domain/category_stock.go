@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+// ProductCategory is a node of the product category tree as seen by stock
+// reports. Path lists the ids from the root down to the category itself.
+type ProductCategory struct {
+	ID       int64  `json:"id"`
+	ParentID *int64 `json:"parent_id"`
+	Path     string `json:"path"`
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+}
+
+// AncestorIDs returns the ids in the path of the category, root first and
+// ending with the category itself.
+func (c *ProductCategory) AncestorIDs() []int64 {
+	var ids []int64
+	for _, part := range strings.Split(strings.Trim(c.Path, "/"), "/") {
+		if id, err := strconv.ParseInt(part, 10, 64); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// AtLevel returns the id of the ancestor of the category at level, 1 being a
+// root category. Categories less deep than level are their own roll-up.
+func (c *ProductCategory) AtLevel(level int) int64 {
+	ids := c.AncestorIDs()
+	if level <= 0 || level > len(ids) {
+		return c.ID
+	}
+	return ids[level-1]
+}
+
+// CategoryStock is the stock of products in one unit. Lots and Products
+// count the lots held and the distinct products they belong to.
+type CategoryStock struct {
+	Unit      string              `json:"unit"`
+	OnHand    customtypes.Decimal `json:"on_hand"`
+	Reserved  customtypes.Decimal `json:"reserved"`
+	Held      customtypes.Decimal `json:"held"`
+	Available customtypes.Decimal `json:"available"`
+	Lots      int                 `json:"lots"`
+	Products  int                 `json:"products"`
+}
+
+func (s *CategoryStock) add(other *CategoryStock) {
+	s.OnHand = s.OnHand.Add(other.OnHand)
+	s.Reserved = s.Reserved.Add(other.Reserved)
+	s.Held = s.Held.Add(other.Held)
+	s.Available = s.OnHand.Sub(s.Reserved).Sub(s.Held)
+	s.Lots += other.Lots
+	s.Products += other.Products
+}
+
+// CategoryStockRow is the stock of the products whose primary category is
+// CategoryID, or of products without a category when it is nil.
+type CategoryStockRow struct {
+	CategoryID *int64
+	CategoryStock
+}
+
+// CategoryStockNode is a category with the stock filed directly under it in
+// Own and the stock of its whole subtree in Total. Products are only counted
+// under their primary category, so nothing is counted twice.
+type CategoryStockNode struct {
+	*ProductCategory
+	Level    int                  `json:"level"`
+	Own      []*CategoryStock     `json:"own"`
+	Total    []*CategoryStock     `json:"total"`
+	Children []*CategoryStockNode `json:"children,omitempty"`
+}
+
+// CategoryStockReport rolls the stock on hand up the category tree.
+type CategoryStockReport struct {
+	AsOf          time.Time            `json:"as_of"`
+	StoreID       *int64               `json:"store_id"`
+	Categories    []*CategoryStockNode `json:"categories"`
+	Uncategorized []*CategoryStock     `json:"uncategorized"`
+}
+
+// BuildCategoryStockReport nests categories, ordered by path, and adds the
+// stock of every category to itself and each of its ancestors. Quantities in
+// different units are never added together.
+func BuildCategoryStockReport(categories []*ProductCategory, rows []*CategoryStockRow) *CategoryStockReport {
+	report := &CategoryStockReport{Categories: []*CategoryStockNode{}, Uncategorized: []*CategoryStock{}}
+	nodes := make(map[int64]*CategoryStockNode, len(categories))
+	for _, category := range categories {
+		node := &CategoryStockNode{ProductCategory: category, Level: len(category.AncestorIDs()), Own: []*CategoryStock{}, Total: []*CategoryStock{}}
+		nodes[category.ID] = node
+		if category.ParentID != nil {
+			if parent, ok := nodes[*category.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		report.Categories = append(report.Categories, node)
+	}
+
+	for _, row := range rows {
+		var node *CategoryStockNode
+		if row.CategoryID != nil {
+			node = nodes[*row.CategoryID]
+		}
+		if node == nil {
+			report.Uncategorized = addCategoryStock(report.Uncategorized, &row.CategoryStock)
+			continue
+		}
+		node.Own = addCategoryStock(node.Own, &row.CategoryStock)
+		for _, id := range node.AncestorIDs() {
+			if ancestor, ok := nodes[id]; ok {
+				ancestor.Total = addCategoryStock(ancestor.Total, &row.CategoryStock)
+			}
+		}
+	}
+	return report
+}
+
+func addCategoryStock(totals []*CategoryStock, stock *CategoryStock) []*CategoryStock {
+	for _, total := range totals {
+		if total.Unit == stock.Unit {
+			total.add(stock)
+			return totals
+		}
+	}
+	total := &CategoryStock{Unit: stock.Unit}
+	total.add(stock)
+	return append(totals, total)
+}
+
+// Flatten lists the nodes of the report depth first, parents before their
+// children.
+func (r *CategoryStockReport) Flatten() []*CategoryStockNode {
+	var nodes []*CategoryStockNode
+	var walk func([]*CategoryStockNode)
+	walk = func(level []*CategoryStockNode) {
+		for _, node := range level {
+			nodes = append(nodes, node)
+			walk(node.Children)
+		}
+	}
+	walk(r.Categories)
+	return nodes
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+func TestBuildCategoryStockReport(t *testing.T) {
+	one, two := int64(1), int64(2)
+	categories := []*ProductCategory{
+		{ID: 1, Path: "/1/", Code: "RM"},
+		{ID: 2, ParentID: &one, Path: "/1/2/", Code: "PAPER"},
+		{ID: 3, ParentID: &two, Path: "/1/2/3/", Code: "KRAFT"},
+		{ID: 4, Path: "/4/", Code: "FG"},
+	}
+	row := func(categoryID *int64, unit string, onHand, reserved, held string, lots int) *CategoryStockRow {
+		return &CategoryStockRow{CategoryID: categoryID, CategoryStock: CategoryStock{
+			Unit:      unit,
+			OnHand:    customtypes.MustParseDecimal(onHand),
+			Reserved:  customtypes.MustParseDecimal(reserved),
+			Held:      customtypes.MustParseDecimal(held),
+			Available: customtypes.MustParseDecimal(onHand),
+			Lots:      lots,
+			Products:  1,
+		}}
+	}
+	three, unknown := int64(3), int64(99)
+	rows := []*CategoryStockRow{
+		row(&three, "kg", "10.5", "2", "1", 2),
+		row(&three, "pcs", "5", "0", "0", 1),
+		row(&one, "kg", "1", "0", "0", 1),
+		row(nil, "kg", "7", "0", "0", 1),
+		row(&unknown, "kg", "3", "1", "0", 1),
+	}
+	report := BuildCategoryStockReport(categories, rows)
+
+	stocks := func(stocks []*CategoryStock) string {
+		var parts []string
+		for _, stock := range stocks {
+			parts = append(parts, fmt.Sprintf("%s %s/%s/%s/%s %d", stock.Unit, stock.OnHand, stock.Reserved, stock.Held, stock.Available, stock.Lots))
+		}
+		return strings.Join(parts, ", ")
+	}
+	want := []struct {
+		code  string
+		level int
+		own   string
+		total string
+	}{
+		{code: "RM", level: 1, own: "kg 1/0/0/1 1", total: "kg 11.5/2/1/8.5 3, pcs 5/0/0/5 1"},
+		{code: "PAPER", level: 2, total: "kg 10.5/2/1/7.5 2, pcs 5/0/0/5 1"},
+		{code: "KRAFT", level: 3, own: "kg 10.5/2/1/7.5 2, pcs 5/0/0/5 1", total: "kg 10.5/2/1/7.5 2, pcs 5/0/0/5 1"},
+		{code: "FG", level: 1},
+	}
+	nodes := report.Flatten()
+	if len(nodes) != len(want) {
+		t.Fatalf("report has %d categories, want %d", len(nodes), len(want))
+	}
+	for i, node := range nodes {
+		if node.Code != want[i].code || node.Level != want[i].level {
+			t.Errorf("category %d = %s at level %d, want %s at level %d", i, node.Code, node.Level, want[i].code, want[i].level)
+		}
+		if got := stocks(node.Own); got != want[i].own {
+			t.Errorf("%s own = %q, want %q", node.Code, got, want[i].own)
+		}
+		if got := stocks(node.Total); got != want[i].total {
+			t.Errorf("%s total = %q, want %q", node.Code, got, want[i].total)
+		}
+	}
+	if len(report.Categories) != 2 {
+		t.Errorf("report has %d root categories, want 2", len(report.Categories))
+	}
+	if got, want := stocks(report.Uncategorized), "kg 10/1/0/9 2"; got != want {
+		t.Errorf("uncategorized = %q, want %q", got, want)
+	}
+}
@@ -2,6 +2,9 @@ package domain
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
@@ -10,35 +13,179 @@ import (
 type ContainerType string
 
 const (
-	PALLET_TYPE ContainerType = "PALLET"
-	BIN_TYPE    ContainerType = "BIN"
+	ZONE_TYPE   ContainerType = "ZONE"
 	RACK_TYPE   ContainerType = "RACK"
+	BIN_TYPE    ContainerType = "BIN"
+	PALLET_TYPE ContainerType = "PALLET"
 )
 
+var (
+	ErrInvalidContainerParent = errors.New("container cannot be placed there")
+	ErrContainerCycle         = errors.New("container cannot be moved under itself")
+	ErrContainerHasChildren   = errors.New("container still holds other containers")
+	ErrContainerMoveTarget    = errors.New("container must be moved into another container or a store")
+)
+
+// containerParents lists the container types each type may be nested in.
+// Types in storeLevelContainers may also sit directly in a store.
+var containerParents = map[ContainerType][]ContainerType{
+	ZONE_TYPE:   nil,
+	RACK_TYPE:   {ZONE_TYPE},
+	BIN_TYPE:    {RACK_TYPE},
+	PALLET_TYPE: {BIN_TYPE, RACK_TYPE, ZONE_TYPE},
+}
+
+var storeLevelContainers = map[ContainerType]bool{
+	ZONE_TYPE:   true,
+	RACK_TYPE:   true,
+	PALLET_TYPE: true,
+}
+
+// Container is a node of the location tree store → zone → rack → bin →
+// pallet. Path lists the ids from the root down to the container itself, e.g.
+// "/3/17/42/", so that a subtree can be selected with a prefix match.
 type Container struct {
 	ID            int64                      `json:"id"`
 	Type          ContainerType              `json:"type"`
+	ParentID      *int64                     `json:"parent_id"`
+	StoreID       *int64                     `json:"store_id"`
+	Path          string                     `json:"path"`
 	Code          customtypes.NullableString `json:"code"`
 	Name          customtypes.NullableString `json:"name"`
 	Address       customtypes.NullableString `json:"address"`
-	Status        customtypes.NullableString `json:"status"`
+	Status        ContainerStatus            `json:"status"`
+	Occupancy     ContainerOccupancy         `json:"occupancy,omitempty"`
+	Capacity      ContainerCapacity          `json:"capacity"`
+	Load          *ContainerLoad             `json:"load,omitempty"`
 	CreatedAt     time.Time                  `json:"created_at"`
 	UpdatedAt     time.Time                  `json:"updated_at"`
 	LastUpdatedBy customtypes.NullableString `json:"last_updated_by"`
+	Version       int64                      `json:"version"`
+	Children      []*Container               `json:"children,omitempty"`
+	Warnings      []string                   `json:"warnings,omitempty"`
 }
 
 func NewContainerWithDefaults() Container {
 	return Container{
 		Type:   PALLET_TYPE,
-		Status: "active",
+		Status: CONTAINER_ACTIVE,
 	}
 }
 
 func (p *Container) ValidateType() error {
 	switch p.Type {
-	case PALLET_TYPE, BIN_TYPE, RACK_TYPE:
+	case ZONE_TYPE, RACK_TYPE, BIN_TYPE, PALLET_TYPE:
 		return nil
 	default:
-		return errors.New("invalid product type")
+		return errors.New("invalid container type")
+	}
+}
+
+// ValidateParent checks the nesting rules for placing the container in
+// parent, or directly in its store when parent is nil.
+func (p *Container) ValidateParent(parent *Container) error {
+	if parent == nil {
+		if !storeLevelContainers[p.Type] {
+			return fmt.Errorf("%w: %s must be placed in a %s", ErrInvalidContainerParent, strings.ToLower(string(p.Type)), strings.ToLower(string(containerParents[p.Type][0])))
+		}
+		if p.Type == ZONE_TYPE && p.StoreID == nil {
+			return fmt.Errorf("%w: zone must belong to a store", ErrInvalidContainerParent)
+		}
+		return nil
+	}
+	if parent.ID == p.ID || p.IsAncestorOf(parent) {
+		return ErrContainerCycle
+	}
+	for _, allowed := range containerParents[p.Type] {
+		if parent.Type == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s cannot be placed in a %s", ErrInvalidContainerParent, strings.ToLower(string(p.Type)), strings.ToLower(string(parent.Type)))
+}
+
+// IsAncestorOf reports whether other is somewhere below the container.
+func (p *Container) IsAncestorOf(other *Container) bool {
+	return p.Path != "" && other.Path != p.Path && strings.HasPrefix(other.Path, p.Path)
+}
+
+// ChildPath returns the path of a container with the given id placed in
+// parent, or directly in a store when parent is nil.
+func ChildPath(parent *Container, id int64) string {
+	prefix := "/"
+	if parent != nil {
+		prefix = parent.Path
+	}
+	return prefix + strconv.FormatInt(id, 10) + "/"
+}
+
+// AncestorIDs returns the ids in the path of the container, root first and
+// ending with the container itself.
+func (p *Container) AncestorIDs() []int64 {
+	var ids []int64
+	for _, part := range strings.Split(strings.Trim(p.Path, "/"), "/") {
+		if id, err := strconv.ParseInt(part, 10, 64); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// isBelow reports whether the container with id ancestorID is one of the
+// ancestors of the container.
+func (p *Container) isBelow(ancestorID int64) bool {
+	for _, id := range p.AncestorIDs() {
+		if id == ancestorID && id != p.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainerLocation is where a container sits, as derived from its
+// ancestors.
+type ContainerLocation struct {
+	StoreID  *int64 `json:"store_id"`
+	ZoneID   *int64 `json:"zone_id"`
+	RackID   *int64 `json:"rack_id"`
+	BinID    *int64 `json:"bin_id"`
+	PalletID *int64 `json:"pallet_id"`
+}
+
+// LocationOf derives the location of the last container of chain, which
+// lists its ancestors root first.
+func LocationOf(chain []*Container) ContainerLocation {
+	var location ContainerLocation
+	for _, container := range chain {
+		id := container.ID
+		switch container.Type {
+		case ZONE_TYPE:
+			location.ZoneID = &id
+		case RACK_TYPE:
+			location.RackID = &id
+		case BIN_TYPE:
+			location.BinID = &id
+		case PALLET_TYPE:
+			location.PalletID = &id
+		}
+	}
+	if len(chain) > 0 {
+		location.StoreID = chain[len(chain)-1].StoreID
+	}
+	return location
+}
+
+// BuildContainerTree nests descendants, ordered by path, under root.
+func BuildContainerTree(root *Container, descendants []*Container) *Container {
+	byID := map[int64]*Container{root.ID: root}
+	for _, container := range descendants {
+		byID[container.ID] = container
+		if container.ParentID == nil {
+			continue
+		}
+		if parent, ok := byID[*container.ParentID]; ok {
+			parent.Children = append(parent.Children, container)
+		}
 	}
+	return root
 }
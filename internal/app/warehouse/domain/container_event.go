@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+type ContainerEventType string
+
+const (
+	CONTAINER_CREATED        ContainerEventType = "CREATED"
+	CONTAINER_MOVED          ContainerEventType = "MOVED"
+	CONTAINER_STOCK_ADDED    ContainerEventType = "STOCK_ADDED"
+	CONTAINER_STOCK_REMOVED  ContainerEventType = "STOCK_REMOVED"
+	CONTAINER_STATUS_CHANGED ContainerEventType = "STATUS_CHANGED"
+	CONTAINER_LABEL_PRINTED  ContainerEventType = "LABEL_PRINTED"
+)
+
+var ErrInvalidContainerEvent = errors.New("invalid container event")
+
+// ContainerEvent is an entry of the timeline of a container. StoreID is the
+// store the container was in at the time. For a move, FromContainerID and
+// ToContainerID are the old and new parent; for stock, they are where the lot
+// came from or went to when it moved between containers. Note holds the
+// reason of a status change or the template of a printed label.
+type ContainerEvent struct {
+	ID              int64                      `json:"id"`
+	ContainerID     int64                      `json:"container_id"`
+	Event           ContainerEventType         `json:"event"`
+	StoreID         *int64                     `json:"store_id"`
+	FromContainerID *int64                     `json:"from_container_id"`
+	ToContainerID   *int64                     `json:"to_container_id"`
+	InventoryID     *int64                     `json:"inventory_id"`
+	ProductID       *int64                     `json:"product_id"`
+	Batch           customtypes.NullableString `json:"batch"`
+	Quantity        customtypes.Decimal        `json:"quantity"`
+	Unit            customtypes.NullableString `json:"unit"`
+	FromStatus      customtypes.NullableString `json:"from_status"`
+	ToStatus        customtypes.NullableString `json:"to_status"`
+	Note            customtypes.NullableString `json:"note"`
+	Actor           customtypes.NullableString `json:"actor"`
+	OccurredAt      time.Time                  `json:"occurred_at"`
+}
+
+func (t ContainerEventType) Validate() error {
+	switch t {
+	case CONTAINER_CREATED, CONTAINER_MOVED, CONTAINER_STOCK_ADDED, CONTAINER_STOCK_REMOVED, CONTAINER_STATUS_CHANGED, CONTAINER_LABEL_PRINTED:
+		return nil
+	default:
+		return ErrInvalidContainerEvent
+	}
+}
+
+// LotHolding is the part of the state of a lot that decides which container
+// holds how much of it.
+type LotHolding struct {
+	InventoryID int64
+	ContainerID *int64
+	Status      InventoryType
+	ProductID   int64
+	Batch       string
+	Quantity    customtypes.Decimal
+	Unit        string
+}
+
+func (h *LotHolding) holds() bool {
+	return h != nil && h.ContainerID != nil && h.Status != STOCK_OUT && h.Quantity.Sign() > 0
+}
+
+// ContentChanges returns the stock events of a lot changing from before to
+// after, either of which is nil when the lot did not or no longer exists. A
+// lot moving between containers is removed from one and added to the other;
+// a lot staying in its container only records the change in quantity.
+func ContentChanges(before, after *LotHolding) []*ContainerEvent {
+	event := func(kind ContainerEventType, holding *LotHolding, quantity customtypes.Decimal) *ContainerEvent {
+		inventoryID, productID := holding.InventoryID, holding.ProductID
+		return &ContainerEvent{
+			ContainerID: *holding.ContainerID,
+			Event:       kind,
+			InventoryID: &inventoryID,
+			ProductID:   &productID,
+			Batch:       customtypes.NullableString(holding.Batch),
+			Quantity:    quantity,
+			Unit:        customtypes.NullableString(holding.Unit),
+		}
+	}
+
+	if before.holds() && after.holds() && *before.ContainerID == *after.ContainerID {
+		delta := after.Quantity.Sub(before.Quantity)
+		switch delta.Sign() {
+		case 1:
+			return []*ContainerEvent{event(CONTAINER_STOCK_ADDED, after, delta)}
+		case -1:
+			return []*ContainerEvent{event(CONTAINER_STOCK_REMOVED, after, before.Quantity.Sub(after.Quantity))}
+		}
+		return nil
+	}
+
+	var events []*ContainerEvent
+	if before.holds() {
+		removed := event(CONTAINER_STOCK_REMOVED, before, before.Quantity)
+		if after.holds() {
+			removed.ToContainerID = after.ContainerID
+		}
+		events = append(events, removed)
+	}
+	if after.holds() {
+		added := event(CONTAINER_STOCK_ADDED, after, after.Quantity)
+		if before.holds() {
+			added.FromContainerID = before.ContainerID
+		}
+		events = append(events, added)
+	}
+	return events
+}
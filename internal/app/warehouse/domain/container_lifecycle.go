@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+// ContainerStatus is the operational state of a container. Only active
+// containers accept stock.
+type ContainerStatus string
+
+const (
+	CONTAINER_ACTIVE       ContainerStatus = "active"
+	CONTAINER_DAMAGED      ContainerStatus = "damaged"
+	CONTAINER_UNDER_REPAIR ContainerStatus = "under repair"
+	CONTAINER_RETIRED      ContainerStatus = "retired"
+)
+
+// ContainerOccupancy is derived from the stock held in a container and
+// everything below it, compared with its capacity.
+type ContainerOccupancy string
+
+const (
+	OCCUPANCY_EMPTY   ContainerOccupancy = "EMPTY"
+	OCCUPANCY_PARTIAL ContainerOccupancy = "PARTIAL"
+	OCCUPANCY_FULL    ContainerOccupancy = "FULL"
+)
+
+var (
+	ErrInvalidContainerStatus    = errors.New("invalid container status")
+	ErrContainerStatusTransition = errors.New("container status cannot change that way")
+	ErrContainerUnavailable      = errors.New("container does not accept stock")
+	ErrContainerNotEmpty         = errors.New("container still holds stock")
+)
+
+// containerTransitions lists the statuses each status may change to. A
+// retired container stays retired.
+var containerTransitions = map[ContainerStatus][]ContainerStatus{
+	CONTAINER_ACTIVE:       {CONTAINER_DAMAGED, CONTAINER_RETIRED},
+	CONTAINER_DAMAGED:      {CONTAINER_UNDER_REPAIR, CONTAINER_RETIRED},
+	CONTAINER_UNDER_REPAIR: {CONTAINER_ACTIVE, CONTAINER_DAMAGED, CONTAINER_RETIRED},
+	CONTAINER_RETIRED:      nil,
+}
+
+func (s ContainerStatus) Validate() error {
+	if _, ok := containerTransitions[s]; !ok {
+		return ErrInvalidContainerStatus
+	}
+	return nil
+}
+
+func (s ContainerStatus) AcceptsStock() bool {
+	return s == CONTAINER_ACTIVE
+}
+
+// ValidateStatusTransition checks that the container may change to status.
+func (p *Container) ValidateStatusTransition(status ContainerStatus) error {
+	if err := status.Validate(); err != nil {
+		return err
+	}
+	for _, allowed := range containerTransitions[p.Status] {
+		if allowed == status {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s to %s", ErrContainerStatusTransition, p.Status, status)
+}
+
+// CheckAcceptsStock refuses a chain of containers, root first, when any of
+// them is not active: stock cannot go into a damaged bin nor into a bin of a
+// retired rack.
+func CheckAcceptsStock(chain []*Container) error {
+	for _, container := range chain {
+		if !container.Status.AcceptsStock() {
+			return fmt.Errorf("%w: %s %s is %s", ErrContainerUnavailable, strings.ToLower(string(container.Type)), container.Code, container.Status)
+		}
+	}
+	return nil
+}
+
+// OccupancyOf derives the occupancy of a container holding load. A container
+// is full once it reaches any of its limits; without limits it is never full.
+func OccupancyOf(capacity ContainerCapacity, load ContainerLoad) ContainerOccupancy {
+	if load.Units.Sign() <= 0 && load.Weight.Sign() <= 0 && load.Volume.Sign() <= 0 {
+		return OCCUPANCY_EMPTY
+	}
+	if (!capacity.MaxWeight.IsZero() && load.Weight.Cmp(capacity.MaxWeight) >= 0) ||
+		(!capacity.MaxVolume.IsZero() && load.Volume.Cmp(capacity.MaxVolume) >= 0) ||
+		(!capacity.MaxUnits.IsZero() && load.Units.Cmp(capacity.MaxUnits) >= 0) {
+		return OCCUPANCY_FULL
+	}
+	return OCCUPANCY_PARTIAL
+}
+
+// SetLoad records what the container holds and derives its occupancy.
+func (p *Container) SetLoad(load ContainerLoad) {
+	p.Load = &load
+	p.Occupancy = OccupancyOf(p.EffectiveCapacity(), load)
+}
+
+type ContainerStatusForm struct {
+	Status    ContainerStatus            `json:"status"`
+	Reason    customtypes.NullableString `json:"reason"`
+	ChangedBy customtypes.NullableString `json:"changed_by"`
+}
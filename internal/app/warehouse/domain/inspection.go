@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+type InspectionStatus string
+
+const (
+	INSPECTION_OPEN     InspectionStatus = "OPEN"
+	INSPECTION_RELEASED InspectionStatus = "RELEASED"
+	INSPECTION_REJECTED InspectionStatus = "REJECTED"
+)
+
+var (
+	ErrInspectionClosed        = errors.New("inspection has already been decided")
+	ErrInspectionFailed        = errors.New("inspection has failed or missing mandatory results and cannot be released")
+	ErrInspectionPending       = errors.New("inventory already has an open inspection")
+	ErrInvalidQCTransition     = errors.New("invalid qc status transition")
+	ErrQuarantineNotRejected   = errors.New("only rejected inventory can be moved to quarantine")
+	ErrQuarantineStoreRequired = errors.New("quarantine store is required")
+)
+
+// InspectionParameter is a characteristic checked when a lot of the product is
+// inspected. Numeric parameters pass when the measured value is within the
+// optional min and max; text parameters pass when they match the expected
+// value.
+type InspectionParameter struct {
+	ID            int64                      `json:"id"`
+	ProductID     int64                      `json:"product_id"`
+	Name          customtypes.NullableString `json:"name"`
+	Unit          customtypes.NullableString `json:"unit"`
+	MinValue      *customtypes.Decimal       `json:"min_value"`
+	MaxValue      *customtypes.Decimal       `json:"max_value"`
+	ExpectedValue customtypes.NullableString `json:"expected_value"`
+	Mandatory     bool                       `json:"mandatory"`
+	Status        customtypes.NullableString `json:"status"`
+	CreatedAt     time.Time                  `json:"created_at"`
+	UpdatedAt     time.Time                  `json:"updated_at"`
+	LastUpdatedBy customtypes.NullableString `json:"last_updated_by"`
+	Version       int64                      `json:"version"`
+}
+
+func NewInspectionParameterWithDefaults() *InspectionParameter {
+	return &InspectionParameter{
+		Mandatory: true,
+		Status:    "active",
+	}
+}
+
+// InitialQCStatus is the QC status a new lot of a product with the given
+// active parameters starts in: pending inspection when any of them is
+// mandatory, else released.
+func InitialQCStatus(parameters []*InspectionParameter) QCStatus {
+	for _, parameter := range parameters {
+		if parameter.Mandatory {
+			return QC_PENDING_INSPECTION
+		}
+	}
+	return QC_RELEASED
+}
+
+func (p *InspectionParameter) IsNumeric() bool {
+	return p.MinValue != nil || p.MaxValue != nil
+}
+
+// Evaluate reports whether value satisfies the parameter.
+func (p *InspectionParameter) Evaluate(value string) (bool, error) {
+	if p.IsNumeric() {
+		measured, err := customtypes.ParseDecimal(value)
+		if err != nil {
+			return false, errors.New("value of " + string(p.Name) + " must be numeric")
+		}
+		if p.MinValue != nil && measured.Cmp(*p.MinValue) < 0 {
+			return false, nil
+		}
+		if p.MaxValue != nil && measured.Cmp(*p.MaxValue) > 0 {
+			return false, nil
+		}
+		return true, nil
+	}
+	if p.ExpectedValue != "" {
+		return strings.EqualFold(strings.TrimSpace(value), string(p.ExpectedValue)), nil
+	}
+	return true, nil
+}
+
+type InspectionResult struct {
+	ID          int64     `json:"id"`
+	ParameterID int64     `json:"parameter_id"`
+	Name        string    `json:"name"`
+	Value       string    `json:"value"`
+	Passed      bool      `json:"passed"`
+	RecordedAt  time.Time `json:"recorded_at"`
+	RecordedBy  string    `json:"recorded_by"`
+}
+
+type Inspection struct {
+	ID          int64                      `json:"id"`
+	InventoryID int64                      `json:"inventory_id"`
+	ProductID   int64                      `json:"product_id"`
+	Batch       string                     `json:"batch"`
+	Status      InspectionStatus           `json:"status"`
+	Inspector   customtypes.NullableString `json:"inspector"`
+	Remarks     customtypes.NullableString `json:"remarks"`
+	DecidedAt   *time.Time                 `json:"decided_at"`
+	CreatedAt   time.Time                  `json:"created_at"`
+	Results     []*InspectionResult        `json:"results"`
+}
+
+// CanRelease checks that every mandatory parameter has a passing result.
+func (i *Inspection) CanRelease(parameters []*InspectionParameter) bool {
+	latest := map[int64]*InspectionResult{}
+	for _, result := range i.Results {
+		latest[result.ParameterID] = result
+	}
+	for _, result := range latest {
+		if !result.Passed {
+			return false
+		}
+	}
+	for _, parameter := range parameters {
+		if parameter.Mandatory && latest[parameter.ID] == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Decide closes the inspection with a release or reject decision.
+func (i *Inspection) Decide(status InspectionStatus, inspector string, remarks string, at time.Time) error {
+	if i.Status != INSPECTION_OPEN {
+		return ErrInspectionClosed
+	}
+	i.Status = status
+	i.Inspector = customtypes.NullableString(inspector)
+	if remarks != "" {
+		i.Remarks = customtypes.NullableString(remarks)
+	}
+	i.DecidedAt = &at
+	return nil
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "testing"
+
+func TestInitialQCStatus(t *testing.T) {
+	optional := &InspectionParameter{Name: "gloss", Mandatory: false}
+	mandatory := &InspectionParameter{Name: "gsm", Mandatory: true}
+	tests := []struct {
+		name       string
+		parameters []*InspectionParameter
+		want       QCStatus
+	}{
+		{name: "no parameters", want: QC_RELEASED},
+		{name: "optional parameters only", parameters: []*InspectionParameter{optional}, want: QC_RELEASED},
+		{name: "a mandatory parameter", parameters: []*InspectionParameter{optional, mandatory}, want: QC_PENDING_INSPECTION},
+	}
+	for _, test := range tests {
+		if got := InitialQCStatus(test.parameters); got != test.want {
+			t.Errorf("%s: InitialQCStatus() = %s, want %s", test.name, got, test.want)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package domain
 import (
 	"errors"
 	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
 )
 
 type InventoryType string
@@ -11,29 +13,60 @@ const (
 	STOCK_IN       InventoryType = "STOCK IN"
 	STOCK_OUT      InventoryType = "STOCK OUT"
 	STOCK_RESERVED InventoryType = "STOCK RESERVED"
+	// STOCK_IN_TRANSIT is a lot dispatched on a transfer order. It belongs to
+	// no store or container until it is received.
+	STOCK_IN_TRANSIT InventoryType = "STOCK IN TRANSIT"
+)
+
+type QCStatus string
+
+const (
+	QC_PENDING_INSPECTION QCStatus = "PENDING INSPECTION"
+	QC_ON_HOLD            QCStatus = "ON HOLD"
+	QC_RELEASED           QCStatus = "RELEASED"
+	QC_REJECTED           QCStatus = "REJECTED"
 )
 
+var (
+	ErrInventoryNotReleased = errors.New("inventory has not been released by quality control")
+	ErrLocationMismatch     = errors.New("location does not match the container tree")
+	ErrInventoryInTransit   = errors.New("inventory is in transit and only changes through its transfer order")
+)
+
+// Inventory is a lot of one product. ContainerID is the container holding the
+// lot; pallet, bin, rack and store are derived from it through the container
+// tree and kept on the lot for reporting. Warnings lists the capacity limits
+// a write went over when those are only warned about.
 type Inventory struct {
-	ID         int64         `json:"id"`
-	Status     InventoryType `json:"status"`
-	PalletID   *int64        `json:"pallet_id"`
-	BinID      *int64        `json:"bin_id"`
-	RackID     *int64        `json:"rack_id"`
-	StoreID    *int64        `json:"store_id"`
-	ProductID  int64         `json:"product_id"`
-	Batch      string        `json:"batch"`
-	Machine    string        `json:"machine"`
-	Shift      string        `json:"shift"`
-	Supervisor string        `json:"supervisor"`
-	Quantity   float64       `json:"quantity"`
-	Unit       string        `json:"unit"`
-	StockInAt  time.Time     `json:"stockin_at"`
-	StockOutAt *time.Time    `json:"stockout_at"`
+	ID          int64               `json:"id"`
+	Status      InventoryType       `json:"status"`
+	QCStatus    QCStatus            `json:"qc_status"`
+	ContainerID *int64              `json:"container_id"`
+	PalletID    *int64              `json:"pallet_id"`
+	BinID       *int64              `json:"bin_id"`
+	RackID      *int64              `json:"rack_id"`
+	StoreID     *int64              `json:"store_id"`
+	ProductID   int64               `json:"product_id"`
+	Batch       string              `json:"batch"`
+	Machine     string              `json:"machine"`
+	Shift       string              `json:"shift"`
+	Supervisor  string              `json:"supervisor"`
+	Quantity    customtypes.Decimal `json:"quantity"`
+	Unit        string              `json:"unit"`
+	StockInAt   time.Time           `json:"stockin_at"`
+	StockOutAt  *time.Time          `json:"stockout_at"`
+	ExpiresAt   *time.Time          `json:"expires_at"`
+	Version     int64               `json:"version"`
+	Warnings    []string            `json:"warnings,omitempty"`
+	// ShiftOverride approves a shift or supervisor other than the shift
+	// calendar gives when the lot is stocked in.
+	ShiftOverride *ShiftOverride `json:"shift_override,omitempty"`
 }
 
 func NewInventoryWithDefaults() *Inventory {
 	return &Inventory{
-		Status: STOCK_IN,
+		Status:   STOCK_IN,
+		QCStatus: QC_RELEASED,
 	}
 }
 
@@ -46,8 +79,115 @@ func (i *Inventory) ValidateStatus() error {
 	}
 }
 
+func (i *Inventory) ValidateQCStatus() error {
+	switch i.QCStatus {
+	case QC_PENDING_INSPECTION, QC_ON_HOLD, QC_RELEASED, QC_REJECTED:
+		return nil
+	default:
+		return errors.New("invalid qc status")
+	}
+}
+
+// IsReleased reports whether the lot may be allocated, reserved or shipped.
+func (i *Inventory) IsReleased() bool {
+	return i.QCStatus == QC_RELEASED
+}
+
+// ValidateTransition rejects status changes that would allocate, reserve or
+// ship stock that quality control has not released, and any change to stock
+// in transit.
+func (i *Inventory) ValidateTransition(to InventoryType) error {
+	if i.Status == STOCK_IN_TRANSIT || to == STOCK_IN_TRANSIT {
+		return ErrInventoryInTransit
+	}
+	if (to == STOCK_RESERVED || to == STOCK_OUT) && i.Status != to && !i.IsReleased() {
+		return ErrInventoryNotReleased
+	}
+	return nil
+}
+
+var qcTransitions = map[QCStatus][]QCStatus{
+	QC_PENDING_INSPECTION: {QC_ON_HOLD, QC_RELEASED, QC_REJECTED},
+	QC_ON_HOLD:            {QC_PENDING_INSPECTION, QC_RELEASED, QC_REJECTED},
+	QC_RELEASED:           {QC_PENDING_INSPECTION, QC_ON_HOLD},
+	QC_REJECTED:           {},
+}
+
+// ChangeQCStatus moves the lot to another QC status. Shipped lots and
+// rejected lots can no longer change.
+func (i *Inventory) ChangeQCStatus(to QCStatus) error {
+	if i.Status == STOCK_OUT {
+		return ErrInvalidQCTransition
+	}
+	for _, allowed := range qcTransitions[i.QCStatus] {
+		if allowed == to {
+			i.QCStatus = to
+			return nil
+		}
+	}
+	return ErrInvalidQCTransition
+}
+
+// MoveToQuarantine relocates a rejected lot to the quarantine store.
+func (i *Inventory) MoveToQuarantine(storeID int64) error {
+	if i.QCStatus != QC_REJECTED {
+		return ErrQuarantineNotRejected
+	}
+	if storeID <= 0 {
+		return ErrQuarantineStoreRequired
+	}
+	i.StoreID = &storeID
+	i.ContainerID = nil
+	i.PalletID = nil
+	i.BinID = nil
+	i.RackID = nil
+	return nil
+}
+
+// LocationContainerID returns the container holding the lot. Lots that only
+// name loose pallet, bin or rack ids are held by the most specific of them.
+func (i *Inventory) LocationContainerID() *int64 {
+	switch {
+	case i.ContainerID != nil:
+		return i.ContainerID
+	case i.PalletID != nil:
+		return i.PalletID
+	case i.BinID != nil:
+		return i.BinID
+	default:
+		return i.RackID
+	}
+}
+
+// SetLocation places the lot in containerID at location. When the lot named
+// no container, the loose ids it did name must agree with the tree; otherwise
+// they are simply replaced. The store is kept for containers outside any
+// store, such as a pallet in transit.
+func (i *Inventory) SetLocation(containerID int64, location ContainerLocation) error {
+	if i.ContainerID == nil {
+		if !agreesWith(i.PalletID, location.PalletID) || !agreesWith(i.BinID, location.BinID) ||
+			!agreesWith(i.RackID, location.RackID) || !agreesWith(i.StoreID, location.StoreID) {
+			return ErrLocationMismatch
+		}
+	}
+	i.ContainerID = &containerID
+	i.PalletID = location.PalletID
+	i.BinID = location.BinID
+	i.RackID = location.RackID
+	if location.StoreID != nil {
+		i.StoreID = location.StoreID
+	}
+	return nil
+}
+
+// agreesWith reports whether a loose id sent by a client is consistent with
+// the derived one. An id that was not sent always agrees.
+func agreesWith(given, derived *int64) bool {
+	return given == nil || derived == nil || *given == *derived
+}
+
 type InventoryFormRawMaterial struct {
-	Product_id string `json:"product_id"`
-	Quantity   int64  `json:"quantity"`
-	Pallet     string `json:"pallet"`
+	Product_id string              `json:"product_id"`
+	Quantity   customtypes.Decimal `json:"quantity"`
+	Pallet     string              `json:"pallet"`
 }
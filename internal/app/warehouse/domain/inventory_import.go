@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+	"github.com/vamika-digital/wms-api-server/internal/utility/spreadsheet"
+)
+
+type ImportStatus string
+
+const (
+	IMPORT_PENDING    ImportStatus = "PENDING"
+	IMPORT_VALIDATING ImportStatus = "VALIDATING"
+	IMPORT_COMMITTING ImportStatus = "COMMITTING"
+	IMPORT_COMPLETED  ImportStatus = "COMPLETED"
+	IMPORT_FAILED     ImportStatus = "FAILED"
+)
+
+var (
+	ErrInvalidImportFile = errors.New("invalid import file")
+	ErrImportCompleted   = errors.New("import has already been completed")
+	ErrImportHasErrors   = errors.New("import has row errors; fix the file and upload it again")
+)
+
+// ImportColumns are the recognised headers of an inventory import file.
+// Header names are matched case-insensitively.
+var ImportColumns = []string{"product_code", "container_code", "container_type", "store", "batch", "quantity", "unit"}
+
+var requiredImportColumns = []string{"product_code", "store", "quantity"}
+
+// InventoryImport is a bulk load of opening stock. Rows are validated in
+// chunks so that a restarted server continues where it stopped, then
+// committed in a single transaction.
+type InventoryImport struct {
+	ID            int64                 `json:"id"`
+	FileName      string                `json:"file_name"`
+	Format        string                `json:"format"`
+	Status        ImportStatus          `json:"status"`
+	TotalRows     int                   `json:"total_rows"`
+	ValidatedRows int                   `json:"validated_rows"`
+	ErrorCount    int                   `json:"error_count"`
+	Message       string                `json:"message"`
+	Errors        []*ImportRowError     `json:"errors,omitempty"`
+	CreatedBy     string                `json:"created_by"`
+	CreatedAt     time.Time             `json:"created_at"`
+	UpdatedAt     time.Time             `json:"updated_at"`
+	CompletedAt   *time.Time            `json:"completed_at"`
+	Rows          []*InventoryImportRow `json:"-"`
+}
+
+func (i *InventoryImport) IsResumable() bool {
+	switch i.Status {
+	case IMPORT_PENDING, IMPORT_VALIDATING, IMPORT_COMMITTING:
+		return true
+	default:
+		return false
+	}
+}
+
+// InventoryImportRow is one data row of the file. RowNumber is the row in the
+// spreadsheet, so the header is row 1 and the first data row is row 2.
+type InventoryImportRow struct {
+	ID            int64
+	RowNumber     int
+	ProductCode   string
+	ContainerCode string
+	ContainerType string
+	Store         string
+	Batch         string
+	Quantity      string
+	Unit          string
+
+	// Filled in by validation
+	Validated   bool
+	Error       string
+	ProductID   int64
+	StoreID     int64
+	ContainerID *int64
+	QCStatus    QCStatus
+}
+
+type ImportRowError struct {
+	RowNumber int    `json:"row_number"`
+	Error     string `json:"error"`
+}
+
+// ParseImportRows maps the columns of an uploaded table to import rows.
+func ParseImportRows(table *spreadsheet.Table) ([]*InventoryImportRow, error) {
+	columns := make(map[string]int)
+	for i, header := range table.Headers {
+		columns[strings.ToLower(strings.TrimSpace(header))] = i
+	}
+	for _, column := range requiredImportColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("%w: missing column %s", ErrInvalidImportFile, column)
+		}
+	}
+
+	cell := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []*InventoryImportRow
+	for i, record := range table.Rows {
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		rows = append(rows, &InventoryImportRow{
+			RowNumber:     i + 2,
+			ProductCode:   cell(record, "product_code"),
+			ContainerCode: cell(record, "container_code"),
+			ContainerType: strings.ToUpper(cell(record, "container_type")),
+			Store:         cell(record, "store"),
+			Batch:         cell(record, "batch"),
+			Quantity:      cell(record, "quantity"),
+			Unit:          cell(record, "unit"),
+		})
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no data rows", ErrInvalidImportFile)
+	}
+	return rows, nil
+}
+
+// ParsedQuantity returns the row quantity checked against the row unit.
+func (r *InventoryImportRow) ParsedQuantity() (customtypes.Decimal, error) {
+	quantity, err := customtypes.ParseDecimal(r.Quantity)
+	if err != nil {
+		return customtypes.Decimal{}, errors.New("invalid quantity " + r.Quantity)
+	}
+	if quantity.Sign() <= 0 {
+		return customtypes.Decimal{}, errors.New("quantity must be greater than zero")
+	}
+	return quantity, quantity.ValidateForUnit(r.Unit)
+}
+
+// ContainerKind returns the type of container to create for the row. PALLET
+// is assumed when the file does not say.
+func (r *InventoryImportRow) ContainerKind() (ContainerType, error) {
+	if r.ContainerType == "" {
+		return PALLET_TYPE, nil
+	}
+	container := Container{Type: ContainerType(r.ContainerType)}
+	if err := container.ValidateType(); err != nil {
+		return "", errors.New("invalid container type " + r.ContainerType)
+	}
+	// New containers are placed directly in the store of the row
+	if container.Type == BIN_TYPE {
+		return "", errors.New("bin " + r.ContainerCode + " does not exist; bins must be created in a rack first")
+	}
+	return container.Type, nil
+}
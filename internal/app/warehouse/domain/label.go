@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+	"github.com/vamika-digital/wms-api-server/internal/utility/label"
+)
+
+type LabelKind string
+
+const (
+	LABEL_CONTAINER LabelKind = "container"
+	LABEL_INVENTORY LabelKind = "inventory"
+	LABEL_PRODUCT   LabelKind = "product"
+)
+
+// LabelTemplate is a stored label layout for one kind of label.
+type LabelTemplate struct {
+	ID            int64                      `json:"id"`
+	Name          customtypes.NullableString `json:"name"`
+	Kind          LabelKind                  `json:"kind"`
+	Width         float64                    `json:"width"`
+	Height        float64                    `json:"height"`
+	DPI           int                        `json:"dpi"`
+	Elements      label.Elements             `json:"elements"`
+	Status        customtypes.NullableString `json:"status"`
+	CreatedAt     time.Time                  `json:"created_at"`
+	UpdatedAt     time.Time                  `json:"updated_at"`
+	LastUpdatedBy customtypes.NullableString `json:"last_updated_by"`
+	Version       int64                      `json:"version"`
+}
+
+func NewLabelTemplateWithDefaults() *LabelTemplate {
+	return &LabelTemplate{
+		Kind:   LABEL_CONTAINER,
+		Width:  100,
+		Height: 60,
+		DPI:    203,
+		Status: "active",
+	}
+}
+
+func (t *LabelTemplate) ValidateKind() error {
+	switch t.Kind {
+	case LABEL_CONTAINER, LABEL_INVENTORY, LABEL_PRODUCT:
+		return nil
+	default:
+		return errors.New("invalid label kind")
+	}
+}
+
+func (t *LabelTemplate) Layout() *label.Layout {
+	return &label.Layout{Width: t.Width, Height: t.Height, DPI: t.DPI, Elements: t.Elements}
+}
+
+// DefaultLabelTemplates are used when no stored template is requested. They
+// are 100 x 60 mm labels at 203 dpi, the most common thermal printer setup.
+var DefaultLabelTemplates = map[LabelKind]*LabelTemplate{
+	LABEL_CONTAINER: {
+		Name: "default-container", Kind: LABEL_CONTAINER, Width: 100, Height: 60, DPI: 203, Status: "active",
+		Elements: label.Elements{
+			{Type: label.ELEMENT_TEXT, X: 4, Y: 4, Height: 6, Value: "{{.Type}} {{.Name}}"},
+			{Type: label.ELEMENT_BARCODE, Symbology: label.CODE128, X: 4, Y: 14, Width: 62, Height: 24, Value: "{{.Code}}", HumanReadable: true},
+			{Type: label.ELEMENT_BARCODE, Symbology: label.QR, X: 70, Y: 14, Height: 26, Value: "{{.Code}}"},
+			{Type: label.ELEMENT_TEXT, X: 4, Y: 46, Height: 5, Value: "{{.Address}}"},
+		},
+	},
+	LABEL_INVENTORY: {
+		Name: "default-inventory", Kind: LABEL_INVENTORY, Width: 100, Height: 60, DPI: 203, Status: "active",
+		Elements: label.Elements{
+			{Type: label.ELEMENT_TEXT, X: 4, Y: 3, Height: 5, Value: "{{.ProductCode}} {{.ProductName}}"},
+			{Type: label.ELEMENT_TEXT, X: 4, Y: 10, Height: 4, Value: "Batch {{.Batch}}  Qty {{.Quantity}} {{.Unit}}  QC {{.QCStatus}}"},
+			{Type: label.ELEMENT_BARCODE, Symbology: label.GS1_128, X: 4, Y: 17, Width: 92, Height: 24, HumanReadable: true},
+			{Type: label.ELEMENT_BARCODE, Symbology: label.QR, X: 78, Y: 43, Height: 15, Value: "INV:{{.ID}}"},
+			{Type: label.ELEMENT_TEXT, X: 4, Y: 46, Height: 4, Value: "Expiry {{.ExpiresAt}}"},
+		},
+	},
+	LABEL_PRODUCT: {
+		Name: "default-product", Kind: LABEL_PRODUCT, Width: 100, Height: 60, DPI: 203, Status: "active",
+		Elements: label.Elements{
+			{Type: label.ELEMENT_TEXT, X: 4, Y: 4, Height: 6, Value: "{{.Name}}"},
+			{Type: label.ELEMENT_TEXT, X: 4, Y: 12, Height: 4, Value: "{{.Type}} / {{.Unit}}"},
+			{Type: label.ELEMENT_BARCODE, Symbology: label.CODE128, X: 4, Y: 20, Width: 62, Height: 26, Value: "{{.Code}}", HumanReadable: true},
+			{Type: label.ELEMENT_BARCODE, Symbology: label.QR, X: 70, Y: 20, Height: 26, Value: "{{.Code}}"},
+		},
+	},
+}
+
+// LabelProduct is the part of a product printed on labels.
+type LabelProduct struct {
+	ID          int64
+	Type        string
+	Code        string
+	Name        string
+	Description string
+	Unit        string
+}
@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/codepattern"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+const (
+	DefaultRackPattern = "A{aisle:2}-R{rack:2}"
+	DefaultBinPattern  = "{rack_code}-L{level}-P{position:2}"
+
+	// MaxLayoutContainers caps the racks and bins a single layout may create.
+	MaxLayoutContainers = 10000
+	// MaxLayoutPadWidth caps the zero padding of a pattern token such as
+	// {rack:2}.
+	MaxLayoutPadWidth = codepattern.MaxPadWidth
+)
+
+type LayoutAction string
+
+const (
+	LAYOUT_CREATE LayoutAction = "CREATE"
+	LAYOUT_EXISTS LayoutAction = "EXISTS"
+)
+
+var (
+	ErrInvalidLayout       = errors.New("invalid layout")
+	ErrLayoutDuplicateCode = errors.New("layout patterns produce the same code twice")
+	ErrLayoutConflict      = errors.New("layout code is already used by a container elsewhere")
+)
+
+// LayoutSpec describes a block of racks for a store: Aisles aisles of
+// RacksPerAisle racks, each rack holding Levels levels of PositionsPerLevel
+// bins. The racks are placed in ZoneID when set, or directly in the store.
+//
+// RackPattern may use {aisle} and {rack}, the rack number within its aisle;
+// BinPattern may use {rack_code}, {aisle}, {rack}, {level} and {position}.
+// Numbers can be zero padded as in {rack:2} or written as letters as in
+// {aisle:alpha}, and {store} is replaced by the store id.
+type LayoutSpec struct {
+	StoreID           int64                      `json:"store_id"`
+	ZoneID            *int64                     `json:"zone_id"`
+	Aisles            int                        `json:"aisles"`
+	RacksPerAisle     int                        `json:"racks_per_aisle"`
+	Levels            int                        `json:"levels"`
+	PositionsPerLevel int                        `json:"positions_per_level"`
+	RackPattern       string                     `json:"rack_pattern"`
+	BinPattern        string                     `json:"bin_pattern"`
+	BinCapacity       ContainerCapacity          `json:"bin_capacity"`
+	LastUpdatedBy     customtypes.NullableString `json:"last_updated_by"`
+}
+
+// LayoutNode is a rack or bin of a layout. ID is set once the layout has been
+// applied, or found to exist already.
+type LayoutNode struct {
+	ID     int64         `json:"id,omitempty"`
+	Type   ContainerType `json:"type"`
+	Code   string        `json:"code"`
+	Action LayoutAction  `json:"action,omitempty"`
+	Bins   []*LayoutNode `json:"bins,omitempty"`
+}
+
+// LayoutPlan lists the racks and bins a spec expands to. Created and Existing
+// count containers once the plan has been applied or dry-run.
+type LayoutPlan struct {
+	Spec     *LayoutSpec   `json:"spec"`
+	Racks    []*LayoutNode `json:"racks"`
+	Total    int           `json:"total"`
+	Created  int           `json:"created"`
+	Existing int           `json:"existing"`
+	DryRun   bool          `json:"dry_run"`
+}
+
+// isAlpha reports whether a token asks for letters, as in {aisle:alpha}.
+func isAlpha(token codepattern.Token) bool {
+	return strings.EqualFold(token.Arg, "alpha")
+}
+
+func (s *LayoutSpec) Validate() error {
+	if s.StoreID <= 0 {
+		return fmt.Errorf("%w: store_id is required", ErrInvalidLayout)
+	}
+	tooMany := fmt.Errorf("%w: a layout may create at most %d containers", ErrInvalidLayout, MaxLayoutContainers)
+	// Every factor and partial product is checked on its own, so that large
+	// dimensions cannot overflow into a small total
+	total := 1
+	for _, dimension := range []int{s.Aisles, s.RacksPerAisle, s.Levels, s.PositionsPerLevel} {
+		if dimension < 1 {
+			return fmt.Errorf("%w: aisles, racks_per_aisle, levels and positions_per_level must be at least 1", ErrInvalidLayout)
+		}
+		if dimension > MaxLayoutContainers {
+			return tooMany
+		}
+		if total *= dimension; total > MaxLayoutContainers {
+			return tooMany
+		}
+	}
+	racks := s.Aisles * s.RacksPerAisle
+	if racks+total > MaxLayoutContainers {
+		return tooMany
+	}
+	for _, pattern := range []string{s.RackPattern, s.BinPattern} {
+		for _, token := range codepattern.Parse(pattern) {
+			if isAlpha(token) {
+				continue
+			}
+			if _, err := token.Width(); err != nil {
+				return fmt.Errorf("%w: %v", ErrInvalidLayout, err)
+			}
+		}
+	}
+	if err := s.BinCapacity.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidLayout, err)
+	}
+	return nil
+}
+
+// Plan expands the spec into its racks and bins, and checks that every code
+// is distinct.
+func (s *LayoutSpec) Plan() (*LayoutPlan, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	if s.RackPattern == "" {
+		s.RackPattern = DefaultRackPattern
+	}
+	if s.BinPattern == "" {
+		s.BinPattern = DefaultBinPattern
+	}
+
+	plan := &LayoutPlan{Spec: s}
+	seen := make(map[string]bool)
+	for aisle := 1; aisle <= s.Aisles; aisle++ {
+		for rackNumber := 1; rackNumber <= s.RacksPerAisle; rackNumber++ {
+			values := map[string]int{"aisle": aisle, "rack": rackNumber}
+			rack := &LayoutNode{Type: RACK_TYPE, Code: s.expand(s.RackPattern, "", values)}
+			for level := 1; level <= s.Levels; level++ {
+				for position := 1; position <= s.PositionsPerLevel; position++ {
+					values["level"], values["position"] = level, position
+					rack.Bins = append(rack.Bins, &LayoutNode{Type: BIN_TYPE, Code: s.expand(s.BinPattern, rack.Code, values)})
+				}
+			}
+			for _, node := range append([]*LayoutNode{rack}, rack.Bins...) {
+				if node.Code == "" || seen[node.Code] {
+					return nil, fmt.Errorf("%w: %q", ErrLayoutDuplicateCode, node.Code)
+				}
+				seen[node.Code] = true
+			}
+			plan.Racks = append(plan.Racks, rack)
+		}
+	}
+	plan.Total = len(seen)
+	return plan, nil
+}
+
+func (s *LayoutSpec) expand(pattern string, rackCode string, values map[string]int) string {
+	return codepattern.Expand(pattern, func(token codepattern.Token) string {
+		switch token.Name {
+		case "STORE":
+			return strconv.FormatInt(s.StoreID, 10)
+		case "RACK_CODE":
+			return rackCode
+		}
+		value, ok := values[strings.ToLower(token.Name)]
+		if !ok {
+			// level and position have no value in a rack code
+			return token.Text
+		}
+		if isAlpha(token) {
+			return alphaNumber(value)
+		}
+		return token.Pad(int64(value))
+	})
+}
+
+// alphaNumber writes n in the spreadsheet column style: A, B, ..., Z, AA.
+func alphaNumber(n int) string {
+	var letters []string
+	for n > 0 {
+		n--
+		letters = append([]string{string(rune('A' + n%26))}, letters...)
+		n /= 26
+	}
+	return strings.Join(letters, "")
+}
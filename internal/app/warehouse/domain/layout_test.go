@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestLayoutSpecPlan(t *testing.T) {
+	tests := []struct {
+		name  string
+		spec  LayoutSpec
+		codes []string
+		total int
+		err   error
+	}{
+		{
+			name:  "default patterns",
+			spec:  LayoutSpec{StoreID: 7, Aisles: 1, RacksPerAisle: 2, Levels: 1, PositionsPerLevel: 2},
+			codes: []string{"A01-R01", "A01-R01-L1-P01", "A01-R01-L1-P02", "A01-R02", "A01-R02-L1-P01", "A01-R02-L1-P02"},
+			total: 6,
+		},
+		{
+			name:  "letters and the store",
+			spec:  LayoutSpec{StoreID: 7, Aisles: 27, RacksPerAisle: 1, Levels: 1, PositionsPerLevel: 1, RackPattern: "S{store}-{aisle:alpha}", BinPattern: "{rack_code}.{level}{position}"},
+			total: 54,
+		},
+		{
+			name: "duplicate codes",
+			spec: LayoutSpec{StoreID: 7, Aisles: 2, RacksPerAisle: 1, Levels: 1, PositionsPerLevel: 1, RackPattern: "R{rack}"},
+			err:  ErrLayoutDuplicateCode,
+		},
+		{
+			name: "missing store",
+			spec: LayoutSpec{Aisles: 1, RacksPerAisle: 1, Levels: 1, PositionsPerLevel: 1},
+			err:  ErrInvalidLayout,
+		},
+		{
+			name: "zero dimension",
+			spec: LayoutSpec{StoreID: 7, Aisles: 1, RacksPerAisle: 0, Levels: 1, PositionsPerLevel: 1},
+			err:  ErrInvalidLayout,
+		},
+		{
+			name: "too many containers",
+			spec: LayoutSpec{StoreID: 7, Aisles: 10, RacksPerAisle: 10, Levels: 10, PositionsPerLevel: 10},
+			err:  ErrInvalidLayout,
+		},
+		{
+			name: "single dimension over the limit",
+			spec: LayoutSpec{StoreID: 7, Aisles: MaxLayoutContainers + 1, RacksPerAisle: 1, Levels: 1, PositionsPerLevel: 1},
+			err:  ErrInvalidLayout,
+		},
+		{
+			name: "partial product over the limit",
+			spec: LayoutSpec{StoreID: 7, Aisles: 1, RacksPerAisle: 1, Levels: 5000, PositionsPerLevel: 5000},
+			err:  ErrInvalidLayout,
+		},
+		{
+			name: "padding too wide",
+			spec: LayoutSpec{StoreID: 7, Aisles: 1, RacksPerAisle: 1, Levels: 1, PositionsPerLevel: 1, RackPattern: "R{rack:1000000000}"},
+			err:  ErrInvalidLayout,
+		},
+		{
+			name: "padding that does not parse",
+			spec: LayoutSpec{StoreID: 7, Aisles: 1, RacksPerAisle: 1, Levels: 1, PositionsPerLevel: 1, BinPattern: "{rack_code}-{position:99999999999999999999}"},
+			err:  ErrInvalidLayout,
+		},
+	}
+	for _, test := range tests {
+		plan, err := test.spec.Plan()
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("%s: err = %v, want %v", test.name, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if plan.Total != test.total {
+			t.Errorf("%s: total %d, want %d", test.name, plan.Total, test.total)
+		}
+		if test.codes != nil {
+			var codes []string
+			for _, rack := range plan.Racks {
+				codes = append(codes, rack.Code)
+				for _, bin := range rack.Bins {
+					codes = append(codes, bin.Code)
+				}
+			}
+			if !reflect.DeepEqual(codes, test.codes) {
+				t.Errorf("%s: codes %q, want %q", test.name, codes, test.codes)
+			}
+		}
+	}
+}
+
+func TestAlphaNumber(t *testing.T) {
+	for n, want := range map[int]string{1: "A", 26: "Z", 27: "AA", 52: "AZ", 703: "AAA"} {
+		if got := alphaNumber(n); got != want {
+			t.Errorf("alphaNumber(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/codepattern"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+type SeriesReset string
+
+const (
+	SERIES_RESET_NEVER   SeriesReset = "NEVER"
+	SERIES_RESET_YEARLY  SeriesReset = "YEARLY"
+	SERIES_RESET_MONTHLY SeriesReset = "MONTHLY"
+)
+
+// MaxGeneratedCodes caps a single bulk generation request.
+const MaxGeneratedCodes = 1000
+
+var (
+	ErrInvalidSeriesPattern   = errors.New("invalid number series pattern")
+	ErrInvalidSeriesReset     = errors.New("invalid number series reset")
+	ErrSeriesResetNeedsPeriod = errors.New("a series that resets must show the period in its pattern")
+	ErrSeriesStoreRequired    = errors.New("number series pattern uses {store} but no store was given")
+	ErrNoNumberSeries         = errors.New("no number series defined for the container type")
+	ErrDuplicateContainerCode = errors.New("container code already exists")
+	ErrDuplicateNumberSeries  = errors.New("a number series already exists for the container type")
+)
+
+// NumberSeries generates the codes of one container type. Pattern tokens are
+// case insensitive: {YYYY}, {YY}, {MM}, {DD}, {store} for the store id and
+// {seq} or {seq:n} for a sequence number zero padded to at most
+// codepattern.MaxPadWidth digits. The sequence restarts
+// at 1 every year or month depending on Reset, and is counted separately per
+// store when the pattern contains {store}.
+type NumberSeries struct {
+	ID            int64                      `json:"id"`
+	ContainerType ContainerType              `json:"container_type"`
+	Pattern       string                     `json:"pattern"`
+	Reset         SeriesReset                `json:"reset"`
+	Status        customtypes.NullableString `json:"status"`
+	CreatedAt     time.Time                  `json:"created_at"`
+	UpdatedAt     time.Time                  `json:"updated_at"`
+	LastUpdatedBy customtypes.NullableString `json:"last_updated_by"`
+	Version       int64                      `json:"version"`
+}
+
+func NewNumberSeriesWithDefaults() *NumberSeries {
+	return &NumberSeries{
+		ContainerType: PALLET_TYPE,
+		Reset:         SERIES_RESET_NEVER,
+		Status:        "active",
+	}
+}
+
+// tokens returns the upper-cased names of the tokens used by the pattern.
+func (s *NumberSeries) tokens() map[string]bool {
+	tokens := make(map[string]bool)
+	for _, token := range codepattern.Parse(s.Pattern) {
+		tokens[token.Name] = true
+	}
+	return tokens
+}
+
+// Validate checks the pattern against the reset option: a series that resets
+// yearly must show the year and one that resets monthly the year and month,
+// otherwise the same code would be handed out again after a reset.
+func (s *NumberSeries) Validate() error {
+	container := Container{Type: s.ContainerType}
+	if err := container.ValidateType(); err != nil {
+		return err
+	}
+	if err := codepattern.ValidateSequence(s.Pattern); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSeriesPattern, err)
+	}
+	tokens := s.tokens()
+	hasYear := tokens["YYYY"] || tokens["YY"]
+	switch s.Reset {
+	case SERIES_RESET_NEVER:
+	case SERIES_RESET_YEARLY:
+		if !hasYear {
+			return ErrSeriesResetNeedsPeriod
+		}
+	case SERIES_RESET_MONTHLY:
+		if !hasYear || !tokens["MM"] {
+			return ErrSeriesResetNeedsPeriod
+		}
+	default:
+		return ErrInvalidSeriesReset
+	}
+	return nil
+}
+
+// UsesStore reports whether codes, and so sequences, differ per store.
+func (s *NumberSeries) UsesStore() bool {
+	return s.tokens()["STORE"]
+}
+
+// Period is the key of the sequence counter that is current at the given
+// time, e.g. "2024" for a yearly series or "2024-03" for a monthly one.
+func (s *NumberSeries) Period(at time.Time) string {
+	switch s.Reset {
+	case SERIES_RESET_YEARLY:
+		return at.Format("2006")
+	case SERIES_RESET_MONTHLY:
+		return at.Format("2006-01")
+	default:
+		return ""
+	}
+}
+
+// Scope is the store the sequence is counted for, or 0 when the pattern does
+// not contain {store}.
+func (s *NumberSeries) Scope(storeID *int64) (int64, error) {
+	if !s.UsesStore() {
+		return 0, nil
+	}
+	if storeID == nil {
+		return 0, ErrSeriesStoreRequired
+	}
+	return *storeID, nil
+}
+
+// Format expands the pattern for one sequence number.
+func (s *NumberSeries) Format(at time.Time, storeID int64, sequence int64) string {
+	return codepattern.Expand(s.Pattern, func(token codepattern.Token) string {
+		switch token.Name {
+		case "YYYY":
+			return at.Format("2006")
+		case "YY":
+			return at.Format("06")
+		case "MM":
+			return at.Format("01")
+		case "DD":
+			return at.Format("02")
+		case "STORE":
+			return strconv.FormatInt(storeID, 10)
+		case codepattern.Sequence:
+			return token.Pad(sequence)
+		default:
+			return token.Text
+		}
+	})
+}
+
+// GenerateContainersForm asks for Count new containers of one type whose
+// codes come from the number series of the type.
+type GenerateContainersForm struct {
+	Type          ContainerType              `json:"type"`
+	Count         int                        `json:"count"`
+	ParentID      *int64                     `json:"parent_id"`
+	StoreID       *int64                     `json:"store_id"`
+	LastUpdatedBy customtypes.NullableString `json:"last_updated_by"`
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNumberSeriesValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		reset   SeriesReset
+		err     error
+	}{
+		{name: "sequence only", pattern: "PAL-{seq:6}", reset: SERIES_RESET_NEVER},
+		{name: "monthly with period", pattern: "PAL-{YYYY}{MM}-{SEQ}", reset: SERIES_RESET_MONTHLY},
+		{name: "no sequence", pattern: "PAL-{YYYY}", reset: SERIES_RESET_NEVER, err: ErrInvalidSeriesPattern},
+		{name: "padding too wide", pattern: "PAL-{seq:999999999}", reset: SERIES_RESET_NEVER, err: ErrInvalidSeriesPattern},
+		{name: "padding that is not a number", pattern: "PAL-{seq:x}", reset: SERIES_RESET_NEVER, err: ErrInvalidSeriesPattern},
+		{name: "yearly without year", pattern: "PAL-{seq}", reset: SERIES_RESET_YEARLY, err: ErrSeriesResetNeedsPeriod},
+	}
+	for _, test := range tests {
+		series := &NumberSeries{ContainerType: PALLET_TYPE, Pattern: test.pattern, Reset: test.reset}
+		if err := series.Validate(); !errors.Is(err, test.err) {
+			t.Errorf("%s: err = %v, want %v", test.name, err, test.err)
+		}
+	}
+}
+
+func TestNumberSeriesFormat(t *testing.T) {
+	series := &NumberSeries{Pattern: "S{store}-{YY}{mm}-{SEQ:4}"}
+	at := time.Date(2026, 3, 7, 0, 0, 0, 0, time.UTC)
+	if got, want := series.Format(at, 3, 27), "S3-2603-0027"; got != want {
+		t.Errorf("Format = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+var ErrInsufficientComponentStock = errors.New("not enough released component stock to backflush")
+
+// BackflushComponent is a BOM line of a produced item, as used by the
+// warehouse to consume raw material.
+type BackflushComponent struct {
+	ComponentID   int64
+	ComponentCode string
+	QuantityPer   customtypes.Decimal
+	Unit          string
+	ScrapFactor   customtypes.Decimal
+}
+
+// Required returns the component quantity consumed by producing quantity
+// units, including scrap, rounded up to the precision of the unit.
+func (c *BackflushComponent) Required(quantity customtypes.Decimal) (customtypes.Decimal, error) {
+	return customtypes.RequiredQuantity(quantity, c.QuantityPer, c.ScrapFactor, c.Unit)
+}
+
+// BackflushRequirement is the quantity of one component to consume.
+type BackflushRequirement struct {
+	ComponentID   int64
+	ComponentCode string
+	Quantity      customtypes.Decimal
+	Unit          string
+}
+
+// Consumption records a quantity of a raw material lot used to produce a
+// finished goods lot.
+type Consumption struct {
+	ID                  int64               `json:"id"`
+	ProducedInventoryID int64               `json:"produced_inventory_id"`
+	ProducedBatch       string              `json:"produced_batch"`
+	ComponentID         int64               `json:"component_id"`
+	InventoryID         int64               `json:"inventory_id"`
+	Batch               string              `json:"batch"`
+	StoreID             *int64              `json:"store_id"`
+	Quantity            customtypes.Decimal `json:"quantity"`
+	Unit                string              `json:"unit"`
+	ConsumedAt          time.Time           `json:"consumed_at"`
+	ConsumedBy          string              `json:"consumed_by"`
+}
+
+// AllocateFIFO takes required, counted in unit, from lots in the order given,
+// which callers sort oldest first. Lots held in another unit are skipped. It
+// returns the consumptions and the quantity that could not be covered.
+func AllocateFIFO(lots []*Inventory, required customtypes.Decimal, unit string) ([]*Consumption, customtypes.Decimal) {
+	var consumptions []*Consumption
+	remaining := required
+	for _, lot := range lots {
+		if remaining.Sign() <= 0 {
+			break
+		}
+		if lot.Quantity.Sign() <= 0 || !strings.EqualFold(lot.Unit, unit) {
+			continue
+		}
+		take := customtypes.MinDecimal(lot.Quantity, remaining)
+		consumptions = append(consumptions, &Consumption{
+			ComponentID: lot.ProductID,
+			InventoryID: lot.ID,
+			Batch:       lot.Batch,
+			StoreID:     lot.StoreID,
+			Quantity:    take,
+			Unit:        lot.Unit,
+		})
+		remaining = remaining.Sub(take)
+	}
+	return consumptions, remaining
+}
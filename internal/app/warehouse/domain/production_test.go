@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+func TestAllocateFIFO(t *testing.T) {
+	lot := func(id int64, quantity string, unit string) *Inventory {
+		return &Inventory{ID: id, ProductID: 5, Quantity: customtypes.MustParseDecimal(quantity), Unit: unit}
+	}
+	tests := []struct {
+		name      string
+		lots      []*Inventory
+		required  string
+		taken     map[int64]string
+		shortfall string
+	}{
+		{
+			name:      "oldest lot covers it",
+			lots:      []*Inventory{lot(1, "10", "kg"), lot(2, "10", "kg")},
+			required:  "4.5",
+			taken:     map[int64]string{1: "4.5"},
+			shortfall: "0",
+		},
+		{
+			name:      "spills into the next lot",
+			lots:      []*Inventory{lot(1, "3", "kg"), lot(2, "10", "kg")},
+			required:  "4.5",
+			taken:     map[int64]string{1: "3", 2: "1.5"},
+			shortfall: "0",
+		},
+		{
+			name:      "empty lots and other units are skipped",
+			lots:      []*Inventory{lot(1, "0", "kg"), lot(2, "50", "g"), lot(3, "2", "KG")},
+			required:  "4",
+			taken:     map[int64]string{3: "2"},
+			shortfall: "2",
+		},
+		{
+			name:      "no stock at all",
+			required:  "1",
+			taken:     map[int64]string{},
+			shortfall: "1",
+		},
+	}
+	for _, test := range tests {
+		consumptions, shortfall := AllocateFIFO(test.lots, customtypes.MustParseDecimal(test.required), "kg")
+		taken := make(map[int64]string, len(consumptions))
+		for _, consumption := range consumptions {
+			taken[consumption.InventoryID] = consumption.Quantity.String()
+		}
+		if !reflect.DeepEqual(taken, test.taken) {
+			t.Errorf("%s: took %v, want %v", test.name, taken, test.taken)
+		}
+		if !shortfall.Equal(customtypes.MustParseDecimal(test.shortfall)) {
+			t.Errorf("%s: shortfall %s, want %s", test.name, shortfall, test.shortfall)
+		}
+	}
+}
@@ -0,0 +1,323 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+var (
+	ErrPutawaySubject         = errors.New("putaway needs either an inventory or a container")
+	ErrPutawayStoreRequired   = errors.New("putaway needs a store for stock that is not in one")
+	ErrPutawayTargetRejected  = errors.New("target is not an accepted putaway location")
+	ErrInvalidPutawayRuleType = errors.New("putaway rule target must be a rack, bin or pallet")
+)
+
+// DefaultPutawaySuggestions is the number of candidates returned when the
+// request does not ask for a specific number.
+const DefaultPutawaySuggestions = 10
+
+// PutawayRule proposes containers of TargetType for incoming stock. Rules are
+// tried by ascending priority; a rule applies when every set condition
+// matches, where ProductID, ProductType and StoreID are compared with the
+// stock and ZoneID restricts the candidates to that zone. Candidates holding
+// other products or other batches of the same product are skipped unless the
+// rule allows mixing, as are candidates that are not active or sit in one
+// that is not, and candidates whose capacity would be exceeded when capacity
+// is enforced.
+type PutawayRule struct {
+	ID                 int64                      `json:"id"`
+	Name               customtypes.NullableString `json:"name"`
+	Priority           int                        `json:"priority"`
+	ProductID          *int64                     `json:"product_id"`
+	ProductType        customtypes.NullableString `json:"product_type"`
+	StoreID            *int64                     `json:"store_id"`
+	ZoneID             *int64                     `json:"zone_id"`
+	TargetType         ContainerType              `json:"target_type"`
+	AllowMixedProducts bool                       `json:"allow_mixed_products"`
+	AllowMixedBatches  bool                       `json:"allow_mixed_batches"`
+	PreferSameProduct  bool                       `json:"prefer_same_product"`
+	Status             customtypes.NullableString `json:"status"`
+	CreatedAt          time.Time                  `json:"created_at"`
+	UpdatedAt          time.Time                  `json:"updated_at"`
+	LastUpdatedBy      customtypes.NullableString `json:"last_updated_by"`
+	Version            int64                      `json:"version"`
+}
+
+func NewPutawayRuleWithDefaults() *PutawayRule {
+	return &PutawayRule{
+		Priority:          100,
+		TargetType:        BIN_TYPE,
+		PreferSameProduct: true,
+		Status:            "active",
+	}
+}
+
+func (r *PutawayRule) ValidateTargetType() error {
+	switch r.TargetType {
+	case RACK_TYPE, BIN_TYPE, PALLET_TYPE:
+		return nil
+	default:
+		return ErrInvalidPutawayRuleType
+	}
+}
+
+// PutawayLot is a lot taking part in a putaway, either as the stock being
+// put away or as stock already held by a candidate.
+type PutawayLot struct {
+	InventoryID int64
+	ContainerID *int64
+	ProductID   int64
+	ProductType string
+	Batch       string
+	Load        ContainerLoad
+}
+
+// PutawaySubject is the stock to put away: a single lot, or a container such
+// as an arriving pallet with everything on it.
+type PutawaySubject struct {
+	InventoryID int64
+	Container   *Container
+	StoreID     *int64
+	Lots        []*PutawayLot
+	Load        ContainerLoad
+}
+
+// Matches reports whether the rule applies to the subject.
+func (r *PutawayRule) Matches(subject *PutawaySubject) bool {
+	if r.StoreID != nil && (subject.StoreID == nil || *subject.StoreID != *r.StoreID) {
+		return false
+	}
+	for _, lot := range subject.Lots {
+		if r.ProductID != nil && lot.ProductID != *r.ProductID {
+			return false
+		}
+		if r.ProductType != "" && lot.ProductType != string(r.ProductType) {
+			return false
+		}
+	}
+	return true
+}
+
+// PutawayStock is the layout of a store with the stock in it.
+type PutawayStock struct {
+	Containers []*Container
+	Lots       []*PutawayLot
+}
+
+// PutawayCandidate is a container the subject may be put in. Load is what the
+// container holds today, including everything below it.
+type PutawayCandidate struct {
+	Container   *Container    `json:"container"`
+	Rank        int           `json:"rank"`
+	RuleID      int64         `json:"rule_id"`
+	RuleName    string        `json:"rule_name"`
+	SameProduct bool          `json:"same_product"`
+	Empty       bool          `json:"empty"`
+	Load        ContainerLoad `json:"load"`
+	Warnings    []string      `json:"warnings,omitempty"`
+}
+
+// PutawayRequest asks where an inventory or a container should go. StoreID
+// is only needed for stock that is not in a store yet.
+type PutawayRequest struct {
+	InventoryID int64  `json:"inventory_id"`
+	ContainerID int64  `json:"container_id"`
+	StoreID     *int64 `json:"store_id"`
+	Limit       int    `json:"limit"`
+}
+
+// PutawayConfirmation puts the inventory or container of the request in
+// TargetID. Version guards the moved inventory or container.
+type PutawayConfirmation struct {
+	PutawayRequest
+	TargetID int64  `json:"target_id"`
+	Version  int64  `json:"version"`
+	MovedBy  string `json:"moved_by"`
+}
+
+type PutawayResult struct {
+	Inventory *Inventory `json:"inventory,omitempty"`
+	Container *Container `json:"container,omitempty"`
+	Warnings  []string   `json:"warnings,omitempty"`
+}
+
+// Suggest returns the containers of the stock the rule accepts for the
+// subject, best first.
+func (r *PutawayRule) Suggest(subject *PutawaySubject, stock *PutawayStock) []*PutawayCandidate {
+	byID := make(map[int64]*Container, len(stock.Containers))
+	for _, container := range stock.Containers {
+		byID[container.ID] = container
+	}
+
+	// Loads and lots are counted for every container they sit in, directly
+	// or further down. The subject is left out so that its new place can be
+	// checked as if it had already left the old one
+	loads := make(map[int64]ContainerLoad)
+	contents := make(map[int64][]*PutawayLot)
+	for _, lot := range stock.Lots {
+		if lot.ContainerID == nil || subject.Holds(lot) {
+			continue
+		}
+		container, ok := byID[*lot.ContainerID]
+		if !ok {
+			continue
+		}
+		for _, id := range container.AncestorIDs() {
+			loads[id] = loads[id].Add(lot.Load)
+			contents[id] = append(contents[id], lot)
+		}
+	}
+
+	var zone *Container
+	if r.ZoneID != nil {
+		if zone = byID[*r.ZoneID]; zone == nil {
+			return nil
+		}
+	}
+
+	var candidates []*PutawayCandidate
+	for _, container := range stock.Containers {
+		if zone != nil && !zone.IsAncestorOf(container) {
+			continue
+		}
+		if !r.Admits(subject, container, contents[container.ID]) {
+			continue
+		}
+
+		var warnings []string
+		refused := false
+		for _, id := range container.AncestorIDs() {
+			ancestor, ok := byID[id]
+			if !ok {
+				continue
+			}
+			if !ancestor.Status.AcceptsStock() {
+				refused = true
+				break
+			}
+			if violations := ancestor.CapacityViolations(loads[id].Add(subject.Load)); len(violations) > 0 {
+				if CapacityEnforcement() == CAPACITY_REJECT {
+					refused = true
+					break
+				}
+				warnings = append(warnings, violations...)
+			}
+		}
+		if refused {
+			continue
+		}
+
+		candidates = append(candidates, &PutawayCandidate{
+			Container:   container,
+			RuleID:      r.ID,
+			RuleName:    string(r.Name),
+			SameProduct: subject.sharesProduct(contents[container.ID]),
+			Empty:       len(contents[container.ID]) == 0,
+			Load:        loads[container.ID],
+			Warnings:    warnings,
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if r.PreferSameProduct && a.SameProduct != b.SameProduct {
+			return a.SameProduct
+		}
+		return a.Container.Path < b.Container.Path
+	})
+	return candidates
+}
+
+// Admits reports whether the rule lets the subject go to target, which holds
+// held in and below it: target must be of the target type, inside the zone of
+// the rule, a place the subject may move to, and the mixing constraints must
+// allow it. Capacity and the status of target and its ancestors are checked
+// by Suggest and by the move itself.
+func (r *PutawayRule) Admits(subject *PutawaySubject, target *Container, held []*PutawayLot) bool {
+	if target.Type != r.TargetType {
+		return false
+	}
+	if r.ZoneID != nil && !target.isBelow(*r.ZoneID) {
+		return false
+	}
+	return subject.canMoveTo(target) && r.accepts(subject, held)
+}
+
+// accepts applies the mixing constraints of the rule to the lots a candidate
+// already holds.
+func (r *PutawayRule) accepts(subject *PutawaySubject, held []*PutawayLot) bool {
+	lots := append(append([]*PutawayLot{}, subject.Lots...), held...)
+	batches := make(map[int64]string)
+	for _, lot := range lots {
+		if !r.AllowMixedProducts && lot.ProductID != lots[0].ProductID {
+			return false
+		}
+		if batch, ok := batches[lot.ProductID]; ok && !r.AllowMixedBatches && batch != lot.Batch {
+			return false
+		}
+		batches[lot.ProductID] = lot.Batch
+	}
+	return true
+}
+
+// Holds reports whether lot is part of the subject.
+func (s *PutawaySubject) Holds(lot *PutawayLot) bool {
+	for _, own := range s.Lots {
+		if own.InventoryID == lot.InventoryID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *PutawaySubject) sharesProduct(held []*PutawayLot) bool {
+	for _, lot := range held {
+		for _, own := range s.Lots {
+			if own.ProductID == lot.ProductID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// canMoveTo reports whether the subject may be placed in container at all,
+// leaving out the place it already is.
+func (s *PutawaySubject) canMoveTo(container *Container) bool {
+	if s.Container == nil {
+		for _, lot := range s.Lots {
+			if lot.ContainerID != nil && *lot.ContainerID == container.ID {
+				return false
+			}
+		}
+		return true
+	}
+	if s.Container.ParentID != nil && *s.Container.ParentID == container.ID {
+		return false
+	}
+	return s.Container.ValidateParent(container) == nil
+}
+
+// RankPutawayCandidates merges the suggestions of rules tried in priority
+// order. A container suggested by several rules keeps its best place.
+func RankPutawayCandidates(suggestions [][]*PutawayCandidate, limit int) []*PutawayCandidate {
+	seen := make(map[int64]bool)
+	ranked := []*PutawayCandidate{}
+	for _, candidates := range suggestions {
+		for _, candidate := range candidates {
+			if seen[candidate.Container.ID] {
+				continue
+			}
+			seen[candidate.Container.ID] = true
+			candidate.Rank = len(ranked) + 1
+			ranked = append(ranked, candidate)
+			if limit > 0 && len(ranked) == limit {
+				return ranked
+			}
+		}
+	}
+	return ranked
+}
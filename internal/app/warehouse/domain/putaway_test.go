@@ -0,0 +1,40 @@
+package domain
+
+import "testing"
+
+func TestPutawayRuleAdmits(t *testing.T) {
+	zoneID, otherZoneID, binID := int64(1), int64(9), int64(3)
+	bin := &Container{ID: binID, Type: BIN_TYPE, Path: "/1/2/3/"}
+	lot := func(id int64, productID int64, batch string, containerID *int64) *PutawayLot {
+		return &PutawayLot{InventoryID: id, ProductID: productID, Batch: batch, ContainerID: containerID}
+	}
+	subject := &PutawaySubject{InventoryID: 10, Lots: []*PutawayLot{lot(10, 5, "B1", nil)}}
+	tests := []struct {
+		name    string
+		rule    PutawayRule
+		subject *PutawaySubject
+		held    []*PutawayLot
+		admits  bool
+	}{
+		{name: "empty bin", rule: PutawayRule{TargetType: BIN_TYPE}, subject: subject, admits: true},
+		{name: "wrong target type", rule: PutawayRule{TargetType: RACK_TYPE}, subject: subject, admits: false},
+		{name: "inside the zone", rule: PutawayRule{TargetType: BIN_TYPE, ZoneID: &zoneID}, subject: subject, admits: true},
+		{name: "outside the zone", rule: PutawayRule{TargetType: BIN_TYPE, ZoneID: &otherZoneID}, subject: subject, admits: false},
+		{name: "the target is not below itself", rule: PutawayRule{TargetType: BIN_TYPE, ZoneID: &binID}, subject: subject, admits: false},
+		{name: "other product without mixing", rule: PutawayRule{TargetType: BIN_TYPE}, subject: subject, held: []*PutawayLot{lot(11, 6, "B1", &binID)}, admits: false},
+		{name: "other product with mixing", rule: PutawayRule{TargetType: BIN_TYPE, AllowMixedProducts: true}, subject: subject, held: []*PutawayLot{lot(11, 6, "B1", &binID)}, admits: true},
+		{name: "other batch without mixing", rule: PutawayRule{TargetType: BIN_TYPE}, subject: subject, held: []*PutawayLot{lot(11, 5, "B2", &binID)}, admits: false},
+		{name: "same product and batch", rule: PutawayRule{TargetType: BIN_TYPE}, subject: subject, held: []*PutawayLot{lot(11, 5, "B1", &binID)}, admits: true},
+		{
+			name:    "where the lot already is",
+			rule:    PutawayRule{TargetType: BIN_TYPE},
+			subject: &PutawaySubject{InventoryID: 10, Lots: []*PutawayLot{lot(10, 5, "B1", &binID)}},
+			admits:  false,
+		},
+	}
+	for _, test := range tests {
+		if got := test.rule.Admits(test.subject, bin, test.held); got != test.admits {
+			t.Errorf("%s: Admits = %v, want %v", test.name, got, test.admits)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+type ScanEntityType string
+
+const (
+	SCAN_CONTAINER ScanEntityType = "container"
+	SCAN_PRODUCT   ScanEntityType = "product"
+	SCAN_INVENTORY ScanEntityType = "inventory"
+	SCAN_BATCH     ScanEntityType = "batch"
+	SCAN_SERIAL    ScanEntityType = "serial"
+)
+
+// ScanAction names what a handheld may offer for the scanned entity.
+type ScanAction string
+
+const (
+	SCAN_ACTION_PRINT_LABEL   ScanAction = "PRINT_LABEL"
+	SCAN_ACTION_VIEW_CONTENTS ScanAction = "VIEW_CONTENTS"
+	SCAN_ACTION_PUTAWAY       ScanAction = "PUTAWAY"
+	SCAN_ACTION_MOVE          ScanAction = "MOVE"
+	SCAN_ACTION_VIEW_STOCK    ScanAction = "VIEW_STOCK"
+	SCAN_ACTION_RECEIVE       ScanAction = "RECEIVE"
+	SCAN_ACTION_RESERVE       ScanAction = "RESERVE"
+	SCAN_ACTION_STOCK_OUT     ScanAction = "STOCK_OUT"
+	SCAN_ACTION_HOLD          ScanAction = "HOLD"
+	SCAN_ACTION_INSPECT       ScanAction = "INSPECT"
+	SCAN_ACTION_QUARANTINE    ScanAction = "QUARANTINE"
+	SCAN_ACTION_VIEW_HISTORY  ScanAction = "VIEW_HISTORY"
+)
+
+// InventoryLabelPrefix marks the inventory id encoded in lot label QR codes.
+const InventoryLabelPrefix = "INV:"
+
+// ScanResult is what a scanned value resolved to. Contents lists the lots on
+// a container or in a batch; Locations lists where a product is on hand.
+type ScanResult struct {
+	Value      string            `json:"value"`
+	EntityType ScanEntityType    `json:"entity_type"`
+	Entity     interface{}       `json:"entity"`
+	GS1        map[string]string `json:"gs1,omitempty"`
+	Actions    []ScanAction      `json:"actions"`
+	Contents   []*Inventory      `json:"contents,omitempty"`
+	Locations  []*StockLocation  `json:"locations,omitempty"`
+}
+
+// ScanProduct is the part of a product returned to scanners.
+type ScanProduct struct {
+	ID         int64                      `json:"id"`
+	Type       string                     `json:"type"`
+	Code       customtypes.NullableString `json:"code"`
+	RawCode    customtypes.NullableString `json:"raw_code"`
+	Name       customtypes.NullableString `json:"name"`
+	Unit       customtypes.NullableString `json:"unit"`
+	Serialized bool                       `json:"serialized"`
+	Status     customtypes.NullableString `json:"status"`
+}
+
+// StockLocation is the on-hand quantity of a product at one location.
+type StockLocation struct {
+	StoreID  *int64              `json:"store_id"`
+	RackID   *int64              `json:"rack_id"`
+	BinID    *int64              `json:"bin_id"`
+	PalletID *int64              `json:"pallet_id"`
+	QCStatus QCStatus            `json:"qc_status"`
+	Quantity customtypes.Decimal `json:"quantity"`
+	Unit     string              `json:"unit"`
+	Lots     int                 `json:"lots"`
+}
+
+func ContainerScanActions(container *Container, contents []*Inventory) []ScanAction {
+	actions := []ScanAction{SCAN_ACTION_PRINT_LABEL}
+	if len(contents) > 0 {
+		return append(actions, SCAN_ACTION_VIEW_CONTENTS, SCAN_ACTION_MOVE)
+	}
+	if container.Status.AcceptsStock() {
+		actions = append(actions, SCAN_ACTION_PUTAWAY)
+	}
+	return actions
+}
+
+func ProductScanActions(product *ScanProduct, locations []*StockLocation) []ScanAction {
+	actions := []ScanAction{SCAN_ACTION_PRINT_LABEL}
+	if strings.EqualFold(string(product.Status), "active") {
+		actions = append(actions, SCAN_ACTION_RECEIVE)
+	}
+	if len(locations) > 0 {
+		actions = append(actions, SCAN_ACTION_VIEW_STOCK)
+	}
+	return actions
+}
+
+// InventoryScanActions follows the stock and quality state of the lot, so a
+// lot waiting for inspection cannot be reserved or shipped from a scan.
+func InventoryScanActions(inventory *Inventory) []ScanAction {
+	actions := []ScanAction{SCAN_ACTION_PRINT_LABEL}
+	if inventory.Status == STOCK_OUT {
+		return actions
+	}
+	switch inventory.QCStatus {
+	case QC_RELEASED:
+		if inventory.Status == STOCK_IN {
+			actions = append(actions, SCAN_ACTION_RESERVE)
+		}
+		actions = append(actions, SCAN_ACTION_STOCK_OUT, SCAN_ACTION_MOVE, SCAN_ACTION_HOLD)
+	case QC_PENDING_INSPECTION, QC_ON_HOLD:
+		actions = append(actions, SCAN_ACTION_INSPECT, SCAN_ACTION_MOVE)
+	case QC_REJECTED:
+		actions = append(actions, SCAN_ACTION_QUARANTINE)
+	}
+	return actions
+}
+
+func BatchScanActions(lots []*Inventory) []ScanAction {
+	actions := []ScanAction{SCAN_ACTION_VIEW_CONTENTS}
+	for _, lot := range lots {
+		if lot.Status == STOCK_IN && lot.IsReleased() {
+			return append(actions, SCAN_ACTION_RESERVE, SCAN_ACTION_STOCK_OUT)
+		}
+	}
+	return actions
+}
+
+func SerialScanActions(serial *SerialNumber) []ScanAction {
+	actions := []ScanAction{SCAN_ACTION_VIEW_HISTORY}
+	if serial.Status == SERIAL_IN_STOCK {
+		actions = append(actions, SCAN_ACTION_MOVE)
+	}
+	return actions
+}
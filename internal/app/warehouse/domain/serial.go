@@ -0,0 +1,209 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/codepattern"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+type SerialStatus string
+
+const (
+	SERIAL_IN_STOCK   SerialStatus = "IN STOCK"
+	SERIAL_RESERVED   SerialStatus = "RESERVED"
+	SERIAL_SHIPPED    SerialStatus = "SHIPPED"
+	SERIAL_IN_TRANSIT SerialStatus = "IN TRANSIT"
+)
+
+type SerialEventType string
+
+const (
+	SERIAL_EVENT_RECEIVED    SerialEventType = "RECEIVED"
+	SERIAL_EVENT_TRANSFERRED SerialEventType = "TRANSFERRED"
+	SERIAL_EVENT_RESERVED    SerialEventType = "RESERVED"
+	SERIAL_EVENT_UNRESERVED  SerialEventType = "UNRESERVED"
+	SERIAL_EVENT_SHIPPED     SerialEventType = "SHIPPED"
+	SERIAL_EVENT_RETURNED    SerialEventType = "RETURNED"
+	SERIAL_EVENT_QUARANTINED SerialEventType = "QUARANTINED"
+)
+
+var (
+	ErrSerialsRequired      = errors.New("serialized products must be received with serial numbers")
+	ErrSerialCountMismatch  = errors.New("quantity of a serialized lot must equal the number of serials")
+	ErrDuplicateSerial      = errors.New("serial number already exists")
+	ErrInvalidSerialPattern = errors.New("invalid serial pattern")
+	ErrInventoryHasSerials  = errors.New("inventory with serial numbers cannot be deleted")
+	ErrTooManySerials       = fmt.Errorf("at most %d serial numbers can be generated at once", MaxGeneratedSerials)
+)
+
+// SerialStatusFor maps the status of a lot to the status of its units.
+func SerialStatusFor(status InventoryType) SerialStatus {
+	switch status {
+	case STOCK_RESERVED:
+		return SERIAL_RESERVED
+	case STOCK_OUT:
+		return SERIAL_SHIPPED
+	case STOCK_IN_TRANSIT:
+		return SERIAL_IN_TRANSIT
+	default:
+		return SERIAL_IN_STOCK
+	}
+}
+
+// SerialEventFor describes how a lot changed between two states, or returns
+// an empty event when nothing that matters to its serials changed.
+func SerialEventFor(from *Inventory, to *Inventory) SerialEventType {
+	if from.Status != to.Status {
+		switch {
+		case to.Status == STOCK_OUT:
+			return SERIAL_EVENT_SHIPPED
+		case to.Status == STOCK_RESERVED:
+			return SERIAL_EVENT_RESERVED
+		case from.Status == STOCK_OUT:
+			return SERIAL_EVENT_RETURNED
+		default:
+			return SERIAL_EVENT_UNRESERVED
+		}
+	}
+	if !sameID(from.StoreID, to.StoreID) || !sameID(from.RackID, to.RackID) || !sameID(from.BinID, to.BinID) || !sameID(from.PalletID, to.PalletID) {
+		return SERIAL_EVENT_TRANSFERRED
+	}
+	return ""
+}
+
+func sameID(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// SerialNumber is an individually tracked unit of a serialized lot. Its
+// location is always the location of the lot it belongs to.
+type SerialNumber struct {
+	ID          int64        `json:"id"`
+	Serial      string       `json:"serial"`
+	ProductID   int64        `json:"product_id"`
+	InventoryID int64        `json:"inventory_id"`
+	Batch       string       `json:"batch"`
+	Status      SerialStatus `json:"status"`
+	StoreID     *int64       `json:"store_id"`
+	RackID      *int64       `json:"rack_id"`
+	BinID       *int64       `json:"bin_id"`
+	PalletID    *int64       `json:"pallet_id"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+type SerialEvent struct {
+	ID          int64                      `json:"id"`
+	SerialID    int64                      `json:"serial_id"`
+	Event       SerialEventType            `json:"event"`
+	InventoryID int64                      `json:"inventory_id"`
+	Status      SerialStatus               `json:"status"`
+	StoreID     *int64                     `json:"store_id"`
+	OccurredAt  time.Time                  `json:"occurred_at"`
+	Actor       customtypes.NullableString `json:"actor"`
+}
+
+type SerialHistory struct {
+	*SerialNumber
+	Events []*SerialEvent `json:"events"`
+}
+
+// SerialSettings are the serial tracking options of a product.
+type SerialSettings struct {
+	ProductID   int64
+	ProductCode string
+	Unit        string
+	Serialized  bool
+	Pattern     string
+}
+
+// DefaultSerialPattern is used for serialized products without a pattern.
+const DefaultSerialPattern = "{CODE}-{YYYY}{MM}-{SEQ:6}"
+
+// MaxGeneratedSerials bounds the serial numbers generated for one lot, so a
+// mistyped quantity cannot reserve and insert millions of them.
+const MaxGeneratedSerials = 10000
+
+// GenerateSerials expands pattern count times using consecutive sequence
+// numbers starting at start. Supported tokens are {CODE}, {BATCH}, {YYYY},
+// {YY}, {MM}, {DD} and {SEQ} or {SEQ:n} for a zero padded sequence, in any
+// case. count must not exceed MaxGeneratedSerials.
+func GenerateSerials(pattern string, settings *SerialSettings, batch string, at time.Time, start int64, count int) ([]string, error) {
+	if count > MaxGeneratedSerials {
+		return nil, ErrTooManySerials
+	}
+	if pattern == "" {
+		pattern = DefaultSerialPattern
+	}
+	if err := codepattern.ValidateSequence(pattern); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSerialPattern, err)
+	}
+
+	serials := make([]string, 0, count)
+	for n := 0; n < count; n++ {
+		sequence := start + int64(n)
+		serial := codepattern.Expand(pattern, func(token codepattern.Token) string {
+			switch token.Name {
+			case "CODE":
+				return settings.ProductCode
+			case "BATCH":
+				return batch
+			case "YYYY":
+				return at.Format("2006")
+			case "YY":
+				return at.Format("06")
+			case "MM":
+				return at.Format("01")
+			case "DD":
+				return at.Format("02")
+			case codepattern.Sequence:
+				return token.Pad(sequence)
+			default:
+				return token.Text
+			}
+		})
+		serials = append(serials, serial)
+	}
+	return serials, nil
+}
+
+// InventoryFormFinishedGoods receives a lot of finished goods. Serialized
+// products either list the scanned serials or ask for them to be generated
+// from the product pattern, in which case Quantity units are generated.
+type InventoryFormFinishedGoods struct {
+	ProductID       int64               `json:"product_id"`
+	Batch           string              `json:"batch"`
+	StoreID         *int64              `json:"store_id"`
+	ContainerID     *int64              `json:"container_id"`
+	RackID          *int64              `json:"rack_id"`
+	BinID           *int64              `json:"bin_id"`
+	PalletID        *int64              `json:"pallet_id"`
+	Machine         string              `json:"machine"`
+	Shift           string              `json:"shift"`
+	Supervisor      string              `json:"supervisor"`
+	ShiftOverride   *ShiftOverride      `json:"shift_override"`
+	Quantity        customtypes.Decimal `json:"quantity"`
+	ExpiresAt       *time.Time          `json:"expires_at"`
+	Serials         []string            `json:"serials"`
+	GenerateSerials bool                `json:"generate_serials"`
+	ReceivedBy      string              `json:"received_by"`
+	// Backflush consumes the BOM components of the received quantity from
+	// BackflushStoreIDs, or the configured line-side stores when empty.
+	Backflush         bool    `json:"backflush"`
+	BackflushStoreIDs []int64 `json:"backflush_store_ids"`
+}
+
+// ValidateSerialCount checks that a serialized lot holds exactly one unit per
+// serial.
+func ValidateSerialCount(quantity customtypes.Decimal, serials int) error {
+	if !quantity.Equal(customtypes.NewDecimalFromInt(int64(serials))) {
+		return ErrSerialCountMismatch
+	}
+	return nil
+}
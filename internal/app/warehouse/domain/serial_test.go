@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGenerateSerials(t *testing.T) {
+	settings := &SerialSettings{ProductCode: "FG-10"}
+	at := time.Date(2026, 3, 7, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		pattern string
+		start   int64
+		count   int
+		want    []string
+		err     error
+	}{
+		{name: "default pattern", start: 41, count: 2, want: []string{"FG-10-202603-000041", "FG-10-202603-000042"}},
+		{name: "all tokens", pattern: "{BATCH}/{YY}{MM}{DD}/{SEQ}", start: 9, count: 2, want: []string{"B7/260307/9", "B7/260307/10"}},
+		{name: "sequence wider than its padding", pattern: "{SEQ:2}", start: 123, count: 1, want: []string{"123"}},
+		{name: "nothing to generate", pattern: "{SEQ}", start: 1, count: 0, want: []string{}},
+		{name: "pattern without a sequence", pattern: "{CODE}-{BATCH}", start: 1, count: 1, err: ErrInvalidSerialPattern},
+		{name: "lower case tokens", pattern: "{code}-{seq:3}", start: 7, count: 1, want: []string{"FG-10-007"}},
+		{name: "padding too wide", pattern: "{SEQ:999999999}", start: 1, count: 1, err: ErrInvalidSerialPattern},
+		{name: "padding that is not a number", pattern: "{CODE}-{SEQ:abc}", start: 1, count: 1, err: ErrInvalidSerialPattern},
+		{name: "too many serials", start: 1, count: MaxGeneratedSerials + 1, err: ErrTooManySerials},
+	}
+	for _, test := range tests {
+		got, err := GenerateSerials(test.pattern, settings, "B7", at, test.start, test.count)
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("%s: err = %v, want %v", test.name, err, test.err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %q, %v, want %q", test.name, got, err, test.want)
+		}
+	}
+}
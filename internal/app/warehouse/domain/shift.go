@@ -0,0 +1,259 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+// ShiftDateLayout is the layout of the business dates of shifts, holidays and
+// supervisor assignments.
+const ShiftDateLayout = "2006-01-02"
+
+const shiftClockLayout = "15:04"
+
+var (
+	ErrInvalidShift               = errors.New("invalid shift")
+	ErrUnknownShift               = errors.New("shift is not defined for the store")
+	ErrDuplicateShift             = errors.New("store already has a shift with that code")
+	ErrShiftOverrideRequired      = errors.New("shift or supervisor differs from the shift calendar and needs an approved override")
+	ErrShiftOverrideNotAuthorized = errors.New("shift overrides must be approved by the owner, a manager or a deputy of the store")
+)
+
+var shiftWeekdays = map[string]time.Weekday{
+	"SUN": time.Sunday,
+	"MON": time.Monday,
+	"TUE": time.Tuesday,
+	"WED": time.Wednesday,
+	"THU": time.Thursday,
+	"FRI": time.Friday,
+	"SAT": time.Saturday,
+}
+
+// Shift is a named time window of a store, such as 22:00 to 06:00. A window
+// whose end is not after its start crosses midnight and belongs to the date it
+// starts on. Days lists the weekdays the shift runs on, e.g. "MON,TUE,WED";
+// an empty list means every day. Supervisor is used on dates without an
+// assignment of their own.
+type Shift struct {
+	ID            int64                      `json:"id"`
+	StoreID       int64                      `json:"store_id"`
+	Code          string                     `json:"code"`
+	Name          customtypes.NullableString `json:"name"`
+	StartTime     string                     `json:"start_time"`
+	EndTime       string                     `json:"end_time"`
+	Days          customtypes.NullableString `json:"days"`
+	Supervisor    customtypes.NullableString `json:"supervisor"`
+	Status        customtypes.NullableString `json:"status"`
+	CreatedAt     time.Time                  `json:"created_at"`
+	UpdatedAt     time.Time                  `json:"updated_at"`
+	LastUpdatedBy customtypes.NullableString `json:"last_updated_by"`
+	Version       int64                      `json:"version"`
+}
+
+func NewShiftWithDefaults() *Shift {
+	return &Shift{Status: "active"}
+}
+
+func (s *Shift) Validate() error {
+	if s.StoreID <= 0 {
+		return fmt.Errorf("%w: store_id is required", ErrInvalidShift)
+	}
+	s.Code = strings.ToUpper(strings.TrimSpace(s.Code))
+	if s.Code == "" {
+		return fmt.Errorf("%w: code is required", ErrInvalidShift)
+	}
+	start, err := time.Parse(shiftClockLayout, s.StartTime)
+	if err != nil {
+		return fmt.Errorf("%w: start_time must be HH:MM", ErrInvalidShift)
+	}
+	end, err := time.Parse(shiftClockLayout, s.EndTime)
+	if err != nil {
+		return fmt.Errorf("%w: end_time must be HH:MM", ErrInvalidShift)
+	}
+	if start.Equal(end) {
+		return fmt.Errorf("%w: start_time and end_time must differ", ErrInvalidShift)
+	}
+	var days []string
+	for _, day := range strings.Split(string(s.Days), ",") {
+		day = strings.ToUpper(strings.TrimSpace(day))
+		if day == "" {
+			continue
+		}
+		if _, ok := shiftWeekdays[day]; !ok {
+			return fmt.Errorf("%w: unknown day %q", ErrInvalidShift, day)
+		}
+		days = append(days, day)
+	}
+	s.Days = customtypes.NullableString(strings.Join(days, ","))
+	return nil
+}
+
+// RunsOn reports whether the weekly pattern of the shift includes day.
+func (s *Shift) RunsOn(day time.Weekday) bool {
+	if s.Days == "" {
+		return true
+	}
+	for _, name := range strings.Split(string(s.Days), ",") {
+		if shiftWeekdays[name] == day {
+			return true
+		}
+	}
+	return false
+}
+
+// Window returns when the shift runs if it starts on date.
+func (s *Shift) Window(date time.Time) (time.Time, time.Time) {
+	start, _ := time.Parse(shiftClockLayout, s.StartTime)
+	end, _ := time.Parse(shiftClockLayout, s.EndTime)
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	from := day.Add(time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute)
+	to := day.Add(time.Duration(end.Hour())*time.Hour + time.Duration(end.Minute())*time.Minute)
+	if !to.After(from) {
+		to = to.AddDate(0, 0, 1)
+	}
+	return from, to
+}
+
+// ParseShiftDate checks that value is a business date such as 2024-03-31.
+func ParseShiftDate(value string) (time.Time, error) {
+	date, err := time.ParseInLocation(ShiftDateLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: dates must be YYYY-MM-DD", ErrInvalidShift)
+	}
+	return date, nil
+}
+
+// ShiftHoliday is a date on which no shift of the store starts.
+type ShiftHoliday struct {
+	ID      int64                      `json:"id"`
+	StoreID int64                      `json:"store_id"`
+	Date    string                     `json:"date"`
+	Name    customtypes.NullableString `json:"name"`
+}
+
+// ShiftAssignment names the supervisor of a shift on a date.
+type ShiftAssignment struct {
+	ID         int64                      `json:"id"`
+	ShiftID    int64                      `json:"shift_id"`
+	Date       string                     `json:"date"`
+	Supervisor string                     `json:"supervisor"`
+	AssignedBy customtypes.NullableString `json:"assigned_by"`
+	AssignedAt time.Time                  `json:"assigned_at"`
+}
+
+// ShiftCalendar is what is needed to resolve the shifts of a store around a
+// point in time. Supervisors maps a shift id and a date to its assigned
+// supervisor.
+type ShiftCalendar struct {
+	Shifts      []*Shift
+	Holidays    map[string]bool
+	Supervisors map[int64]map[string]string
+}
+
+// ShiftResolution is the shift running at a point in time, with the date it
+// started on and its supervisor.
+type ShiftResolution struct {
+	Shift      *Shift `json:"shift"`
+	Date       string `json:"date"`
+	Supervisor string `json:"supervisor"`
+}
+
+// ShiftOverride lets a lot be booked to another shift or supervisor than the
+// calendar gives. Approver is the username the request was authenticated as
+// and is never read from the body; ApprovedBy is that user's id, resolved when
+// the override is recorded, and must be the owner, a manager or a deputy of
+// the store.
+type ShiftOverride struct {
+	Approver   string `json:"-"`
+	ApprovedBy int64  `json:"-"`
+	Reason     string `json:"reason"`
+}
+
+// Resolve returns the shift running at at, or nil when none is. A shift that
+// started the day before and runs past midnight is found as well; when shifts
+// overlap, the one that started last wins.
+func (c *ShiftCalendar) Resolve(at time.Time) *ShiftResolution {
+	at = at.In(time.Local)
+	var best *ShiftResolution
+	var bestStart time.Time
+	for _, date := range []time.Time{at.AddDate(0, 0, -1), at} {
+		key := date.Format(ShiftDateLayout)
+		if c.Holidays[key] {
+			continue
+		}
+		for _, shift := range c.Shifts {
+			if !shift.RunsOn(date.Weekday()) {
+				continue
+			}
+			from, to := shift.Window(date)
+			if at.Before(from) || !at.Before(to) || (best != nil && !from.After(bestStart)) {
+				continue
+			}
+			best = &ShiftResolution{Shift: shift, Date: key, Supervisor: c.SupervisorOf(shift, key)}
+			bestStart = from
+		}
+	}
+	return best
+}
+
+// SupervisorOf returns the supervisor assigned to the shift on date, or its
+// default supervisor.
+func (c *ShiftCalendar) SupervisorOf(shift *Shift, date string) string {
+	if supervisor, ok := c.Supervisors[shift.ID][date]; ok {
+		return supervisor
+	}
+	return string(shift.Supervisor)
+}
+
+// Find returns the shift with the given code or name, ignoring case.
+func (c *ShiftCalendar) Find(value string) *Shift {
+	value = strings.TrimSpace(value)
+	for _, shift := range c.Shifts {
+		if strings.EqualFold(shift.Code, value) || (shift.Name != "" && strings.EqualFold(string(shift.Name), value)) {
+			return shift
+		}
+	}
+	return nil
+}
+
+// Apply fills in the shift and supervisor of a lot stocked in at at from the
+// calendar. A shift or supervisor given by the operator that differs from the
+// calendar needs an override; the returned resolution is what the calendar
+// gave, and overridden reports whether the lot departs from it. Stores
+// without shifts keep whatever was entered.
+func (c *ShiftCalendar) Apply(inventory *Inventory, at time.Time) (resolution *ShiftResolution, overridden bool, err error) {
+	if len(c.Shifts) == 0 {
+		return nil, false, nil
+	}
+	resolution = c.Resolve(at)
+	expectedShift, expectedSupervisor, date := "", "", at.In(time.Local).Format(ShiftDateLayout)
+	if resolution != nil {
+		expectedShift, expectedSupervisor, date = resolution.Shift.Code, resolution.Supervisor, resolution.Date
+	}
+
+	if inventory.Shift == "" {
+		inventory.Shift = expectedShift
+	} else {
+		shift := c.Find(inventory.Shift)
+		if shift == nil {
+			return nil, false, fmt.Errorf("%w: %s", ErrUnknownShift, inventory.Shift)
+		}
+		inventory.Shift = shift.Code
+		if inventory.Supervisor == "" && shift.Code != expectedShift {
+			inventory.Supervisor = c.SupervisorOf(shift, date)
+		}
+	}
+	if inventory.Supervisor == "" {
+		inventory.Supervisor = expectedSupervisor
+	}
+
+	overridden = inventory.Shift != expectedShift || !strings.EqualFold(strings.TrimSpace(inventory.Supervisor), expectedSupervisor)
+	if overridden && inventory.ShiftOverride == nil {
+		return nil, false, ErrShiftOverrideRequired
+	}
+	return resolution, overridden, nil
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShiftCalendarResolve(t *testing.T) {
+	calendar := &ShiftCalendar{
+		Shifts: []*Shift{
+			{ID: 1, Code: "A", StartTime: "06:00", EndTime: "14:00"},
+			{ID: 2, Code: "B", StartTime: "14:00", EndTime: "22:00"},
+			{ID: 3, Code: "C", StartTime: "22:00", EndTime: "06:00", Days: "MON,TUE,WED,THU,FRI", Supervisor: "Ravi"},
+			{ID: 4, Code: "D", StartTime: "14:30", EndTime: "16:00", Days: "SAT"},
+		},
+		Holidays:    map[string]bool{"2024-03-06": true},
+		Supervisors: map[int64]map[string]string{3: {"2024-03-04": "Anil"}},
+	}
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, time.March, day, hour, minute, 0, 0, time.Local)
+	}
+	tests := []struct {
+		name       string
+		at         time.Time
+		shift      string
+		date       string
+		supervisor string
+	}{
+		{name: "day shift", at: at(4, 10, 0), shift: "A", date: "2024-03-04"},
+		{name: "end is exclusive", at: at(4, 14, 0), shift: "B", date: "2024-03-04"},
+		{name: "night shift after midnight", at: at(5, 2, 0), shift: "C", date: "2024-03-04", supervisor: "Anil"},
+		{name: "night shift before midnight", at: at(5, 23, 0), shift: "C", date: "2024-03-05", supervisor: "Ravi"},
+		{name: "friday night runs into saturday", at: at(9, 2, 0), shift: "C", date: "2024-03-08", supervisor: "Ravi"},
+		{name: "no night shift on saturday", at: at(10, 2, 0)},
+		{name: "later start wins an overlap", at: at(9, 15, 0), shift: "D", date: "2024-03-09"},
+		{name: "overlapping shift off its days", at: at(8, 15, 0), shift: "B", date: "2024-03-08"},
+		{name: "holiday", at: at(6, 10, 0)},
+		{name: "night shift started on a holiday", at: at(7, 2, 0)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resolution := calendar.Resolve(test.at)
+			if test.shift == "" {
+				if resolution != nil {
+					t.Fatalf("Resolve() = shift %s on %s, want none", resolution.Shift.Code, resolution.Date)
+				}
+				return
+			}
+			if resolution == nil {
+				t.Fatalf("Resolve() = nil, want shift %s", test.shift)
+			}
+			if resolution.Shift.Code != test.shift || resolution.Date != test.date || resolution.Supervisor != test.supervisor {
+				t.Errorf("Resolve() = %s on %s by %q, want %s on %s by %q", resolution.Shift.Code, resolution.Date, resolution.Supervisor, test.shift, test.date, test.supervisor)
+			}
+		})
+	}
+}
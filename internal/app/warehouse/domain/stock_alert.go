@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+type StockAlertStatus string
+
+const (
+	ALERT_OPEN         StockAlertStatus = "OPEN"
+	ALERT_ACKNOWLEDGED StockAlertStatus = "ACKNOWLEDGED"
+	ALERT_RESOLVED     StockAlertStatus = "RESOLVED"
+)
+
+var ErrInvalidAlertTransition = errors.New("invalid stock alert status transition")
+
+// StockAlert reports that the available stock of a stock level dropped below
+// its reorder point. An alert resolved by hand keeps the stock level quiet
+// until stock recovers; only a drop after RecoveredAt raises a new alert.
+type StockAlert struct {
+	ID                int64                      `json:"id"`
+	StockLevelID      int64                      `json:"stock_level_id"`
+	ProductID         int64                      `json:"product_id"`
+	StoreID           int64                      `json:"store_id"`
+	Status            StockAlertStatus           `json:"status"`
+	OnHand            customtypes.Decimal        `json:"on_hand"`
+	Reserved          customtypes.Decimal        `json:"reserved"`
+	Available         customtypes.Decimal        `json:"available"`
+	ReorderPoint      customtypes.Decimal        `json:"reorder_point"`
+	SuggestedQuantity customtypes.Decimal        `json:"suggested_quantity"`
+	Unit              string                     `json:"unit"`
+	RaisedAt          time.Time                  `json:"raised_at"`
+	AcknowledgedAt    *time.Time                 `json:"acknowledged_at"`
+	AcknowledgedBy    customtypes.NullableString `json:"acknowledged_by"`
+	ResolvedAt        *time.Time                 `json:"resolved_at"`
+	RecoveredAt       *time.Time                 `json:"recovered_at"`
+	UpdatedAt         time.Time                  `json:"updated_at"`
+}
+
+func NewStockAlert(position *StockLevelPosition, raisedAt time.Time) *StockAlert {
+	alert := &StockAlert{
+		StockLevelID: position.Level.ID,
+		ProductID:    position.Level.ProductID,
+		StoreID:      position.Level.StoreID,
+		Status:       ALERT_OPEN,
+		RaisedAt:     raisedAt,
+	}
+	alert.Refresh(position)
+	return alert
+}
+
+// Refresh copies the latest stock figures of position into the alert.
+func (a *StockAlert) Refresh(position *StockLevelPosition) {
+	a.OnHand = position.OnHand
+	a.Reserved = position.Reserved
+	a.Available = position.Available()
+	a.ReorderPoint = position.Level.ReorderPoint
+	a.SuggestedQuantity = position.Level.SuggestedReorderQuantity(a.Available)
+	a.Unit = position.Level.Unit
+}
+
+func (a *StockAlert) IsActive() bool {
+	return a.Status == ALERT_OPEN || a.Status == ALERT_ACKNOWLEDGED
+}
+
+func (a *StockAlert) Acknowledge(by string, at time.Time) error {
+	if a.Status != ALERT_OPEN {
+		return ErrInvalidAlertTransition
+	}
+	a.Status = ALERT_ACKNOWLEDGED
+	a.AcknowledgedAt = &at
+	a.AcknowledgedBy = customtypes.NullableString(by)
+	return nil
+}
+
+func (a *StockAlert) Resolve(at time.Time) error {
+	if !a.IsActive() {
+		return ErrInvalidAlertTransition
+	}
+	a.Status = ALERT_RESOLVED
+	a.ResolvedAt = &at
+	return nil
+}
+
+// Recover records that stock is back at or above the reorder point, resolving
+// the alert if nobody did so by hand.
+func (a *StockAlert) Recover(position *StockLevelPosition, at time.Time) {
+	a.Refresh(position)
+	if a.IsActive() {
+		a.Status = ALERT_RESOLVED
+		a.ResolvedAt = &at
+	}
+	a.RecoveredAt = &at
+}
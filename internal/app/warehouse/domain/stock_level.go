@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+// StockLevel holds the replenishment settings of a product in a store.
+type StockLevel struct {
+	ID            int64                      `json:"id"`
+	ProductID     int64                      `json:"product_id"`
+	StoreID       int64                      `json:"store_id"`
+	MinQuantity   customtypes.Decimal        `json:"min_quantity"`
+	MaxQuantity   customtypes.Decimal        `json:"max_quantity"`
+	ReorderPoint  customtypes.Decimal        `json:"reorder_point"`
+	Unit          string                     `json:"unit"`
+	Status        customtypes.NullableString `json:"status"`
+	CreatedAt     time.Time                  `json:"created_at"`
+	UpdatedAt     time.Time                  `json:"updated_at"`
+	LastUpdatedBy customtypes.NullableString `json:"last_updated_by"`
+	Version       int64                      `json:"version"`
+}
+
+func NewStockLevelWithDefaults() *StockLevel {
+	return &StockLevel{
+		Status: "active",
+	}
+}
+
+func (s *StockLevel) ValidateQuantities() error {
+	if s.MinQuantity.Sign() < 0 || s.MaxQuantity.Sign() < 0 || s.ReorderPoint.Sign() < 0 {
+		return errors.New("stock level quantities cannot be negative")
+	}
+	if s.ReorderPoint.Cmp(s.MinQuantity) < 0 {
+		return errors.New("reorder point cannot be below the minimum quantity")
+	}
+	if !s.MaxQuantity.IsZero() && s.MaxQuantity.Cmp(s.ReorderPoint) < 0 {
+		return errors.New("maximum quantity cannot be below the reorder point")
+	}
+	return nil
+}
+
+// SuggestedReorderQuantity tops the available stock up to the maximum, or to
+// the reorder point when no maximum is configured.
+func (s *StockLevel) SuggestedReorderQuantity(available customtypes.Decimal) customtypes.Decimal {
+	target := s.MaxQuantity
+	if target.IsZero() {
+		target = s.ReorderPoint
+	}
+	suggested := target.Sub(available)
+	if suggested.Sign() < 0 {
+		return customtypes.Decimal{}
+	}
+	return suggested.Ceil(customtypes.UnitScale(s.Unit))
+}
+
+// StockLevelPosition is a stock level together with the inventory currently
+// held for its product and store. Held is the part of OnHand that quality
+// control has not released.
+type StockLevelPosition struct {
+	Level    *StockLevel
+	OnHand   customtypes.Decimal
+	Reserved customtypes.Decimal
+	Held     customtypes.Decimal
+}
+
+// AddStock counts stock of the product held in unit towards the position.
+// Stock in another unit than the level's cannot be compared with its
+// quantities and is left out.
+func (p *StockLevelPosition) AddStock(unit string, onHand, reserved, held customtypes.Decimal) {
+	if !strings.EqualFold(strings.TrimSpace(unit), strings.TrimSpace(p.Level.Unit)) {
+		return
+	}
+	p.OnHand = p.OnHand.Add(onHand)
+	p.Reserved = p.Reserved.Add(reserved)
+	p.Held = p.Held.Add(held)
+}
+
+func (p *StockLevelPosition) Available() customtypes.Decimal {
+	return p.OnHand.Sub(p.Reserved).Sub(p.Held)
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+func TestStockLevelPositionAddStock(t *testing.T) {
+	decimal := customtypes.MustParseDecimal
+	position := &StockLevelPosition{Level: &StockLevel{Unit: "kg", ReorderPoint: decimal("100")}}
+	position.AddStock("kg", decimal("60"), decimal("5"), decimal("0"))
+	position.AddStock("KG", decimal("10.5"), decimal("0"), decimal("2"))
+	// Lots counted in another unit, or none at all, are not comparable with
+	// the level and must not hide a shortage.
+	position.AddStock("pcs", decimal("500"), decimal("0"), decimal("0"))
+	position.AddStock("", decimal("0"), decimal("0"), decimal("0"))
+
+	if !position.OnHand.Equal(decimal("70.5")) || !position.Reserved.Equal(decimal("5")) || !position.Held.Equal(decimal("2")) {
+		t.Errorf("position = %s on hand, %s reserved, %s held, want 70.5, 5 and 2", position.OnHand, position.Reserved, position.Held)
+	}
+	if got := position.Available(); !got.Equal(decimal("63.5")) {
+		t.Errorf("Available() = %s, want 63.5", got)
+	}
+	if position.Available().Cmp(position.Level.ReorderPoint) >= 0 {
+		t.Errorf("available %s reaches the reorder point %s, want it below", position.Available(), position.Level.ReorderPoint)
+	}
+}
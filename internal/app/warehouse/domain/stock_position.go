@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+type MovementType string
+
+const (
+	MOVEMENT_CREATED MovementType = "CREATED"
+	MOVEMENT_UPDATED MovementType = "UPDATED"
+	MOVEMENT_DELETED MovementType = "DELETED"
+	// MOVEMENT_CONSUMED is a raw material lot used up by production
+	MOVEMENT_CONSUMED MovementType = "CONSUMED"
+	// MOVEMENT_DISPATCHED and MOVEMENT_RECEIVED are stock leaving a store on
+	// a transfer order and arriving at the other end
+	MOVEMENT_DISPATCHED MovementType = "DISPATCHED"
+	MOVEMENT_RECEIVED   MovementType = "RECEIVED"
+)
+
+// StockPosition is the stock of a product held at one location: a store and
+// the container the lots are in. OnHand includes reserved stock; Held is the
+// part that quality control has not released.
+type StockPosition struct {
+	ProductID   int64               `json:"product_id"`
+	StoreID     *int64              `json:"store_id"`
+	ContainerID *int64              `json:"container_id"`
+	RackID      *int64              `json:"rack_id"`
+	BinID       *int64              `json:"bin_id"`
+	PalletID    *int64              `json:"pallet_id"`
+	Unit        string              `json:"unit"`
+	OnHand      customtypes.Decimal `json:"on_hand"`
+	Reserved    customtypes.Decimal `json:"reserved"`
+	Held        customtypes.Decimal `json:"held"`
+	Available   customtypes.Decimal `json:"available"`
+}
+
+func (p *StockPosition) CalculateAvailable() {
+	p.Available = p.OnHand.Sub(p.Reserved).Sub(p.Held)
+}
+
+// StockPositionReport is the answer to "what did we hold at AsOf".
+type StockPositionReport struct {
+	AsOf       time.Time        `json:"as_of"`
+	SnapshotAt *time.Time       `json:"snapshot_at"`
+	Positions  []*StockPosition `json:"positions"`
+}
+
+// ClosingTime is the instant a daily snapshot of date is taken: the last
+// second of that day in the location of date.
+func ClosingTime(date time.Time) time.Time {
+	year, month, day := date.Date()
+	return time.Date(year, month, day, 23, 59, 59, 0, date.Location())
+}
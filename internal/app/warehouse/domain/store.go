@@ -1,11 +1,26 @@
 package domain
 
 import (
+	"errors"
 	"time"
 
 	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
 )
 
+type StoreRole string
+
+const (
+	STORE_MANAGER StoreRole = "MANAGER"
+	STORE_DEPUTY  StoreRole = "DEPUTY"
+)
+
+var (
+	ErrStoreUserUnavailable = errors.New("user does not exist or is not active")
+	ErrInvalidStoreRole     = errors.New("store role must be MANAGER or DEPUTY")
+)
+
+// StoreOwner is the user owning a store. Only the ID is written; the other
+// fields are read from the user directory.
 type StoreOwner struct {
 	ID      customtypes.NullableInt64  `json:"id"`
 	Name    customtypes.NullableString `json:"name"`
@@ -22,6 +37,8 @@ type Store struct {
 	UpdatedAt     time.Time                  `json:"updated_at"`
 	LastUpdatedBy customtypes.NullableString `json:"last_updated_by"`
 	Owner         *StoreOwner                `json:"owner"`
+	Members       []*StoreMember             `json:"members,omitempty"`
+	Version       int64                      `json:"version"`
 }
 
 func NewStoreWithDefaults() *Store {
@@ -30,3 +47,53 @@ func NewStoreWithDefaults() *Store {
 		Owner:  &StoreOwner{},
 	}
 }
+
+// OwnerID returns the id of the owner, or 0 for a store without one.
+func (s *Store) OwnerID() int64 {
+	if s.Owner == nil {
+		return 0
+	}
+	return int64(s.Owner.ID)
+}
+
+func (r StoreRole) Validate() error {
+	switch r {
+	case STORE_MANAGER, STORE_DEPUTY:
+		return nil
+	default:
+		return ErrInvalidStoreRole
+	}
+}
+
+// StoreMember is a user helping to run a store next to its owner. Name,
+// StaffID and Email are read from the user directory.
+type StoreMember struct {
+	StoreID    int64                      `json:"store_id"`
+	UserID     int64                      `json:"user_id"`
+	Role       StoreRole                  `json:"role"`
+	Name       customtypes.NullableString `json:"name"`
+	StaffID    customtypes.NullableString `json:"staff_id"`
+	Email      customtypes.NullableString `json:"email"`
+	AssignedBy customtypes.NullableString `json:"assigned_by"`
+	AssignedAt time.Time                  `json:"assigned_at"`
+}
+
+// StoreOwnerChange is an entry of the ownership history of a store. A nil
+// owner id means the store had, or was left, without an owner.
+type StoreOwnerChange struct {
+	ID          int64                      `json:"id"`
+	StoreID     int64                      `json:"store_id"`
+	FromOwnerID *int64                     `json:"from_owner_id"`
+	ToOwnerID   *int64                     `json:"to_owner_id"`
+	Reason      customtypes.NullableString `json:"reason"`
+	ChangedBy   customtypes.NullableString `json:"changed_by"`
+	ChangedAt   time.Time                  `json:"changed_at"`
+}
+
+// StoreOwnerForm hands a store over to OwnerID, or leaves it without an owner
+// when OwnerID is 0.
+type StoreOwnerForm struct {
+	OwnerID   int64                      `json:"owner_id"`
+	Reason    customtypes.NullableString `json:"reason"`
+	ChangedBy customtypes.NullableString `json:"changed_by"`
+}
@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+const (
+	// DefaultExpiryWindowDays is how far ahead a store summary looks for
+	// expiring lots unless asked otherwise.
+	DefaultExpiryWindowDays = 30
+	MaxExpiryWindowDays     = 365
+
+	// StoreSummaryListLimit caps the alerts and lots listed in a summary; the
+	// counts always cover all of them.
+	StoreSummaryListLimit = 10
+)
+
+// StoreSummary is the state of one store at a glance: what it holds, what
+// came in and went out today, and what needs attention.
+type StoreSummary struct {
+	StoreID      int64                `json:"store_id"`
+	StoreName    string               `json:"store_name"`
+	GeneratedAt  time.Time            `json:"generated_at"`
+	OnHand       []*ProductTypeStock  `json:"on_hand"`
+	Receipts     StockTotals          `json:"receipts_today"`
+	Issues       StockTotals          `json:"issues_today"`
+	Reservations StockTotals          `json:"open_reservations"`
+	Containers   []*ContainerUsage    `json:"containers"`
+	LowStock     LowStockSummary      `json:"low_stock"`
+	Expiring     ExpiringStockSummary `json:"expiring"`
+}
+
+func NewStoreSummary(storeID int64, at time.Time) *StoreSummary {
+	return &StoreSummary{
+		StoreID:      storeID,
+		GeneratedAt:  at,
+		OnHand:       []*ProductTypeStock{},
+		Receipts:     StockTotals{Quantities: []*UnitQuantity{}},
+		Issues:       StockTotals{Quantities: []*UnitQuantity{}},
+		Reservations: StockTotals{Quantities: []*UnitQuantity{}},
+		Containers:   []*ContainerUsage{},
+		LowStock:     LowStockSummary{Alerts: []*LowStockAlert{}},
+		Expiring:     ExpiringStockSummary{Lots: []*ExpiringLot{}},
+	}
+}
+
+// ProductTypeStock is the stock of one product type in one unit. OnHand
+// includes reserved stock; Held is the part quality control has not released.
+type ProductTypeStock struct {
+	ProductType string              `json:"product_type"`
+	Unit        string              `json:"unit"`
+	Lots        int                 `json:"lots"`
+	OnHand      customtypes.Decimal `json:"on_hand"`
+	Reserved    customtypes.Decimal `json:"reserved"`
+	Held        customtypes.Decimal `json:"held"`
+	Available   customtypes.Decimal `json:"available"`
+}
+
+// StockTotals counts lots and sums their quantities per unit.
+type StockTotals struct {
+	Lots       int             `json:"lots"`
+	Quantities []*UnitQuantity `json:"quantities"`
+}
+
+type UnitQuantity struct {
+	Unit     string              `json:"unit"`
+	Quantity customtypes.Decimal `json:"quantity"`
+}
+
+// Add counts lots lots holding quantity of unit.
+func (t *StockTotals) Add(unit string, lots int, quantity customtypes.Decimal) {
+	t.Lots += lots
+	for _, total := range t.Quantities {
+		if total.Unit == unit {
+			total.Quantity = total.Quantity.Add(quantity)
+			return
+		}
+	}
+	t.Quantities = append(t.Quantities, &UnitQuantity{Unit: unit, Quantity: quantity})
+	sort.Slice(t.Quantities, func(i, j int) bool { return t.Quantities[i].Unit < t.Quantities[j].Unit })
+}
+
+// ContainerUsage counts the containers of one type that hold stock, directly
+// or further down the tree, and those that are empty.
+type ContainerUsage struct {
+	Type  ContainerType `json:"type"`
+	Total int           `json:"total"`
+	InUse int           `json:"in_use"`
+	Empty int           `json:"empty"`
+}
+
+type LowStockSummary struct {
+	Open         int              `json:"open"`
+	Acknowledged int              `json:"acknowledged"`
+	Alerts       []*LowStockAlert `json:"alerts"`
+}
+
+type LowStockAlert struct {
+	AlertID      int64               `json:"alert_id"`
+	ProductID    int64               `json:"product_id"`
+	ProductCode  string              `json:"product_code"`
+	Status       StockAlertStatus    `json:"status"`
+	Available    customtypes.Decimal `json:"available"`
+	ReorderPoint customtypes.Decimal `json:"reorder_point"`
+	Unit         string              `json:"unit"`
+	RaisedAt     time.Time           `json:"raised_at"`
+}
+
+// ExpiringStockSummary counts the lots that have expired and those expiring
+// within WithinDays, and lists the first of them to expire.
+type ExpiringStockSummary struct {
+	WithinDays int            `json:"within_days"`
+	Expired    int            `json:"expired"`
+	Expiring   int            `json:"expiring"`
+	Lots       []*ExpiringLot `json:"lots"`
+}
+
+type ExpiringLot struct {
+	InventoryID int64               `json:"inventory_id"`
+	ProductID   int64               `json:"product_id"`
+	ProductCode string              `json:"product_code"`
+	Batch       string              `json:"batch"`
+	ContainerID *int64              `json:"container_id"`
+	Quantity    customtypes.Decimal `json:"quantity"`
+	Unit        string              `json:"unit"`
+	ExpiresAt   time.Time           `json:"expires_at"`
+}
@@ -0,0 +1,228 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+type TransferStatus string
+
+const (
+	TRANSFER_DRAFT              TransferStatus = "DRAFT"
+	TRANSFER_DISPATCHED         TransferStatus = "DISPATCHED"
+	TRANSFER_PARTIALLY_RECEIVED TransferStatus = "PARTIALLY RECEIVED"
+	TRANSFER_RECEIVED           TransferStatus = "RECEIVED"
+	TRANSFER_CANCELLED          TransferStatus = "CANCELLED"
+)
+
+var (
+	ErrInvalidTransfer           = errors.New("invalid transfer order")
+	ErrTransferNotDraft          = errors.New("transfer order has been dispatched and can no longer be changed")
+	ErrInvalidTransferTransition = errors.New("invalid transfer order status transition")
+	ErrTransferLotUnavailable    = errors.New("lot cannot be transferred")
+	ErrTransferDiscrepancyReason = errors.New("a received quantity that differs from the dispatched quantity needs a reason")
+)
+
+// TransferOrder moves lots from one store to another. Dispatching takes the
+// stock out of the source store and puts it in transit, where it belongs to
+// neither store; receiving books it into the destination store line by line.
+type TransferOrder struct {
+	ID            int64                      `json:"id"`
+	FromStoreID   int64                      `json:"from_store_id"`
+	ToStoreID     int64                      `json:"to_store_id"`
+	Status        TransferStatus             `json:"status"`
+	Vehicle       customtypes.NullableString `json:"vehicle"`
+	Remarks       customtypes.NullableString `json:"remarks"`
+	DispatchedAt  *time.Time                 `json:"dispatched_at"`
+	DispatchedBy  customtypes.NullableString `json:"dispatched_by"`
+	ReceivedAt    *time.Time                 `json:"received_at"`
+	CancelledAt   *time.Time                 `json:"cancelled_at"`
+	CancelReason  customtypes.NullableString `json:"cancel_reason"`
+	CreatedAt     time.Time                  `json:"created_at"`
+	UpdatedAt     time.Time                  `json:"updated_at"`
+	LastUpdatedBy customtypes.NullableString `json:"last_updated_by"`
+	Version       int64                      `json:"version"`
+	Lines         []*TransferLine            `json:"lines,omitempty"`
+	// Warnings lists the capacity limits the received stock went over when
+	// capacity is only warned about.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// TransferLine sends Quantity of a source lot. A lot sent in full travels
+// itself; otherwise the quantity is split off into a new lot on dispatch.
+// TransitInventoryID is the lot in transit, which becomes the received lot at
+// the destination. Discrepancy is the received less the dispatched quantity.
+type TransferLine struct {
+	ID                 int64                      `json:"id"`
+	TransferID         int64                      `json:"transfer_id"`
+	InventoryID        int64                      `json:"inventory_id"`
+	ProductID          int64                      `json:"product_id"`
+	Batch              string                     `json:"batch"`
+	Quantity           customtypes.Decimal        `json:"quantity"`
+	Unit               string                     `json:"unit"`
+	SourceContainerID  *int64                     `json:"source_container_id"`
+	TransitInventoryID *int64                     `json:"transit_inventory_id"`
+	ReceivedQuantity   *customtypes.Decimal       `json:"received_quantity"`
+	ToContainerID      *int64                     `json:"to_container_id"`
+	Discrepancy        customtypes.Decimal        `json:"discrepancy"`
+	DiscrepancyReason  customtypes.NullableString `json:"discrepancy_reason"`
+	ReceivedAt         *time.Time                 `json:"received_at"`
+	ReceivedBy         customtypes.NullableString `json:"received_by"`
+}
+
+func (l *TransferLine) IsReceived() bool {
+	return l.ReceivedAt != nil
+}
+
+// TransferReceiptLine confirms what arrived of one line and where it was put.
+type TransferReceiptLine struct {
+	LineID      int64               `json:"line_id"`
+	Quantity    customtypes.Decimal `json:"quantity"`
+	ContainerID *int64              `json:"container_id"`
+	Reason      string              `json:"reason"`
+}
+
+type TransferReceiptForm struct {
+	ReceivedBy string                 `json:"received_by"`
+	Lines      []*TransferReceiptLine `json:"lines"`
+}
+
+type TransferDispatchForm struct {
+	DispatchedBy string `json:"dispatched_by"`
+	Vehicle      string `json:"vehicle"`
+}
+
+type TransferCancelForm struct {
+	Reason      string `json:"reason"`
+	CancelledBy string `json:"cancelled_by"`
+}
+
+func NewTransferOrderWithDefaults() *TransferOrder {
+	return &TransferOrder{Status: TRANSFER_DRAFT}
+}
+
+func (s TransferStatus) Validate() error {
+	switch s {
+	case TRANSFER_DRAFT, TRANSFER_DISPATCHED, TRANSFER_PARTIALLY_RECEIVED, TRANSFER_RECEIVED, TRANSFER_CANCELLED:
+		return nil
+	default:
+		return errors.New("invalid transfer status")
+	}
+}
+
+func (t *TransferOrder) Validate() error {
+	if t.FromStoreID <= 0 || t.ToStoreID <= 0 {
+		return fmt.Errorf("%w: from_store_id and to_store_id are required", ErrInvalidTransfer)
+	}
+	if t.FromStoreID == t.ToStoreID {
+		return fmt.Errorf("%w: source and destination store must differ", ErrInvalidTransfer)
+	}
+	if len(t.Lines) == 0 {
+		return fmt.Errorf("%w: at least one line is required", ErrInvalidTransfer)
+	}
+	lots := make(map[int64]bool)
+	for _, line := range t.Lines {
+		if line.InventoryID <= 0 {
+			return fmt.Errorf("%w: inventory_id is required on every line", ErrInvalidTransfer)
+		}
+		if line.Quantity.Sign() <= 0 {
+			return fmt.Errorf("%w: quantity of lot %d must be positive", ErrInvalidTransfer, line.InventoryID)
+		}
+		if lots[line.InventoryID] {
+			return fmt.Errorf("%w: lot %d is on more than one line", ErrInvalidTransfer, line.InventoryID)
+		}
+		lots[line.InventoryID] = true
+	}
+	return nil
+}
+
+// Dispatch marks a draft order as on its way.
+func (t *TransferOrder) Dispatch(form *TransferDispatchForm, at time.Time) error {
+	if t.Status != TRANSFER_DRAFT {
+		return fmt.Errorf("%w: cannot dispatch a %s order", ErrInvalidTransferTransition, t.Status)
+	}
+	t.Status = TRANSFER_DISPATCHED
+	t.DispatchedAt = &at
+	t.DispatchedBy = customtypes.NullableString(form.DispatchedBy)
+	if form.Vehicle != "" {
+		t.Vehicle = customtypes.NullableString(form.Vehicle)
+	}
+	return nil
+}
+
+// Receive confirms the lines of the form and returns them. Each line is
+// received once; the order is received when no line is left in transit.
+func (t *TransferOrder) Receive(form *TransferReceiptForm, at time.Time) ([]*TransferLine, error) {
+	if t.Status != TRANSFER_DISPATCHED && t.Status != TRANSFER_PARTIALLY_RECEIVED {
+		return nil, fmt.Errorf("%w: cannot receive a %s order", ErrInvalidTransferTransition, t.Status)
+	}
+	if len(form.Lines) == 0 {
+		return nil, fmt.Errorf("%w: at least one line must be received", ErrInvalidTransfer)
+	}
+	lines := make(map[int64]*TransferLine, len(t.Lines))
+	for _, line := range t.Lines {
+		lines[line.ID] = line
+	}
+
+	var received []*TransferLine
+	for _, receipt := range form.Lines {
+		line, ok := lines[receipt.LineID]
+		if !ok {
+			return nil, fmt.Errorf("%w: line %d is not on the order", ErrInvalidTransfer, receipt.LineID)
+		}
+		if line.IsReceived() {
+			return nil, fmt.Errorf("%w: line %d has already been received", ErrInvalidTransfer, receipt.LineID)
+		}
+		if receipt.Quantity.Sign() < 0 {
+			return nil, fmt.Errorf("%w: received quantity of line %d cannot be negative", ErrInvalidTransfer, receipt.LineID)
+		}
+		if err := receipt.Quantity.ValidateForUnit(line.Unit); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidTransfer, receipt.LineID, err)
+		}
+		discrepancy := receipt.Quantity.Sub(line.Quantity)
+		if !discrepancy.IsZero() && receipt.Reason == "" {
+			return nil, fmt.Errorf("%w: line %d", ErrTransferDiscrepancyReason, receipt.LineID)
+		}
+		quantity := receipt.Quantity
+		line.ReceivedQuantity = &quantity
+		line.ToContainerID = receipt.ContainerID
+		line.Discrepancy = discrepancy
+		line.DiscrepancyReason = customtypes.NullableString(receipt.Reason)
+		line.ReceivedAt = &at
+		line.ReceivedBy = customtypes.NullableString(form.ReceivedBy)
+		received = append(received, line)
+	}
+
+	t.Status = TRANSFER_RECEIVED
+	for _, line := range t.Lines {
+		if !line.IsReceived() {
+			t.Status = TRANSFER_PARTIALLY_RECEIVED
+			break
+		}
+	}
+	if t.Status == TRANSFER_RECEIVED {
+		t.ReceivedAt = &at
+	}
+	return received, nil
+}
+
+// Cancel calls the order off. A draft is simply dropped; a dispatched order
+// can be cancelled while nothing has been received, and its stock goes back
+// to the source store. Orders that were received in part or in full cannot
+// be cancelled.
+func (t *TransferOrder) Cancel(form *TransferCancelForm, at time.Time) error {
+	if t.Status != TRANSFER_DRAFT && t.Status != TRANSFER_DISPATCHED {
+		return fmt.Errorf("%w: cannot cancel a %s order", ErrInvalidTransferTransition, t.Status)
+	}
+	if t.Status == TRANSFER_DISPATCHED && form.Reason == "" {
+		return fmt.Errorf("%w: a dispatched order needs a reason to be cancelled", ErrInvalidTransfer)
+	}
+	t.Status = TRANSFER_CANCELLED
+	t.CancelledAt = &at
+	t.CancelReason = customtypes.NullableString(form.Reason)
+	t.LastUpdatedBy = customtypes.NullableString(form.CancelledBy)
+	return nil
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+func TestTransferReceiveQuantityPrecision(t *testing.T) {
+	tests := []struct {
+		quantity string
+		err      error
+	}{
+		{quantity: "4"},
+		{quantity: "4.5", err: ErrInvalidTransfer},
+	}
+	for _, test := range tests {
+		order := &TransferOrder{
+			Status: TRANSFER_DISPATCHED,
+			Lines:  []*TransferLine{{ID: 1, Quantity: customtypes.NewDecimalFromInt(4), Unit: "pcs"}},
+		}
+		form := &TransferReceiptForm{
+			ReceivedBy: "store keeper",
+			Lines:      []*TransferReceiptLine{{LineID: 1, Quantity: customtypes.MustParseDecimal(test.quantity), Reason: "short"}},
+		}
+		if _, err := order.Receive(form, time.Now()); !errors.Is(err, test.err) {
+			t.Errorf("receiving %s pcs: err = %v, want %v", test.quantity, err, test.err)
+		}
+	}
+}
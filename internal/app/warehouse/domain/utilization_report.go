@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+const (
+	UTILIZATION_LEVEL_STORE = "store"
+	UTILIZATION_LEVEL_ZONE  = "zone"
+	UTILIZATION_LEVEL_RACK  = "rack"
+)
+
+var ErrInvalidUtilizationLevel = errors.New("level must be store, zone or rack")
+
+// UtilizationRow is the use of one rack, zone or store. Percentages are nil
+// for limits that are not set. Locations are the containers at the bottom of
+// the tree, such as bins and racks without bins; pallets move between them
+// and are not counted.
+type UtilizationRow struct {
+	ContainerID    *int64            `json:"container_id,omitempty"`
+	Code           string            `json:"code,omitempty"`
+	StoreID        *int64            `json:"store_id"`
+	StoreName      string            `json:"store_name,omitempty"`
+	Capacity       ContainerCapacity `json:"capacity"`
+	Load           ContainerLoad     `json:"load"`
+	WeightUsed     *float64          `json:"weight_used_percent"`
+	VolumeUsed     *float64          `json:"volume_used_percent"`
+	UnitsUsed      *float64          `json:"units_used_percent"`
+	Locations      int               `json:"locations"`
+	EmptyLocations int               `json:"empty_locations"`
+}
+
+type UtilizationReport struct {
+	Level string            `json:"level"`
+	Rows  []*UtilizationRow `json:"rows"`
+}
+
+// BuildUtilizationReport rolls the loads held directly in each container up
+// the tree and reports on the containers of level. The capacity of a store is
+// the sum of the containers placed directly in it; a limit is only summed
+// when every one of them sets it.
+func BuildUtilizationReport(level string, containers []*Container, loads map[int64]ContainerLoad, storeNames map[int64]string) (*UtilizationReport, error) {
+	var containerType ContainerType
+	switch level {
+	case UTILIZATION_LEVEL_STORE:
+	case UTILIZATION_LEVEL_ZONE:
+		containerType = ZONE_TYPE
+	case UTILIZATION_LEVEL_RACK:
+		containerType = RACK_TYPE
+	default:
+		return nil, ErrInvalidUtilizationLevel
+	}
+
+	subtreeLoads := make(map[int64]ContainerLoad, len(containers))
+	hasLocationChildren := make(map[int64]bool)
+	for _, container := range containers {
+		load := loads[container.ID]
+		for _, id := range container.AncestorIDs() {
+			subtreeLoads[id] = subtreeLoads[id].Add(load)
+		}
+		if container.ParentID != nil && container.Type != PALLET_TYPE {
+			hasLocationChildren[*container.ParentID] = true
+		}
+	}
+
+	report := &UtilizationReport{Level: level, Rows: []*UtilizationRow{}}
+	rowsByKey := make(map[int64]*UtilizationRow)
+	storeCapacities := make(map[int64][]ContainerCapacity)
+	rowFor := func(container *Container) *UtilizationRow {
+		key := container.ID
+		if containerType == "" {
+			if container.StoreID == nil {
+				return nil
+			}
+			key = *container.StoreID
+		} else if container.Type != containerType {
+			return nil
+		}
+		row, ok := rowsByKey[key]
+		if !ok {
+			row = &UtilizationRow{StoreID: container.StoreID}
+			if container.StoreID != nil {
+				row.StoreName = storeNames[*container.StoreID]
+			}
+			if containerType != "" {
+				id := container.ID
+				row.ContainerID = &id
+				row.Code = string(container.Code)
+				row.Capacity = container.EffectiveCapacity()
+				row.Load = subtreeLoads[container.ID]
+			}
+			rowsByKey[key] = row
+			report.Rows = append(report.Rows, row)
+		}
+		return row
+	}
+
+	byID := make(map[int64]*Container, len(containers))
+	for _, container := range containers {
+		byID[container.ID] = container
+	}
+	for _, container := range containers {
+		if containerType == "" && container.ParentID == nil {
+			if row := rowFor(container); row != nil {
+				row.Load = row.Load.Add(subtreeLoads[container.ID])
+				storeCapacities[*container.StoreID] = append(storeCapacities[*container.StoreID], container.EffectiveCapacity())
+			}
+		}
+		if container.Type == PALLET_TYPE || hasLocationChildren[container.ID] {
+			continue
+		}
+		// A location counts for every reported container above it
+		for _, id := range container.AncestorIDs() {
+			ancestor, ok := byID[id]
+			if !ok || (containerType == "" && ancestor.ParentID != nil) {
+				continue
+			}
+			if row := rowFor(ancestor); row != nil {
+				row.Locations++
+				if subtreeLoads[container.ID].Units.IsZero() {
+					row.EmptyLocations++
+				}
+			}
+		}
+	}
+	for storeID, capacities := range storeCapacities {
+		rowsByKey[storeID].Capacity = sumCapacities(capacities)
+	}
+
+	for _, row := range report.Rows {
+		row.WeightUsed = usedPercent(row.Load.Weight.Float64(), row.Capacity.MaxWeight.Float64())
+		row.VolumeUsed = usedPercent(row.Load.Volume.Float64(), row.Capacity.MaxVolume.Float64())
+		row.UnitsUsed = usedPercent(row.Load.Units.Float64(), row.Capacity.MaxUnits.Float64())
+	}
+	sort.SliceStable(report.Rows, func(i, j int) bool {
+		a, b := report.Rows[i], report.Rows[j]
+		if a.StoreID != nil && b.StoreID != nil && *a.StoreID != *b.StoreID {
+			return *a.StoreID < *b.StoreID
+		}
+		return a.Code < b.Code
+	})
+	return report, nil
+}
+
+func sumCapacities(capacities []ContainerCapacity) ContainerCapacity {
+	var total ContainerCapacity
+	weight, volume, units := true, true, true
+	for _, capacity := range capacities {
+		weight = weight && !capacity.MaxWeight.IsZero()
+		volume = volume && !capacity.MaxVolume.IsZero()
+		units = units && !capacity.MaxUnits.IsZero()
+		total.MaxWeight = total.MaxWeight.Add(capacity.MaxWeight)
+		total.MaxVolume = total.MaxVolume.Add(capacity.MaxVolume)
+		total.MaxUnits = total.MaxUnits.Add(capacity.MaxUnits)
+	}
+	if !weight {
+		total.MaxWeight = customtypes.Decimal{}
+	}
+	if !volume {
+		total.MaxVolume = customtypes.Decimal{}
+	}
+	if !units {
+		total.MaxUnits = customtypes.Decimal{}
+	}
+	return total
+}
+
+// usedPercent is rounded to two decimals. Percentages are for display only.
+func usedPercent(used, limit float64) *float64 {
+	if limit <= 0 {
+		return nil
+	}
+	percent := math.Round(used/limit*10000) / 100
+	return &percent
+}
@@ -3,13 +3,18 @@ package rest
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
 	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
 	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+	"github.com/vamika-digital/wms-api-server/internal/utility/etag"
 	"github.com/vamika-digital/wms-api-server/internal/utility/valueobjects"
 )
 
@@ -21,6 +26,8 @@ func NewContainerHandler(useCase usecase.ContainerUseCase) *ContainerHandler {
 	return &ContainerHandler{UseCase: useCase}
 }
 
+// CreateContainer numbers a container sent without a code from the number
+// series of its type.
 func (handler *ContainerHandler) CreateContainer(w http.ResponseWriter, r *http.Request) {
 	var container domain.Container = domain.NewContainerWithDefaults()
 
@@ -35,11 +42,90 @@ func (handler *ContainerHandler) CreateContainer(w http.ResponseWriter, r *http.
 	}
 
 	if err := handler.UseCase.CreateContainer(&container); err != nil {
+		handler.handleWriteError(w, 0, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(container); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// GenerateContainers creates a batch of containers whose codes come from the
+// number series of their type, so that their labels can be printed at once.
+func (handler *ContainerHandler) GenerateContainers(w http.ResponseWriter, r *http.Request) {
+	form := domain.GenerateContainersForm{Type: domain.PALLET_TYPE}
+	if err := json.NewDecoder(r.Body).Decode(&form); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	container := domain.Container{Type: form.Type}
+	if err := container.ValidateType(); err != nil {
+		http.Error(w, "type should be valid", http.StatusBadRequest)
+		return
+	}
+	if form.Count < 1 || form.Count > domain.MaxGeneratedCodes {
+		http.Error(w, fmt.Sprintf("count must be between 1 and %d", domain.MaxGeneratedCodes), http.StatusBadRequest)
+		return
+	}
+
+	containers, err := handler.UseCase.GenerateContainers(&form)
+	if err != nil {
+		handler.handleWriteError(w, 0, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(containers); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// PreviewLayout returns the racks and bins a layout spec expands to, without
+// touching the database.
+func (handler *ContainerHandler) PreviewLayout(w http.ResponseWriter, r *http.Request) {
+	var spec domain.LayoutSpec
+	if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	plan, err := handler.UseCase.PreviewLayout(&spec)
+	if err != nil {
+		handler.handleWriteError(w, 0, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(plan); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// ApplyLayout creates the racks and bins of a layout spec in one transaction.
+// Containers left by an earlier run are kept, and ?dry_run=true reports what
+// would be created without writing anything.
+func (handler *ContainerHandler) ApplyLayout(w http.ResponseWriter, r *http.Request) {
+	var spec domain.LayoutSpec
+	if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+
+	plan, err := handler.UseCase.ApplyLayout(&spec, dryRun)
+	if err != nil {
+		handler.handleWriteError(w, 0, err)
+		return
+	}
+
+	if !dryRun && plan.Created > 0 {
+		w.WriteHeader(http.StatusCreated)
+	}
+	if err := json.NewEncoder(w).Encode(plan); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
 }
 
 func (handler *ContainerHandler) UpdateContainer(w http.ResponseWriter, r *http.Request) {
@@ -60,13 +146,30 @@ func (handler *ContainerHandler) UpdateContainer(w http.ResponseWriter, r *http.
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if container.Code == "" {
+		http.Error(w, "code is required", http.StatusBadRequest)
+		return
+	}
+	if container.Name == "" {
+		http.Error(w, "name is required", http.StatusBadRequest)
+		return
+	}
 
 	container.ID = int64(id)
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if version > 0 {
+		container.Version = version
+	}
 	if err := handler.UseCase.UpdateContainer(&container); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		handler.handleWriteError(w, container.ID, err)
 		return
 	}
 
+	etag.Set(w, container.Version)
 	w.WriteHeader(http.StatusOK)
 }
 
@@ -78,14 +181,193 @@ func (handler *ContainerHandler) DeleteContainer(w http.ResponseWriter, r *http.
 		return
 	}
 
-	if err := handler.UseCase.DeleteContainer(id); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.DeleteContainer(id, version); err != nil {
+		handler.handleWriteError(w, id, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 }
 
+type moveContainerRequest struct {
+	ParentID *int64 `json:"parent_id"`
+	StoreID  *int64 `json:"store_id"`
+}
+
+// MoveContainer moves the container with everything in it. The body names the
+// new parent, or only a store to place the container directly in it.
+func (handler *ContainerHandler) MoveContainer(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Container ID", http.StatusBadRequest)
+		return
+	}
+
+	var request moveContainerRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	container, err := handler.UseCase.MoveContainer(id, request.ParentID, request.StoreID, version)
+	if err != nil {
+		handler.handleWriteError(w, id, err)
+		return
+	}
+
+	setWarnings(w, container.Warnings)
+	etag.Set(w, container.Version)
+	if err := json.NewEncoder(w).Encode(container); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// ChangeContainerStatus moves the container along its lifecycle, e.g. from
+// active to damaged. The body names the new status, why and who changed it.
+func (handler *ContainerHandler) ChangeContainerStatus(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Container ID", http.StatusBadRequest)
+		return
+	}
+
+	var form domain.ContainerStatusForm
+	if err := json.NewDecoder(r.Body).Decode(&form); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	if err := form.Status.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if form.ChangedBy == "" {
+		http.Error(w, "changed_by is required", http.StatusBadRequest)
+		return
+	}
+
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	container, err := handler.UseCase.ChangeContainerStatus(id, &form, version)
+	if err != nil {
+		handler.handleWriteError(w, id, err)
+		return
+	}
+
+	etag.Set(w, container.Version)
+	if err := json.NewEncoder(w).Encode(container); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// GetContainerHistory returns the timeline of a container, oldest first. It
+// can be narrowed with event and with from and to, which take RFC 3339
+// timestamps or dates.
+func (handler *ContainerHandler) GetContainerHistory(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Container ID", http.StatusBadRequest)
+		return
+	}
+	handler.writeHistory(w, r, func(page int, pageSize int, filter repository.ContainerEventFilterOptions) ([]*domain.ContainerEvent, int, error) {
+		return handler.UseCase.GetContainerHistory(id, page, pageSize, filter)
+	})
+}
+
+func (handler *ContainerHandler) GetContainerHistoryByCode(w http.ResponseWriter, r *http.Request) {
+	code := mux.Vars(r)["code"]
+	handler.writeHistory(w, r, func(page int, pageSize int, filter repository.ContainerEventFilterOptions) ([]*domain.ContainerEvent, int, error) {
+		return handler.UseCase.GetContainerHistoryByCode(code, page, pageSize, filter)
+	})
+}
+
+type historySource func(page int, pageSize int, filter repository.ContainerEventFilterOptions) ([]*domain.ContainerEvent, int, error)
+
+func (handler *ContainerHandler) writeHistory(w http.ResponseWriter, r *http.Request, source historySource) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	filterOptions := repository.ContainerEventFilterOptions{Event: strings.ToUpper(r.URL.Query().Get("event"))}
+	if filterOptions.Event != "" {
+		if err := domain.ContainerEventType(filterOptions.Event).Validate(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if err := filterOptions.SetRange(r.URL.Query().Get("from"), r.URL.Query().Get("to")); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	events, total, err := source(page, pageSize, filterOptions)
+	if err != nil {
+		if errors.Is(err, customerrors.ErrResourceNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := valueobjects.PaginatedResponse{
+		Data:       events,
+		TotalItems: total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (total + pageSize - 1) / pageSize,
+	}
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *ContainerHandler) GetContainerTree(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Container ID", http.StatusBadRequest)
+		return
+	}
+
+	tree, err := handler.UseCase.GetContainerTree(id)
+	if err != nil {
+		if errors.Is(err, customerrors.ErrResourceNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(tree); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
 func (handler *ContainerHandler) GetContainerByID(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	id, err := strconv.ParseInt(params["id"], 10, 64)
@@ -100,6 +382,7 @@ func (handler *ContainerHandler) GetContainerByID(w http.ResponseWriter, r *http
 		return
 	}
 
+	etag.Set(w, container.Version)
 	if err := json.NewEncoder(w).Encode(container); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
@@ -127,7 +410,12 @@ func (handler *ContainerHandler) GetAllContainers(w http.ResponseWriter, r *http
 		Code:   r.URL.Query().Get("code"),
 		Name:   r.URL.Query().Get("name"),
 		Status: r.URL.Query().Get("status"),
+		Under:  r.URL.Query().Get("under"),
 	}
+	parentID, _ := strconv.ParseInt(r.URL.Query().Get("parent_id"), 10, 64)
+	filterOptions.ParentID = customtypes.NullableInt64(parentID)
+	storeID, _ := strconv.ParseInt(r.URL.Query().Get("store_id"), 10, 64)
+	filterOptions.StoreID = customtypes.NullableInt64(storeID)
 
 	containers, totalContainers, err := handler.UseCase.GetAllContainers(page, pageSize, sort, filterOptions)
 	if err != nil {
@@ -156,19 +444,40 @@ func (handler *ContainerHandler) GetAllContainers(w http.ResponseWriter, r *http
 	}
 }
 
+func (handler *ContainerHandler) handleWriteError(w http.ResponseWriter, containerID int64, err error) {
+	current := func() (int64, interface{}, error) {
+		container, err := handler.UseCase.GetContainerByID(containerID)
+		if err != nil {
+			return 0, nil, err
+		}
+		return container.Version, container, nil
+	}
+	etag.WriteError(w, err, current, map[error]int{
+		domain.ErrContainerCycle:            http.StatusConflict,
+		domain.ErrContainerHasChildren:      http.StatusConflict,
+		domain.ErrCapacityExceeded:          http.StatusConflict,
+		domain.ErrDuplicateContainerCode:    http.StatusConflict,
+		domain.ErrContainerUnavailable:      http.StatusConflict,
+		domain.ErrContainerStatusTransition: http.StatusConflict,
+		domain.ErrContainerNotEmpty:         http.StatusConflict,
+		domain.ErrLayoutConflict:            http.StatusConflict,
+		domain.ErrInvalidContainerParent:    http.StatusBadRequest,
+		domain.ErrContainerMoveTarget:       http.StatusBadRequest,
+		domain.ErrNoNumberSeries:            http.StatusBadRequest,
+		domain.ErrSeriesStoreRequired:       http.StatusBadRequest,
+		domain.ErrInvalidContainerStatus:    http.StatusBadRequest,
+		domain.ErrInvalidLayout:             http.StatusBadRequest,
+		domain.ErrLayoutDuplicateCode:       http.StatusBadRequest,
+	})
+}
+
 func validateContainer(container *domain.Container) error {
 	typeErr := container.ValidateType()
 	if typeErr != nil {
 		return errors.New("type should be valid")
 	}
-	if container.Code == "" {
-		return errors.New("code is required")
-	}
-	if container.Name == "" {
-		return errors.New("name is required")
-	}
-	if container.Status == "" {
-		return errors.New("status is required")
+	if err := container.Capacity.Validate(); err != nil {
+		return err
 	}
 	return nil
 }
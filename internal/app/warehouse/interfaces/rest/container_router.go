@@ -25,7 +25,15 @@ func (u *ContainerModule) RegisterRoutes(r *mux.Router) {
 	subRouter := r.PathPrefix("/containers").Subrouter()
 	subRouter.HandleFunc("", u.Handler.CreateContainer).Methods(http.MethodPost)
 	subRouter.HandleFunc("", u.Handler.GetAllContainers).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/generate", u.Handler.GenerateContainers).Methods(http.MethodPost)
+	subRouter.HandleFunc("/layout", u.Handler.ApplyLayout).Methods(http.MethodPost)
+	subRouter.HandleFunc("/layout/preview", u.Handler.PreviewLayout).Methods(http.MethodPost)
 	subRouter.HandleFunc("/{id}", u.Handler.GetContainerByID).Methods(http.MethodGet, http.MethodOptions)
 	subRouter.HandleFunc("/{id}", u.Handler.UpdateContainer).Methods(http.MethodPut)
 	subRouter.HandleFunc("/{id}", u.Handler.DeleteContainer).Methods(http.MethodDelete)
+	subRouter.HandleFunc("/{id}/tree", u.Handler.GetContainerTree).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/{id}/move", u.Handler.MoveContainer).Methods(http.MethodPost)
+	subRouter.HandleFunc("/{id}/status", u.Handler.ChangeContainerStatus).Methods(http.MethodPost)
+	subRouter.HandleFunc("/{id}/history", u.Handler.GetContainerHistory).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/code/{code}/history", u.Handler.GetContainerHistoryByCode).Methods(http.MethodGet, http.MethodOptions)
 }
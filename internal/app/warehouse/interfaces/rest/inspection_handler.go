@@ -0,0 +1,467 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/internal/utility/etag"
+	"github.com/vamika-digital/wms-api-server/internal/utility/valueobjects"
+)
+
+type InspectionHandler struct {
+	UseCase usecase.InspectionUseCase
+}
+
+func NewInspectionHandler(useCase usecase.InspectionUseCase) *InspectionHandler {
+	return &InspectionHandler{UseCase: useCase}
+}
+
+func (handler *InspectionHandler) CreateInspectionParameter(w http.ResponseWriter, r *http.Request) {
+	var parameter *domain.InspectionParameter = domain.NewInspectionParameterWithDefaults()
+
+	if err := json.NewDecoder(r.Body).Decode(parameter); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validateInspectionParameter(parameter); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.CreateInspectionParameter(parameter); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (handler *InspectionHandler) UpdateInspectionParameter(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.Atoi(params["id"])
+	if err != nil {
+		http.Error(w, "Invalid Inspection Parameter ID", http.StatusBadRequest)
+		return
+	}
+
+	var parameter *domain.InspectionParameter = domain.NewInspectionParameterWithDefaults()
+	if err := json.NewDecoder(r.Body).Decode(parameter); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := validateInspectionParameter(parameter); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	parameter.ID = int64(id)
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if version > 0 {
+		parameter.Version = version
+	}
+	if err := handler.UseCase.UpdateInspectionParameter(parameter); err != nil {
+		handler.handleWriteError(w, parameter.ID, err)
+		return
+	}
+
+	etag.Set(w, parameter.Version)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (handler *InspectionHandler) DeleteInspectionParameter(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "invalid Inspection Parameter ID", http.StatusBadRequest)
+		return
+	}
+
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.DeleteInspectionParameter(id, version); err != nil {
+		handler.handleWriteError(w, id, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (handler *InspectionHandler) GetInspectionParameterByID(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Inspection Parameter ID", http.StatusBadRequest)
+		return
+	}
+
+	parameter, err := handler.UseCase.GetInspectionParameterByID(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	etag.Set(w, parameter.Version)
+	if err := json.NewEncoder(w).Encode(parameter); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *InspectionHandler) GetAllInspectionParameters(w http.ResponseWriter, r *http.Request) {
+	pageQuery := r.URL.Query().Get("page")
+	pageSizeQuery := r.URL.Query().Get("pageSize")
+	sort := r.URL.Query().Get("sort")
+
+	// Default values for pagination
+	page := 1
+	pageSize := 10
+
+	if pageQuery != "" {
+		page, _ = strconv.Atoi(pageQuery)
+	}
+	if pageSizeQuery != "" {
+		pageSize, _ = strconv.Atoi(pageSizeQuery)
+	}
+
+	// Extract filter parameters
+	filterOptions := repository.InspectionParameterFilterOptions{
+		Status: r.URL.Query().Get("status"),
+	}
+	filterOptions.ProductID, _ = strconv.ParseInt(r.URL.Query().Get("product_id"), 10, 64)
+
+	parameters, totalParameters, err := handler.UseCase.GetAllInspectionParameters(page, pageSize, sort, filterOptions)
+	if err != nil {
+		// Handle error
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	if parameters == nil {
+		parameters = []*domain.InspectionParameter{}
+	}
+
+	// Create a response with pagination details
+	response := valueobjects.PaginatedResponse{
+		Data:       parameters,
+		TotalItems: totalParameters,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (totalParameters + pageSize - 1) / pageSize, // Calculate total pages
+	}
+
+	// Respond with the fetched parameters and pagination details
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *InspectionHandler) OpenInspection(w http.ResponseWriter, r *http.Request) {
+	var form struct {
+		InventoryID int64  `json:"inventory_id"`
+		Inspector   string `json:"inspector"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&form); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	if form.InventoryID <= 0 {
+		http.Error(w, "inventory_id is required", http.StatusBadRequest)
+		return
+	}
+
+	inspection, err := handler.UseCase.OpenInspection(form.InventoryID, form.Inspector)
+	if err != nil {
+		handler.handleTransitionError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(inspection); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *InspectionHandler) RecordInspectionResults(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Inspection ID", http.StatusBadRequest)
+		return
+	}
+
+	var form struct {
+		RecordedBy string                     `json:"recorded_by"`
+		Results    []*domain.InspectionResult `json:"results"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&form); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	if form.RecordedBy == "" {
+		http.Error(w, "recorded_by is required", http.StatusBadRequest)
+		return
+	}
+	if len(form.Results) == 0 {
+		http.Error(w, "results are required", http.StatusBadRequest)
+		return
+	}
+
+	inspection, err := handler.UseCase.RecordInspectionResults(id, form.Results, form.RecordedBy)
+	if err != nil {
+		handler.handleTransitionError(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(inspection); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *InspectionHandler) ReleaseInspection(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Inspection ID", http.StatusBadRequest)
+		return
+	}
+
+	form, err := decodeInspectionDecision(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	inspection, err := handler.UseCase.ReleaseInspection(id, form.Inspector, form.Remarks)
+	if err != nil {
+		handler.handleTransitionError(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(inspection); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *InspectionHandler) RejectInspection(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Inspection ID", http.StatusBadRequest)
+		return
+	}
+
+	form, err := decodeInspectionDecision(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	inspection, err := handler.UseCase.RejectInspection(id, form.Inspector, form.Remarks, form.QuarantineStoreID)
+	if err != nil {
+		handler.handleTransitionError(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(inspection); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *InspectionHandler) GetInspectionByID(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Inspection ID", http.StatusBadRequest)
+		return
+	}
+
+	inspection, err := handler.UseCase.GetInspectionByID(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(inspection); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *InspectionHandler) GetAllInspections(w http.ResponseWriter, r *http.Request) {
+	pageQuery := r.URL.Query().Get("page")
+	pageSizeQuery := r.URL.Query().Get("pageSize")
+	sort := r.URL.Query().Get("sort")
+
+	// Default values for pagination
+	page := 1
+	pageSize := 10
+
+	if pageQuery != "" {
+		page, _ = strconv.Atoi(pageQuery)
+	}
+	if pageSizeQuery != "" {
+		pageSize, _ = strconv.Atoi(pageSizeQuery)
+	}
+
+	// Extract filter parameters
+	filterOptions := repository.InspectionFilterOptions{}
+	filterOptions.SetStatuses(r.URL.Query().Get("status"))
+	filterOptions.InventoryID, _ = strconv.ParseInt(r.URL.Query().Get("inventory_id"), 10, 64)
+	filterOptions.ProductID, _ = strconv.ParseInt(r.URL.Query().Get("product_id"), 10, 64)
+
+	inspections, totalInspections, err := handler.UseCase.GetAllInspections(page, pageSize, sort, filterOptions)
+	if err != nil {
+		// Handle error
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	if inspections == nil {
+		inspections = []*domain.Inspection{}
+	}
+
+	// Create a response with pagination details
+	response := valueobjects.PaginatedResponse{
+		Data:       inspections,
+		TotalItems: totalInspections,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (totalInspections + pageSize - 1) / pageSize, // Calculate total pages
+	}
+
+	// Respond with the fetched inspections and pagination details
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *InspectionHandler) HoldInventory(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Inventory ID", http.StatusBadRequest)
+		return
+	}
+
+	inventory, err := handler.UseCase.HoldInventory(id)
+	if err != nil {
+		handler.handleTransitionError(w, err)
+		return
+	}
+
+	etag.Set(w, inventory.Version)
+	if err := json.NewEncoder(w).Encode(inventory); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// QuarantineInventory moves a rejected lot to the store given in the body or
+// to the configured quarantine store.
+func (handler *InspectionHandler) QuarantineInventory(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Inventory ID", http.StatusBadRequest)
+		return
+	}
+
+	var form struct {
+		StoreID int64 `json:"store_id"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&form); err != nil {
+			http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	inventory, err := handler.UseCase.QuarantineInventory(id, form.StoreID)
+	if err != nil {
+		handler.handleTransitionError(w, err)
+		return
+	}
+
+	etag.Set(w, inventory.Version)
+	if err := json.NewEncoder(w).Encode(inventory); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+type inspectionDecisionForm struct {
+	Inspector         string `json:"inspector"`
+	Remarks           string `json:"remarks"`
+	QuarantineStoreID int64  `json:"quarantine_store_id"`
+}
+
+func decodeInspectionDecision(r *http.Request) (*inspectionDecisionForm, error) {
+	form := &inspectionDecisionForm{}
+	if err := json.NewDecoder(r.Body).Decode(form); err != nil {
+		return nil, errors.New("Invalid JSON body")
+	}
+	if form.Inspector == "" {
+		return nil, errors.New("inspector is required")
+	}
+	return form, nil
+}
+
+func (handler *InspectionHandler) handleWriteError(w http.ResponseWriter, parameterID int64, err error) {
+	current := func() (int64, interface{}, error) {
+		parameter, err := handler.UseCase.GetInspectionParameterByID(parameterID)
+		if err != nil {
+			return 0, nil, err
+		}
+		return parameter.Version, parameter, nil
+	}
+	etag.WriteError(w, err, current, nil)
+}
+
+func (handler *InspectionHandler) handleTransitionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, customerrors.ErrResourceNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, domain.ErrInspectionClosed),
+		errors.Is(err, domain.ErrInspectionFailed),
+		errors.Is(err, domain.ErrInspectionPending),
+		errors.Is(err, domain.ErrInvalidQCTransition),
+		errors.Is(err, domain.ErrQuarantineNotRejected),
+		errors.Is(err, customerrors.ErrVersionConflict):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, domain.ErrQuarantineStoreRequired):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func validateInspectionParameter(parameter *domain.InspectionParameter) error {
+	if parameter.ProductID <= 0 {
+		return errors.New("product is required")
+	}
+	if parameter.Name == "" {
+		return errors.New("name is required")
+	}
+	if parameter.Status == "" {
+		return errors.New("status is required")
+	}
+	if parameter.MinValue != nil && parameter.MaxValue != nil && parameter.MinValue.Cmp(*parameter.MaxValue) > 0 {
+		return errors.New("min value cannot be greater than max value")
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/config"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+type InspectionModule struct {
+	Handler *InspectionHandler
+}
+
+func NewInspectionModule(db database.Connection) *InspectionModule {
+	inspectionRepo := repository.NewInspectionRepository(db)
+	parameterRepo := repository.NewInspectionParameterRepository(db)
+	inventoryRepo := repository.NewInventoryRepository(db)
+	inspectionUsecase := usecase.NewInspectionUseCase(inspectionRepo, parameterRepo, inventoryRepo, config.AppConfig.Quality.QuarantineStoreID)
+	inspectionHandler := NewInspectionHandler(inspectionUsecase)
+
+	return &InspectionModule{Handler: inspectionHandler}
+}
+
+func (u *InspectionModule) RegisterRoutes(r *mux.Router) {
+	parameterRouter := r.PathPrefix("/inspection-parameters").Subrouter()
+	parameterRouter.HandleFunc("", u.Handler.CreateInspectionParameter).Methods(http.MethodPost)
+	parameterRouter.HandleFunc("", u.Handler.GetAllInspectionParameters).Methods(http.MethodGet, http.MethodOptions)
+	parameterRouter.HandleFunc("/{id}", u.Handler.GetInspectionParameterByID).Methods(http.MethodGet, http.MethodOptions)
+	parameterRouter.HandleFunc("/{id}", u.Handler.UpdateInspectionParameter).Methods(http.MethodPut)
+	parameterRouter.HandleFunc("/{id}", u.Handler.DeleteInspectionParameter).Methods(http.MethodDelete)
+
+	inspectionRouter := r.PathPrefix("/inspections").Subrouter()
+	inspectionRouter.HandleFunc("", u.Handler.OpenInspection).Methods(http.MethodPost)
+	inspectionRouter.HandleFunc("", u.Handler.GetAllInspections).Methods(http.MethodGet, http.MethodOptions)
+	inspectionRouter.HandleFunc("/{id}", u.Handler.GetInspectionByID).Methods(http.MethodGet, http.MethodOptions)
+	inspectionRouter.HandleFunc("/{id}/results", u.Handler.RecordInspectionResults).Methods(http.MethodPost)
+	inspectionRouter.HandleFunc("/{id}/release", u.Handler.ReleaseInspection).Methods(http.MethodPost)
+	inspectionRouter.HandleFunc("/{id}/reject", u.Handler.RejectInspection).Methods(http.MethodPost)
+
+	inventoryRouter := r.PathPrefix("/inventories").Subrouter()
+	inventoryRouter.HandleFunc("/{id}/hold", u.Handler.HoldInventory).Methods(http.MethodPost)
+	inventoryRouter.HandleFunc("/{id}/quarantine", u.Handler.QuarantineInventory).Methods(http.MethodPost)
+}
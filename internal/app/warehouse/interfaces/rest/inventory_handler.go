@@ -10,6 +10,10 @@ import (
 	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
 	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
 	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/internal/middlewares"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+	"github.com/vamika-digital/wms-api-server/internal/utility/etag"
 	"github.com/vamika-digital/wms-api-server/internal/utility/valueobjects"
 )
 
@@ -22,7 +26,6 @@ func NewInventoryHandler(useCase usecase.InventoryUseCase) *InventoryHandler {
 }
 
 // subRouter.HandleFunc("/raw-material", u.Handler.CreateInventoryForRawMaterial).Methods(http.MethodPost)
-// subRouter.HandleFunc("/finished-goods", u.Handler.CreateInventoryForFinishedGoods).Methods(http.MethodPost)
 
 func (handler *InventoryHandler) CreateInventoryForRawMaterial(w http.ResponseWriter, r *http.Request) {
 	var inventoryForm *domain.InventoryFormRawMaterial = &domain.InventoryFormRawMaterial{}
@@ -45,6 +48,37 @@ func (handler *InventoryHandler) CreateInventoryForRawMaterial(w http.ResponseWr
 	w.WriteHeader(http.StatusCreated)
 }
 
+func (handler *InventoryHandler) CreateInventoryForFinishedGoods(w http.ResponseWriter, r *http.Request) {
+	var inventoryForm *domain.InventoryFormFinishedGoods = &domain.InventoryFormFinishedGoods{}
+
+	if err := json.NewDecoder(r.Body).Decode(inventoryForm); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validateInventoryFormFinishedGoods(inventoryForm); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := authenticateShiftOverride(r, inventoryForm.ShiftOverride); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	inventory, err := handler.UseCase.CreateInventoryForFinishedGoods(inventoryForm)
+	if err != nil {
+		handler.handleWriteError(w, 0, err)
+		return
+	}
+
+	setWarnings(w, inventory.Warnings)
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(inventory); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
 func (handler *InventoryHandler) CreateInventory(w http.ResponseWriter, r *http.Request) {
 	var inventory *domain.Inventory = domain.NewInventoryWithDefaults()
 
@@ -58,11 +92,17 @@ func (handler *InventoryHandler) CreateInventory(w http.ResponseWriter, r *http.
 		return
 	}
 
+	if err := authenticateShiftOverride(r, inventory.ShiftOverride); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
 	if err := handler.UseCase.CreateInventory(inventory); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		handler.handleWriteError(w, inventory.ID, err)
 		return
 	}
 
+	setWarnings(w, inventory.Warnings)
 	w.WriteHeader(http.StatusCreated)
 }
 
@@ -86,11 +126,21 @@ func (handler *InventoryHandler) UpdateInventory(w http.ResponseWriter, r *http.
 	}
 
 	inventory.ID = int64(id)
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if version > 0 {
+		inventory.Version = version
+	}
 	if err := handler.UseCase.UpdateInventory(inventory); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		handler.handleWriteError(w, inventory.ID, err)
 		return
 	}
 
+	setWarnings(w, inventory.Warnings)
+	etag.Set(w, inventory.Version)
 	w.WriteHeader(http.StatusOK)
 }
 
@@ -102,8 +152,14 @@ func (handler *InventoryHandler) DeleteInventory(w http.ResponseWriter, r *http.
 		return
 	}
 
-	if err := handler.UseCase.DeleteInventory(id); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.DeleteInventory(id, version); err != nil {
+		handler.handleWriteError(w, id, err)
 		return
 	}
 
@@ -124,6 +180,7 @@ func (handler *InventoryHandler) GetInventoryByID(w http.ResponseWriter, r *http
 		return
 	}
 
+	etag.Set(w, inventory.Version)
 	if err := json.NewEncoder(w).Encode(inventory); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
@@ -145,15 +202,17 @@ func (handler *InventoryHandler) GetAllInventories(w http.ResponseWriter, r *htt
 		pageSize, _ = strconv.Atoi(pageSizeQuery)
 	}
 
-	// Extract filter parameters
-	filterOptions := repository.InventoryFilterOptions{
-		// Name: r.URL.Query().Get("name"),
-		// Status:    r.URL.Query().Get("status"),
-		// ProductID: r.URL.Query().Get("name"),
-		// RackID:    r.URL.Query().Get("name"),
-		// StoreID:   r.URL.Query().Get("name"),
+	filterOptions, err := parseInventoryFilterOptions(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Scanners page through large result sets with a cursor instead of a page number
+	if r.URL.Query().Has("cursor") {
+		handler.getInventoriesByCursor(w, pageSize, sort, r.URL.Query().Get("cursor"), filterOptions)
+		return
 	}
-	// filterOptions.SetOwnerID(r.URL.Query().Get("owner_id"))
 
 	inventories, totalInventories, err := handler.UseCase.GetAllInventories(page, pageSize, sort, filterOptions)
 	if err != nil {
@@ -182,10 +241,258 @@ func (handler *InventoryHandler) GetAllInventories(w http.ResponseWriter, r *htt
 	}
 }
 
+func (handler *InventoryHandler) getInventoriesByCursor(w http.ResponseWriter, pageSize int, sort string, cursor string, filterOptions repository.InventoryFilterOptions) {
+	inventories, nextCursor, err := handler.UseCase.GetInventoriesByCursor(pageSize, sort, cursor, filterOptions)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if inventories == nil {
+		inventories = []*domain.Inventory{}
+	}
+
+	response := valueobjects.CursorPaginatedResponse{
+		Data:       inventories,
+		PageSize:   len(inventories),
+		NextCursor: nextCursor,
+		HasMore:    nextCursor != "",
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// GetSerialHistory looks a unit up by its serial number and returns its
+// current lot and location together with everything that happened to it.
+func (handler *InventoryHandler) GetSerialHistory(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	history, err := handler.UseCase.GetSerialHistory(params["serial"])
+	if err != nil {
+		if errors.Is(err, customerrors.ErrResourceNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(history); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *InventoryHandler) GetAllSerials(w http.ResponseWriter, r *http.Request) {
+	pageQuery := r.URL.Query().Get("page")
+	pageSizeQuery := r.URL.Query().Get("pageSize")
+	sort := r.URL.Query().Get("sort")
+
+	// Default values for pagination
+	page := 1
+	pageSize := 10
+
+	if pageQuery != "" {
+		page, _ = strconv.Atoi(pageQuery)
+	}
+	if pageSizeQuery != "" {
+		pageSize, _ = strconv.Atoi(pageSizeQuery)
+	}
+
+	// Extract filter parameters
+	filterOptions := repository.SerialFilterOptions{
+		SerialPrefix: r.URL.Query().Get("serial"),
+	}
+	filterOptions.SetStatuses(r.URL.Query().Get("status"))
+	filterOptions.ProductID, _ = strconv.ParseInt(r.URL.Query().Get("product_id"), 10, 64)
+	filterOptions.InventoryID, _ = strconv.ParseInt(r.URL.Query().Get("inventory_id"), 10, 64)
+
+	serials, totalSerials, err := handler.UseCase.GetAllSerials(page, pageSize, sort, filterOptions)
+	if err != nil {
+		// Handle error
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	if serials == nil {
+		serials = []*domain.SerialNumber{}
+	}
+
+	// Create a response with pagination details
+	response := valueobjects.PaginatedResponse{
+		Data:       serials,
+		TotalItems: totalSerials,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (totalSerials + pageSize - 1) / pageSize, // Calculate total pages
+	}
+
+	// Respond with the fetched serials and pagination details
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// GetAllConsumptions lists the raw material backflushed by finished goods
+// receipts.
+func (handler *InventoryHandler) GetAllConsumptions(w http.ResponseWriter, r *http.Request) {
+	pageQuery := r.URL.Query().Get("page")
+	pageSizeQuery := r.URL.Query().Get("pageSize")
+	sort := r.URL.Query().Get("sort")
+
+	// Default values for pagination
+	page := 1
+	pageSize := 10
+
+	if pageQuery != "" {
+		page, _ = strconv.Atoi(pageQuery)
+	}
+	if pageSizeQuery != "" {
+		pageSize, _ = strconv.Atoi(pageSizeQuery)
+	}
+
+	// Extract filter parameters
+	filterOptions := repository.ConsumptionFilterOptions{
+		ProducedBatch: r.URL.Query().Get("produced_batch"),
+	}
+	filterOptions.ProducedInventoryID, _ = strconv.ParseInt(r.URL.Query().Get("produced_inventory_id"), 10, 64)
+	filterOptions.ComponentID, _ = strconv.ParseInt(r.URL.Query().Get("component_id"), 10, 64)
+	filterOptions.InventoryID, _ = strconv.ParseInt(r.URL.Query().Get("inventory_id"), 10, 64)
+
+	consumptions, totalConsumptions, err := handler.UseCase.GetAllConsumptions(page, pageSize, sort, filterOptions)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if consumptions == nil {
+		consumptions = []*domain.Consumption{}
+	}
+
+	response := valueobjects.PaginatedResponse{
+		Data:       consumptions,
+		TotalItems: totalConsumptions,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (totalConsumptions + pageSize - 1) / pageSize, // Calculate total pages
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func parseInventoryFilterOptions(r *http.Request) (repository.InventoryFilterOptions, error) {
+	if err := rejectAsOf(r); err != nil {
+		return repository.InventoryFilterOptions{}, err
+	}
+	query := r.URL.Query()
+	filterOptions := repository.InventoryFilterOptions{
+		BatchPrefix:    query.Get("batch"),
+		Machine:        query.Get("machine"),
+		Shift:          query.Get("shift"),
+		Supervisor:     query.Get("supervisor"),
+		UnderContainer: query.Get("under"),
+	}
+	filterOptions.SetStatuses(query.Get("status"))
+	filterOptions.SetQCStatuses(query.Get("qc_status"))
+	if err := filterOptions.SetProductIDs(query.Get("product_id")); err != nil {
+		return filterOptions, err
+	}
+	if err := filterOptions.SetStockInRange(query.Get("stockin_at_from"), query.Get("stockin_at_to")); err != nil {
+		return filterOptions, err
+	}
+	if err := filterOptions.SetStockOutRange(query.Get("stockout_at_from"), query.Get("stockout_at_to")); err != nil {
+		return filterOptions, err
+	}
+
+	idFilters := map[string]*customtypes.NullableInt64{
+		"container_id": &filterOptions.ContainerID,
+		"pallet_id":    &filterOptions.PalletID,
+		"bin_id":       &filterOptions.BinID,
+		"rack_id":      &filterOptions.RackID,
+		"store_id":     &filterOptions.StoreID,
+	}
+	for param, target := range idFilters {
+		value := query.Get(param)
+		if value == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return filterOptions, errors.New("invalid " + param)
+		}
+		*target = customtypes.NullableInt64(id)
+	}
+
+	return filterOptions, nil
+}
+
+func (handler *InventoryHandler) handleWriteError(w http.ResponseWriter, inventoryID int64, err error) {
+	current := func() (int64, interface{}, error) {
+		inventory, err := handler.UseCase.GetInventoryByID(inventoryID)
+		if err != nil {
+			return 0, nil, err
+		}
+		return inventory.Version, inventory, nil
+	}
+	etag.WriteError(w, err, current, map[error]int{
+		domain.ErrInventoryNotReleased:       http.StatusConflict,
+		domain.ErrDuplicateSerial:            http.StatusConflict,
+		domain.ErrInventoryHasSerials:        http.StatusConflict,
+		domain.ErrInsufficientComponentStock: http.StatusConflict,
+		domain.ErrCapacityExceeded:           http.StatusConflict,
+		domain.ErrContainerUnavailable:       http.StatusConflict,
+		domain.ErrInventoryInTransit:         http.StatusConflict,
+		domain.ErrSerialsRequired:            http.StatusBadRequest,
+		domain.ErrSerialCountMismatch:        http.StatusBadRequest,
+		domain.ErrInvalidSerialPattern:       http.StatusBadRequest,
+		domain.ErrTooManySerials:             http.StatusBadRequest,
+		domain.ErrLocationMismatch:           http.StatusBadRequest,
+		domain.ErrUnknownShift:               http.StatusBadRequest,
+		domain.ErrShiftOverrideRequired:      http.StatusBadRequest,
+		customtypes.ErrDecimalOverflow:       http.StatusBadRequest,
+		domain.ErrShiftOverrideNotAuthorized: http.StatusForbidden,
+	})
+}
+
+// authenticateShiftOverride takes the approver of a shift override from the
+// request's access token rather than from the body.
+func authenticateShiftOverride(r *http.Request, override *domain.ShiftOverride) error {
+	if override == nil {
+		return nil
+	}
+	username, err := middlewares.AuthenticatedUsername(r)
+	if err != nil {
+		return err
+	}
+	override.Approver = username
+	return nil
+}
+
+// setWarnings reports capacity limits that were exceeded but only warned
+// about as HTTP warning headers.
+func setWarnings(w http.ResponseWriter, warnings []string) {
+	for _, warning := range warnings {
+		w.Header().Add("Warning", "199 - "+strconv.Quote(warning))
+	}
+}
+
 func validateInventory(inventory *domain.Inventory) error {
 	if inventory.Status == "" {
 		return errors.New("status is required")
 	}
+	if inventory.Quantity.Sign() < 0 {
+		return errors.New("quantity cannot be negative")
+	}
+	if err := inventory.Quantity.ValidateForUnit(inventory.Unit); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -196,7 +503,23 @@ func validateInventoryFormRawMaterial(inventory *domain.InventoryFormRawMaterial
 	if inventory.Pallet == "" {
 		return errors.New("pallet code is required")
 	}
-	if inventory.Quantity <= 0 {
+	if inventory.Quantity.Sign() <= 0 {
+		return errors.New("quantity is required")
+	}
+	return nil
+}
+
+func validateInventoryFormFinishedGoods(inventory *domain.InventoryFormFinishedGoods) error {
+	if inventory.ProductID <= 0 {
+		return errors.New("product is required")
+	}
+	if inventory.Batch == "" {
+		return errors.New("batch is required")
+	}
+	if inventory.Quantity.Sign() < 0 {
+		return errors.New("quantity cannot be negative")
+	}
+	if inventory.Quantity.IsZero() && len(inventory.Serials) == 0 {
 		return errors.New("quantity is required")
 	}
 	return nil
@@ -0,0 +1,181 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/internal/utility/spreadsheet"
+	"github.com/vamika-digital/wms-api-server/internal/utility/valueobjects"
+)
+
+// maxImportFileSize is the largest accepted upload, in bytes.
+const maxImportFileSize = 32 << 20
+
+type InventoryImportHandler struct {
+	UseCase usecase.InventoryImportUseCase
+	Worker  *usecase.InventoryImportWorker
+}
+
+func NewInventoryImportHandler(useCase usecase.InventoryImportUseCase, worker *usecase.InventoryImportWorker) *InventoryImportHandler {
+	return &InventoryImportHandler{UseCase: useCase, Worker: worker}
+}
+
+// CreateImport accepts a multipart upload with a "file" part holding a CSV or
+// XLSX file and an optional "created_by" field. The import runs in the
+// background; poll GetImportByID for its status and row errors.
+func (handler *InventoryImportHandler) CreateImport(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportFileSize)
+	if err := r.ParseMultipartForm(maxImportFileSize); err != nil {
+		http.Error(w, "Invalid upload: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	format := r.FormValue("format")
+	if format == "" {
+		format = spreadsheet.FormatOf(header.Filename)
+	}
+	if format != spreadsheet.FormatCSV && format != spreadsheet.FormatXLSX {
+		http.Error(w, "file must be a csv or xlsx file", http.StatusBadRequest)
+		return
+	}
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	table, err := spreadsheet.Read(bytes.NewReader(content), int64(len(content)), format)
+	if err != nil {
+		http.Error(w, "Invalid "+format+" file: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	inventoryImport, err := handler.UseCase.CreateImport(header.Filename, format, table, r.FormValue("created_by"))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidImportFile) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	handler.Worker.Notify()
+
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(inventoryImport); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *InventoryImportHandler) GetImportByID(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Import ID", http.StatusBadRequest)
+		return
+	}
+
+	inventoryImport, err := handler.UseCase.GetImportByID(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(inventoryImport); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *InventoryImportHandler) GetAllImports(w http.ResponseWriter, r *http.Request) {
+	pageQuery := r.URL.Query().Get("page")
+	pageSizeQuery := r.URL.Query().Get("pageSize")
+	sort := r.URL.Query().Get("sort")
+
+	// Default values for pagination
+	page := 1
+	pageSize := 10
+
+	if pageQuery != "" {
+		page, _ = strconv.Atoi(pageQuery)
+	}
+	if pageSizeQuery != "" {
+		pageSize, _ = strconv.Atoi(pageSizeQuery)
+	}
+
+	// Extract filter parameters
+	filterOptions := repository.InventoryImportFilterOptions{
+		Status: r.URL.Query().Get("status"),
+	}
+
+	imports, totalImports, err := handler.UseCase.GetAllImports(page, pageSize, sort, filterOptions)
+	if err != nil {
+		// Handle error
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	if imports == nil {
+		imports = []*domain.InventoryImport{}
+	}
+
+	// Create a response with pagination details
+	response := valueobjects.PaginatedResponse{
+		Data:       imports,
+		TotalItems: totalImports,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (totalImports + pageSize - 1) / pageSize, // Calculate total pages
+	}
+
+	// Respond with the fetched imports and pagination details
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// ResumeImport restarts an import that failed for a reason other than row
+// errors, such as a lost database connection.
+func (handler *InventoryImportHandler) ResumeImport(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Import ID", http.StatusBadRequest)
+		return
+	}
+
+	inventoryImport, err := handler.UseCase.ResumeImport(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, customerrors.ErrResourceNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, domain.ErrImportCompleted), errors.Is(err, domain.ErrImportHasErrors):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	handler.Worker.Notify()
+
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(inventoryImport); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
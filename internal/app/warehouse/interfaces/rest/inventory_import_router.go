@@ -0,0 +1,37 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/config"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+type InventoryImportModule struct {
+	Handler *InventoryImportHandler
+	Worker  *usecase.InventoryImportWorker
+}
+
+func NewInventoryImportModule(db database.Connection) *InventoryImportModule {
+	importRepo := repository.NewInventoryImportRepository(db)
+	scanRepo := repository.NewScanRepository(db)
+	containerRepo := repository.NewContainerRepository(db)
+	parameterRepo := repository.NewInspectionParameterRepository(db)
+	importUsecase := usecase.NewInventoryImportUseCase(importRepo, scanRepo, containerRepo, parameterRepo)
+	worker := usecase.NewInventoryImportWorker(importUsecase, time.Duration(config.AppConfig.Imports.Interval)*time.Second)
+	importHandler := NewInventoryImportHandler(importUsecase, worker)
+
+	return &InventoryImportModule{Handler: importHandler, Worker: worker}
+}
+
+func (u *InventoryImportModule) RegisterRoutes(r *mux.Router) {
+	subRouter := r.PathPrefix("/inventory-imports").Subrouter()
+	subRouter.HandleFunc("", u.Handler.CreateImport).Methods(http.MethodPost)
+	subRouter.HandleFunc("", u.Handler.GetAllImports).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/{id}", u.Handler.GetImportByID).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/{id}/resume", u.Handler.ResumeImport).Methods(http.MethodPost)
+}
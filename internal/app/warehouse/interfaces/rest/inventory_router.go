@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/config"
 	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
 	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
 	"github.com/vamika-digital/wms-api-server/pkg/database"
@@ -15,7 +16,7 @@ type InventoryModule struct {
 
 func NewInventoryModule(db database.Connection) *InventoryModule {
 	inventoryRepo := repository.NewInventoryRepository(db)
-	inventoryUsecase := usecase.NewInventoryUseCase(inventoryRepo)
+	inventoryUsecase := usecase.NewInventoryUseCase(inventoryRepo, repository.NewInspectionParameterRepository(db), repository.NewSerialRepository(db), repository.NewProductionRepository(db), config.AppConfig.Production.BackflushStoreIDs)
 	inventoryHandler := NewInventoryHandler(inventoryUsecase)
 
 	return &InventoryModule{Handler: inventoryHandler}
@@ -25,11 +26,17 @@ func (u *InventoryModule) RegisterRoutes(r *mux.Router) {
 	subRouter := r.PathPrefix("/inventories").Subrouter()
 
 	subRouter.HandleFunc("/raw-material", u.Handler.CreateInventoryForRawMaterial).Methods(http.MethodPost)
-	// subRouter.HandleFunc("/finished-goods", u.Handler.CreateInventoryForFinishedGoods).Methods(http.MethodPost)
+	subRouter.HandleFunc("/finished-goods", u.Handler.CreateInventoryForFinishedGoods).Methods(http.MethodPost)
 
 	subRouter.HandleFunc("", u.Handler.CreateInventory).Methods(http.MethodPost)
 	subRouter.HandleFunc("", u.Handler.GetAllInventories).Methods(http.MethodGet, http.MethodOptions)
 	subRouter.HandleFunc("/{id}", u.Handler.GetInventoryByID).Methods(http.MethodGet, http.MethodOptions)
 	subRouter.HandleFunc("/{id}", u.Handler.UpdateInventory).Methods(http.MethodPut)
 	subRouter.HandleFunc("/{id}", u.Handler.DeleteInventory).Methods(http.MethodDelete)
+
+	serialRouter := r.PathPrefix("/serials").Subrouter()
+	serialRouter.HandleFunc("", u.Handler.GetAllSerials).Methods(http.MethodGet, http.MethodOptions)
+	serialRouter.HandleFunc("/{serial}", u.Handler.GetSerialHistory).Methods(http.MethodGet, http.MethodOptions)
+
+	r.HandleFunc("/consumptions", u.Handler.GetAllConsumptions).Methods(http.MethodGet, http.MethodOptions)
 }
@@ -0,0 +1,276 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/internal/utility/etag"
+	"github.com/vamika-digital/wms-api-server/internal/utility/gs1"
+	"github.com/vamika-digital/wms-api-server/internal/utility/label"
+	"github.com/vamika-digital/wms-api-server/internal/utility/valueobjects"
+)
+
+type LabelHandler struct {
+	UseCase usecase.LabelUseCase
+}
+
+func NewLabelHandler(useCase usecase.LabelUseCase) *LabelHandler {
+	return &LabelHandler{UseCase: useCase}
+}
+
+func (handler *LabelHandler) CreateLabelTemplate(w http.ResponseWriter, r *http.Request) {
+	var labelTemplate *domain.LabelTemplate = domain.NewLabelTemplateWithDefaults()
+
+	if err := json.NewDecoder(r.Body).Decode(labelTemplate); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validateLabelTemplate(labelTemplate); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.CreateLabelTemplate(labelTemplate); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (handler *LabelHandler) UpdateLabelTemplate(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.Atoi(params["id"])
+	if err != nil {
+		http.Error(w, "Invalid Label Template ID", http.StatusBadRequest)
+		return
+	}
+
+	var labelTemplate *domain.LabelTemplate = domain.NewLabelTemplateWithDefaults()
+	if err := json.NewDecoder(r.Body).Decode(labelTemplate); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := validateLabelTemplate(labelTemplate); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	labelTemplate.ID = int64(id)
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if version > 0 {
+		labelTemplate.Version = version
+	}
+	if err := handler.UseCase.UpdateLabelTemplate(labelTemplate); err != nil {
+		handler.handleWriteError(w, labelTemplate.ID, err)
+		return
+	}
+
+	etag.Set(w, labelTemplate.Version)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (handler *LabelHandler) DeleteLabelTemplate(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "invalid Label Template ID", http.StatusBadRequest)
+		return
+	}
+
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.DeleteLabelTemplate(id, version); err != nil {
+		handler.handleWriteError(w, id, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (handler *LabelHandler) GetLabelTemplateByID(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Label Template ID", http.StatusBadRequest)
+		return
+	}
+
+	labelTemplate, err := handler.UseCase.GetLabelTemplateByID(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	etag.Set(w, labelTemplate.Version)
+	if err := json.NewEncoder(w).Encode(labelTemplate); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *LabelHandler) GetAllLabelTemplates(w http.ResponseWriter, r *http.Request) {
+	pageQuery := r.URL.Query().Get("page")
+	pageSizeQuery := r.URL.Query().Get("pageSize")
+	sort := r.URL.Query().Get("sort")
+
+	// Default values for pagination
+	page := 1
+	pageSize := 10
+
+	if pageQuery != "" {
+		page, _ = strconv.Atoi(pageQuery)
+	}
+	if pageSizeQuery != "" {
+		pageSize, _ = strconv.Atoi(pageSizeQuery)
+	}
+
+	// Extract filter parameters
+	filterOptions := repository.LabelTemplateFilterOptions{
+		Kind:   r.URL.Query().Get("kind"),
+		Status: r.URL.Query().Get("status"),
+	}
+
+	labelTemplates, totalLabelTemplates, err := handler.UseCase.GetAllLabelTemplates(page, pageSize, sort, filterOptions)
+	if err != nil {
+		// Handle error
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	if labelTemplates == nil {
+		labelTemplates = []*domain.LabelTemplate{}
+	}
+
+	// Create a response with pagination details
+	response := valueobjects.PaginatedResponse{
+		Data:       labelTemplates,
+		TotalItems: totalLabelTemplates,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (totalLabelTemplates + pageSize - 1) / pageSize, // Calculate total pages
+	}
+
+	// Respond with the fetched label templates and pagination details
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// GetContainerLabel renders the label of a container, e.g.
+// /labels/containers/12?format=zpl&template=pallet-large.
+func (handler *LabelHandler) GetContainerLabel(w http.ResponseWriter, r *http.Request) {
+	handler.writeLabel(w, r, handler.UseCase.GetContainerLabel, nil)
+}
+
+// PrintContainerLabel renders the label of a container like
+// GetContainerLabel and adds the print to the timeline of the container once
+// the label has rendered.
+func (handler *LabelHandler) PrintContainerLabel(w http.ResponseWriter, r *http.Request) {
+	handler.writeLabel(w, r, handler.UseCase.GetContainerLabel, handler.UseCase.RecordContainerLabelPrinted)
+}
+
+func (handler *LabelHandler) GetInventoryLabel(w http.ResponseWriter, r *http.Request) {
+	handler.writeLabel(w, r, handler.UseCase.GetInventoryLabel, nil)
+}
+
+func (handler *LabelHandler) GetProductLabel(w http.ResponseWriter, r *http.Request) {
+	handler.writeLabel(w, r, handler.UseCase.GetProductLabel, nil)
+}
+
+type labelSource func(id int64, templateName string) (*label.Layout, *label.Data, error)
+
+// labelPrinted records that the label rendered for id is being printed.
+type labelPrinted func(id int64, templateName string) error
+
+func (handler *LabelHandler) writeLabel(w http.ResponseWriter, r *http.Request, source labelSource, printed labelPrinted) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = label.FormatPNG
+	}
+
+	templateName := r.URL.Query().Get("template")
+	layout, data, err := source(id, templateName)
+	if err != nil {
+		handler.handleLabelError(w, err)
+		return
+	}
+
+	// Render fully before writing so a failure still yields a clean error
+	var body bytes.Buffer
+	if err := label.Write(&body, format, layout, data); err != nil {
+		handler.handleLabelError(w, err)
+		return
+	}
+	if printed != nil {
+		if err := printed(id, templateName); err != nil {
+			handler.handleLabelError(w, err)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", label.ContentType(format))
+	w.Write(body.Bytes())
+}
+
+func (handler *LabelHandler) handleLabelError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, customerrors.ErrResourceNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, label.ErrUnsupportedFormat),
+		errors.Is(err, gs1.ErrInvalidElement),
+		errors.Is(err, usecase.ErrLabelTemplateKind):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *LabelHandler) handleWriteError(w http.ResponseWriter, labelTemplateID int64, err error) {
+	current := func() (int64, interface{}, error) {
+		labelTemplate, err := handler.UseCase.GetLabelTemplateByID(labelTemplateID)
+		if err != nil {
+			return 0, nil, err
+		}
+		return labelTemplate.Version, labelTemplate, nil
+	}
+	etag.WriteError(w, err, current, nil)
+}
+
+func validateLabelTemplate(labelTemplate *domain.LabelTemplate) error {
+	if labelTemplate.Name == "" {
+		return errors.New("name is required")
+	}
+	if err := labelTemplate.ValidateKind(); err != nil {
+		return err
+	}
+	if labelTemplate.Status == "" {
+		return errors.New("status is required")
+	}
+	return labelTemplate.Layout().Validate()
+}
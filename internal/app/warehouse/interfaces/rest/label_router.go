@@ -0,0 +1,38 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+type LabelModule struct {
+	Handler *LabelHandler
+}
+
+func NewLabelModule(db database.Connection) *LabelModule {
+	labelRepo := repository.NewLabelRepository(db)
+	containerRepo := repository.NewContainerRepository(db)
+	inventoryRepo := repository.NewInventoryRepository(db)
+	labelUsecase := usecase.NewLabelUseCase(labelRepo, containerRepo, inventoryRepo)
+	labelHandler := NewLabelHandler(labelUsecase)
+
+	return &LabelModule{Handler: labelHandler}
+}
+
+func (u *LabelModule) RegisterRoutes(r *mux.Router) {
+	subRouter := r.PathPrefix("/labels").Subrouter()
+	subRouter.HandleFunc("/containers/{id}", u.Handler.GetContainerLabel).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/containers/{id}/print", u.Handler.PrintContainerLabel).Methods(http.MethodPost)
+	subRouter.HandleFunc("/inventories/{id}", u.Handler.GetInventoryLabel).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/products/{id}", u.Handler.GetProductLabel).Methods(http.MethodGet, http.MethodOptions)
+
+	subRouter.HandleFunc("/templates", u.Handler.CreateLabelTemplate).Methods(http.MethodPost)
+	subRouter.HandleFunc("/templates", u.Handler.GetAllLabelTemplates).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/templates/{id}", u.Handler.GetLabelTemplateByID).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/templates/{id}", u.Handler.UpdateLabelTemplate).Methods(http.MethodPut)
+	subRouter.HandleFunc("/templates/{id}", u.Handler.DeleteLabelTemplate).Methods(http.MethodDelete)
+}
@@ -0,0 +1,163 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/internal/utility/etag"
+)
+
+type NumberSeriesHandler struct {
+	UseCase usecase.NumberSeriesUseCase
+}
+
+func NewNumberSeriesHandler(useCase usecase.NumberSeriesUseCase) *NumberSeriesHandler {
+	return &NumberSeriesHandler{UseCase: useCase}
+}
+
+func (handler *NumberSeriesHandler) CreateNumberSeries(w http.ResponseWriter, r *http.Request) {
+	var series *domain.NumberSeries = domain.NewNumberSeriesWithDefaults()
+
+	if err := json.NewDecoder(r.Body).Decode(series); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validateNumberSeries(series); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.CreateNumberSeries(series); err != nil {
+		handler.handleWriteError(w, 0, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(series); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *NumberSeriesHandler) UpdateNumberSeries(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Number Series ID", http.StatusBadRequest)
+		return
+	}
+
+	var series *domain.NumberSeries = domain.NewNumberSeriesWithDefaults()
+	if err := json.NewDecoder(r.Body).Decode(series); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := validateNumberSeries(series); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	series.ID = id
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if version > 0 {
+		series.Version = version
+	}
+	if err := handler.UseCase.UpdateNumberSeries(series); err != nil {
+		handler.handleWriteError(w, series.ID, err)
+		return
+	}
+
+	etag.Set(w, series.Version)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (handler *NumberSeriesHandler) DeleteNumberSeries(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "invalid Number Series ID", http.StatusBadRequest)
+		return
+	}
+
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.DeleteNumberSeries(id, version); err != nil {
+		handler.handleWriteError(w, id, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (handler *NumberSeriesHandler) GetNumberSeriesByID(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Number Series ID", http.StatusBadRequest)
+		return
+	}
+
+	series, err := handler.UseCase.GetNumberSeriesByID(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	etag.Set(w, series.Version)
+	if err := json.NewEncoder(w).Encode(series); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *NumberSeriesHandler) GetAllNumberSeries(w http.ResponseWriter, r *http.Request) {
+	series, err := handler.UseCase.GetAllNumberSeries()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if series == nil {
+		series = []*domain.NumberSeries{}
+	}
+
+	if err := json.NewEncoder(w).Encode(series); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *NumberSeriesHandler) handleWriteError(w http.ResponseWriter, seriesID int64, err error) {
+	current := func() (int64, interface{}, error) {
+		series, err := handler.UseCase.GetNumberSeriesByID(seriesID)
+		if err != nil {
+			return 0, nil, err
+		}
+		return series.Version, series, nil
+	}
+	etag.WriteError(w, err, current, map[error]int{
+		domain.ErrDuplicateNumberSeries: http.StatusConflict,
+	})
+}
+
+func validateNumberSeries(series *domain.NumberSeries) error {
+	if err := series.Validate(); err != nil {
+		return err
+	}
+	if series.Status == "" {
+		return errors.New("status is required")
+	}
+	return nil
+}
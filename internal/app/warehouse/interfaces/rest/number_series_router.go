@@ -0,0 +1,31 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+type NumberSeriesModule struct {
+	Handler *NumberSeriesHandler
+}
+
+func NewNumberSeriesModule(db database.Connection) *NumberSeriesModule {
+	seriesRepo := repository.NewNumberSeriesRepository(db)
+	seriesUsecase := usecase.NewNumberSeriesUseCase(seriesRepo)
+	seriesHandler := NewNumberSeriesHandler(seriesUsecase)
+
+	return &NumberSeriesModule{Handler: seriesHandler}
+}
+
+func (u *NumberSeriesModule) RegisterRoutes(r *mux.Router) {
+	subRouter := r.PathPrefix("/number-series").Subrouter()
+	subRouter.HandleFunc("", u.Handler.CreateNumberSeries).Methods(http.MethodPost)
+	subRouter.HandleFunc("", u.Handler.GetAllNumberSeries).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/{id}", u.Handler.GetNumberSeriesByID).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/{id}", u.Handler.UpdateNumberSeries).Methods(http.MethodPut)
+	subRouter.HandleFunc("/{id}", u.Handler.DeleteNumberSeries).Methods(http.MethodDelete)
+}
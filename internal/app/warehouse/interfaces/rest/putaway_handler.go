@@ -0,0 +1,272 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+	"github.com/vamika-digital/wms-api-server/internal/utility/etag"
+	"github.com/vamika-digital/wms-api-server/internal/utility/valueobjects"
+)
+
+type PutawayHandler struct {
+	UseCase usecase.PutawayUseCase
+}
+
+func NewPutawayHandler(useCase usecase.PutawayUseCase) *PutawayHandler {
+	return &PutawayHandler{UseCase: useCase}
+}
+
+// Suggest returns ranked candidate containers for the inventory or container
+// named in the body.
+func (handler *PutawayHandler) Suggest(w http.ResponseWriter, r *http.Request) {
+	var request domain.PutawayRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	candidates, err := handler.UseCase.Suggest(&request)
+	if err != nil {
+		handler.handlePutawayError(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(candidates); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// Confirm puts the inventory or container away in the chosen target.
+func (handler *PutawayHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	var confirmation domain.PutawayConfirmation
+	if err := json.NewDecoder(r.Body).Decode(&confirmation); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	if confirmation.TargetID <= 0 {
+		http.Error(w, "target_id is required", http.StatusBadRequest)
+		return
+	}
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if version > 0 {
+		confirmation.Version = version
+	}
+
+	result, err := handler.UseCase.Confirm(&confirmation)
+	if err != nil {
+		handler.handlePutawayError(w, err)
+		return
+	}
+
+	setWarnings(w, result.Warnings)
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *PutawayHandler) handlePutawayError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, customerrors.ErrResourceNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, customerrors.ErrVersionConflict):
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+	case errors.Is(err, domain.ErrPutawaySubject), errors.Is(err, domain.ErrPutawayStoreRequired):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, domain.ErrPutawayTargetRejected), errors.Is(err, domain.ErrCapacityExceeded), errors.Is(err, domain.ErrContainerUnavailable),
+		errors.Is(err, domain.ErrContainerCycle), errors.Is(err, domain.ErrInvalidContainerParent):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *PutawayHandler) CreatePutawayRule(w http.ResponseWriter, r *http.Request) {
+	var rule *domain.PutawayRule = domain.NewPutawayRuleWithDefaults()
+
+	if err := json.NewDecoder(r.Body).Decode(rule); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validatePutawayRule(rule); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.CreatePutawayRule(rule); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(rule); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *PutawayHandler) UpdatePutawayRule(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Putaway Rule ID", http.StatusBadRequest)
+		return
+	}
+
+	var rule *domain.PutawayRule = domain.NewPutawayRuleWithDefaults()
+	if err := json.NewDecoder(r.Body).Decode(rule); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := validatePutawayRule(rule); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rule.ID = id
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if version > 0 {
+		rule.Version = version
+	}
+	if err := handler.UseCase.UpdatePutawayRule(rule); err != nil {
+		handler.handleWriteError(w, rule.ID, err)
+		return
+	}
+
+	etag.Set(w, rule.Version)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (handler *PutawayHandler) DeletePutawayRule(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "invalid Putaway Rule ID", http.StatusBadRequest)
+		return
+	}
+
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.DeletePutawayRule(id, version); err != nil {
+		handler.handleWriteError(w, id, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (handler *PutawayHandler) GetPutawayRuleByID(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Putaway Rule ID", http.StatusBadRequest)
+		return
+	}
+
+	rule, err := handler.UseCase.GetPutawayRuleByID(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	etag.Set(w, rule.Version)
+	if err := json.NewEncoder(w).Encode(rule); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *PutawayHandler) GetAllPutawayRules(w http.ResponseWriter, r *http.Request) {
+	pageQuery := r.URL.Query().Get("page")
+	pageSizeQuery := r.URL.Query().Get("pageSize")
+	sort := r.URL.Query().Get("sort")
+
+	// Default values for pagination
+	page := 1
+	pageSize := 10
+
+	if pageQuery != "" {
+		page, _ = strconv.Atoi(pageQuery)
+	}
+	if pageSizeQuery != "" {
+		pageSize, _ = strconv.Atoi(pageSizeQuery)
+	}
+
+	// Extract filter parameters
+	filterOptions := repository.PutawayRuleFilterOptions{
+		Status: r.URL.Query().Get("status"),
+	}
+	storeID, _ := strconv.ParseInt(r.URL.Query().Get("store_id"), 10, 64)
+	filterOptions.StoreID = customtypes.NullableInt64(storeID)
+	productID, _ := strconv.ParseInt(r.URL.Query().Get("product_id"), 10, 64)
+	filterOptions.ProductID = customtypes.NullableInt64(productID)
+
+	rules, totalRules, err := handler.UseCase.GetAllPutawayRules(page, pageSize, sort, filterOptions)
+	if err != nil {
+		// Handle error
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	if rules == nil {
+		rules = []*domain.PutawayRule{}
+	}
+
+	// Create a response with pagination details
+	response := valueobjects.PaginatedResponse{
+		Data:       rules,
+		TotalItems: totalRules,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (totalRules + pageSize - 1) / pageSize, // Calculate total pages
+	}
+
+	// Respond with the fetched putaway rules and pagination details
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *PutawayHandler) handleWriteError(w http.ResponseWriter, ruleID int64, err error) {
+	current := func() (int64, interface{}, error) {
+		rule, err := handler.UseCase.GetPutawayRuleByID(ruleID)
+		if err != nil {
+			return 0, nil, err
+		}
+		return rule.Version, rule, nil
+	}
+	etag.WriteError(w, err, current, nil)
+}
+
+func validatePutawayRule(rule *domain.PutawayRule) error {
+	if rule.Name == "" {
+		return errors.New("name is required")
+	}
+	if err := rule.ValidateTargetType(); err != nil {
+		return err
+	}
+	if rule.Status == "" {
+		return errors.New("status is required")
+	}
+	return nil
+}
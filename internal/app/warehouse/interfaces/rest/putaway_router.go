@@ -0,0 +1,36 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+type PutawayModule struct {
+	Handler *PutawayHandler
+}
+
+func NewPutawayModule(db database.Connection) *PutawayModule {
+	putawayRepo := repository.NewPutawayRepository(db)
+	containerRepo := repository.NewContainerRepository(db)
+	inventoryRepo := repository.NewInventoryRepository(db)
+	putawayUsecase := usecase.NewPutawayUseCase(putawayRepo, containerRepo, inventoryRepo)
+	putawayHandler := NewPutawayHandler(putawayUsecase)
+
+	return &PutawayModule{Handler: putawayHandler}
+}
+
+func (u *PutawayModule) RegisterRoutes(r *mux.Router) {
+	subRouter := r.PathPrefix("/putaway").Subrouter()
+	subRouter.HandleFunc("/suggest", u.Handler.Suggest).Methods(http.MethodPost)
+	subRouter.HandleFunc("/confirm", u.Handler.Confirm).Methods(http.MethodPost)
+
+	subRouter.HandleFunc("/rules", u.Handler.CreatePutawayRule).Methods(http.MethodPost)
+	subRouter.HandleFunc("/rules", u.Handler.GetAllPutawayRules).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/rules/{id}", u.Handler.GetPutawayRuleByID).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/rules/{id}", u.Handler.UpdatePutawayRule).Methods(http.MethodPut)
+	subRouter.HandleFunc("/rules/{id}", u.Handler.DeletePutawayRule).Methods(http.MethodDelete)
+}
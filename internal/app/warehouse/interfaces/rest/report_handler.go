@@ -0,0 +1,212 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/internal/utility/spreadsheet"
+)
+
+type ReportHandler struct {
+	UseCase usecase.ReportUseCase
+}
+
+func NewReportHandler(useCase usecase.ReportUseCase) *ReportHandler {
+	return &ReportHandler{UseCase: useCase}
+}
+
+// GetInventoryAging returns the aging report as JSON, or as a download when
+// format is csv or xlsx.
+func (handler *ReportHandler) GetInventoryAging(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	buckets, err := domain.ParseAgingBuckets(query.Get("buckets"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	options := usecase.AgingReportOptions{
+		Buckets: buckets,
+		AsOf:    time.Now(),
+		Filter: repository.ReportFilterOptions{
+			ProductType: query.Get("product_type"),
+		},
+	}
+	for _, group := range strings.Split(query.Get("group_by"), ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			options.GroupBy = append(options.GroupBy, group)
+		}
+	}
+	options.SlowMovingDays, _ = strconv.Atoi(query.Get("slow_moving_days"))
+	options.Filter.StoreID, _ = strconv.ParseInt(query.Get("store_id"), 10, 64)
+	options.Filter.ProductID, _ = strconv.ParseInt(query.Get("product_id"), 10, 64)
+	options.Filter.CategoryID, _ = strconv.ParseInt(query.Get("category_id"), 10, 64)
+	options.CategoryLevel, _ = strconv.Atoi(query.Get("category_level"))
+
+	report, err := handler.UseCase.GetInventoryAging(options)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	format := strings.ToLower(query.Get("format"))
+	if format == spreadsheet.FormatCSV || format == spreadsheet.FormatXLSX {
+		filename := fmt.Sprintf("inventory-aging-%s.%s", report.AsOf.Format("2006-01-02"), format)
+		w.Header().Set("Content-Type", spreadsheet.ContentType(format))
+		w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+		writeSheet(w, format, agingReportSheet(report))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func agingReportSheet(report *domain.AgingReport) *spreadsheet.Sheet {
+	sheet := &spreadsheet.Sheet{Name: "Inventory Aging"}
+	sheet.Headers = []string{"Product Code", "Product Name", "Product Type", "Category", "Store", "Unit"}
+	for _, bucket := range report.Buckets {
+		sheet.Headers = append(sheet.Headers, bucket.Label+" days")
+	}
+	sheet.Headers = append(sheet.Headers, "Total", "Lots", "Oldest Stock In", "Last Movement", "Slow Moving")
+
+	for _, row := range report.Rows {
+		cells := []interface{}{row.ProductCode, row.ProductName, row.ProductType, row.CategoryName, row.StoreName, row.Unit}
+		for _, quantity := range row.Buckets {
+			cells = append(cells, quantity)
+		}
+		cells = append(cells, row.TotalQuantity, row.LotCount, row.OldestStockInAt, row.LastMovementAt, row.SlowMoving)
+		sheet.Rows = append(sheet.Rows, cells)
+	}
+	return sheet
+}
+
+// GetUtilization returns the capacity used per ?level=rack (default), zone or
+// store, as JSON or as a csv or xlsx download.
+func (handler *ReportHandler) GetUtilization(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	level := strings.ToLower(query.Get("level"))
+	storeID, _ := strconv.ParseInt(query.Get("store_id"), 10, 64)
+
+	report, err := handler.UseCase.GetUtilization(level, storeID)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidUtilizationLevel) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	format := strings.ToLower(query.Get("format"))
+	if format == spreadsheet.FormatCSV || format == spreadsheet.FormatXLSX {
+		filename := fmt.Sprintf("utilization-%s-%s.%s", report.Level, time.Now().Format("2006-01-02"), format)
+		w.Header().Set("Content-Type", spreadsheet.ContentType(format))
+		w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+		writeSheet(w, format, utilizationReportSheet(report))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func utilizationReportSheet(report *domain.UtilizationReport) *spreadsheet.Sheet {
+	sheet := &spreadsheet.Sheet{Name: "Utilization"}
+	sheet.Headers = []string{"Store", "Code", "Weight (kg)", "Max Weight (kg)", "Weight Used %", "Volume (m³)", "Max Volume (m³)", "Volume Used %", "Units", "Max Units", "Units Used %", "Locations", "Empty Locations"}
+	percent := func(value *float64) interface{} {
+		if value == nil {
+			return nil
+		}
+		return *value
+	}
+	for _, row := range report.Rows {
+		sheet.Rows = append(sheet.Rows, []interface{}{
+			row.StoreName, row.Code,
+			row.Load.Weight, row.Capacity.MaxWeight, percent(row.WeightUsed),
+			row.Load.Volume, row.Capacity.MaxVolume, percent(row.VolumeUsed),
+			row.Load.Units, row.Capacity.MaxUnits, percent(row.UnitsUsed),
+			row.Locations, row.EmptyLocations,
+		})
+	}
+	return sheet
+}
+
+// GetStockByCategory returns the stock on hand rolled up the product category
+// tree, for ?store_id= or every store, as JSON or as a csv or xlsx download.
+// Only current stock is reported, so ?as_of is refused.
+func (handler *ReportHandler) GetStockByCategory(w http.ResponseWriter, r *http.Request) {
+	if err := rejectAsOf(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	query := r.URL.Query()
+	storeID, _ := strconv.ParseInt(query.Get("store_id"), 10, 64)
+
+	report, err := handler.UseCase.GetStockByCategory(storeID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	format := strings.ToLower(query.Get("format"))
+	if format == spreadsheet.FormatCSV || format == spreadsheet.FormatXLSX {
+		filename := fmt.Sprintf("stock-by-category-%s.%s", report.AsOf.Format("2006-01-02"), format)
+		w.Header().Set("Content-Type", spreadsheet.ContentType(format))
+		w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+		writeSheet(w, format, categoryStockSheet(report))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// categoryStockSheet lists the subtree totals of every category, one row per
+// unit, with the names of its ancestors joined into a path.
+func categoryStockSheet(report *domain.CategoryStockReport) *spreadsheet.Sheet {
+	sheet := &spreadsheet.Sheet{Name: "Stock by Category"}
+	sheet.Headers = []string{"Category Path", "Code", "Name", "Level", "Unit", "On Hand", "Reserved", "Held", "Available", "Lots", "Products"}
+	names := map[int64]string{}
+	for _, node := range report.Flatten() {
+		names[node.ID] = node.Name
+		var path []string
+		for _, id := range node.AncestorIDs() {
+			path = append(path, names[id])
+		}
+		for _, total := range node.Total {
+			sheet.Rows = append(sheet.Rows, []interface{}{
+				strings.Join(path, " > "), node.Code, node.Name, node.Level, total.Unit,
+				total.OnHand, total.Reserved, total.Held, total.Available, total.Lots, total.Products,
+			})
+		}
+	}
+	for _, total := range report.Uncategorized {
+		sheet.Rows = append(sheet.Rows, []interface{}{
+			"", "", "Uncategorized", 0, total.Unit,
+			total.OnHand, total.Reserved, total.Held, total.Available, total.Lots, total.Products,
+		})
+	}
+	return sheet
+}
+
+// writeSheet streams sheet as the response body. Once the first bytes are out
+// the status cannot change, so a failure can only be logged.
+func writeSheet(w http.ResponseWriter, format string, sheet *spreadsheet.Sheet) {
+	if err := spreadsheet.Write(w, format, sheet); err != nil {
+		log.Printf("failed to write %s export: %v", format, err)
+	}
+}
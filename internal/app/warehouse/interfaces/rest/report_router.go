@@ -0,0 +1,29 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+type ReportModule struct {
+	Handler *ReportHandler
+}
+
+func NewReportModule(db database.Connection) *ReportModule {
+	reportRepo := repository.NewReportRepository(db)
+	reportUsecase := usecase.NewReportUseCase(reportRepo)
+	reportHandler := NewReportHandler(reportUsecase)
+
+	return &ReportModule{Handler: reportHandler}
+}
+
+func (u *ReportModule) RegisterRoutes(r *mux.Router) {
+	subRouter := r.PathPrefix("/reports").Subrouter()
+	subRouter.HandleFunc("/inventory-aging", u.Handler.GetInventoryAging).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/utilization", u.Handler.GetUtilization).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/stock-by-category", u.Handler.GetStockByCategory).Methods(http.MethodGet, http.MethodOptions)
+}
@@ -0,0 +1,50 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/internal/utility/gs1"
+)
+
+type ScanHandler struct {
+	UseCase usecase.ScanUseCase
+}
+
+func NewScanHandler(useCase usecase.ScanUseCase) *ScanHandler {
+	return &ScanHandler{UseCase: useCase}
+}
+
+type scanForm struct {
+	Value string `json:"value"`
+}
+
+// Scan resolves a scanned value, e.g. {"value": "PAL-0001"} or a raw GS1-128
+// payload, to the entity it refers to and the actions allowed on it.
+func (handler *ScanHandler) Scan(w http.ResponseWriter, r *http.Request) {
+	form := &scanForm{}
+	if err := json.NewDecoder(r.Body).Decode(form); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	result, err := handler.UseCase.Resolve(form.Value)
+	if err != nil {
+		switch {
+		case errors.Is(err, customerrors.ErrResourceNotFound):
+			http.Error(w, "no container, product, lot, serial or batch matches the scanned value", http.StatusNotFound)
+		case errors.Is(err, usecase.ErrEmptyScan), errors.Is(err, gs1.ErrInvalidElement):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,29 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+type ScanModule struct {
+	Handler *ScanHandler
+}
+
+func NewScanModule(db database.Connection) *ScanModule {
+	scanRepo := repository.NewScanRepository(db)
+	containerRepo := repository.NewContainerRepository(db)
+	inventoryRepo := repository.NewInventoryRepository(db)
+	serialRepo := repository.NewSerialRepository(db)
+	scanUsecase := usecase.NewScanUseCase(scanRepo, containerRepo, inventoryRepo, serialRepo)
+	scanHandler := NewScanHandler(scanUsecase)
+
+	return &ScanModule{Handler: scanHandler}
+}
+
+func (u *ScanModule) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/scan", u.Handler.Scan).Methods(http.MethodPost, http.MethodOptions)
+}
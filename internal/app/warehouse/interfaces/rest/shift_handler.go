@@ -0,0 +1,319 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/internal/utility/etag"
+)
+
+type ShiftHandler struct {
+	UseCase usecase.ShiftUseCase
+}
+
+func NewShiftHandler(useCase usecase.ShiftUseCase) *ShiftHandler {
+	return &ShiftHandler{UseCase: useCase}
+}
+
+func (handler *ShiftHandler) CreateShift(w http.ResponseWriter, r *http.Request) {
+	var shift *domain.Shift = domain.NewShiftWithDefaults()
+
+	if err := json.NewDecoder(r.Body).Decode(shift); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validateShift(shift); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.CreateShift(shift); err != nil {
+		handler.handleWriteError(w, 0, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(shift); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *ShiftHandler) UpdateShift(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Shift ID", http.StatusBadRequest)
+		return
+	}
+
+	var shift *domain.Shift = domain.NewShiftWithDefaults()
+	if err := json.NewDecoder(r.Body).Decode(shift); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := validateShift(shift); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	shift.ID = id
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if version > 0 {
+		shift.Version = version
+	}
+	if err := handler.UseCase.UpdateShift(shift); err != nil {
+		handler.handleWriteError(w, shift.ID, err)
+		return
+	}
+
+	etag.Set(w, shift.Version)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (handler *ShiftHandler) DeleteShift(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "invalid Shift ID", http.StatusBadRequest)
+		return
+	}
+
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.DeleteShift(id, version); err != nil {
+		handler.handleWriteError(w, id, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (handler *ShiftHandler) GetShiftByID(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Shift ID", http.StatusBadRequest)
+		return
+	}
+
+	shift, err := handler.UseCase.GetShiftByID(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	etag.Set(w, shift.Version)
+	if err := json.NewEncoder(w).Encode(shift); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *ShiftHandler) GetAllShifts(w http.ResponseWriter, r *http.Request) {
+	var filterOptions repository.ShiftFilterOptions
+	if value := r.URL.Query().Get("store_id"); value != "" {
+		storeID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid Store ID", http.StatusBadRequest)
+			return
+		}
+		filterOptions.StoreID = storeID
+	}
+	filterOptions.Status = r.URL.Query().Get("status")
+
+	shifts, err := handler.UseCase.GetAllShifts(filterOptions)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(shifts); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *ShiftHandler) CreateHoliday(w http.ResponseWriter, r *http.Request) {
+	var holiday domain.ShiftHoliday
+	if err := json.NewDecoder(r.Body).Decode(&holiday); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	if holiday.StoreID <= 0 {
+		http.Error(w, "store_id is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.CreateHoliday(&holiday); err != nil {
+		handler.handleWriteError(w, 0, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(holiday); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *ShiftHandler) DeleteHoliday(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Holiday ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.DeleteHoliday(id); err != nil {
+		handler.handleWriteError(w, 0, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetHolidays lists the holidays of a store, optionally limited to the dates
+// ?from=2024-01-01&to=2024-12-31.
+func (handler *ShiftHandler) GetHolidays(w http.ResponseWriter, r *http.Request) {
+	storeID, err := strconv.ParseInt(r.URL.Query().Get("store_id"), 10, 64)
+	if err != nil {
+		http.Error(w, "store_id is required", http.StatusBadRequest)
+		return
+	}
+
+	holidays, err := handler.UseCase.GetHolidays(storeID, r.URL.Query().Get("from"), r.URL.Query().Get("to"))
+	if err != nil {
+		handler.handleWriteError(w, 0, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(holidays); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *ShiftHandler) GetAssignments(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Shift ID", http.StatusBadRequest)
+		return
+	}
+
+	assignments, err := handler.UseCase.GetAssignments(id, r.URL.Query().Get("from"), r.URL.Query().Get("to"))
+	if err != nil {
+		handler.handleWriteError(w, id, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(assignments); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// AssignSupervisor sets the supervisor of the shift on the date in the path.
+func (handler *ShiftHandler) AssignSupervisor(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Shift ID", http.StatusBadRequest)
+		return
+	}
+
+	var assignment domain.ShiftAssignment
+	if err := json.NewDecoder(r.Body).Decode(&assignment); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	assignment.ShiftID, assignment.Date = id, params["date"]
+
+	if err := handler.UseCase.AssignSupervisor(&assignment); err != nil {
+		handler.handleWriteError(w, id, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(assignment); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *ShiftHandler) RemoveAssignment(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Shift ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.RemoveAssignment(id, params["date"]); err != nil {
+		handler.handleWriteError(w, id, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// ResolveShift returns the shift of a store running at ?at=2024-03-31T23:15:00Z,
+// or now, with its supervisor. The body is null when no shift is running.
+func (handler *ShiftHandler) ResolveShift(w http.ResponseWriter, r *http.Request) {
+	storeID, err := strconv.ParseInt(r.URL.Query().Get("store_id"), 10, 64)
+	if err != nil {
+		http.Error(w, "store_id is required", http.StatusBadRequest)
+		return
+	}
+	at := time.Now()
+	if value := r.URL.Query().Get("at"); value != "" {
+		if at, err = time.Parse(time.RFC3339, value); err != nil {
+			http.Error(w, "invalid at: "+value, http.StatusBadRequest)
+			return
+		}
+	}
+
+	resolution, err := handler.UseCase.ResolveShift(storeID, at)
+	if err != nil {
+		handler.handleWriteError(w, 0, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(resolution); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *ShiftHandler) handleWriteError(w http.ResponseWriter, shiftID int64, err error) {
+	current := func() (int64, interface{}, error) {
+		shift, err := handler.UseCase.GetShiftByID(shiftID)
+		if err != nil {
+			return 0, nil, err
+		}
+		return shift.Version, shift, nil
+	}
+	etag.WriteError(w, err, current, map[error]int{
+		domain.ErrDuplicateShift: http.StatusConflict,
+		domain.ErrInvalidShift:   http.StatusBadRequest,
+	})
+}
+
+func validateShift(shift *domain.Shift) error {
+	if err := shift.Validate(); err != nil {
+		return err
+	}
+	if shift.Status == "" {
+		return errors.New("status is required")
+	}
+	return nil
+}
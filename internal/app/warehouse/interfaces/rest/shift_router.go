@@ -0,0 +1,39 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+type ShiftModule struct {
+	Handler *ShiftHandler
+}
+
+func NewShiftModule(db database.Connection) *ShiftModule {
+	shiftRepo := repository.NewShiftRepository(db)
+	storeRepo := repository.NewStoreRepository(db)
+	shiftUsecase := usecase.NewShiftUseCase(shiftRepo, storeRepo)
+	shiftHandler := NewShiftHandler(shiftUsecase)
+
+	return &ShiftModule{Handler: shiftHandler}
+}
+
+func (u *ShiftModule) RegisterRoutes(r *mux.Router) {
+	subRouter := r.PathPrefix("/shifts").Subrouter()
+	subRouter.HandleFunc("", u.Handler.CreateShift).Methods(http.MethodPost)
+	subRouter.HandleFunc("", u.Handler.GetAllShifts).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/resolve", u.Handler.ResolveShift).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/holidays", u.Handler.CreateHoliday).Methods(http.MethodPost)
+	subRouter.HandleFunc("/holidays", u.Handler.GetHolidays).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/holidays/{id}", u.Handler.DeleteHoliday).Methods(http.MethodDelete)
+	subRouter.HandleFunc("/{id}", u.Handler.GetShiftByID).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/{id}", u.Handler.UpdateShift).Methods(http.MethodPut)
+	subRouter.HandleFunc("/{id}", u.Handler.DeleteShift).Methods(http.MethodDelete)
+	subRouter.HandleFunc("/{id}/assignments", u.Handler.GetAssignments).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/{id}/assignments/{date}", u.Handler.AssignSupervisor).Methods(http.MethodPut)
+	subRouter.HandleFunc("/{id}/assignments/{date}", u.Handler.RemoveAssignment).Methods(http.MethodDelete)
+}
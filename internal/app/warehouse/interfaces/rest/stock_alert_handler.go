@@ -0,0 +1,167 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/internal/utility/valueobjects"
+)
+
+type StockAlertHandler struct {
+	UseCase usecase.StockAlertUseCase
+}
+
+func NewStockAlertHandler(useCase usecase.StockAlertUseCase) *StockAlertHandler {
+	return &StockAlertHandler{UseCase: useCase}
+}
+
+func (handler *StockAlertHandler) GetStockAlertByID(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Stock Alert ID", http.StatusBadRequest)
+		return
+	}
+
+	alert, err := handler.UseCase.GetStockAlertByID(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(alert); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// GetAllStockAlerts lists alerts, defaulting to the ones still open.
+func (handler *StockAlertHandler) GetAllStockAlerts(w http.ResponseWriter, r *http.Request) {
+	pageQuery := r.URL.Query().Get("page")
+	pageSizeQuery := r.URL.Query().Get("pageSize")
+	sort := r.URL.Query().Get("sort")
+
+	// Default values for pagination
+	page := 1
+	pageSize := 10
+
+	if pageQuery != "" {
+		page, _ = strconv.Atoi(pageQuery)
+	}
+	if pageSizeQuery != "" {
+		pageSize, _ = strconv.Atoi(pageSizeQuery)
+	}
+
+	// Extract filter parameters
+	filterOptions := repository.StockAlertFilterOptions{}
+	filterOptions.SetStatuses(r.URL.Query().Get("status"))
+	if len(filterOptions.Statuses) == 0 {
+		filterOptions.Statuses = []domain.StockAlertStatus{domain.ALERT_OPEN}
+	}
+	filterOptions.ProductID, _ = strconv.ParseInt(r.URL.Query().Get("product_id"), 10, 64)
+	filterOptions.StoreID, _ = strconv.ParseInt(r.URL.Query().Get("store_id"), 10, 64)
+
+	alerts, totalAlerts, err := handler.UseCase.GetAllStockAlerts(page, pageSize, sort, filterOptions)
+	if err != nil {
+		// Handle error
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	if alerts == nil {
+		alerts = []*domain.StockAlert{}
+	}
+
+	// Create a response with pagination details
+	response := valueobjects.PaginatedResponse{
+		Data:       alerts,
+		TotalItems: totalAlerts,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (totalAlerts + pageSize - 1) / pageSize, // Calculate total pages
+	}
+
+	// Respond with the fetched alerts and pagination details
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *StockAlertHandler) AcknowledgeStockAlert(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Stock Alert ID", http.StatusBadRequest)
+		return
+	}
+
+	var form struct {
+		AcknowledgedBy string `json:"acknowledged_by"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&form); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	if form.AcknowledgedBy == "" {
+		http.Error(w, "acknowledged_by is required", http.StatusBadRequest)
+		return
+	}
+
+	alert, err := handler.UseCase.AcknowledgeStockAlert(id, form.AcknowledgedBy)
+	if err != nil {
+		handler.handleTransitionError(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(alert); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *StockAlertHandler) ResolveStockAlert(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Stock Alert ID", http.StatusBadRequest)
+		return
+	}
+
+	alert, err := handler.UseCase.ResolveStockAlert(id)
+	if err != nil {
+		handler.handleTransitionError(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(alert); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// EvaluateStockLevels runs the evaluator immediately instead of waiting for
+// the next background cycle.
+func (handler *StockAlertHandler) EvaluateStockLevels(w http.ResponseWriter, r *http.Request) {
+	if err := handler.UseCase.EvaluateStockLevels(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (handler *StockAlertHandler) handleTransitionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, customerrors.ErrResourceNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, domain.ErrInvalidAlertTransition):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,42 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/config"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+type StockAlertModule struct {
+	Handler   *StockAlertHandler
+	Evaluator *usecase.StockLevelEvaluator
+}
+
+func NewStockAlertModule(db database.Connection) *StockAlertModule {
+	stockAlertRepo := repository.NewStockAlertRepository(db)
+	stockLevelRepo := repository.NewStockLevelRepository(db)
+
+	notifiers := usecase.MultiStockAlertNotifier{&usecase.LogStockAlertNotifier{}}
+	if config.AppConfig.Alerts.WebhookURL != "" {
+		notifiers = append(notifiers, usecase.NewWebhookStockAlertNotifier(config.AppConfig.Alerts.WebhookURL))
+	}
+
+	stockAlertUsecase := usecase.NewStockAlertUseCase(stockAlertRepo, stockLevelRepo, notifiers)
+	stockAlertHandler := NewStockAlertHandler(stockAlertUsecase)
+	evaluator := usecase.NewStockLevelEvaluator(stockAlertUsecase, time.Duration(config.AppConfig.Alerts.EvaluationInterval)*time.Second)
+
+	return &StockAlertModule{Handler: stockAlertHandler, Evaluator: evaluator}
+}
+
+func (u *StockAlertModule) RegisterRoutes(r *mux.Router) {
+	subRouter := r.PathPrefix("/stock-alerts").Subrouter()
+	subRouter.HandleFunc("", u.Handler.GetAllStockAlerts).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/evaluate", u.Handler.EvaluateStockLevels).Methods(http.MethodPost)
+	subRouter.HandleFunc("/{id}", u.Handler.GetStockAlertByID).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/{id}/acknowledge", u.Handler.AcknowledgeStockAlert).Methods(http.MethodPost)
+	subRouter.HandleFunc("/{id}/resolve", u.Handler.ResolveStockAlert).Methods(http.MethodPost)
+}
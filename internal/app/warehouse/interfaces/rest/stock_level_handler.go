@@ -0,0 +1,203 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+	"github.com/vamika-digital/wms-api-server/internal/utility/etag"
+	"github.com/vamika-digital/wms-api-server/internal/utility/valueobjects"
+)
+
+type StockLevelHandler struct {
+	UseCase usecase.StockLevelUseCase
+}
+
+func NewStockLevelHandler(useCase usecase.StockLevelUseCase) *StockLevelHandler {
+	return &StockLevelHandler{UseCase: useCase}
+}
+
+func (handler *StockLevelHandler) CreateStockLevel(w http.ResponseWriter, r *http.Request) {
+	var stockLevel *domain.StockLevel = domain.NewStockLevelWithDefaults()
+
+	if err := json.NewDecoder(r.Body).Decode(stockLevel); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validateStockLevel(stockLevel); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.CreateStockLevel(stockLevel); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (handler *StockLevelHandler) UpdateStockLevel(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.Atoi(params["id"])
+	if err != nil {
+		http.Error(w, "Invalid Stock Level ID", http.StatusBadRequest)
+		return
+	}
+
+	var stockLevel *domain.StockLevel = domain.NewStockLevelWithDefaults()
+	if err := json.NewDecoder(r.Body).Decode(stockLevel); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := validateStockLevel(stockLevel); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	stockLevel.ID = int64(id)
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if version > 0 {
+		stockLevel.Version = version
+	}
+	if err := handler.UseCase.UpdateStockLevel(stockLevel); err != nil {
+		handler.handleWriteError(w, stockLevel.ID, err)
+		return
+	}
+
+	etag.Set(w, stockLevel.Version)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (handler *StockLevelHandler) DeleteStockLevel(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "invalid Stock Level ID", http.StatusBadRequest)
+		return
+	}
+
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.DeleteStockLevel(id, version); err != nil {
+		handler.handleWriteError(w, id, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (handler *StockLevelHandler) GetStockLevelByID(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Stock Level ID", http.StatusBadRequest)
+		return
+	}
+
+	stockLevel, err := handler.UseCase.GetStockLevelByID(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	etag.Set(w, stockLevel.Version)
+	if err := json.NewEncoder(w).Encode(stockLevel); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *StockLevelHandler) GetAllStockLevels(w http.ResponseWriter, r *http.Request) {
+	pageQuery := r.URL.Query().Get("page")
+	pageSizeQuery := r.URL.Query().Get("pageSize")
+	sort := r.URL.Query().Get("sort")
+
+	// Default values for pagination
+	page := 1
+	pageSize := 10
+
+	if pageQuery != "" {
+		page, _ = strconv.Atoi(pageQuery)
+	}
+	if pageSizeQuery != "" {
+		pageSize, _ = strconv.Atoi(pageSizeQuery)
+	}
+
+	// Extract filter parameters
+	filterOptions := repository.StockLevelFilterOptions{
+		Status: r.URL.Query().Get("status"),
+	}
+	filterOptions.ProductID, _ = strconv.ParseInt(r.URL.Query().Get("product_id"), 10, 64)
+	filterOptions.StoreID, _ = strconv.ParseInt(r.URL.Query().Get("store_id"), 10, 64)
+
+	stockLevels, totalStockLevels, err := handler.UseCase.GetAllStockLevels(page, pageSize, sort, filterOptions)
+	if err != nil {
+		// Handle error
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	if stockLevels == nil {
+		stockLevels = []*domain.StockLevel{}
+	}
+
+	// Create a response with pagination details
+	response := valueobjects.PaginatedResponse{
+		Data:       stockLevels,
+		TotalItems: totalStockLevels,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (totalStockLevels + pageSize - 1) / pageSize, // Calculate total pages
+	}
+
+	// Respond with the fetched stockLevels and pagination details
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *StockLevelHandler) handleWriteError(w http.ResponseWriter, stockLevelID int64, err error) {
+	current := func() (int64, interface{}, error) {
+		stockLevel, err := handler.UseCase.GetStockLevelByID(stockLevelID)
+		if err != nil {
+			return 0, nil, err
+		}
+		return stockLevel.Version, stockLevel, nil
+	}
+	etag.WriteError(w, err, current, nil)
+}
+
+func validateStockLevel(stockLevel *domain.StockLevel) error {
+	if stockLevel.ProductID <= 0 {
+		return errors.New("product is required")
+	}
+	if stockLevel.StoreID <= 0 {
+		return errors.New("store is required")
+	}
+	if stockLevel.Status == "" {
+		return errors.New("status is required")
+	}
+	for _, quantity := range []customtypes.Decimal{stockLevel.MinQuantity, stockLevel.MaxQuantity, stockLevel.ReorderPoint} {
+		if err := quantity.ValidateForUnit(stockLevel.Unit); err != nil {
+			return err
+		}
+	}
+	return stockLevel.ValidateQuantities()
+}
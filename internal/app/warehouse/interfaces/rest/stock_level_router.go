@@ -0,0 +1,31 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+type StockLevelModule struct {
+	Handler *StockLevelHandler
+}
+
+func NewStockLevelModule(db database.Connection) *StockLevelModule {
+	stockLevelRepo := repository.NewStockLevelRepository(db)
+	stockLevelUsecase := usecase.NewStockLevelUseCase(stockLevelRepo)
+	stockLevelHandler := NewStockLevelHandler(stockLevelUsecase)
+
+	return &StockLevelModule{Handler: stockLevelHandler}
+}
+
+func (u *StockLevelModule) RegisterRoutes(r *mux.Router) {
+	subRouter := r.PathPrefix("/stock-levels").Subrouter()
+	subRouter.HandleFunc("", u.Handler.CreateStockLevel).Methods(http.MethodPost)
+	subRouter.HandleFunc("", u.Handler.GetAllStockLevels).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/{id}", u.Handler.GetStockLevelByID).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/{id}", u.Handler.UpdateStockLevel).Methods(http.MethodPut)
+	subRouter.HandleFunc("/{id}", u.Handler.DeleteStockLevel).Methods(http.MethodDelete)
+}
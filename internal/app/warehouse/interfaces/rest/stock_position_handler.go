@@ -0,0 +1,66 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+)
+
+var errAsOfUnsupported = errors.New("as_of is not supported here; use /stock-positions?as_of= for stock at a past instant")
+
+// rejectAsOf refuses ?as_of on stock APIs that only report the present, so
+// that current stock is never returned for a past instant.
+func rejectAsOf(r *http.Request) error {
+	if r.URL.Query().Has("as_of") {
+		return errAsOfUnsupported
+	}
+	return nil
+}
+
+type StockPositionHandler struct {
+	UseCase usecase.StockPositionUseCase
+}
+
+func NewStockPositionHandler(useCase usecase.StockPositionUseCase) *StockPositionHandler {
+	return &StockPositionHandler{UseCase: useCase}
+}
+
+// GetStockPositions returns stock per product, store and container, optionally
+// as it was at ?as_of=2026-03-31T23:59:59Z. It is the only stock API that
+// answers for a past instant.
+func (handler *StockPositionHandler) GetStockPositions(w http.ResponseWriter, r *http.Request) {
+	filterOptions := repository.StockPositionFilterOptions{}
+	if err := filterOptions.SetAsOf(r.URL.Query().Get("as_of")); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filterOptions.ProductID, _ = strconv.ParseInt(r.URL.Query().Get("product_id"), 10, 64)
+	filterOptions.StoreID, _ = strconv.ParseInt(r.URL.Query().Get("store_id"), 10, 64)
+	filterOptions.ContainerID, _ = strconv.ParseInt(r.URL.Query().Get("container_id"), 10, 64)
+	filterOptions.CategoryID, _ = strconv.ParseInt(r.URL.Query().Get("category_id"), 10, 64)
+
+	report, err := handler.UseCase.GetStockPositions(filterOptions)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// TakeSnapshots materializes any missing daily snapshots immediately instead
+// of waiting for the next background cycle.
+func (handler *StockPositionHandler) TakeSnapshots(w http.ResponseWriter, r *http.Request) {
+	if err := handler.UseCase.TakeDailySnapshots(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
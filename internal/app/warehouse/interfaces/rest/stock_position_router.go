@@ -0,0 +1,32 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/config"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+type StockPositionModule struct {
+	Handler     *StockPositionHandler
+	SnapshotJob *usecase.StockSnapshotJob
+}
+
+func NewStockPositionModule(db database.Connection) *StockPositionModule {
+	stockPositionRepo := repository.NewStockPositionRepository(db)
+	stockPositionUsecase := usecase.NewStockPositionUseCase(stockPositionRepo)
+	stockPositionHandler := NewStockPositionHandler(stockPositionUsecase)
+	snapshotJob := usecase.NewStockSnapshotJob(stockPositionUsecase, time.Duration(config.AppConfig.Snapshots.Interval)*time.Second)
+
+	return &StockPositionModule{Handler: stockPositionHandler, SnapshotJob: snapshotJob}
+}
+
+func (u *StockPositionModule) RegisterRoutes(r *mux.Router) {
+	subRouter := r.PathPrefix("/stock-positions").Subrouter()
+	subRouter.HandleFunc("", u.Handler.GetStockPositions).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/snapshots", u.Handler.TakeSnapshots).Methods(http.MethodPost)
+}
@@ -3,6 +3,7 @@ package rest
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -10,6 +11,8 @@ import (
 	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
 	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
 	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/internal/utility/etag"
 	"github.com/vamika-digital/wms-api-server/internal/utility/valueobjects"
 )
 
@@ -35,7 +38,7 @@ func (handler *StoreHandler) CreateStore(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := handler.UseCase.CreateStore(store); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		handler.handleWriteError(w, 0, err)
 		return
 	}
 
@@ -62,11 +65,20 @@ func (handler *StoreHandler) UpdateStore(w http.ResponseWriter, r *http.Request)
 	}
 
 	store.ID = int64(id)
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if version > 0 {
+		store.Version = version
+	}
 	if err := handler.UseCase.UpdateStore(store); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		handler.handleWriteError(w, store.ID, err)
 		return
 	}
 
+	etag.Set(w, store.Version)
 	w.WriteHeader(http.StatusOK)
 }
 
@@ -78,8 +90,14 @@ func (handler *StoreHandler) DeleteStore(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if err := handler.UseCase.DeleteStore(id); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.DeleteStore(id, version); err != nil {
+		handler.handleWriteError(w, id, err)
 		return
 	}
 
@@ -100,6 +118,7 @@ func (handler *StoreHandler) GetStoreByID(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	etag.Set(w, store.Version)
 	if err := json.NewEncoder(w).Encode(store); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
@@ -156,6 +175,190 @@ func (handler *StoreHandler) GetAllStores(w http.ResponseWriter, r *http.Request
 	}
 }
 
+// ChangeStoreOwner hands a store over to another active user. The body names
+// the new owner, why and who changed it; an owner_id of 0 leaves the store
+// without an owner.
+func (handler *StoreHandler) ChangeStoreOwner(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Store ID", http.StatusBadRequest)
+		return
+	}
+
+	var form domain.StoreOwnerForm
+	if err := json.NewDecoder(r.Body).Decode(&form); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	if form.ChangedBy == "" {
+		http.Error(w, "changed_by is required", http.StatusBadRequest)
+		return
+	}
+
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	store, err := handler.UseCase.ChangeStoreOwner(id, &form, version)
+	if err != nil {
+		handler.handleWriteError(w, id, err)
+		return
+	}
+
+	etag.Set(w, store.Version)
+	if err := json.NewEncoder(w).Encode(store); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// GetStoreSummary returns the operations dashboard of the store. Lots expiring
+// within expiring_within_days days are listed; refresh=true bypasses the
+// cache.
+func (handler *StoreHandler) GetStoreSummary(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Store ID", http.StatusBadRequest)
+		return
+	}
+	withinDays := domain.DefaultExpiryWindowDays
+	if value := r.URL.Query().Get("expiring_within_days"); value != "" {
+		withinDays, err = strconv.Atoi(value)
+		if err != nil || withinDays < 1 || withinDays > domain.MaxExpiryWindowDays {
+			http.Error(w, fmt.Sprintf("expiring_within_days must be between 1 and %d", domain.MaxExpiryWindowDays), http.StatusBadRequest)
+			return
+		}
+	}
+	refresh, _ := strconv.ParseBool(r.URL.Query().Get("refresh"))
+
+	summary, err := handler.UseCase.GetStoreSummary(id, withinDays, refresh)
+	if err != nil {
+		handler.handleReadError(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(summary); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *StoreHandler) GetStoreOwnerHistory(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Store ID", http.StatusBadRequest)
+		return
+	}
+
+	history, err := handler.UseCase.GetStoreOwnerHistory(id)
+	if err != nil {
+		handler.handleReadError(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(history); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *StoreHandler) GetStoreMembers(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Store ID", http.StatusBadRequest)
+		return
+	}
+
+	members, err := handler.UseCase.GetStoreMembers(id)
+	if err != nil {
+		handler.handleReadError(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(members); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// SaveStoreMember makes an active user a manager or deputy of the store, or
+// changes the role the user already has there.
+func (handler *StoreHandler) SaveStoreMember(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Store ID", http.StatusBadRequest)
+		return
+	}
+	userID, err := strconv.ParseInt(params["user_id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid User ID", http.StatusBadRequest)
+		return
+	}
+
+	member := domain.StoreMember{Role: domain.STORE_MANAGER}
+	if err := json.NewDecoder(r.Body).Decode(&member); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	member.StoreID, member.UserID = id, userID
+	if err := member.Role.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.SaveStoreMember(&member); err != nil {
+		handler.handleWriteError(w, id, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (handler *StoreHandler) RemoveStoreMember(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Store ID", http.StatusBadRequest)
+		return
+	}
+	userID, err := strconv.ParseInt(params["user_id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid User ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.RemoveStoreMember(id, userID); err != nil {
+		handler.handleWriteError(w, id, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (handler *StoreHandler) handleReadError(w http.ResponseWriter, err error) {
+	if errors.Is(err, customerrors.ErrResourceNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+func (handler *StoreHandler) handleWriteError(w http.ResponseWriter, storeID int64, err error) {
+	current := func() (int64, interface{}, error) {
+		store, err := handler.UseCase.GetStoreByID(storeID)
+		if err != nil {
+			return 0, nil, err
+		}
+		return store.Version, store, nil
+	}
+	etag.WriteError(w, err, current, map[error]int{
+		domain.ErrStoreUserUnavailable: http.StatusBadRequest,
+	})
+}
+
 func validateStore(store *domain.Store) error {
 	if store.Name == "" {
 		return errors.New("name is required")
@@ -2,8 +2,10 @@ package rest
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/config"
 	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
 	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
 	"github.com/vamika-digital/wms-api-server/pkg/database"
@@ -15,7 +17,7 @@ type StoreModule struct {
 
 func NewStoreModule(db database.Connection) *StoreModule {
 	storeRepo := repository.NewStoreRepository(db)
-	storeUsecase := usecase.NewStoreUseCase(storeRepo)
+	storeUsecase := usecase.NewStoreUseCase(storeRepo, time.Duration(config.AppConfig.Dashboard.SummaryCacheTTL)*time.Second)
 	storeHandler := NewStoreHandler(storeUsecase)
 
 	return &StoreModule{Handler: storeHandler}
@@ -28,4 +30,10 @@ func (u *StoreModule) RegisterRoutes(r *mux.Router) {
 	subRouter.HandleFunc("/{id}", u.Handler.GetStoreByID).Methods(http.MethodGet, http.MethodOptions)
 	subRouter.HandleFunc("/{id}", u.Handler.UpdateStore).Methods(http.MethodPut)
 	subRouter.HandleFunc("/{id}", u.Handler.DeleteStore).Methods(http.MethodDelete)
+	subRouter.HandleFunc("/{id}/summary", u.Handler.GetStoreSummary).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/{id}/owner", u.Handler.ChangeStoreOwner).Methods(http.MethodPost)
+	subRouter.HandleFunc("/{id}/owner/history", u.Handler.GetStoreOwnerHistory).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/{id}/members", u.Handler.GetStoreMembers).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/{id}/members/{user_id}", u.Handler.SaveStoreMember).Methods(http.MethodPut)
+	subRouter.HandleFunc("/{id}/members/{user_id}", u.Handler.RemoveStoreMember).Methods(http.MethodDelete)
 }
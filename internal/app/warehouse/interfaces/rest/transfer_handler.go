@@ -0,0 +1,259 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/internal/utility/etag"
+	"github.com/vamika-digital/wms-api-server/internal/utility/valueobjects"
+)
+
+type TransferHandler struct {
+	UseCase usecase.TransferUseCase
+}
+
+func NewTransferHandler(useCase usecase.TransferUseCase) *TransferHandler {
+	return &TransferHandler{UseCase: useCase}
+}
+
+func (handler *TransferHandler) CreateTransfer(w http.ResponseWriter, r *http.Request) {
+	var order *domain.TransferOrder = domain.NewTransferOrderWithDefaults()
+
+	if err := json.NewDecoder(r.Body).Decode(order); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	if err := order.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.CreateTransfer(order); err != nil {
+		handler.handleWriteError(w, 0, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(order); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *TransferHandler) UpdateTransfer(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Transfer ID", http.StatusBadRequest)
+		return
+	}
+
+	var order *domain.TransferOrder = domain.NewTransferOrderWithDefaults()
+	if err := json.NewDecoder(r.Body).Decode(order); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := order.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	order.ID = id
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if version > 0 {
+		order.Version = version
+	}
+	if err := handler.UseCase.UpdateTransfer(order); err != nil {
+		handler.handleWriteError(w, order.ID, err)
+		return
+	}
+
+	etag.Set(w, order.Version)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (handler *TransferHandler) DeleteTransfer(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "invalid Transfer ID", http.StatusBadRequest)
+		return
+	}
+
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := handler.UseCase.DeleteTransfer(id, version); err != nil {
+		handler.handleWriteError(w, id, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (handler *TransferHandler) GetTransferByID(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Transfer ID", http.StatusBadRequest)
+		return
+	}
+
+	order, err := handler.UseCase.GetTransferByID(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	etag.Set(w, order.Version)
+	if err := json.NewEncoder(w).Encode(order); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *TransferHandler) GetAllTransfers(w http.ResponseWriter, r *http.Request) {
+	pageQuery := r.URL.Query().Get("page")
+	pageSizeQuery := r.URL.Query().Get("pageSize")
+	sort := r.URL.Query().Get("sort")
+
+	// Default values for pagination
+	page := 1
+	pageSize := 10
+
+	if pageQuery != "" {
+		page, _ = strconv.Atoi(pageQuery)
+	}
+	if pageSizeQuery != "" {
+		pageSize, _ = strconv.Atoi(pageSizeQuery)
+	}
+
+	// Extract filter parameters
+	filterOptions := repository.TransferFilterOptions{}
+	filterOptions.SetStatuses(r.URL.Query().Get("status"))
+	filterOptions.FromStoreID, _ = strconv.ParseInt(r.URL.Query().Get("from_store_id"), 10, 64)
+	filterOptions.ToStoreID, _ = strconv.ParseInt(r.URL.Query().Get("to_store_id"), 10, 64)
+	filterOptions.StoreID, _ = strconv.ParseInt(r.URL.Query().Get("store_id"), 10, 64)
+
+	orders, totalOrders, err := handler.UseCase.GetAllTransfers(page, pageSize, sort, filterOptions)
+	if err != nil {
+		// Handle error
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	if orders == nil {
+		orders = []*domain.TransferOrder{}
+	}
+
+	// Create a response with pagination details
+	response := valueobjects.PaginatedResponse{
+		Data:       orders,
+		TotalItems: totalOrders,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (totalOrders + pageSize - 1) / pageSize, // Calculate total pages
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// DispatchTransfer puts the stock of a draft order in transit.
+func (handler *TransferHandler) DispatchTransfer(w http.ResponseWriter, r *http.Request) {
+	var form domain.TransferDispatchForm
+	handler.transition(w, r, &form, func(id int64, version int64) (*domain.TransferOrder, error) {
+		if form.DispatchedBy == "" {
+			return nil, fmt.Errorf("%w: dispatched_by is required", domain.ErrInvalidTransfer)
+		}
+		return handler.UseCase.DispatchTransfer(id, &form, version)
+	})
+}
+
+// ReceiveTransfer confirms what arrived of some or all of the lines in transit.
+func (handler *TransferHandler) ReceiveTransfer(w http.ResponseWriter, r *http.Request) {
+	var form domain.TransferReceiptForm
+	handler.transition(w, r, &form, func(id int64, version int64) (*domain.TransferOrder, error) {
+		if form.ReceivedBy == "" {
+			return nil, fmt.Errorf("%w: received_by is required", domain.ErrInvalidTransfer)
+		}
+		return handler.UseCase.ReceiveTransfer(id, &form, version)
+	})
+}
+
+func (handler *TransferHandler) CancelTransfer(w http.ResponseWriter, r *http.Request) {
+	var form domain.TransferCancelForm
+	handler.transition(w, r, &form, func(id int64, version int64) (*domain.TransferOrder, error) {
+		return handler.UseCase.CancelTransfer(id, &form, version)
+	})
+}
+
+// transition decodes the form of a status change into form and runs apply
+// with the order id and the If-Match version.
+func (handler *TransferHandler) transition(w http.ResponseWriter, r *http.Request, form interface{}, apply func(id int64, version int64) (*domain.TransferOrder, error)) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Transfer ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(form); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	version, err := etag.ParseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	order, err := apply(id, version)
+	if err != nil {
+		handler.handleWriteError(w, id, err)
+		return
+	}
+
+	etag.Set(w, order.Version)
+	setWarnings(w, order.Warnings)
+	if err := json.NewEncoder(w).Encode(order); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *TransferHandler) handleWriteError(w http.ResponseWriter, orderID int64, err error) {
+	current := func() (int64, interface{}, error) {
+		order, err := handler.UseCase.GetTransferByID(orderID)
+		if err != nil {
+			return 0, nil, err
+		}
+		return order.Version, order, nil
+	}
+	etag.WriteError(w, err, current, map[error]int{
+		domain.ErrTransferNotDraft:          http.StatusConflict,
+		domain.ErrInvalidTransferTransition: http.StatusConflict,
+		domain.ErrTransferLotUnavailable:    http.StatusConflict,
+		domain.ErrCapacityExceeded:          http.StatusConflict,
+		domain.ErrContainerUnavailable:      http.StatusConflict,
+		domain.ErrInvalidTransfer:           http.StatusBadRequest,
+		domain.ErrTransferDiscrepancyReason: http.StatusBadRequest,
+		domain.ErrSerialCountMismatch:       http.StatusBadRequest,
+		domain.ErrLocationMismatch:          http.StatusBadRequest,
+	})
+}
@@ -0,0 +1,35 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/usecase"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+type TransferModule struct {
+	Handler *TransferHandler
+}
+
+func NewTransferModule(db database.Connection) *TransferModule {
+	transferRepo := repository.NewTransferRepository(db)
+	storeRepo := repository.NewStoreRepository(db)
+	transferUsecase := usecase.NewTransferUseCase(transferRepo, storeRepo)
+	transferHandler := NewTransferHandler(transferUsecase)
+
+	return &TransferModule{Handler: transferHandler}
+}
+
+func (u *TransferModule) RegisterRoutes(r *mux.Router) {
+	subRouter := r.PathPrefix("/transfers").Subrouter()
+	subRouter.HandleFunc("", u.Handler.CreateTransfer).Methods(http.MethodPost)
+	subRouter.HandleFunc("", u.Handler.GetAllTransfers).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/{id}", u.Handler.GetTransferByID).Methods(http.MethodGet, http.MethodOptions)
+	subRouter.HandleFunc("/{id}", u.Handler.UpdateTransfer).Methods(http.MethodPut)
+	subRouter.HandleFunc("/{id}", u.Handler.DeleteTransfer).Methods(http.MethodDelete)
+	subRouter.HandleFunc("/{id}/dispatch", u.Handler.DispatchTransfer).Methods(http.MethodPost)
+	subRouter.HandleFunc("/{id}/receive", u.Handler.ReceiveTransfer).Methods(http.MethodPost)
+	subRouter.HandleFunc("/{id}/cancel", u.Handler.CancelTransfer).Methods(http.MethodPost)
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+// loadColumns sums the units, weight and volume of the lots of a query that
+// joins inventories i with products p, counting units only for lots held in
+// count units. The products are rounded back to the scale of the quantity
+// columns. The returned arguments bind the placeholders of the columns and
+// come before those of the rest of the query.
+func loadColumns() (string, []interface{}) {
+	units := "0"
+	var args []interface{}
+	if countUnits := domain.CountUnits(); len(countUnits) > 0 {
+		units = "COALESCE(SUM(CASE WHEN i.unit IN (" + placeholders(len(countUnits)) + ") THEN i.quantity END), 0)"
+		for _, unit := range countUnits {
+			args = append(args, unit)
+		}
+	}
+	return "ROUND(" + units + ", 6), ROUND(COALESCE(SUM(i.quantity * p.weight), 0), 6), ROUND(COALESCE(SUM(i.quantity * p.volume), 0), 6)", args
+}
+
+// lotLoad is the load quantity of the product, held in unit, adds to a
+// container.
+func lotLoad(tx *sql.Tx, productID int64, quantity customtypes.Decimal, unit string) (domain.ContainerLoad, error) {
+	var weight, volume customtypes.Decimal
+	err := tx.QueryRow("SELECT weight, volume FROM products WHERE id = ?", productID).Scan(&weight, &volume)
+	if err != nil && err != sql.ErrNoRows {
+		return domain.ContainerLoad{}, err
+	}
+	return domain.LotLoad(quantity, unit, weight, volume)
+}
+
+// subtreeLoad is what the container and everything below it hold.
+func subtreeLoad(tx *sql.Tx, container *domain.Container) (domain.ContainerLoad, error) {
+	columns, args := loadColumns()
+	query := `SELECT ` + columns + ` FROM inventories i
+		JOIN containers c ON c.id = i.container_id JOIN products p ON p.id = i.product_id
+		WHERE c.path LIKE ? AND i.status <> ?`
+	var load domain.ContainerLoad
+	err := tx.QueryRow(query, append(args, container.Path+"%", domain.STOCK_OUT)...).Scan(&load.Units, &load.Weight, &load.Volume)
+	return load, err
+}
+
+// enforceCapacity checks that adding load to every container of chain keeps
+// it within its capacity. inventoryID is the lot being written, which is
+// left out of the current loads; a lot that grows in none of units, weight
+// and volume inside a container is never refused there, even when the
+// container is already over capacity.
+// Depending on the configured enforcement, violations are returned as an
+// error or as warnings.
+func enforceCapacity(tx *sql.Tx, chain []*domain.Container, load domain.ContainerLoad, inventoryID int64) ([]string, error) {
+	columns, columnArgs := loadColumns()
+	var violations []string
+	for _, container := range chain {
+		if container.EffectiveCapacity().IsUnlimited() {
+			continue
+		}
+		if inventoryID > 0 {
+			query := `SELECT COUNT(*), ` + columns + ` FROM inventories i
+				JOIN containers c ON c.id = i.container_id JOIN products p ON p.id = i.product_id
+				WHERE i.id = ? AND c.path LIKE ? AND i.status <> ?`
+			args := append(append([]interface{}{}, columnArgs...), inventoryID, container.Path+"%", domain.STOCK_OUT)
+			var lots int
+			var previous domain.ContainerLoad
+			if err := tx.QueryRow(query, args...).Scan(&lots, &previous.Units, &previous.Weight, &previous.Volume); err != nil {
+				return nil, err
+			}
+			if lots > 0 && !load.Exceeds(previous) {
+				continue
+			}
+		}
+
+		query := `SELECT ` + columns + ` FROM inventories i
+			JOIN containers c ON c.id = i.container_id JOIN products p ON p.id = i.product_id
+			WHERE c.path LIKE ? AND i.status <> ? AND i.id <> ?`
+		args := append(append([]interface{}{}, columnArgs...), container.Path+"%", domain.STOCK_OUT, inventoryID)
+		var current domain.ContainerLoad
+		if err := tx.QueryRow(query, args...).Scan(&current.Units, &current.Weight, &current.Volume); err != nil {
+			return nil, err
+		}
+		violations = append(violations, container.CapacityViolations(current.Add(load))...)
+	}
+	if len(violations) == 0 {
+		return nil, nil
+	}
+	if domain.CapacityEnforcement() == domain.CAPACITY_WARN {
+		return violations, nil
+	}
+	return nil, fmt.Errorf("%w: %s", domain.ErrCapacityExceeded, strings.Join(violations, "; "))
+}
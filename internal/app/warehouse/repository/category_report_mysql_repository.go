@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+)
+
+// categoryProductsFilter matches the rows whose product has the category as
+// primary category, or any category below it. It takes the category id.
+const categoryProductsFilter = `product_id IN (SELECT cp.id FROM products cp
+	JOIN categories c ON c.id = cp.category_id JOIN categories f ON c.path LIKE CONCAT(f.path, '%')
+	WHERE f.id = ?)`
+
+// GetProductCategories returns the category tree ordered by path, so that
+// parents come before their children.
+func (r *MySqlReportRepository) GetProductCategories() ([]*domain.ProductCategory, error) {
+	rows, err := r.conn.GetDB().Query("SELECT id, parent_id, path, code, COALESCE(name, '') FROM categories ORDER BY path")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := []*domain.ProductCategory{}
+	for rows.Next() {
+		category := &domain.ProductCategory{}
+		if err := rows.Scan(&category.ID, &category.ParentID, &category.Path, &category.Code, &category.Name); err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+	return categories, rows.Err()
+}
+
+// GetCategoryStock sums the stock on hand of storeID, or of every store when
+// it is zero, by the primary category of the products and unit.
+func (r *MySqlReportRepository) GetCategoryStock(storeID int64) ([]*domain.CategoryStockRow, error) {
+	query := `SELECT p.category_id, COALESCE(i.unit, ''),
+			SUM(i.quantity),
+			SUM(CASE WHEN i.status = ? THEN i.quantity ELSE 0 END),
+			SUM(CASE WHEN i.status = ? AND i.qc_status <> ? THEN i.quantity ELSE 0 END),
+			COUNT(*), COUNT(DISTINCT i.product_id)
+		FROM inventories i JOIN products p ON p.id = i.product_id
+		WHERE i.status IN (?, ?)`
+	args := []interface{}{domain.STOCK_RESERVED, domain.STOCK_IN, domain.QC_RELEASED, domain.STOCK_IN, domain.STOCK_RESERVED}
+	if storeID > 0 {
+		query += " AND i.store_id = ?"
+		args = append(args, storeID)
+	}
+	query += " GROUP BY p.category_id, COALESCE(i.unit, '') ORDER BY p.category_id, COALESCE(i.unit, '')"
+
+	rows, err := r.conn.GetDB().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stock []*domain.CategoryStockRow
+	for rows.Next() {
+		row := &domain.CategoryStockRow{}
+		if err := rows.Scan(&row.CategoryID, &row.Unit, &row.OnHand, &row.Reserved, &row.Held, &row.Lots, &row.Products); err != nil {
+			return nil, err
+		}
+		row.Available = row.OnHand.Sub(row.Reserved).Sub(row.Held)
+		stock = append(stock, row)
+	}
+	return stock, rows.Err()
+}
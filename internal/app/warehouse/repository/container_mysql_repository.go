@@ -3,13 +3,20 @@ package repository
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
 
 	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
 	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
 	"github.com/vamika-digital/wms-api-server/pkg/database"
 )
 
+const containerColumns = "id, type, parent_id, store_id, path, code, name, address, status, max_weight, max_volume, max_units, created_at, updated_at, last_updated_by, version"
+
 type MySqlContainerRepository struct {
 	conn database.Connection
 }
@@ -19,28 +26,498 @@ func NewContainerRepository(conn database.Connection) ContainerRepository {
 }
 
 func (r *MySqlContainerRepository) Create(container *domain.Container) error {
-	query := "INSERT INTO containers (type, code, name, address, status, last_updated_by) VALUES (?, ?, ?, ?, ?, ?)"
-	_, err := r.conn.GetDB().Exec(query, container.Type, container.Code, container.Name, container.Address, container.Status, container.LastUpdatedBy)
-	return err
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		return insertContainer(tx, container)
+	})
+}
+
+// insertContainer is shared with repositories that create containers as part
+// of a larger transaction. The container takes the store of its parent, and
+// its path is only known once the id is assigned. A container without a code
+// gets the next one of the number series of its type.
+func insertContainer(tx *sql.Tx, container *domain.Container) error {
+	var parent *domain.Container
+	if container.ParentID != nil {
+		var err error
+		if parent, err = lockContainer(tx, *container.ParentID); err != nil {
+			return err
+		}
+		container.StoreID = parent.StoreID
+	}
+	if err := container.ValidateParent(parent); err != nil {
+		return err
+	}
+	if container.Code == "" {
+		if err := assignContainerCode(tx, container); err != nil {
+			return err
+		}
+	}
+	if container.Name == "" {
+		container.Name = container.Code
+	}
+	// New containers start their lifecycle active
+	container.Status = domain.CONTAINER_ACTIVE
+
+	query := "INSERT INTO containers (type, parent_id, store_id, code, name, address, status, max_weight, max_volume, max_units, last_updated_by) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := tx.Exec(query, container.Type, container.ParentID, container.StoreID, container.Code, container.Name, container.Address, container.Status, container.Capacity.MaxWeight, container.Capacity.MaxVolume, container.Capacity.MaxUnits, container.LastUpdatedBy)
+	if err != nil {
+		return duplicateContainerCodeError(err)
+	}
+	if container.ID, err = result.LastInsertId(); err != nil {
+		return err
+	}
+	container.Path = domain.ChildPath(parent, container.ID)
+	if _, err = tx.Exec("UPDATE containers SET path = ? WHERE id = ?", container.Path, container.ID); err != nil {
+		return err
+	}
+	return insertContainerEvent(tx, &domain.ContainerEvent{
+		ContainerID:   container.ID,
+		Event:         domain.CONTAINER_CREATED,
+		ToContainerID: container.ParentID,
+		Actor:         container.LastUpdatedBy,
+	})
 }
 
 func (r *MySqlContainerRepository) Update(container *domain.Container) error {
-	query := "UPDATE containers SET type=?, code=?, name=?, address=?, status=?, last_updated_by=? WHERE id=?"
-	_, err := r.conn.GetDB().Exec(query, container.Type, container.Code, container.Name, container.Address, container.Status, container.LastUpdatedBy, container.ID)
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		query := "UPDATE containers SET type=?, code=?, name=?, address=?, max_weight=?, max_volume=?, max_units=?, last_updated_by=?, version=LAST_INSERT_ID(version+1) WHERE id=? AND (? = 0 OR version=?)"
+		result, err := tx.Exec(query, container.Type, container.Code, container.Name, container.Address, container.Capacity.MaxWeight, container.Capacity.MaxVolume, container.Capacity.MaxUnits, container.LastUpdatedBy, container.ID, container.Version, container.Version)
+		if err != nil {
+			return duplicateContainerCodeError(err)
+		}
+		if err := database.CheckVersionedWrite(result, "container", container.ID, container.Version); err != nil {
+			return err
+		}
+		container.Version = database.NextVersion(result, container.Version)
+		return nil
+	})
+}
+
+// Generate creates form.Count containers in one transaction, each with the
+// next code of the number series of the type and named after its code.
+func (r *MySqlContainerRepository) Generate(form *domain.GenerateContainersForm) ([]*domain.Container, error) {
+	var containers []*domain.Container
+	err := database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		for n := 0; n < form.Count; n++ {
+			container := &domain.Container{
+				Type:          form.Type,
+				ParentID:      form.ParentID,
+				StoreID:       form.StoreID,
+				LastUpdatedBy: form.LastUpdatedBy,
+			}
+			if err := insertContainer(tx, container); err != nil {
+				return err
+			}
+			containers = append(containers, container)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return containers, nil
+}
+
+// errLayoutDryRun rolls back the transaction of a dry run.
+var errLayoutDryRun = errors.New("layout dry run")
+
+// ApplyLayout creates the racks and bins of plan in one transaction. A rack or
+// bin whose code already exists with the same type and in the same place was
+// created by an earlier run and is kept, so that a layout can be applied again
+// after it was extended. A dry run goes through the same checks and then rolls
+// back.
+func (r *MySqlContainerRepository) ApplyLayout(plan *domain.LayoutPlan, dryRun bool) error {
+	spec := plan.Spec
+	plan.DryRun = dryRun
+	err := database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		var stores int
+		if err := tx.QueryRow("SELECT COUNT(*) FROM stores WHERE id = ?", spec.StoreID).Scan(&stores); err != nil {
+			return err
+		}
+		if stores == 0 {
+			return customerrors.ErrResourceNotFound
+		}
+		if spec.ZoneID != nil {
+			zone, err := lockContainer(tx, *spec.ZoneID)
+			if err != nil {
+				return err
+			}
+			if zone.Type != domain.ZONE_TYPE || zone.StoreID == nil || *zone.StoreID != spec.StoreID {
+				return fmt.Errorf("%w: container %d is not a zone of store %d", domain.ErrInvalidLayout, zone.ID, spec.StoreID)
+			}
+		}
+
+		plan.Created, plan.Existing = 0, 0
+		for _, rack := range plan.Racks {
+			if err := applyLayoutNode(tx, plan, rack, spec.ZoneID); err != nil {
+				return err
+			}
+			rackID := rack.ID
+			for _, bin := range rack.Bins {
+				if err := applyLayoutNode(tx, plan, bin, &rackID); err != nil {
+					return err
+				}
+			}
+		}
+		if dryRun {
+			return errLayoutDryRun
+		}
+		return nil
+	})
+	if errors.Is(err, errLayoutDryRun) {
+		// The ids of containers that were rolled back mean nothing
+		for _, rack := range plan.Racks {
+			for _, node := range append([]*domain.LayoutNode{rack}, rack.Bins...) {
+				if node.Action == domain.LAYOUT_CREATE {
+					node.ID = 0
+				}
+			}
+		}
+		return nil
+	}
 	return err
 }
 
-func (r *MySqlContainerRepository) Delete(containerID int64) error {
-	query := "DELETE FROM containers WHERE id=?"
-	_, err := r.conn.GetDB().Exec(query, containerID)
+func applyLayoutNode(tx *sql.Tx, plan *domain.LayoutPlan, node *domain.LayoutNode, parentID *int64) error {
+	row := tx.QueryRow("SELECT "+containerColumns+" FROM containers WHERE code = ? FOR UPDATE", node.Code)
+	existing, err := scanContainer(row)
+	if err == sql.ErrNoRows {
+		container := &domain.Container{
+			Type:          node.Type,
+			ParentID:      parentID,
+			StoreID:       &plan.Spec.StoreID,
+			Code:          customtypes.NullableString(node.Code),
+			LastUpdatedBy: plan.Spec.LastUpdatedBy,
+		}
+		if node.Type == domain.BIN_TYPE {
+			container.Capacity = plan.Spec.BinCapacity
+		}
+		if err := insertContainer(tx, container); err != nil {
+			return err
+		}
+		node.ID, node.Action = container.ID, domain.LAYOUT_CREATE
+		plan.Created++
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	sameParent := (existing.ParentID == nil && parentID == nil) ||
+		(existing.ParentID != nil && parentID != nil && *existing.ParentID == *parentID)
+	if existing.Type != node.Type || !sameParent || existing.StoreID == nil || *existing.StoreID != plan.Spec.StoreID {
+		return fmt.Errorf("%w: %s", domain.ErrLayoutConflict, node.Code)
+	}
+	node.ID, node.Action = existing.ID, domain.LAYOUT_EXISTS
+	plan.Existing++
+	return nil
+}
+
+func (r *MySqlContainerRepository) Delete(containerID int64, version int64) error {
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		var children int
+		if err := tx.QueryRow("SELECT COUNT(*) FROM containers WHERE parent_id = ?", containerID).Scan(&children); err != nil {
+			return err
+		}
+		if children > 0 {
+			return domain.ErrContainerHasChildren
+		}
+		query := "DELETE FROM containers WHERE id=? AND (? = 0 OR version=?)"
+		result, err := tx.Exec(query, containerID, version, version)
+		if err != nil {
+			return err
+		}
+		return database.CheckVersionedWrite(result, "container", containerID, version)
+	})
+}
+
+// Move places the container, together with everything below it, in parentID
+// or directly in storeID when parentID is nil. The lots held anywhere in the
+// subtree get their derived location updated and a ledger entry, and the move
+// is added to the timeline of the container. New ancestors must be active. It
+// returns the capacity limits of the new ancestors that were only warned
+// about.
+func (r *MySqlContainerRepository) Move(containerID int64, parentID *int64, storeID *int64, version int64) ([]string, error) {
+	var warnings []string
+	err := database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		var err error
+		warnings, err = moveContainer(tx, containerID, parentID, storeID, version)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return warnings, nil
+}
+
+// moveContainer is shared with repositories that move a container as part of
+// a larger transaction.
+func moveContainer(tx *sql.Tx, containerID int64, parentID *int64, storeID *int64, version int64) ([]string, error) {
+	var warnings []string
+	container, err := lockContainer(tx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	var parent *domain.Container
+	if parentID != nil {
+		if parent, err = lockContainer(tx, *parentID); err != nil {
+			return nil, err
+		}
+		storeID = parent.StoreID
+	}
+	container.StoreID = storeID
+	if err := container.ValidateParent(parent); err != nil {
+		return nil, err
+	}
+	if parent != nil {
+		chain, err := loadContainerChain(tx, parent.ID)
+		if err != nil {
+			return nil, err
+		}
+		load, err := subtreeLoad(tx, container)
+		if err != nil {
+			return nil, err
+		}
+		// Ancestors the container already sits in hold its load already
+		var newAncestors []*domain.Container
+		for _, ancestor := range chain {
+			if !ancestor.IsAncestorOf(container) {
+				newAncestors = append(newAncestors, ancestor)
+			}
+		}
+		if err := domain.CheckAcceptsStock(newAncestors); err != nil {
+			return nil, err
+		}
+		if warnings, err = enforceCapacity(tx, newAncestors, load, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	query := "UPDATE containers SET parent_id=?, version=version+1 WHERE id=? AND (? = 0 OR version=?)"
+	result, err := tx.Exec(query, parentID, containerID, version, version)
+	if err != nil {
+		return nil, err
+	}
+	if err := database.CheckVersionedWrite(result, "container", containerID, version); err != nil {
+		return nil, err
+	}
+
+	oldPath := container.Path
+	newPath := domain.ChildPath(parent, containerID)
+	query = "UPDATE containers SET path = CONCAT(?, SUBSTRING(path, ?)), store_id = ? WHERE path LIKE ?"
+	if _, err := tx.Exec(query, newPath, len(oldPath)+1, storeID, oldPath+"%"); err != nil {
+		return nil, err
+	}
+	container.Path = newPath
+
+	err = insertContainerEvent(tx, &domain.ContainerEvent{
+		ContainerID:     containerID,
+		Event:           domain.CONTAINER_MOVED,
+		FromContainerID: container.ParentID,
+		ToContainerID:   parentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := relocateInventories(tx, container); err != nil {
+		return nil, err
+	}
+	return warnings, nil
+}
+
+// ChangeStatus moves the container along its lifecycle and records who
+// changed it and why. A container can only be retired once it is empty.
+func (r *MySqlContainerRepository) ChangeStatus(containerID int64, form *domain.ContainerStatusForm, version int64) error {
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		container, err := lockContainer(tx, containerID)
+		if err != nil {
+			return err
+		}
+		if err := container.ValidateStatusTransition(form.Status); err != nil {
+			return err
+		}
+		if form.Status == domain.CONTAINER_RETIRED {
+			load, err := subtreeLoad(tx, container)
+			if err != nil {
+				return err
+			}
+			if load.Units.Sign() > 0 {
+				return domain.ErrContainerNotEmpty
+			}
+		}
+
+		query := "UPDATE containers SET status=?, last_updated_by=?, version=version+1 WHERE id=? AND (? = 0 OR version=?)"
+		result, err := tx.Exec(query, form.Status, form.ChangedBy, containerID, version, version)
+		if err != nil {
+			return err
+		}
+		if err := database.CheckVersionedWrite(result, "container", containerID, version); err != nil {
+			return err
+		}
+		return insertContainerEvent(tx, &domain.ContainerEvent{
+			ContainerID: containerID,
+			Event:       domain.CONTAINER_STATUS_CHANGED,
+			FromStatus:  customtypes.NullableString(container.Status),
+			ToStatus:    customtypes.NullableString(form.Status),
+			Note:        form.Reason,
+			Actor:       form.ChangedBy,
+		})
+	})
+}
+
+// RecordEvent adds an event that happens outside the repository, such as a
+// printed label, to the timeline of a container.
+func (r *MySqlContainerRepository) RecordEvent(event *domain.ContainerEvent) error {
+	return insertContainerEvent(r.conn.GetDB(), event)
+}
+
+// insertContainerEvent appends event to the timeline of its container, noting
+// the store the container is in at the time.
+func insertContainerEvent(db database.Execer, event *domain.ContainerEvent) error {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	query := `INSERT INTO container_events (container_id, event, store_id, from_container_id, to_container_id, inventory_id, product_id, batch, quantity, unit, from_status, to_status, note, actor, occurred_at)
+		SELECT id, ?, store_id, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? FROM containers WHERE id = ?`
+	_, err := db.Exec(query, event.Event, event.FromContainerID, event.ToContainerID, event.InventoryID, event.ProductID, event.Batch, event.Quantity, event.Unit, event.FromStatus, event.ToStatus, event.Note, event.Actor, event.OccurredAt, event.ContainerID)
 	return err
 }
 
-func (r *MySqlContainerRepository) GetByCode(code string) (*domain.Container, error) {
-	query := "SELECT id, type, code, name, address, status, created_at, updated_at, last_updated_by FROM containers WHERE code = ?"
-	row := r.conn.GetDB().QueryRow(query, code)
-	container := &domain.Container{}
-	err := row.Scan(&container.ID, &container.Type, &container.Code, &container.Name, &container.Address, &container.Status, &container.CreatedAt, &container.UpdatedAt, &container.LastUpdatedBy)
+func (r *MySqlContainerRepository) GetEventCount(containerID int64, filter ContainerEventFilterOptions) (int, error) {
+	query, args := buildEventFilterQuery("SELECT COUNT(*) FROM container_events WHERE container_id = ?", containerID, filter)
+	var count int
+	err := r.conn.GetDB().QueryRow(query, args...).Scan(&count)
+	return count, err
+}
+
+// GetEvents returns a page of the timeline of the container, oldest first.
+func (r *MySqlContainerRepository) GetEvents(containerID int64, page int, pageSize int, filter ContainerEventFilterOptions) ([]*domain.ContainerEvent, error) {
+	query, args := buildEventFilterQuery(`SELECT id, container_id, event, store_id, from_container_id, to_container_id, inventory_id, product_id, batch, quantity, unit, from_status, to_status, note, actor, occurred_at
+		FROM container_events WHERE container_id = ?`, containerID, filter)
+	query += " ORDER BY occurred_at, id LIMIT ? OFFSET ?"
+	args = append(args, pageSize, (page-1)*pageSize)
+
+	rows, err := r.conn.GetDB().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []*domain.ContainerEvent{}
+	for rows.Next() {
+		event := &domain.ContainerEvent{}
+		err := rows.Scan(&event.ID, &event.ContainerID, &event.Event, &event.StoreID, &event.FromContainerID, &event.ToContainerID, &event.InventoryID, &event.ProductID, &event.Batch, &event.Quantity, &event.Unit, &event.FromStatus, &event.ToStatus, &event.Note, &event.Actor, &event.OccurredAt)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func buildEventFilterQuery(baseQuery string, containerID int64, filter ContainerEventFilterOptions) (string, []interface{}) {
+	query := baseQuery
+	args := []interface{}{containerID}
+	if filter.Event != "" {
+		query += " AND event = ?"
+		args = append(args, filter.Event)
+	}
+	if filter.From != nil {
+		query += " AND occurred_at >= ?"
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		query += " AND occurred_at <= ?"
+		args = append(args, *filter.To)
+	}
+	return query, args
+}
+
+// relocateInventories refreshes the derived location of every lot held in
+// the subtree of container, which has already been moved.
+func relocateInventories(tx *sql.Tx, container *domain.Container) error {
+	rows, err := tx.Query("SELECT "+containerColumns+" FROM containers WHERE id IN ("+placeholders(len(container.AncestorIDs()))+") OR path LIKE ?", append(int64Args(container.AncestorIDs()), container.Path+"%")...)
+	if err != nil {
+		return err
+	}
+	byID := make(map[int64]*domain.Container)
+	var subtree []*domain.Container
+	for rows.Next() {
+		c, err := scanContainer(rows)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		byID[c.ID] = c
+		if strings.HasPrefix(c.Path, container.Path) {
+			subtree = append(subtree, c)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, c := range subtree {
+		var chain []*domain.Container
+		for _, id := range c.AncestorIDs() {
+			if ancestor, ok := byID[id]; ok {
+				chain = append(chain, ancestor)
+			}
+		}
+		location := domain.LocationOf(chain)
+
+		var lotIDs []int64
+		lots, err := tx.Query("SELECT id FROM inventories WHERE container_id = ? AND status <> ? FOR UPDATE", c.ID, domain.STOCK_OUT)
+		if err != nil {
+			return err
+		}
+		for lots.Next() {
+			var id int64
+			if err := lots.Scan(&id); err != nil {
+				lots.Close()
+				return err
+			}
+			lotIDs = append(lotIDs, id)
+		}
+		lots.Close()
+		if err := lots.Err(); err != nil {
+			return err
+		}
+
+		for _, id := range lotIDs {
+			query := "UPDATE inventories SET pallet_id=?, bin_id=?, rack_id=?, store_id=COALESCE(?, store_id), version=version+1 WHERE id=?"
+			if _, err := tx.Exec(query, location.PalletID, location.BinID, location.RackID, location.StoreID, id); err != nil {
+				return err
+			}
+			if err := recordMovement(tx, id, domain.MOVEMENT_UPDATED); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func int64Args(values []int64) []interface{} {
+	args := make([]interface{}, len(values))
+	for i, value := range values {
+		args[i] = value
+	}
+	return args
+}
+
+// duplicateContainerCodeError maps a violation of the unique container code
+// to ErrDuplicateContainerCode.
+func duplicateContainerCodeError(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+		return domain.ErrDuplicateContainerCode
+	}
+	return err
+}
+
+func lockContainer(tx *sql.Tx, containerID int64) (*domain.Container, error) {
+	row := tx.QueryRow("SELECT "+containerColumns+" FROM containers WHERE id = ? FOR UPDATE", containerID)
+	container, err := scanContainer(row)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, customerrors.ErrResourceNotFound
@@ -50,20 +527,125 @@ func (r *MySqlContainerRepository) GetByCode(code string) (*domain.Container, er
 	return container, nil
 }
 
-func (r *MySqlContainerRepository) GetById(containerID int64) (*domain.Container, error) {
-	query := "SELECT id, type, code, name, address, status, created_at, updated_at, last_updated_by FROM containers WHERE id = ?"
-	row := r.conn.GetDB().QueryRow(query, containerID)
+// loadContainerChain returns the container and its ancestors, root first.
+// They stay locked until the transaction ends so that concurrent writes
+// cannot overfill them together. Locking the container first keeps its path
+// from changing; the ancestors named in the path are then locked by id.
+func loadContainerChain(tx *sql.Tx, containerID int64) ([]*domain.Container, error) {
+	container, err := lockContainer(tx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	ids := container.AncestorIDs()
+	rows, err := tx.Query("SELECT "+containerColumns+" FROM containers WHERE id IN ("+placeholders(len(ids))+") ORDER BY id FOR UPDATE", int64Args(ids)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byID := make(map[int64]*domain.Container, len(ids))
+	for rows.Next() {
+		ancestor, err := scanContainer(rows)
+		if err != nil {
+			return nil, err
+		}
+		byID[ancestor.ID] = ancestor
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	chain := make([]*domain.Container, 0, len(ids))
+	for _, id := range ids {
+		if ancestor, ok := byID[id]; ok {
+			chain = append(chain, ancestor)
+		}
+	}
+	return chain, nil
+}
+
+func scanContainer(row rowScanner) (*domain.Container, error) {
 	container := &domain.Container{}
-	err := row.Scan(&container.ID, &container.Type, &container.Code, &container.Name, &container.Address, &container.Status, &container.CreatedAt, &container.UpdatedAt, &container.LastUpdatedBy)
+	err := row.Scan(&container.ID, &container.Type, &container.ParentID, &container.StoreID, &container.Path, &container.Code, &container.Name, &container.Address, &container.Status, &container.Capacity.MaxWeight, &container.Capacity.MaxVolume, &container.Capacity.MaxUnits, &container.CreatedAt, &container.UpdatedAt, &container.LastUpdatedBy, &container.Version)
+	if err != nil {
+		return nil, err
+	}
+	return container, nil
+}
+
+func (r *MySqlContainerRepository) GetByCode(code string) (*domain.Container, error) {
+	query := "SELECT " + containerColumns + " FROM containers WHERE code = ?"
+	return r.getContainer(query, code)
+}
+
+func (r *MySqlContainerRepository) GetById(containerID int64) (*domain.Container, error) {
+	query := "SELECT " + containerColumns + " FROM containers WHERE id = ?"
+	return r.getContainer(query, containerID)
+}
+
+func (r *MySqlContainerRepository) getContainer(query string, args ...interface{}) (*domain.Container, error) {
+	container, err := scanContainer(r.conn.GetDB().QueryRow(query, args...))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, customerrors.ErrResourceNotFound
 		}
 		return nil, err
 	}
+	if err := r.setLoads([]*domain.Container{container}); err != nil {
+		return nil, err
+	}
 	return container, nil
 }
 
+// setLoads fills in what each container holds, counting everything below
+// it, from which its occupancy is derived.
+func (r *MySqlContainerRepository) setLoads(containers []*domain.Container) error {
+	if len(containers) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(containers))
+	for i, container := range containers {
+		ids[i] = container.ID
+	}
+	columns, args := loadColumns()
+	query := `SELECT a.id, ` + columns + ` FROM containers a
+		JOIN containers c ON c.path LIKE CONCAT(a.path, '%')
+		JOIN inventories i ON i.container_id = c.id JOIN products p ON p.id = i.product_id
+		WHERE a.id IN (` + placeholders(len(ids)) + `) AND i.status <> ? GROUP BY a.id`
+	args = append(append(args, int64Args(ids)...), domain.STOCK_OUT)
+	rows, err := r.conn.GetDB().Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	loads := make(map[int64]domain.ContainerLoad)
+	for rows.Next() {
+		var id int64
+		var load domain.ContainerLoad
+		if err := rows.Scan(&id, &load.Units, &load.Weight, &load.Volume); err != nil {
+			return err
+		}
+		loads[id] = load
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, container := range containers {
+		container.SetLoad(loads[container.ID])
+	}
+	return nil
+}
+
+func (r *MySqlContainerRepository) GetChildren(containerID int64) ([]*domain.Container, error) {
+	return r.queryContainers("SELECT "+containerColumns+" FROM containers WHERE parent_id = ? ORDER BY id", containerID)
+}
+
+// GetDescendants returns everything below the container, ordered by path so
+// that parents come before their children.
+func (r *MySqlContainerRepository) GetDescendants(container *domain.Container) ([]*domain.Container, error) {
+	return r.queryContainers("SELECT "+containerColumns+" FROM containers WHERE path LIKE ? AND id <> ? ORDER BY path", container.Path+"%", container.ID)
+}
+
 func (r *MySqlContainerRepository) GetTotalCount(filter ContainerFilterOptions) (int, error) {
 	query, args := r.buildFilterQuery("SELECT COUNT(*) FROM containers", filter)
 
@@ -76,7 +658,7 @@ func (r *MySqlContainerRepository) GetTotalCount(filter ContainerFilterOptions)
 }
 
 func (r *MySqlContainerRepository) GetAll(page int, pageSize int, sort string, filter ContainerFilterOptions) ([]*domain.Container, error) {
-	query, args := r.buildFilterQuery("SELECT id, type, code, name, address, status, created_at, updated_at, last_updated_by FROM containers", filter)
+	query, args := r.buildFilterQuery("SELECT "+containerColumns+" FROM containers", filter)
 	var allowedSortOrders = map[string]bool{
 		"type ASC":    true,
 		"type DESC":   true,
@@ -86,6 +668,8 @@ func (r *MySqlContainerRepository) GetAll(page int, pageSize int, sort string, f
 		"code DESC":   true,
 		"status ASC":  true,
 		"status DESC": true,
+		"path ASC":    true,
+		"path DESC":   true,
 	}
 
 	if sort != "" {
@@ -99,6 +683,10 @@ func (r *MySqlContainerRepository) GetAll(page int, pageSize int, sort string, f
 	query += " LIMIT ? OFFSET ?"
 	args = append(args, pageSize, page)
 
+	return r.queryContainers(query, args...)
+}
+
+func (r *MySqlContainerRepository) queryContainers(query string, args ...interface{}) ([]*domain.Container, error) {
 	rows, err := r.conn.GetDB().Query(query, args...)
 	if err != nil {
 		return nil, err
@@ -107,12 +695,18 @@ func (r *MySqlContainerRepository) GetAll(page int, pageSize int, sort string, f
 
 	var containers []*domain.Container
 	for rows.Next() {
-		container := &domain.Container{}
-		if err := rows.Scan(&container.ID, &container.Type, &container.Code, &container.Name, &container.Address, &container.Status, &container.CreatedAt, &container.UpdatedAt, &container.LastUpdatedBy); err != nil {
+		container, err := scanContainer(rows)
+		if err != nil {
 			return nil, err
 		}
 		containers = append(containers, container)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := r.setLoads(containers); err != nil {
+		return nil, err
+	}
 	return containers, nil
 }
 
@@ -136,6 +730,18 @@ func (r *MySqlContainerRepository) buildFilterQuery(baseQuery string, filter Con
 		filters = append(filters, "status = ?")
 		args = append(args, filter.Status)
 	}
+	if filter.ParentID > 0 {
+		filters = append(filters, "parent_id = ?")
+		args = append(args, filter.ParentID)
+	}
+	if filter.StoreID > 0 {
+		filters = append(filters, "store_id = ?")
+		args = append(args, filter.StoreID)
+	}
+	if filter.Under != "" {
+		filters = append(filters, "path LIKE CONCAT((SELECT a.path FROM containers a WHERE a.code = ? LIMIT 1), '%') AND code <> ?")
+		args = append(args, filter.Under, filter.Under)
+	}
 
 	if len(filters) > 0 {
 		return baseQuery + " WHERE " + strings.Join(filters, " AND "), args
@@ -1,20 +1,54 @@
 package repository
 
-import "github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+import (
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
 
 type ContainerRepository interface {
 	Create(container *domain.Container) error
 	Update(container *domain.Container) error
-	Delete(containerID int64) error
+	Delete(containerID int64, version int64) error
+	Generate(form *domain.GenerateContainersForm) ([]*domain.Container, error)
+	ApplyLayout(plan *domain.LayoutPlan, dryRun bool) error
+	Move(containerID int64, parentID *int64, storeID *int64, version int64) ([]string, error)
+	ChangeStatus(containerID int64, form *domain.ContainerStatusForm, version int64) error
+	RecordEvent(event *domain.ContainerEvent) error
+	GetEventCount(containerID int64, filter ContainerEventFilterOptions) (int, error)
+	GetEvents(containerID int64, page int, pageSize int, filter ContainerEventFilterOptions) ([]*domain.ContainerEvent, error)
 	GetByCode(code string) (*domain.Container, error)
 	GetById(containerID int64) (*domain.Container, error)
+	GetChildren(containerID int64) ([]*domain.Container, error)
+	GetDescendants(container *domain.Container) ([]*domain.Container, error)
 	GetTotalCount(filter ContainerFilterOptions) (int, error)
 	GetAll(page int, pageSize int, sort string, filter ContainerFilterOptions) ([]*domain.Container, error)
 }
 
 type ContainerFilterOptions struct {
-	Type   string
-	Code   string
-	Name   string
-	Status string
+	Type     string
+	Code     string
+	Name     string
+	Status   string
+	ParentID customtypes.NullableInt64
+	StoreID  customtypes.NullableInt64
+	// Under is the code of a container whose descendants are listed.
+	Under string
+}
+
+// ContainerEventFilterOptions narrows the timeline of a container to one kind
+// of event and to a time range; a nil bound is open.
+type ContainerEventFilterOptions struct {
+	Event string
+	From  *time.Time
+	To    *time.Time
+}
+
+func (f *ContainerEventFilterOptions) SetRange(fromStr string, toStr string) (err error) {
+	if f.From, err = parseTimeBound(fromStr, false); err != nil {
+		return err
+	}
+	f.To, err = parseTimeBound(toStr, true)
+	return err
 }
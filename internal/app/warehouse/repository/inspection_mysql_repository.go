@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+const inspectionColumns = "id, inventory_id, product_id, batch, status, inspector, remarks, decided_at, created_at"
+
+type MySqlInspectionRepository struct {
+	conn database.Connection
+}
+
+func NewInspectionRepository(conn database.Connection) InspectionRepository {
+	return &MySqlInspectionRepository{conn: conn}
+}
+
+func (r *MySqlInspectionRepository) Create(inspection *domain.Inspection) error {
+	query := "INSERT INTO inspections (inventory_id, product_id, batch, status, inspector, remarks) VALUES (?, ?, ?, ?, ?, ?)"
+	result, err := r.conn.GetDB().Exec(query, inspection.InventoryID, inspection.ProductID, inspection.Batch, inspection.Status, inspection.Inspector, inspection.Remarks)
+	if err != nil {
+		return err
+	}
+	inspection.ID, err = result.LastInsertId()
+	return err
+}
+
+func (r *MySqlInspectionRepository) Update(inspection *domain.Inspection) error {
+	query := "UPDATE inspections SET status=?, inspector=?, remarks=?, decided_at=? WHERE id=?"
+	_, err := r.conn.GetDB().Exec(query, inspection.Status, inspection.Inspector, inspection.Remarks, inspection.DecidedAt, inspection.ID)
+	return err
+}
+
+// Decide closes an open inspection with its decision and writes the new QC
+// status and location of the lot, and the serial event when event is not
+// empty, in one transaction. The lot is guarded by its version, so a lot that
+// changed since it was read fails the decision as a whole.
+func (r *MySqlInspectionRepository) Decide(inspection *domain.Inspection, inventory *domain.Inventory, event domain.SerialEventType, actor string) error {
+	var version int64
+	err := database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		query := "UPDATE inspections SET status=?, inspector=?, remarks=?, decided_at=? WHERE id=? AND status=?"
+		result, err := tx.Exec(query, inspection.Status, inspection.Inspector, inspection.Remarks, inspection.DecidedAt, inspection.ID, domain.INSPECTION_OPEN)
+		if err != nil {
+			return err
+		}
+		if affected, err := result.RowsAffected(); err != nil {
+			return err
+		} else if affected == 0 {
+			return domain.ErrInspectionClosed
+		}
+		if version, err = updateInventory(tx, inventory); err != nil {
+			return err
+		}
+		if event == "" {
+			return nil
+		}
+		return syncSerials(tx, inventory, event, actor)
+	})
+	if err != nil {
+		return err
+	}
+	inventory.Version = version
+	return nil
+}
+
+func (r *MySqlInspectionRepository) AddResult(inspectionID int64, result *domain.InspectionResult) error {
+	query := "INSERT INTO inspection_results (inspection_id, parameter_id, name, value, passed, recorded_at, recorded_by) VALUES (?, ?, ?, ?, ?, ?, ?)"
+	res, err := r.conn.GetDB().Exec(query, inspectionID, result.ParameterID, result.Name, result.Value, result.Passed, result.RecordedAt, result.RecordedBy)
+	if err != nil {
+		return err
+	}
+	result.ID, err = res.LastInsertId()
+	return err
+}
+
+func (r *MySqlInspectionRepository) GetById(inspectionID int64) (*domain.Inspection, error) {
+	query := "SELECT " + inspectionColumns + " FROM inspections WHERE id = ?"
+	inspection, err := r.scanInspection(r.conn.GetDB().QueryRow(query, inspectionID))
+	if err != nil {
+		return nil, err
+	}
+	if inspection.Results, err = r.getResults(inspection.ID); err != nil {
+		return nil, err
+	}
+	return inspection, nil
+}
+
+func (r *MySqlInspectionRepository) GetOpenByInventory(inventoryID int64) (*domain.Inspection, error) {
+	query := "SELECT " + inspectionColumns + " FROM inspections WHERE inventory_id = ? AND status = ? ORDER BY id DESC LIMIT 1"
+	inspection, err := r.scanInspection(r.conn.GetDB().QueryRow(query, inventoryID, domain.INSPECTION_OPEN))
+	if err != nil {
+		return nil, err
+	}
+	if inspection.Results, err = r.getResults(inspection.ID); err != nil {
+		return nil, err
+	}
+	return inspection, nil
+}
+
+func (r *MySqlInspectionRepository) GetTotalCount(filter InspectionFilterOptions) (int, error) {
+	query, args := r.buildFilterQuery("SELECT COUNT(*) FROM inspections", filter)
+
+	var count int
+	if err := r.conn.GetDB().QueryRow(query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetAll lists inspections without their results; fetch a single inspection
+// to see what was recorded.
+func (r *MySqlInspectionRepository) GetAll(page int, pageSize int, sort string, filter InspectionFilterOptions) ([]*domain.Inspection, error) {
+	query, args := r.buildFilterQuery("SELECT "+inspectionColumns+" FROM inspections", filter)
+	var allowedSortOrders = map[string]bool{
+		"created_at ASC":  true,
+		"created_at DESC": true,
+		"decided_at ASC":  true,
+		"decided_at DESC": true,
+		"status ASC":      true,
+		"status DESC":     true,
+	}
+
+	if sort != "" {
+		if _, ok := allowedSortOrders[sort]; ok {
+			query += " ORDER BY " + sort
+		} else {
+			return nil, errors.New("invalid sort order")
+		}
+	}
+
+	query += " LIMIT ? OFFSET ?"
+	args = append(args, pageSize, page)
+
+	rows, err := r.conn.GetDB().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var inspections []*domain.Inspection
+	for rows.Next() {
+		inspection, err := r.scanInspection(rows)
+		if err != nil {
+			return nil, err
+		}
+		inspections = append(inspections, inspection)
+	}
+	return inspections, rows.Err()
+}
+
+func (r *MySqlInspectionRepository) getResults(inspectionID int64) ([]*domain.InspectionResult, error) {
+	query := "SELECT id, parameter_id, name, value, passed, recorded_at, recorded_by FROM inspection_results WHERE inspection_id = ? ORDER BY id"
+	rows, err := r.conn.GetDB().Query(query, inspectionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []*domain.InspectionResult{}
+	for rows.Next() {
+		result := &domain.InspectionResult{}
+		if err := rows.Scan(&result.ID, &result.ParameterID, &result.Name, &result.Value, &result.Passed, &result.RecordedAt, &result.RecordedBy); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
+
+func (r *MySqlInspectionRepository) scanInspection(row rowScanner) (*domain.Inspection, error) {
+	inspection := &domain.Inspection{}
+	err := row.Scan(&inspection.ID, &inspection.InventoryID, &inspection.ProductID, &inspection.Batch, &inspection.Status, &inspection.Inspector, &inspection.Remarks, &inspection.DecidedAt, &inspection.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, customerrors.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return inspection, nil
+}
+
+func (r *MySqlInspectionRepository) buildFilterQuery(baseQuery string, filter InspectionFilterOptions) (string, []interface{}) {
+	var filters []string
+	var args []interface{}
+
+	if len(filter.Statuses) > 0 {
+		filters = append(filters, "status IN ("+placeholders(len(filter.Statuses))+")")
+		for _, status := range filter.Statuses {
+			args = append(args, status)
+		}
+	}
+	if filter.InventoryID > 0 {
+		filters = append(filters, "inventory_id = ?")
+		args = append(args, filter.InventoryID)
+	}
+	if filter.ProductID > 0 {
+		filters = append(filters, "product_id = ?")
+		args = append(args, filter.ProductID)
+	}
+
+	if len(filters) > 0 {
+		return baseQuery + " WHERE " + strings.Join(filters, " AND "), args
+	}
+
+	return baseQuery, args
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+const inspectionParameterColumns = "id, product_id, name, unit, min_value, max_value, expected_value, mandatory, status, created_at, updated_at, last_updated_by, version"
+
+type MySqlInspectionParameterRepository struct {
+	conn database.Connection
+}
+
+func NewInspectionParameterRepository(conn database.Connection) InspectionParameterRepository {
+	return &MySqlInspectionParameterRepository{conn: conn}
+}
+
+func (r *MySqlInspectionParameterRepository) Create(parameter *domain.InspectionParameter) error {
+	query := "INSERT INTO inspection_parameters (product_id, name, unit, min_value, max_value, expected_value, mandatory, status, last_updated_by) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	_, err := r.conn.GetDB().Exec(query, parameter.ProductID, parameter.Name, parameter.Unit, parameter.MinValue, parameter.MaxValue, parameter.ExpectedValue, parameter.Mandatory, parameter.Status, parameter.LastUpdatedBy)
+	return err
+}
+
+func (r *MySqlInspectionParameterRepository) Update(parameter *domain.InspectionParameter) error {
+	query := "UPDATE inspection_parameters SET product_id=?, name=?, unit=?, min_value=?, max_value=?, expected_value=?, mandatory=?, status=?, last_updated_by=?, version=LAST_INSERT_ID(version+1) WHERE id=? AND (? = 0 OR version=?)"
+	result, err := r.conn.GetDB().Exec(query, parameter.ProductID, parameter.Name, parameter.Unit, parameter.MinValue, parameter.MaxValue, parameter.ExpectedValue, parameter.Mandatory, parameter.Status, parameter.LastUpdatedBy, parameter.ID, parameter.Version, parameter.Version)
+	if err != nil {
+		return err
+	}
+	if err := database.CheckVersionedWrite(result, "inspection parameter", parameter.ID, parameter.Version); err != nil {
+		return err
+	}
+	parameter.Version = database.NextVersion(result, parameter.Version)
+	return nil
+}
+
+func (r *MySqlInspectionParameterRepository) Delete(parameterID int64, version int64) error {
+	query := "DELETE FROM inspection_parameters WHERE id=? AND (? = 0 OR version=?)"
+	result, err := r.conn.GetDB().Exec(query, parameterID, version, version)
+	if err != nil {
+		return err
+	}
+	return database.CheckVersionedWrite(result, "inspection parameter", parameterID, version)
+}
+
+func (r *MySqlInspectionParameterRepository) GetById(parameterID int64) (*domain.InspectionParameter, error) {
+	query := "SELECT " + inspectionParameterColumns + " FROM inspection_parameters WHERE id = ?"
+	return r.scanParameter(r.conn.GetDB().QueryRow(query, parameterID))
+}
+
+func (r *MySqlInspectionParameterRepository) GetActiveByProduct(productID int64) ([]*domain.InspectionParameter, error) {
+	query := "SELECT " + inspectionParameterColumns + " FROM inspection_parameters WHERE product_id = ? AND status = 'active' ORDER BY id"
+	return r.queryParameters(query, productID)
+}
+
+func (r *MySqlInspectionParameterRepository) GetTotalCount(filter InspectionParameterFilterOptions) (int, error) {
+	query, args := r.buildFilterQuery("SELECT COUNT(*) FROM inspection_parameters", filter)
+
+	var count int
+	if err := r.conn.GetDB().QueryRow(query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *MySqlInspectionParameterRepository) GetAll(page int, pageSize int, sort string, filter InspectionParameterFilterOptions) ([]*domain.InspectionParameter, error) {
+	query, args := r.buildFilterQuery("SELECT "+inspectionParameterColumns+" FROM inspection_parameters", filter)
+	var allowedSortOrders = map[string]bool{
+		"product_id ASC":  true,
+		"product_id DESC": true,
+		"name ASC":        true,
+		"name DESC":       true,
+		"updated_at ASC":  true,
+		"updated_at DESC": true,
+	}
+
+	if sort != "" {
+		if _, ok := allowedSortOrders[sort]; ok {
+			query += " ORDER BY " + sort
+		} else {
+			return nil, errors.New("invalid sort order")
+		}
+	}
+
+	query += " LIMIT ? OFFSET ?"
+	args = append(args, pageSize, page)
+
+	return r.queryParameters(query, args...)
+}
+
+func (r *MySqlInspectionParameterRepository) queryParameters(query string, args ...interface{}) ([]*domain.InspectionParameter, error) {
+	rows, err := r.conn.GetDB().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var parameters []*domain.InspectionParameter
+	for rows.Next() {
+		parameter, err := r.scanParameter(rows)
+		if err != nil {
+			return nil, err
+		}
+		parameters = append(parameters, parameter)
+	}
+	return parameters, rows.Err()
+}
+
+func (r *MySqlInspectionParameterRepository) scanParameter(row rowScanner) (*domain.InspectionParameter, error) {
+	parameter := domain.NewInspectionParameterWithDefaults()
+	err := row.Scan(&parameter.ID, &parameter.ProductID, &parameter.Name, &parameter.Unit, &parameter.MinValue, &parameter.MaxValue, &parameter.ExpectedValue, &parameter.Mandatory, &parameter.Status, &parameter.CreatedAt, &parameter.UpdatedAt, &parameter.LastUpdatedBy, &parameter.Version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, customerrors.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return parameter, nil
+}
+
+func (r *MySqlInspectionParameterRepository) buildFilterQuery(baseQuery string, filter InspectionParameterFilterOptions) (string, []interface{}) {
+	var filters []string
+	var args []interface{}
+
+	if filter.ProductID > 0 {
+		filters = append(filters, "product_id = ?")
+		args = append(args, filter.ProductID)
+	}
+	if filter.Status != "" {
+		filters = append(filters, "status = ?")
+		args = append(args, filter.Status)
+	}
+
+	if len(filters) > 0 {
+		return baseQuery + " WHERE " + strings.Join(filters, " AND "), args
+	}
+
+	return baseQuery, args
+}
@@ -0,0 +1,42 @@
+package repository
+
+import "github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+
+type InspectionParameterRepository interface {
+	Create(parameter *domain.InspectionParameter) error
+	Update(parameter *domain.InspectionParameter) error
+	Delete(parameterID int64, version int64) error
+	GetById(parameterID int64) (*domain.InspectionParameter, error)
+	GetActiveByProduct(productID int64) ([]*domain.InspectionParameter, error)
+	GetTotalCount(filter InspectionParameterFilterOptions) (int, error)
+	GetAll(page int, pageSize int, sort string, filter InspectionParameterFilterOptions) ([]*domain.InspectionParameter, error)
+}
+
+type InspectionParameterFilterOptions struct {
+	ProductID int64
+	Status    string
+}
+
+type InspectionRepository interface {
+	Create(inspection *domain.Inspection) error
+	Update(inspection *domain.Inspection) error
+	Decide(inspection *domain.Inspection, inventory *domain.Inventory, event domain.SerialEventType, actor string) error
+	AddResult(inspectionID int64, result *domain.InspectionResult) error
+	GetById(inspectionID int64) (*domain.Inspection, error)
+	GetOpenByInventory(inventoryID int64) (*domain.Inspection, error)
+	GetTotalCount(filter InspectionFilterOptions) (int, error)
+	GetAll(page int, pageSize int, sort string, filter InspectionFilterOptions) ([]*domain.Inspection, error)
+}
+
+type InspectionFilterOptions struct {
+	Statuses    []domain.InspectionStatus
+	InventoryID int64
+	ProductID   int64
+}
+
+func (f *InspectionFilterOptions) SetStatuses(statusesStr string) {
+	f.Statuses = nil
+	for _, status := range splitList(statusesStr) {
+		f.Statuses = append(f.Statuses, domain.InspectionStatus(status))
+	}
+}
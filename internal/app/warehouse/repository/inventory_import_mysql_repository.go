@@ -0,0 +1,353 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+const inventoryImportColumns = "id, file_name, format, status, total_rows, validated_rows, error_count, message, created_by, created_at, updated_at, completed_at"
+
+// importInsertBatch is the number of staged rows written per INSERT.
+const importInsertBatch = 500
+
+type MySqlInventoryImportRepository struct {
+	conn database.Connection
+}
+
+func NewInventoryImportRepository(conn database.Connection) InventoryImportRepository {
+	return &MySqlInventoryImportRepository{conn: conn}
+}
+
+// Create stores the import and stages all of its rows.
+func (r *MySqlInventoryImportRepository) Create(inventoryImport *domain.InventoryImport) error {
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		query := "INSERT INTO inventory_imports (file_name, format, status, total_rows, created_by) VALUES (?, ?, ?, ?, ?)"
+		result, err := tx.Exec(query, inventoryImport.FileName, inventoryImport.Format, inventoryImport.Status, len(inventoryImport.Rows), inventoryImport.CreatedBy)
+		if err != nil {
+			return err
+		}
+		if inventoryImport.ID, err = result.LastInsertId(); err != nil {
+			return err
+		}
+		inventoryImport.TotalRows = len(inventoryImport.Rows)
+
+		for start := 0; start < len(inventoryImport.Rows); start += importInsertBatch {
+			end := start + importInsertBatch
+			if end > len(inventoryImport.Rows) {
+				end = len(inventoryImport.Rows)
+			}
+			values := make([]string, 0, end-start)
+			args := make([]interface{}, 0, (end-start)*9)
+			for _, row := range inventoryImport.Rows[start:end] {
+				values = append(values, "("+placeholders(9)+")")
+				args = append(args, inventoryImport.ID, row.RowNumber, row.ProductCode, row.ContainerCode, row.ContainerType, row.Store, row.Batch, row.Quantity, row.Unit)
+			}
+			query := "INSERT INTO inventory_import_rows (import_id, row_num, product_code, container_code, container_type, store, batch, quantity, unit) VALUES " + strings.Join(values, ", ")
+			if _, err := tx.Exec(query, args...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetById returns the import with the errors of all failed rows.
+func (r *MySqlInventoryImportRepository) GetById(importID int64) (*domain.InventoryImport, error) {
+	query := "SELECT " + inventoryImportColumns + " FROM inventory_imports WHERE id = ?"
+	inventoryImport, err := r.scanImport(r.conn.GetDB().QueryRow(query, importID))
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.conn.GetDB().Query("SELECT row_num, error FROM inventory_import_rows WHERE import_id = ? AND error <> '' ORDER BY row_num", importID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	inventoryImport.Errors = []*domain.ImportRowError{}
+	for rows.Next() {
+		rowError := &domain.ImportRowError{}
+		if err := rows.Scan(&rowError.RowNumber, &rowError.Error); err != nil {
+			return nil, err
+		}
+		inventoryImport.Errors = append(inventoryImport.Errors, rowError)
+	}
+	return inventoryImport, rows.Err()
+}
+
+func (r *MySqlInventoryImportRepository) GetTotalCount(filter InventoryImportFilterOptions) (int, error) {
+	query, args := r.buildFilterQuery("SELECT COUNT(*) FROM inventory_imports", filter)
+
+	var count int
+	if err := r.conn.GetDB().QueryRow(query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *MySqlInventoryImportRepository) GetAll(page int, pageSize int, sort string, filter InventoryImportFilterOptions) ([]*domain.InventoryImport, error) {
+	query, args := r.buildFilterQuery("SELECT "+inventoryImportColumns+" FROM inventory_imports", filter)
+	var allowedSortOrders = map[string]bool{
+		"id ASC":          true,
+		"id DESC":         true,
+		"created_at ASC":  true,
+		"created_at DESC": true,
+		"status ASC":      true,
+		"status DESC":     true,
+	}
+
+	if sort != "" {
+		if _, ok := allowedSortOrders[sort]; ok {
+			query += " ORDER BY " + sort
+		} else {
+			return nil, errors.New("invalid sort order")
+		}
+	}
+
+	query += " LIMIT ? OFFSET ?"
+	args = append(args, pageSize, page)
+
+	rows, err := r.conn.GetDB().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var imports []*domain.InventoryImport
+	for rows.Next() {
+		inventoryImport, err := r.scanImport(rows)
+		if err != nil {
+			return nil, err
+		}
+		imports = append(imports, inventoryImport)
+	}
+	return imports, rows.Err()
+}
+
+func (r *MySqlInventoryImportRepository) GetResumableIDs() ([]int64, error) {
+	query := "SELECT id FROM inventory_imports WHERE status IN (?, ?, ?) ORDER BY id"
+	rows, err := r.conn.GetDB().Query(query, domain.IMPORT_PENDING, domain.IMPORT_VALIDATING, domain.IMPORT_COMMITTING)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (r *MySqlInventoryImportRepository) SetStatus(importID int64, status domain.ImportStatus, message string) error {
+	query := "UPDATE inventory_imports SET status = ?, message = ? WHERE id = ?"
+	_, err := r.conn.GetDB().Exec(query, status, message, importID)
+	return err
+}
+
+func (r *MySqlInventoryImportRepository) GetUnvalidatedRows(importID int64, limit int) ([]*domain.InventoryImportRow, error) {
+	query := `SELECT id, row_num, product_code, container_code, container_type, store, batch, quantity, unit
+		FROM inventory_import_rows WHERE import_id = ? AND validated = FALSE ORDER BY row_num LIMIT ?`
+	rows, err := r.conn.GetDB().Query(query, importID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var importRows []*domain.InventoryImportRow
+	for rows.Next() {
+		row := &domain.InventoryImportRow{}
+		if err := rows.Scan(&row.ID, &row.RowNumber, &row.ProductCode, &row.ContainerCode, &row.ContainerType, &row.Store, &row.Batch, &row.Quantity, &row.Unit); err != nil {
+			return nil, err
+		}
+		importRows = append(importRows, row)
+	}
+	return importRows, rows.Err()
+}
+
+// GetNewContainerType returns the type that earlier valid rows gave to a
+// container the import will create, or "" when no row did.
+func (r *MySqlInventoryImportRepository) GetNewContainerType(importID int64, containerCode string) (string, error) {
+	query := `SELECT container_type FROM inventory_import_rows
+		WHERE import_id = ? AND container_code = ? AND validated = TRUE AND error = '' AND container_id IS NULL LIMIT 1`
+	var containerType string
+	err := r.conn.GetDB().QueryRow(query, importID, containerCode).Scan(&containerType)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return containerType, err
+}
+
+// SaveValidatedRows checkpoints a validated chunk together with the progress
+// counters of the import.
+func (r *MySqlInventoryImportRepository) SaveValidatedRows(importID int64, rows []*domain.InventoryImportRow) error {
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		query := `UPDATE inventory_import_rows SET validated = TRUE, error = ?, product_id = ?, store_id = ?, container_id = ?, container_type = ?, unit = ?, qc_status = ?
+			WHERE id = ?`
+		errorCount := 0
+		for _, row := range rows {
+			if row.Error != "" {
+				errorCount++
+			}
+			if _, err := tx.Exec(query, row.Error, nullIfZero(row.ProductID), nullIfZero(row.StoreID), row.ContainerID, row.ContainerType, row.Unit, row.QCStatus, row.ID); err != nil {
+				return err
+			}
+		}
+		_, err := tx.Exec("UPDATE inventory_imports SET validated_rows = validated_rows + ?, error_count = error_count + ? WHERE id = ?", len(rows), errorCount, importID)
+		return err
+	})
+}
+
+// GetStoreID resolves a store by its name or numeric id.
+func (r *MySqlInventoryImportRepository) GetStoreID(store string) (int64, error) {
+	storeID, _ := strconv.ParseInt(store, 10, 64)
+	var id int64
+	err := r.conn.GetDB().QueryRow("SELECT id FROM stores WHERE name = ? OR id = ? ORDER BY name = ? DESC LIMIT 1", store, storeID, store).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, customerrors.ErrResourceNotFound
+	}
+	return id, err
+}
+
+// Commit creates the missing containers and all lots of a fully validated
+// import in one transaction, so either every row is stocked or none is.
+func (r *MySqlInventoryImportRepository) Commit(importID int64) error {
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		// Lock the import so that two workers cannot commit it twice
+		var status domain.ImportStatus
+		var errorCount int
+		if err := tx.QueryRow("SELECT status, error_count FROM inventory_imports WHERE id = ? FOR UPDATE", importID).Scan(&status, &errorCount); err != nil {
+			if err == sql.ErrNoRows {
+				return customerrors.ErrResourceNotFound
+			}
+			return err
+		}
+		if status != domain.IMPORT_COMMITTING {
+			return fmt.Errorf("import %d is %s, not %s", importID, status, domain.IMPORT_COMMITTING)
+		}
+		if errorCount > 0 {
+			return domain.ErrImportHasErrors
+		}
+
+		query := `SELECT row_num, container_code, container_type, container_id, product_id, store_id, batch, quantity, unit, qc_status
+			FROM inventory_import_rows WHERE import_id = ? ORDER BY row_num`
+		rows, err := tx.Query(query, importID)
+		if err != nil {
+			return err
+		}
+		var importRows []*domain.InventoryImportRow
+		var quantities []customtypes.Decimal
+		for rows.Next() {
+			row := &domain.InventoryImportRow{}
+			var quantity customtypes.Decimal
+			if err := rows.Scan(&row.RowNumber, &row.ContainerCode, &row.ContainerType, &row.ContainerID, &row.ProductID, &row.StoreID, &row.Batch, &quantity, &row.Unit, &row.QCStatus); err != nil {
+				rows.Close()
+				return err
+			}
+			importRows = append(importRows, row)
+			quantities = append(quantities, quantity)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		containerIDs := make(map[string]int64)
+		for i, row := range importRows {
+			if row.ContainerCode != "" && row.ContainerID == nil {
+				containerID, ok := containerIDs[row.ContainerCode]
+				if !ok {
+					// The container may have been created since validation
+					err := tx.QueryRow("SELECT id FROM containers WHERE code = ? AND type = ?", row.ContainerCode, row.ContainerType).Scan(&containerID)
+					if err != nil && err != sql.ErrNoRows {
+						return err
+					}
+					ok = err == nil
+				}
+				if !ok {
+					storeID := row.StoreID
+					container := &domain.Container{
+						Type:    domain.ContainerType(row.ContainerType),
+						StoreID: &storeID,
+						Code:    customtypes.NullableString(row.ContainerCode),
+						Name:    customtypes.NullableString(row.ContainerCode),
+						Status:  "active",
+					}
+					if err := insertContainer(tx, container); err != nil {
+						return fmt.Errorf("row %d: %w", row.RowNumber, err)
+					}
+					containerID = container.ID
+				}
+				containerIDs[row.ContainerCode] = containerID
+				row.ContainerID = &containerID
+			}
+
+			inventory := domain.NewInventoryWithDefaults()
+			inventory.QCStatus = row.QCStatus
+			inventory.ProductID = row.ProductID
+			inventory.StoreID = &row.StoreID
+			inventory.Batch = row.Batch
+			inventory.Quantity = quantities[i]
+			inventory.Unit = row.Unit
+			inventory.StockInAt = now
+			inventory.ContainerID = row.ContainerID
+			if err := insertInventory(tx, inventory); err != nil {
+				return fmt.Errorf("row %d: %w", row.RowNumber, err)
+			}
+		}
+
+		_, err = tx.Exec("UPDATE inventory_imports SET status = ?, message = '', completed_at = ? WHERE id = ?", domain.IMPORT_COMPLETED, now, importID)
+		return err
+	})
+}
+
+func (r *MySqlInventoryImportRepository) scanImport(row rowScanner) (*domain.InventoryImport, error) {
+	inventoryImport := &domain.InventoryImport{}
+	err := row.Scan(&inventoryImport.ID, &inventoryImport.FileName, &inventoryImport.Format, &inventoryImport.Status, &inventoryImport.TotalRows, &inventoryImport.ValidatedRows, &inventoryImport.ErrorCount, &inventoryImport.Message, &inventoryImport.CreatedBy, &inventoryImport.CreatedAt, &inventoryImport.UpdatedAt, &inventoryImport.CompletedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, customerrors.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return inventoryImport, nil
+}
+
+func (r *MySqlInventoryImportRepository) buildFilterQuery(baseQuery string, filter InventoryImportFilterOptions) (string, []interface{}) {
+	var filters []string
+	var args []interface{}
+
+	if filter.Status != "" {
+		filters = append(filters, "status = ?")
+		args = append(args, filter.Status)
+	}
+
+	if len(filters) > 0 {
+		return baseQuery + " WHERE " + strings.Join(filters, " AND "), args
+	}
+
+	return baseQuery, args
+}
+
+func nullIfZero(id int64) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
@@ -0,0 +1,21 @@
+package repository
+
+import "github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+
+type InventoryImportRepository interface {
+	Create(inventoryImport *domain.InventoryImport) error
+	GetById(importID int64) (*domain.InventoryImport, error)
+	GetTotalCount(filter InventoryImportFilterOptions) (int, error)
+	GetAll(page int, pageSize int, sort string, filter InventoryImportFilterOptions) ([]*domain.InventoryImport, error)
+	GetResumableIDs() ([]int64, error)
+	SetStatus(importID int64, status domain.ImportStatus, message string) error
+	GetUnvalidatedRows(importID int64, limit int) ([]*domain.InventoryImportRow, error)
+	GetNewContainerType(importID int64, containerCode string) (string, error)
+	SaveValidatedRows(importID int64, rows []*domain.InventoryImportRow) error
+	GetStoreID(store string) (int64, error)
+	Commit(importID int64) error
+}
+
+type InventoryImportFilterOptions struct {
+	Status string
+}
@@ -2,8 +2,9 @@ package repository
 
 import (
 	"database/sql"
-	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
 	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
@@ -19,29 +20,194 @@ func NewInventoryRepository(conn database.Connection) InventoryRepository {
 }
 
 func (r *MySqlInventoryRepository) Create(inventory *domain.Inventory) error {
-	query := `INSERT INTO inventories (status, pallet_id, bin_id, rack_id, store_id, product_id, batch, machine, shift, supervisor, quantity, unit, stockin_at) 
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
-	_, err := r.conn.GetDB().Exec(query, inventory.Status, inventory.PalletID, inventory.BinID, inventory.RackID, inventory.StoreID, inventory.ProductID, inventory.Batch, inventory.Machine, inventory.Shift, inventory.Supervisor, inventory.Quantity, inventory.Unit, inventory.StockInAt)
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		return insertInventory(tx, inventory)
+	})
+}
+
+// insertInventory is shared with repositories that create a lot as part of a
+// larger transaction, so that the lot and its ledger entry are written
+// together. The shift and supervisor of the lot come from the shift calendar
+// of its store.
+func insertInventory(db *sql.Tx, inventory *domain.Inventory) error {
+	if err := resolveLocation(db, inventory); err != nil {
+		return err
+	}
+	recordOverride, err := applyShiftCalendar(db, inventory)
+	if err != nil {
+		return err
+	}
+	query := `INSERT INTO inventories (status, qc_status, container_id, pallet_id, bin_id, rack_id, store_id, product_id, batch, machine, shift, supervisor, quantity, unit, stockin_at, expires_at) 
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := db.Exec(query, inventory.Status, inventory.QCStatus, inventory.ContainerID, inventory.PalletID, inventory.BinID, inventory.RackID, inventory.StoreID, inventory.ProductID, inventory.Batch, inventory.Machine, inventory.Shift, inventory.Supervisor, inventory.Quantity, inventory.Unit, inventory.StockInAt, inventory.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	if inventory.ID, err = result.LastInsertId(); err != nil {
+		return err
+	}
+	if err := recordOverride(); err != nil {
+		return err
+	}
+	return recordMovement(db, inventory.ID, domain.MOVEMENT_CREATED)
+}
+
+// resolveLocation derives the pallet, bin, rack and store of the lot from the
+// container holding it, and checks that the container and its ancestors have
+// room for the lot.
+func resolveLocation(tx *sql.Tx, inventory *domain.Inventory) error {
+	containerID := inventory.LocationContainerID()
+	if containerID == nil {
+		return nil
+	}
+	chain, err := loadContainerChain(tx, *containerID)
+	if err != nil {
+		return err
+	}
+	if len(chain) == 0 {
+		return fmt.Errorf("%w: container %d does not exist", domain.ErrLocationMismatch, *containerID)
+	}
+	if err := inventory.SetLocation(*containerID, domain.LocationOf(chain)); err != nil {
+		return err
+	}
+	if inventory.Status == domain.STOCK_OUT {
+		return nil
+	}
+	// Only a lot coming into the container needs it to accept stock; lots
+	// already on a damaged pallet can still be updated and moved off it
+	entering := true
+	if inventory.ID > 0 {
+		var previous *int64
+		err := tx.QueryRow("SELECT container_id FROM inventories WHERE id = ?", inventory.ID).Scan(&previous)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		entering = previous == nil || *previous != *containerID
+	}
+	if entering {
+		if err := domain.CheckAcceptsStock(chain); err != nil {
+			return err
+		}
+	}
+
+	load, err := lotLoad(tx, inventory.ProductID, inventory.Quantity, inventory.Unit)
+	if err != nil {
+		return err
+	}
+	inventory.Warnings, err = enforceCapacity(tx, chain, load, inventory.ID)
 	return err
 }
 
+// recordMovement appends the stored state of a lot to the inventory ledger.
+// It must run after inserts and updates, and before deletes. Comparing with
+// the previous entry tells which containers gained or lost stock, which is
+// added to their timelines.
+func recordMovement(tx *sql.Tx, inventoryID int64, movement domain.MovementType) error {
+	before, err := ledgerHolding(tx, inventoryID)
+	if err != nil {
+		return err
+	}
+	at := time.Now()
+	query := `INSERT INTO inventory_movements (inventory_id, movement, status, qc_status, product_id, store_id, container_id, rack_id, bin_id, pallet_id, batch, quantity, unit, occurred_at)
+			SELECT id, ?, status, qc_status, product_id, store_id, container_id, rack_id, bin_id, pallet_id, batch, quantity, unit, ? FROM inventories WHERE id = ?`
+	if _, err := tx.Exec(query, movement, at, inventoryID); err != nil {
+		return err
+	}
+
+	var after *domain.LotHolding
+	if movement != domain.MOVEMENT_DELETED {
+		if after, err = ledgerHolding(tx, inventoryID); err != nil {
+			return err
+		}
+	}
+	for _, event := range domain.ContentChanges(before, after) {
+		event.OccurredAt = at
+		if err := insertContainerEvent(tx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ledgerHolding returns where the latest ledger entry of the lot puts it, or
+// nil for a lot without entries.
+func ledgerHolding(tx *sql.Tx, inventoryID int64) (*domain.LotHolding, error) {
+	query := "SELECT inventory_id, container_id, status, product_id, batch, quantity, unit FROM inventory_movements WHERE inventory_id = ? ORDER BY id DESC LIMIT 1"
+	holding := &domain.LotHolding{}
+	err := tx.QueryRow(query, inventoryID).Scan(&holding.InventoryID, &holding.ContainerID, &holding.Status, &holding.ProductID, &holding.Batch, &holding.Quantity, &holding.Unit)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return holding, nil
+}
+
 func (r *MySqlInventoryRepository) Update(inventory *domain.Inventory) error {
-	query := `UPDATE inventories SET status=?, pallet_id=?, bin_id=?, rack_id=?, store_id=?, product_id=?, batch=?, machine=?, shift=?, supervisor=?, quantity=?, unit=?, stockout_at=? WHERE id=?`
-	_, err := r.conn.GetDB().Exec(query, inventory.Status, inventory.PalletID, inventory.BinID, inventory.RackID, inventory.StoreID, inventory.ProductID, inventory.Batch, inventory.Machine, inventory.Shift, inventory.Supervisor, inventory.Quantity, inventory.Unit, inventory.StockOutAt, inventory.ID)
-	return err
+	return r.UpdateWithSerialEvent(inventory, "", "")
 }
 
-func (r *MySqlInventoryRepository) Delete(inventoryID int64) error {
-	query := "DELETE FROM inventories WHERE id=?"
-	_, err := r.conn.GetDB().Exec(query, inventoryID)
-	return err
+// UpdateWithSerialEvent updates the lot and, unless event is empty, brings
+// its serial numbers in line and records event against each of them in the
+// same transaction.
+func (r *MySqlInventoryRepository) UpdateWithSerialEvent(inventory *domain.Inventory, event domain.SerialEventType, actor string) error {
+	var version int64
+	err := database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		var err error
+		if version, err = updateInventory(tx, inventory); err != nil {
+			return err
+		}
+		if event == "" {
+			return nil
+		}
+		return syncSerials(tx, inventory, event, actor)
+	})
+	if err != nil {
+		return err
+	}
+	inventory.Version = version
+	return nil
+}
+
+// updateInventory writes the lot, guarded by its version, and returns the
+// version it has now. It is shared with repositories that change a lot as
+// part of a larger transaction.
+func updateInventory(tx *sql.Tx, inventory *domain.Inventory) (int64, error) {
+	query := `UPDATE inventories SET status=?, qc_status=?, container_id=?, pallet_id=?, bin_id=?, rack_id=?, store_id=?, product_id=?, batch=?, machine=?, shift=?, supervisor=?, quantity=?, unit=?, stockout_at=?, expires_at=?, version=LAST_INSERT_ID(version+1) WHERE id=? AND (? = 0 OR version=?)`
+	if err := resolveLocation(tx, inventory); err != nil {
+		return 0, err
+	}
+	result, err := tx.Exec(query, inventory.Status, inventory.QCStatus, inventory.ContainerID, inventory.PalletID, inventory.BinID, inventory.RackID, inventory.StoreID, inventory.ProductID, inventory.Batch, inventory.Machine, inventory.Shift, inventory.Supervisor, inventory.Quantity, inventory.Unit, inventory.StockOutAt, inventory.ExpiresAt, inventory.ID, inventory.Version, inventory.Version)
+	if err != nil {
+		return 0, err
+	}
+	if err := database.CheckVersionedWrite(result, "inventory", inventory.ID, inventory.Version); err != nil {
+		return 0, err
+	}
+	version := database.NextVersion(result, inventory.Version)
+	return version, recordMovement(tx, inventory.ID, domain.MOVEMENT_UPDATED)
+}
+
+func (r *MySqlInventoryRepository) Delete(inventoryID int64, version int64) error {
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		if err := recordMovement(tx, inventoryID, domain.MOVEMENT_DELETED); err != nil {
+			return err
+		}
+		query := "DELETE FROM inventories WHERE id=? AND (? = 0 OR version=?)"
+		result, err := tx.Exec(query, inventoryID, version, version)
+		if err != nil {
+			return err
+		}
+		return database.CheckVersionedWrite(result, "inventory", inventoryID, version)
+	})
 }
 
 func (r *MySqlInventoryRepository) GetById(inventoryID int64) (*domain.Inventory, error) {
-	query := `SELECT id, status, pallet_id, bin_id, rack_id, store_id, product_id, batch, machine, shift, supervisor, quantity, unit, stockin_at, stockout_at FROM inventories WHERE id = ?`
+	query := `SELECT id, status, qc_status, container_id, pallet_id, bin_id, rack_id, store_id, product_id, batch, machine, shift, supervisor, quantity, unit, stockin_at, stockout_at, expires_at, version FROM inventories WHERE id = ?`
 	row := r.conn.GetDB().QueryRow(query, inventoryID)
 	inventory := domain.NewInventoryWithDefaults()
-	err := row.Scan(&inventory.ID, &inventory.Status, &inventory.PalletID, &inventory.BinID, &inventory.RackID, &inventory.StoreID, &inventory.ProductID, &inventory.Batch, &inventory.Machine, &inventory.Shift, &inventory.Supervisor, &inventory.Quantity, &inventory.Unit, &inventory.StockInAt, &inventory.StockOutAt)
+	err := row.Scan(&inventory.ID, &inventory.Status, &inventory.QCStatus, &inventory.ContainerID, &inventory.PalletID, &inventory.BinID, &inventory.RackID, &inventory.StoreID, &inventory.ProductID, &inventory.Batch, &inventory.Machine, &inventory.Shift, &inventory.Supervisor, &inventory.Quantity, &inventory.Unit, &inventory.StockInAt, &inventory.StockOutAt, &inventory.ExpiresAt, &inventory.Version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, customerrors.ErrResourceNotFound
@@ -63,27 +229,68 @@ func (r *MySqlInventoryRepository) GetTotalCount(filter InventoryFilterOptions)
 }
 
 func (r *MySqlInventoryRepository) GetAll(page int, pageSize int, sort string, filter InventoryFilterOptions) ([]*domain.Inventory, error) {
-	query, args := r.buildFilterQuery("SELECT id, status, pallet_id, bin_id, rack_id, store_id, product_id, batch, machine, shift, supervisor, quantity, unit, stockin_at, stockout_at FROM inventories", filter)
-	var allowedSortOrders = map[string]bool{
-		"stockin_at ASC":   true,
-		"stockin_at DESC":  true,
-		"stockin_out ASC":  true,
-		"stockin_out DESC": true,
-		"status ASC":       true,
-		"status DESC":      true,
-	}
+	query, args := r.buildFilterQuery("SELECT id, status, qc_status, container_id, pallet_id, bin_id, rack_id, store_id, product_id, batch, machine, shift, supervisor, quantity, unit, stockin_at, stockout_at, expires_at, version FROM inventories", filter)
 
 	if sort != "" {
-		if _, ok := allowedSortOrders[sort]; ok {
-			query += " ORDER BY " + sort
-		} else {
-			return nil, errors.New("invalid sort order")
+		keys, err := parseInventorySort(sort)
+		if err != nil {
+			return nil, err
 		}
+		query += " ORDER BY " + keys.orderBy()
 	}
 
 	query += " LIMIT ? OFFSET ?"
 	args = append(args, pageSize, page)
 
+	return r.queryInventories(query, args...)
+}
+
+// GetAllByCursor pages through inventories with keyset pagination. The cursor
+// encodes the sort key values of the last row of the previous page, so every
+// page is an index range scan instead of an OFFSET scan.
+func (r *MySqlInventoryRepository) GetAllByCursor(pageSize int, sort string, cursor string, filter InventoryFilterOptions) ([]*domain.Inventory, string, error) {
+	keys, err := parseInventorySort(sort)
+	if err != nil {
+		return nil, "", err
+	}
+	keys = keys.withTiebreaker()
+
+	filters, args := r.buildFilterConditions(filter)
+	if cursor != "" {
+		values, err := decodeCursor(cursor, len(keys))
+		if err != nil {
+			return nil, "", err
+		}
+		condition, conditionArgs, err := keys.after(values)
+		if err != nil {
+			return nil, "", err
+		}
+		filters = append(filters, condition)
+		args = append(args, conditionArgs...)
+	}
+
+	query := "SELECT id, status, qc_status, container_id, pallet_id, bin_id, rack_id, store_id, product_id, batch, machine, shift, supervisor, quantity, unit, stockin_at, stockout_at, expires_at, version FROM inventories"
+	if len(filters) > 0 {
+		query += " WHERE " + strings.Join(filters, " AND ")
+	}
+	// Fetch one extra row to find out whether another page exists
+	query += " ORDER BY " + keys.orderBy() + " LIMIT ?"
+	args = append(args, pageSize+1)
+
+	inventories, err := r.queryInventories(query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(inventories) > pageSize {
+		inventories = inventories[:pageSize]
+		nextCursor = encodeCursor(keys.values(inventories[pageSize-1]))
+	}
+	return inventories, nextCursor, nil
+}
+
+func (r *MySqlInventoryRepository) queryInventories(query string, args ...interface{}) ([]*domain.Inventory, error) {
 	rows, err := r.conn.GetDB().Query(query, args...)
 	if err != nil {
 		return nil, err
@@ -93,25 +300,57 @@ func (r *MySqlInventoryRepository) GetAll(page int, pageSize int, sort string, f
 	var inventories []*domain.Inventory
 	for rows.Next() {
 		inventory := domain.NewInventoryWithDefaults()
-		if err := rows.Scan(&inventory.ID, &inventory.Status, &inventory.PalletID, &inventory.BinID, &inventory.RackID, &inventory.StoreID, &inventory.ProductID, &inventory.Batch, &inventory.Machine, &inventory.Shift, &inventory.Supervisor, &inventory.Quantity, &inventory.Unit, &inventory.StockInAt, &inventory.StockOutAt); err != nil {
+		if err := rows.Scan(&inventory.ID, &inventory.Status, &inventory.QCStatus, &inventory.ContainerID, &inventory.PalletID, &inventory.BinID, &inventory.RackID, &inventory.StoreID, &inventory.ProductID, &inventory.Batch, &inventory.Machine, &inventory.Shift, &inventory.Supervisor, &inventory.Quantity, &inventory.Unit, &inventory.StockInAt, &inventory.StockOutAt, &inventory.ExpiresAt, &inventory.Version); err != nil {
 			return nil, err
 		}
 		inventories = append(inventories, inventory)
 	}
-	return inventories, nil
+	return inventories, rows.Err()
 }
 
 func (r *MySqlInventoryRepository) buildFilterQuery(baseQuery string, filter InventoryFilterOptions) (string, []interface{}) {
+	filters, args := r.buildFilterConditions(filter)
+
+	if len(filters) > 0 {
+		return baseQuery + " WHERE " + strings.Join(filters, " AND "), args
+	}
+
+	return baseQuery, args
+}
+
+func (r *MySqlInventoryRepository) buildFilterConditions(filter InventoryFilterOptions) ([]string, []interface{}) {
 	var filters []string
 	var args []interface{}
 
-	if filter.Status != "" {
-		filters = append(filters, "status = ?")
-		args = append(args, filter.Status)
+	if len(filter.Statuses) > 0 {
+		filters = append(filters, "status IN ("+placeholders(len(filter.Statuses))+")")
+		for _, status := range filter.Statuses {
+			args = append(args, status)
+		}
+	}
+	if len(filter.QCStatuses) > 0 {
+		filters = append(filters, "qc_status IN ("+placeholders(len(filter.QCStatuses))+")")
+		for _, qcStatus := range filter.QCStatuses {
+			args = append(args, qcStatus)
+		}
+	}
+	if len(filter.ProductIDs) > 0 {
+		filters = append(filters, "product_id IN ("+placeholders(len(filter.ProductIDs))+")")
+		for _, productID := range filter.ProductIDs {
+			args = append(args, productID)
+		}
+	}
+	if filter.ContainerID > 0 {
+		filters = append(filters, "container_id = ?")
+		args = append(args, filter.ContainerID)
 	}
-	if filter.ProductID > 0 {
-		filters = append(filters, "product_id = ?")
-		args = append(args, filter.ProductID)
+	if filter.UnderContainer != "" {
+		filters = append(filters, "container_id IN (SELECT c.id FROM containers c JOIN containers a ON c.path LIKE CONCAT(a.path, '%') WHERE a.code = ?)")
+		args = append(args, filter.UnderContainer)
+	}
+	if filter.PalletID > 0 {
+		filters = append(filters, "pallet_id = ?")
+		args = append(args, filter.PalletID)
 	}
 	if filter.BinID > 0 {
 		filters = append(filters, "bin_id = ?")
@@ -125,10 +364,42 @@ func (r *MySqlInventoryRepository) buildFilterQuery(baseQuery string, filter Inv
 		filters = append(filters, "store_id = ?")
 		args = append(args, filter.StoreID)
 	}
-
-	if len(filters) > 0 {
-		return baseQuery + " WHERE " + strings.Join(filters, " AND "), args
+	if filter.Batch != "" {
+		filters = append(filters, "batch = ?")
+		args = append(args, filter.Batch)
+	}
+	if filter.BatchPrefix != "" {
+		filters = append(filters, "batch LIKE ?")
+		args = append(args, escapeLike(filter.BatchPrefix)+"%")
+	}
+	if filter.Machine != "" {
+		filters = append(filters, "machine = ?")
+		args = append(args, filter.Machine)
+	}
+	if filter.Shift != "" {
+		filters = append(filters, "shift = ?")
+		args = append(args, filter.Shift)
+	}
+	if filter.Supervisor != "" {
+		filters = append(filters, "supervisor = ?")
+		args = append(args, filter.Supervisor)
+	}
+	if filter.StockInFrom != nil {
+		filters = append(filters, "stockin_at >= ?")
+		args = append(args, *filter.StockInFrom)
+	}
+	if filter.StockInTo != nil {
+		filters = append(filters, "stockin_at <= ?")
+		args = append(args, *filter.StockInTo)
+	}
+	if filter.StockOutFrom != nil {
+		filters = append(filters, "stockout_at >= ?")
+		args = append(args, *filter.StockOutFrom)
+	}
+	if filter.StockOutTo != nil {
+		filters = append(filters, "stockout_at <= ?")
+		args = append(args, *filter.StockOutTo)
 	}
 
-	return baseQuery, args
+	return filters, args
 }
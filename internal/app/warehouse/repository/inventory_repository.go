@@ -1,6 +1,11 @@
 package repository
 
 import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
 	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
 )
@@ -8,16 +13,106 @@ import (
 type InventoryRepository interface {
 	Create(inventory *domain.Inventory) error
 	Update(inventory *domain.Inventory) error
-	Delete(inventoryID int64) error
+	UpdateWithSerialEvent(inventory *domain.Inventory, event domain.SerialEventType, actor string) error
+	Delete(inventoryID int64, version int64) error
 	GetById(inventoryID int64) (*domain.Inventory, error)
 	GetTotalCount(filter InventoryFilterOptions) (int, error)
 	GetAll(page int, pageSize int, sort string, filter InventoryFilterOptions) ([]*domain.Inventory, error)
+	GetAllByCursor(pageSize int, sort string, cursor string, filter InventoryFilterOptions) ([]*domain.Inventory, string, error)
 }
 
 type InventoryFilterOptions struct {
-	Status    domain.InventoryType
-	ProductID customtypes.NullableInt64
-	BinID     customtypes.NullableInt64
-	RackID    customtypes.NullableInt64
-	StoreID   customtypes.NullableInt64
+	Statuses    []domain.InventoryType
+	QCStatuses  []domain.QCStatus
+	ProductIDs  []int64
+	ContainerID customtypes.NullableInt64
+	// UnderContainer is the code of a container whose whole subtree is
+	// searched, e.g. every lot in any bin of rack A03.
+	UnderContainer string
+	PalletID       customtypes.NullableInt64
+	BinID          customtypes.NullableInt64
+	RackID         customtypes.NullableInt64
+	StoreID        customtypes.NullableInt64
+	Batch          string
+	BatchPrefix    string
+	Machine        string
+	Shift          string
+	Supervisor     string
+	StockInFrom    *time.Time
+	StockInTo      *time.Time
+	StockOutFrom   *time.Time
+	StockOutTo     *time.Time
+}
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+func (f *InventoryFilterOptions) SetStatuses(statusesStr string) {
+	f.Statuses = nil
+	for _, status := range splitList(statusesStr) {
+		f.Statuses = append(f.Statuses, domain.InventoryType(status))
+	}
+}
+
+func (f *InventoryFilterOptions) SetQCStatuses(qcStatusesStr string) {
+	f.QCStatuses = nil
+	for _, qcStatus := range splitList(qcStatusesStr) {
+		f.QCStatuses = append(f.QCStatuses, domain.QCStatus(qcStatus))
+	}
+}
+
+func (f *InventoryFilterOptions) SetProductIDs(productIDsStr string) error {
+	f.ProductIDs = nil
+	for _, value := range splitList(productIDsStr) {
+		productID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return errors.New("invalid product_id: " + value)
+		}
+		f.ProductIDs = append(f.ProductIDs, productID)
+	}
+	return nil
+}
+
+func (f *InventoryFilterOptions) SetStockInRange(fromStr string, toStr string) (err error) {
+	if f.StockInFrom, err = parseTimeBound(fromStr, false); err != nil {
+		return err
+	}
+	f.StockInTo, err = parseTimeBound(toStr, true)
+	return err
+}
+
+func (f *InventoryFilterOptions) SetStockOutRange(fromStr string, toStr string) (err error) {
+	if f.StockOutFrom, err = parseTimeBound(fromStr, false); err != nil {
+		return err
+	}
+	f.StockOutTo, err = parseTimeBound(toStr, true)
+	return err
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseTimeBound accepts RFC 3339 timestamps or plain dates. A plain date used
+// as an upper bound covers the whole day.
+func parseTimeBound(value string, upper bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, errors.New("invalid date: " + value)
+	}
+	if upper {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &t, nil
 }
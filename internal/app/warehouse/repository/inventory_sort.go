@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+const cursorTimeLayout = "2006-01-02 15:04:05.999999"
+
+// inventorySortColumn is a column the inventory list can be sorted by. value
+// renders the column of a row for a cursor and parse turns it back into a
+// typed argument, so that "10" sorts after "9" and times compare as times.
+// A nullable column holds NULL as an empty value; MySQL sorts NULLs first in
+// ascending and last in descending order.
+type inventorySortColumn struct {
+	expression  string
+	placeholder string
+	nullable    bool
+	value       func(inventory *domain.Inventory) string
+	parse       func(value string) (interface{}, error)
+}
+
+func parseCursorInt(value string) (interface{}, error) {
+	return strconv.ParseInt(value, 10, 64)
+}
+
+func parseCursorTime(value string) (interface{}, error) {
+	return time.Parse(cursorTimeLayout, value)
+}
+
+func parseCursorDecimal(value string) (interface{}, error) {
+	return customtypes.ParseDecimal(value)
+}
+
+func parseCursorString(value string) (interface{}, error) {
+	return value, nil
+}
+
+var inventorySortColumns = map[string]inventorySortColumn{
+	"id": {
+		expression: "id",
+		value:      func(i *domain.Inventory) string { return strconv.FormatInt(i.ID, 10) },
+		parse:      parseCursorInt,
+	},
+	"stockin_at": {
+		expression: "stockin_at",
+		value:      func(i *domain.Inventory) string { return i.StockInAt.UTC().Format(cursorTimeLayout) },
+		parse:      parseCursorTime,
+	},
+	"stockout_at": {
+		expression: "stockout_at",
+		nullable:   true,
+		value: func(i *domain.Inventory) string {
+			if i.StockOutAt == nil {
+				return ""
+			}
+			return i.StockOutAt.UTC().Format(cursorTimeLayout)
+		},
+		parse: parseCursorTime,
+	},
+	"status": {
+		expression: "status",
+		value:      func(i *domain.Inventory) string { return string(i.Status) },
+		parse:      parseCursorString,
+	},
+	"product_id": {
+		expression: "product_id",
+		value:      func(i *domain.Inventory) string { return strconv.FormatInt(i.ProductID, 10) },
+		parse:      parseCursorInt,
+	},
+	"batch": {
+		expression: "batch",
+		value:      func(i *domain.Inventory) string { return i.Batch },
+		parse:      parseCursorString,
+	},
+	"quantity": {
+		expression:  "quantity",
+		placeholder: "CAST(? AS DECIMAL(18,6))",
+		value:       func(i *domain.Inventory) string { return i.Quantity.String() },
+		parse:       parseCursorDecimal,
+	},
+}
+
+func (c inventorySortColumn) bind() string {
+	if c.placeholder != "" {
+		return c.placeholder
+	}
+	return "?"
+}
+
+// equal is the condition for the column being value, nil standing for NULL.
+func (c inventorySortColumn) equal(value interface{}) (string, []interface{}) {
+	if value == nil {
+		return c.expression + " IS NULL", nil
+	}
+	return c.expression + " = " + c.bind(), []interface{}{value}
+}
+
+// beyond is the condition for the column sorting after value in the given
+// direction, nil standing for NULL.
+func (c inventorySortColumn) beyond(value interface{}, descending bool) (string, []interface{}) {
+	switch {
+	case !descending && value == nil:
+		return c.expression + " IS NOT NULL", nil
+	case !descending:
+		return c.expression + " > " + c.bind(), []interface{}{value}
+	case value == nil:
+		return "FALSE", nil
+	case c.nullable:
+		return "(" + c.expression + " < " + c.bind() + " OR " + c.expression + " IS NULL)", []interface{}{value}
+	default:
+		return c.expression + " < " + c.bind(), []interface{}{value}
+	}
+}
+
+type inventorySortKey struct {
+	column     string
+	descending bool
+}
+
+type inventorySortKeys []inventorySortKey
+
+// parseInventorySort parses a comma separated list such as
+// "stockin_at DESC,batch ASC" against the allowed sort columns.
+func parseInventorySort(sort string) (inventorySortKeys, error) {
+	var keys inventorySortKeys
+	seen := map[string]bool{}
+	for _, part := range splitList(sort) {
+		fields := strings.Fields(part)
+		if len(fields) == 0 || len(fields) > 2 {
+			return nil, errors.New("invalid sort order")
+		}
+		column := strings.ToLower(fields[0])
+		if _, ok := inventorySortColumns[column]; !ok || seen[column] {
+			return nil, errors.New("invalid sort order")
+		}
+		key := inventorySortKey{column: column}
+		if len(fields) == 2 {
+			switch strings.ToUpper(fields[1]) {
+			case "ASC":
+			case "DESC":
+				key.descending = true
+			default:
+				return nil, errors.New("invalid sort order")
+			}
+		}
+		seen[column] = true
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// withTiebreaker appends the primary key so that the ordering is total, which
+// keyset pagination depends on.
+func (keys inventorySortKeys) withTiebreaker() inventorySortKeys {
+	for _, key := range keys {
+		if key.column == "id" {
+			return keys
+		}
+	}
+	return append(keys, inventorySortKey{column: "id"})
+}
+
+func (keys inventorySortKeys) orderBy() string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		direction := "ASC"
+		if key.descending {
+			direction = "DESC"
+		}
+		parts[i] = inventorySortColumns[key.column].expression + " " + direction
+	}
+	return strings.Join(parts, ", ")
+}
+
+// after builds the condition selecting rows that sort after the given cursor
+// values: (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ... The first key is bounded
+// on its own as well, so that MySQL can range scan its index rather than
+// evaluate the alternatives row by row.
+func (keys inventorySortKeys) after(values []string) (string, []interface{}, error) {
+	typed := make([]interface{}, len(keys))
+	for i, key := range keys {
+		column := inventorySortColumns[key.column]
+		if values[i] == "" && column.nullable {
+			continue
+		}
+		value, err := column.parse(values[i])
+		if err != nil {
+			return "", nil, ErrInvalidCursor
+		}
+		typed[i] = value
+	}
+
+	var alternatives []string
+	var args []interface{}
+	for i, key := range keys {
+		var terms []string
+		for j := 0; j < i; j++ {
+			term, termArgs := inventorySortColumns[keys[j].column].equal(typed[j])
+			terms = append(terms, term)
+			args = append(args, termArgs...)
+		}
+		term, termArgs := inventorySortColumns[key.column].beyond(typed[i], key.descending)
+		terms = append(terms, term)
+		args = append(args, termArgs...)
+		alternatives = append(alternatives, "("+strings.Join(terms, " AND ")+")")
+	}
+	condition := "(" + strings.Join(alternatives, " OR ") + ")"
+
+	first := inventorySortColumns[keys[0].column]
+	if typed[0] != nil && !first.nullable {
+		operator := " >= "
+		if keys[0].descending {
+			operator = " <= "
+		}
+		condition = first.expression + operator + first.bind() + " AND " + condition
+		args = append([]interface{}{typed[0]}, args...)
+	}
+	return condition, args, nil
+}
+
+func (keys inventorySortKeys) values(inventory *domain.Inventory) []string {
+	values := make([]string, len(keys))
+	for i, key := range keys {
+		values[i] = inventorySortColumns[key.column].value(inventory)
+	}
+	return values
+}
+
+func encodeCursor(values []string) string {
+	data, _ := json.Marshal(values)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(cursor string, expected int) ([]string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil || len(values) != expected {
+		return nil, ErrInvalidCursor
+	}
+	return values, nil
+}
+
+func placeholders(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strings.Repeat("?, ", n-1) + "?"
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
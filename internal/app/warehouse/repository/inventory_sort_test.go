@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+func TestParseInventorySort(t *testing.T) {
+	tests := []struct {
+		sort    string
+		orderBy string
+		err     bool
+	}{
+		{sort: "", orderBy: "id ASC"},
+		{sort: "stockin_at DESC,batch", orderBy: "stockin_at DESC, batch ASC, id ASC"},
+		{sort: "id desc", orderBy: "id DESC"},
+		{sort: "Quantity asc", orderBy: "quantity ASC, id ASC"},
+		{sort: "batch,batch", err: true},
+		{sort: "price", err: true},
+		{sort: "batch up", err: true},
+		{sort: "batch asc extra", err: true},
+	}
+	for _, test := range tests {
+		keys, err := parseInventorySort(test.sort)
+		if test.err {
+			if err == nil {
+				t.Errorf("parseInventorySort(%q) succeeded, want an error", test.sort)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseInventorySort(%q): %v", test.sort, err)
+			continue
+		}
+		if got := keys.withTiebreaker().orderBy(); got != test.orderBy {
+			t.Errorf("parseInventorySort(%q) orders by %q, want %q", test.sort, got, test.orderBy)
+		}
+	}
+}
+
+func TestInventorySortAfter(t *testing.T) {
+	at := time.Date(2026, 3, 1, 8, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		sort      string
+		values    []string
+		condition string
+		args      []interface{}
+	}{
+		{
+			name:      "quantity compares as a decimal",
+			sort:      "quantity",
+			values:    []string{"9", "41"},
+			condition: "quantity >= CAST(? AS DECIMAL(18,6)) AND ((quantity > CAST(? AS DECIMAL(18,6))) OR (quantity = CAST(? AS DECIMAL(18,6)) AND id > ?))",
+			args:      []interface{}{customtypes.MustParseDecimal("9"), customtypes.MustParseDecimal("9"), customtypes.MustParseDecimal("9"), int64(41)},
+		},
+		{
+			name:      "descending time",
+			sort:      "stockin_at DESC",
+			values:    []string{"2026-03-01 08:30:00", "7"},
+			condition: "stockin_at <= ? AND ((stockin_at < ?) OR (stockin_at = ? AND id > ?))",
+			args:      []interface{}{at, at, at, int64(7)},
+		},
+		{
+			name:      "ascending from a NULL stock-out time",
+			sort:      "stockout_at",
+			values:    []string{"", "3"},
+			condition: "((stockout_at IS NOT NULL) OR (stockout_at IS NULL AND id > ?))",
+			args:      []interface{}{int64(3)},
+		},
+		{
+			name:      "ascending from a stock-out time",
+			sort:      "stockout_at",
+			values:    []string{"2026-03-01 08:30:00", "3"},
+			condition: "((stockout_at > ?) OR (stockout_at = ? AND id > ?))",
+			args:      []interface{}{at, at, int64(3)},
+		},
+		{
+			name:      "descending from a stock-out time reaches the NULLs",
+			sort:      "stockout_at DESC",
+			values:    []string{"2026-03-01 08:30:00", "3"},
+			condition: "(((stockout_at < ? OR stockout_at IS NULL)) OR (stockout_at = ? AND id > ?))",
+			args:      []interface{}{at, at, int64(3)},
+		},
+		{
+			name:      "descending from a NULL stock-out time",
+			sort:      "stockout_at DESC",
+			values:    []string{"", "3"},
+			condition: "((FALSE) OR (stockout_at IS NULL AND id > ?))",
+			args:      []interface{}{int64(3)},
+		},
+	}
+	for _, test := range tests {
+		keys, err := parseInventorySort(test.sort)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		condition, args, err := keys.withTiebreaker().after(test.values)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if condition != test.condition {
+			t.Errorf("%s:\ncondition %s\nwant      %s", test.name, condition, test.condition)
+		}
+		if !reflect.DeepEqual(args, test.args) {
+			t.Errorf("%s: args %v, want %v", test.name, args, test.args)
+		}
+	}
+}
+
+func TestInventorySortAfterRejectsBadValues(t *testing.T) {
+	tests := []struct {
+		sort   string
+		values []string
+	}{
+		{sort: "quantity", values: []string{"ten", "1"}},
+		{sort: "stockin_at", values: []string{"yesterday", "1"}},
+		{sort: "stockin_at", values: []string{"", "1"}},
+		{sort: "id", values: []string{"1; DROP TABLE inventories"}},
+	}
+	for _, test := range tests {
+		keys, _ := parseInventorySort(test.sort)
+		if _, _, err := keys.withTiebreaker().after(test.values); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("after(%q, %q) = %v, want ErrInvalidCursor", test.sort, test.values, err)
+		}
+	}
+}
+
+func TestInventoryCursorRoundTrip(t *testing.T) {
+	keys, _ := parseInventorySort("stockout_at DESC,quantity")
+	keys = keys.withTiebreaker()
+	inventory := &domain.Inventory{ID: 12, Quantity: customtypes.MustParseDecimal("10.5")}
+
+	cursor := encodeCursor(keys.values(inventory))
+	values, err := decodeCursor(cursor, len(keys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"", "10.5", "12"}; !reflect.DeepEqual(values, want) {
+		t.Errorf("decoded %q, want %q", values, want)
+	}
+	if _, err := decodeCursor(cursor, len(keys)+1); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("decoding with the wrong number of keys: %v, want ErrInvalidCursor", err)
+	}
+	if _, err := decodeCursor("not base64!", len(keys)); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("decoding garbage: %v, want ErrInvalidCursor", err)
+	}
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+const labelTemplateColumns = "id, name, kind, width, height, dpi, elements, status, created_at, updated_at, last_updated_by, version"
+
+type MySqlLabelRepository struct {
+	conn database.Connection
+}
+
+func NewLabelRepository(conn database.Connection) LabelRepository {
+	return &MySqlLabelRepository{conn: conn}
+}
+
+func (r *MySqlLabelRepository) Create(labelTemplate *domain.LabelTemplate) error {
+	query := "INSERT INTO label_templates (name, kind, width, height, dpi, elements, status, last_updated_by) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+	_, err := r.conn.GetDB().Exec(query, labelTemplate.Name, labelTemplate.Kind, labelTemplate.Width, labelTemplate.Height, labelTemplate.DPI, labelTemplate.Elements, labelTemplate.Status, labelTemplate.LastUpdatedBy)
+	return err
+}
+
+func (r *MySqlLabelRepository) Update(labelTemplate *domain.LabelTemplate) error {
+	query := "UPDATE label_templates SET name=?, kind=?, width=?, height=?, dpi=?, elements=?, status=?, last_updated_by=?, version=LAST_INSERT_ID(version+1) WHERE id=? AND (? = 0 OR version=?)"
+	result, err := r.conn.GetDB().Exec(query, labelTemplate.Name, labelTemplate.Kind, labelTemplate.Width, labelTemplate.Height, labelTemplate.DPI, labelTemplate.Elements, labelTemplate.Status, labelTemplate.LastUpdatedBy, labelTemplate.ID, labelTemplate.Version, labelTemplate.Version)
+	if err != nil {
+		return err
+	}
+	if err := database.CheckVersionedWrite(result, "label template", labelTemplate.ID, labelTemplate.Version); err != nil {
+		return err
+	}
+	labelTemplate.Version = database.NextVersion(result, labelTemplate.Version)
+	return nil
+}
+
+func (r *MySqlLabelRepository) Delete(labelTemplateID int64, version int64) error {
+	query := "DELETE FROM label_templates WHERE id=? AND (? = 0 OR version=?)"
+	result, err := r.conn.GetDB().Exec(query, labelTemplateID, version, version)
+	if err != nil {
+		return err
+	}
+	return database.CheckVersionedWrite(result, "label template", labelTemplateID, version)
+}
+
+func (r *MySqlLabelRepository) GetById(labelTemplateID int64) (*domain.LabelTemplate, error) {
+	query := "SELECT " + labelTemplateColumns + " FROM label_templates WHERE id = ?"
+	return r.scanLabelTemplate(r.conn.GetDB().QueryRow(query, labelTemplateID))
+}
+
+func (r *MySqlLabelRepository) GetByName(name string) (*domain.LabelTemplate, error) {
+	query := "SELECT " + labelTemplateColumns + " FROM label_templates WHERE name = ?"
+	return r.scanLabelTemplate(r.conn.GetDB().QueryRow(query, name))
+}
+
+func (r *MySqlLabelRepository) GetTotalCount(filter LabelTemplateFilterOptions) (int, error) {
+	query, args := r.buildFilterQuery("SELECT COUNT(*) FROM label_templates", filter)
+
+	var count int
+	if err := r.conn.GetDB().QueryRow(query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *MySqlLabelRepository) GetAll(page int, pageSize int, sort string, filter LabelTemplateFilterOptions) ([]*domain.LabelTemplate, error) {
+	query, args := r.buildFilterQuery("SELECT "+labelTemplateColumns+" FROM label_templates", filter)
+	var allowedSortOrders = map[string]bool{
+		"name ASC":        true,
+		"name DESC":       true,
+		"kind ASC":        true,
+		"kind DESC":       true,
+		"updated_at ASC":  true,
+		"updated_at DESC": true,
+	}
+
+	if sort != "" {
+		if _, ok := allowedSortOrders[sort]; ok {
+			query += " ORDER BY " + sort
+		} else {
+			return nil, errors.New("invalid sort order")
+		}
+	}
+
+	query += " LIMIT ? OFFSET ?"
+	args = append(args, pageSize, page)
+
+	rows, err := r.conn.GetDB().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var labelTemplates []*domain.LabelTemplate
+	for rows.Next() {
+		labelTemplate, err := r.scanLabelTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		labelTemplates = append(labelTemplates, labelTemplate)
+	}
+	return labelTemplates, rows.Err()
+}
+
+func (r *MySqlLabelRepository) GetProduct(productID int64) (*domain.LabelProduct, error) {
+	query := "SELECT id, type, COALESCE(code, ''), COALESCE(name, ''), COALESCE(description, ''), COALESCE(unit, '') FROM products WHERE id = ?"
+	product := &domain.LabelProduct{}
+	err := r.conn.GetDB().QueryRow(query, productID).Scan(&product.ID, &product.Type, &product.Code, &product.Name, &product.Description, &product.Unit)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, customerrors.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return product, nil
+}
+
+func (r *MySqlLabelRepository) scanLabelTemplate(row rowScanner) (*domain.LabelTemplate, error) {
+	labelTemplate := domain.NewLabelTemplateWithDefaults()
+	err := row.Scan(&labelTemplate.ID, &labelTemplate.Name, &labelTemplate.Kind, &labelTemplate.Width, &labelTemplate.Height, &labelTemplate.DPI, &labelTemplate.Elements, &labelTemplate.Status, &labelTemplate.CreatedAt, &labelTemplate.UpdatedAt, &labelTemplate.LastUpdatedBy, &labelTemplate.Version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, customerrors.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return labelTemplate, nil
+}
+
+func (r *MySqlLabelRepository) buildFilterQuery(baseQuery string, filter LabelTemplateFilterOptions) (string, []interface{}) {
+	var filters []string
+	var args []interface{}
+
+	if filter.Kind != "" {
+		filters = append(filters, "kind = ?")
+		args = append(args, filter.Kind)
+	}
+	if filter.Status != "" {
+		filters = append(filters, "status = ?")
+		args = append(args, filter.Status)
+	}
+
+	if len(filters) > 0 {
+		return baseQuery + " WHERE " + strings.Join(filters, " AND "), args
+	}
+
+	return baseQuery, args
+}
@@ -0,0 +1,19 @@
+package repository
+
+import "github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+
+type LabelRepository interface {
+	Create(labelTemplate *domain.LabelTemplate) error
+	Update(labelTemplate *domain.LabelTemplate) error
+	Delete(labelTemplateID int64, version int64) error
+	GetById(labelTemplateID int64) (*domain.LabelTemplate, error)
+	GetByName(name string) (*domain.LabelTemplate, error)
+	GetTotalCount(filter LabelTemplateFilterOptions) (int, error)
+	GetAll(page int, pageSize int, sort string, filter LabelTemplateFilterOptions) ([]*domain.LabelTemplate, error)
+	GetProduct(productID int64) (*domain.LabelProduct, error)
+}
+
+type LabelTemplateFilterOptions struct {
+	Kind   string
+	Status string
+}
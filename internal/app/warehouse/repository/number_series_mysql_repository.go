@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+const numberSeriesColumns = "id, container_type, pattern, reset, status, created_at, updated_at, last_updated_by, version"
+
+// maxCodeAttempts bounds how many sequence numbers are skipped because their
+// code was already taken by hand before a new code is given up on.
+const maxCodeAttempts = 1000
+
+type MySqlNumberSeriesRepository struct {
+	conn database.Connection
+}
+
+func NewNumberSeriesRepository(conn database.Connection) NumberSeriesRepository {
+	return &MySqlNumberSeriesRepository{conn: conn}
+}
+
+func (r *MySqlNumberSeriesRepository) Create(series *domain.NumberSeries) error {
+	query := "INSERT INTO number_series (container_type, pattern, reset, status, last_updated_by) VALUES (?, ?, ?, ?, ?)"
+	result, err := r.conn.GetDB().Exec(query, series.ContainerType, series.Pattern, series.Reset, series.Status, series.LastUpdatedBy)
+	if err != nil {
+		return duplicateSeriesError(err)
+	}
+	series.ID, err = result.LastInsertId()
+	return err
+}
+
+func (r *MySqlNumberSeriesRepository) Update(series *domain.NumberSeries) error {
+	query := "UPDATE number_series SET container_type=?, pattern=?, reset=?, status=?, last_updated_by=?, version=LAST_INSERT_ID(version+1) WHERE id=? AND (? = 0 OR version=?)"
+	result, err := r.conn.GetDB().Exec(query, series.ContainerType, series.Pattern, series.Reset, series.Status, series.LastUpdatedBy, series.ID, series.Version, series.Version)
+	if err != nil {
+		return duplicateSeriesError(err)
+	}
+	if err := database.CheckVersionedWrite(result, "number series", series.ID, series.Version); err != nil {
+		return err
+	}
+	series.Version = database.NextVersion(result, series.Version)
+	return nil
+}
+
+func (r *MySqlNumberSeriesRepository) Delete(seriesID int64, version int64) error {
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		query := "DELETE FROM number_series WHERE id=? AND (? = 0 OR version=?)"
+		result, err := tx.Exec(query, seriesID, version, version)
+		if err != nil {
+			return err
+		}
+		if err := database.CheckVersionedWrite(result, "number series", seriesID, version); err != nil {
+			return err
+		}
+		_, err = tx.Exec("DELETE FROM number_series_counters WHERE series_id = ?", seriesID)
+		return err
+	})
+}
+
+func (r *MySqlNumberSeriesRepository) GetById(seriesID int64) (*domain.NumberSeries, error) {
+	query := "SELECT " + numberSeriesColumns + " FROM number_series WHERE id = ?"
+	series, err := scanNumberSeries(r.conn.GetDB().QueryRow(query, seriesID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, customerrors.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return series, nil
+}
+
+// GetAll lists every series. There is at most one per container type, so the
+// list is not paginated.
+func (r *MySqlNumberSeriesRepository) GetAll() ([]*domain.NumberSeries, error) {
+	rows, err := r.conn.GetDB().Query("SELECT " + numberSeriesColumns + " FROM number_series ORDER BY container_type")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var series []*domain.NumberSeries
+	for rows.Next() {
+		s, err := scanNumberSeries(rows)
+		if err != nil {
+			return nil, err
+		}
+		series = append(series, s)
+	}
+	return series, rows.Err()
+}
+
+func scanNumberSeries(row rowScanner) (*domain.NumberSeries, error) {
+	series := &domain.NumberSeries{}
+	err := row.Scan(&series.ID, &series.ContainerType, &series.Pattern, &series.Reset, &series.Status, &series.CreatedAt, &series.UpdatedAt, &series.LastUpdatedBy, &series.Version)
+	if err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+func duplicateSeriesError(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+		return domain.ErrDuplicateNumberSeries
+	}
+	return err
+}
+
+// assignContainerCode gives the container the next free code of the active
+// series of its type. The counter row stays locked until the transaction
+// ends, so concurrent requests get distinct numbers, and a rolled back
+// container gives its number back. Numbers whose code was already entered by
+// hand are skipped.
+func assignContainerCode(tx *sql.Tx, container *domain.Container) error {
+	row := tx.QueryRow("SELECT "+numberSeriesColumns+" FROM number_series WHERE container_type = ? AND status = 'active'", container.Type)
+	series, err := scanNumberSeries(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.ErrNoNumberSeries
+		}
+		return err
+	}
+	scope, err := series.Scope(container.StoreID)
+	if err != nil {
+		return err
+	}
+
+	at := time.Now()
+	period := series.Period(at)
+	query := "INSERT INTO number_series_counters (series_id, period, store_id, last_value) VALUES (?, ?, ?, LAST_INSERT_ID(1)) ON DUPLICATE KEY UPDATE last_value = LAST_INSERT_ID(last_value + 1)"
+	for attempt := 0; attempt < maxCodeAttempts; attempt++ {
+		if _, err := tx.Exec(query, series.ID, period, scope); err != nil {
+			return err
+		}
+		var sequence int64
+		if err := tx.QueryRow("SELECT LAST_INSERT_ID()").Scan(&sequence); err != nil {
+			return err
+		}
+		code := series.Format(at, scope, sequence)
+		taken, err := containerCodeTaken(tx, code)
+		if err != nil {
+			return err
+		}
+		if !taken {
+			container.Code = customtypes.NullableString(code)
+			return nil
+		}
+	}
+	return domain.ErrDuplicateContainerCode
+}
+
+// containerCodeTaken reports whether a container already uses the code, so
+// that the series can skip codes entered by hand. The unique key on the code
+// still decides between concurrent inserts.
+func containerCodeTaken(tx *sql.Tx, code string) (bool, error) {
+	var count int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM containers WHERE code = ?", code).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
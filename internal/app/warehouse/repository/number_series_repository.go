@@ -0,0 +1,11 @@
+package repository
+
+import "github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+
+type NumberSeriesRepository interface {
+	Create(series *domain.NumberSeries) error
+	Update(series *domain.NumberSeries) error
+	Delete(seriesID int64, version int64) error
+	GetById(seriesID int64) (*domain.NumberSeries, error)
+	GetAll() ([]*domain.NumberSeries, error)
+}
@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+const consumptionColumns = "id, produced_inventory_id, produced_batch, component_id, inventory_id, batch, store_id, quantity, unit, consumed_at, consumed_by"
+
+type MySqlProductionRepository struct {
+	conn database.Connection
+}
+
+func NewProductionRepository(conn database.Connection) ProductionRepository {
+	return &MySqlProductionRepository{conn: conn}
+}
+
+// GetBackflushComponents returns the BOM lines of the product that are
+// effective at time at.
+func (r *MySqlProductionRepository) GetBackflushComponents(productID int64, at time.Time) ([]*domain.BackflushComponent, error) {
+	query := `SELECT b.component_id, COALESCE(p.code, ''), b.quantity_per, b.unit, b.scrap_factor
+		FROM bom_lines b JOIN products p ON p.id = b.component_id
+		WHERE b.product_id = ? AND (b.effective_from IS NULL OR b.effective_from <= ?) AND (b.effective_to IS NULL OR b.effective_to >= ?)
+		ORDER BY b.id`
+	rows, err := r.conn.GetDB().Query(query, productID, at, at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var components []*domain.BackflushComponent
+	for rows.Next() {
+		component := &domain.BackflushComponent{}
+		if err := rows.Scan(&component.ComponentID, &component.ComponentCode, &component.QuantityPer, &component.Unit, &component.ScrapFactor); err != nil {
+			return nil, err
+		}
+		components = append(components, component)
+	}
+	return components, rows.Err()
+}
+
+// ReceiveWithBackflush receives the produced lot and consumes its components
+// from released stock in storeIDs, oldest lot first, in one transaction. The
+// candidate lots are locked so concurrent receipts cannot consume the same
+// stock twice.
+func (r *MySqlProductionRepository) ReceiveWithBackflush(inventory *domain.Inventory, serials []string, actor string, requirements []*domain.BackflushRequirement, storeIDs []int64) ([]*domain.Consumption, error) {
+	var consumptions []*domain.Consumption
+	err := database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		if err := receiveLot(tx, inventory, serials, actor); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, requirement := range requirements {
+			lots, err := r.lockComponentLots(tx, requirement.ComponentID, requirement.Unit, storeIDs)
+			if err != nil {
+				return err
+			}
+			allocated, shortfall := domain.AllocateFIFO(lots, requirement.Quantity, requirement.Unit)
+			if shortfall.Sign() > 0 {
+				return fmt.Errorf("%w: %s needs %s %s, %s short", domain.ErrInsufficientComponentStock, requirement.ComponentCode, requirement.Quantity, requirement.Unit, shortfall)
+			}
+
+			lotsByID := make(map[int64]*domain.Inventory, len(lots))
+			for _, lot := range lots {
+				lotsByID[lot.ID] = lot
+			}
+			for _, consumption := range allocated {
+				if err := consumeLot(tx, lotsByID[consumption.InventoryID], consumption.Quantity, now); err != nil {
+					return err
+				}
+				consumption.ProducedInventoryID = inventory.ID
+				consumption.ProducedBatch = inventory.Batch
+				consumption.ConsumedAt = now
+				consumption.ConsumedBy = actor
+				query := "INSERT INTO production_consumptions (produced_inventory_id, produced_batch, component_id, inventory_id, batch, store_id, quantity, unit, consumed_at, consumed_by) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+				result, err := tx.Exec(query, consumption.ProducedInventoryID, consumption.ProducedBatch, consumption.ComponentID, consumption.InventoryID, consumption.Batch, consumption.StoreID, consumption.Quantity, consumption.Unit, consumption.ConsumedAt, consumption.ConsumedBy)
+				if err != nil {
+					return err
+				}
+				if consumption.ID, err = result.LastInsertId(); err != nil {
+					return err
+				}
+			}
+			consumptions = append(consumptions, allocated...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return consumptions, nil
+}
+
+// lockComponentLots locks the released lots of a component held in unit;
+// lots counted in any other unit cannot be consumed against the BOM.
+func (r *MySqlProductionRepository) lockComponentLots(tx *sql.Tx, componentID int64, unit string, storeIDs []int64) ([]*domain.Inventory, error) {
+	query := `SELECT id, product_id, batch, store_id, quantity, unit FROM inventories
+		WHERE product_id = ? AND unit = ? AND status = ? AND qc_status = ? AND store_id IN (` + placeholders(len(storeIDs)) + `)
+		ORDER BY stockin_at, id FOR UPDATE`
+	args := []interface{}{componentID, unit, domain.STOCK_IN, domain.QC_RELEASED}
+	for _, storeID := range storeIDs {
+		args = append(args, storeID)
+	}
+
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lots []*domain.Inventory
+	for rows.Next() {
+		lot := domain.NewInventoryWithDefaults()
+		if err := rows.Scan(&lot.ID, &lot.ProductID, &lot.Batch, &lot.StoreID, &lot.Quantity, &lot.Unit); err != nil {
+			return nil, err
+		}
+		lots = append(lots, lot)
+	}
+	return lots, rows.Err()
+}
+
+// consumeLot takes quantity from a lot. A lot that is used up is stocked out
+// with its last quantity kept, like any other stock out.
+func consumeLot(tx *sql.Tx, lot *domain.Inventory, quantity customtypes.Decimal, at time.Time) error {
+	var err error
+	if quantity.Cmp(lot.Quantity) >= 0 {
+		_, err = tx.Exec("UPDATE inventories SET status=?, stockout_at=?, version=version+1 WHERE id=?", domain.STOCK_OUT, at, lot.ID)
+	} else {
+		_, err = tx.Exec("UPDATE inventories SET quantity=?, version=version+1 WHERE id=?", lot.Quantity.Sub(quantity), lot.ID)
+	}
+	if err != nil {
+		return err
+	}
+	return recordMovement(tx, lot.ID, domain.MOVEMENT_CONSUMED)
+}
+
+func (r *MySqlProductionRepository) GetTotalCount(filter ConsumptionFilterOptions) (int, error) {
+	query, args := r.buildFilterQuery("SELECT COUNT(*) FROM production_consumptions", filter)
+
+	var count int
+	if err := r.conn.GetDB().QueryRow(query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *MySqlProductionRepository) GetAll(page int, pageSize int, sort string, filter ConsumptionFilterOptions) ([]*domain.Consumption, error) {
+	query, args := r.buildFilterQuery("SELECT "+consumptionColumns+" FROM production_consumptions", filter)
+	var allowedSortOrders = map[string]bool{
+		"consumed_at ASC":  true,
+		"consumed_at DESC": true,
+		"id ASC":           true,
+		"id DESC":          true,
+	}
+
+	if sort != "" {
+		if _, ok := allowedSortOrders[sort]; ok {
+			query += " ORDER BY " + sort
+		} else {
+			return nil, errors.New("invalid sort order")
+		}
+	}
+
+	query += " LIMIT ? OFFSET ?"
+	args = append(args, pageSize, page)
+
+	rows, err := r.conn.GetDB().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var consumptions []*domain.Consumption
+	for rows.Next() {
+		consumption := &domain.Consumption{}
+		if err := rows.Scan(&consumption.ID, &consumption.ProducedInventoryID, &consumption.ProducedBatch, &consumption.ComponentID, &consumption.InventoryID, &consumption.Batch, &consumption.StoreID, &consumption.Quantity, &consumption.Unit, &consumption.ConsumedAt, &consumption.ConsumedBy); err != nil {
+			return nil, err
+		}
+		consumptions = append(consumptions, consumption)
+	}
+	return consumptions, rows.Err()
+}
+
+func (r *MySqlProductionRepository) buildFilterQuery(baseQuery string, filter ConsumptionFilterOptions) (string, []interface{}) {
+	var filters []string
+	var args []interface{}
+
+	if filter.ProducedInventoryID > 0 {
+		filters = append(filters, "produced_inventory_id = ?")
+		args = append(args, filter.ProducedInventoryID)
+	}
+	if filter.ProducedBatch != "" {
+		filters = append(filters, "produced_batch = ?")
+		args = append(args, filter.ProducedBatch)
+	}
+	if filter.ComponentID > 0 {
+		filters = append(filters, "component_id = ?")
+		args = append(args, filter.ComponentID)
+	}
+	if filter.InventoryID > 0 {
+		filters = append(filters, "inventory_id = ?")
+		args = append(args, filter.InventoryID)
+	}
+
+	if len(filters) > 0 {
+		return baseQuery + " WHERE " + strings.Join(filters, " AND "), args
+	}
+
+	return baseQuery, args
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+)
+
+type ProductionRepository interface {
+	GetBackflushComponents(productID int64, at time.Time) ([]*domain.BackflushComponent, error)
+	ReceiveWithBackflush(inventory *domain.Inventory, serials []string, actor string, requirements []*domain.BackflushRequirement, storeIDs []int64) ([]*domain.Consumption, error)
+	GetTotalCount(filter ConsumptionFilterOptions) (int, error)
+	GetAll(page int, pageSize int, sort string, filter ConsumptionFilterOptions) ([]*domain.Consumption, error)
+}
+
+type ConsumptionFilterOptions struct {
+	ProducedInventoryID int64
+	ProducedBatch       string
+	ComponentID         int64
+	InventoryID         int64
+}
@@ -0,0 +1,348 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+const putawayRuleColumns = "id, name, priority, product_id, product_type, store_id, zone_id, target_type, allow_mixed_products, allow_mixed_batches, prefer_same_product, status, created_at, updated_at, last_updated_by, version"
+
+// putawayLotQuery selects lots as domain.PutawayLot rows; the caller appends
+// the conditions on inventories i and containers c.
+const putawayLotQuery = `SELECT i.id, i.container_id, i.product_id, COALESCE(p.type, ''), COALESCE(i.batch, ''),
+		i.quantity, COALESCE(i.unit, ''), ROUND(i.quantity * p.weight, 6), ROUND(i.quantity * p.volume, 6)
+	FROM inventories i JOIN products p ON p.id = i.product_id LEFT JOIN containers c ON c.id = i.container_id
+	WHERE i.status <> ?`
+
+type MySqlPutawayRepository struct {
+	conn database.Connection
+}
+
+func NewPutawayRepository(conn database.Connection) PutawayRepository {
+	return &MySqlPutawayRepository{conn: conn}
+}
+
+func (r *MySqlPutawayRepository) Create(rule *domain.PutawayRule) error {
+	query := "INSERT INTO putaway_rules (name, priority, product_id, product_type, store_id, zone_id, target_type, allow_mixed_products, allow_mixed_batches, prefer_same_product, status, last_updated_by) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := r.conn.GetDB().Exec(query, rule.Name, rule.Priority, rule.ProductID, rule.ProductType, rule.StoreID, rule.ZoneID, rule.TargetType, rule.AllowMixedProducts, rule.AllowMixedBatches, rule.PreferSameProduct, rule.Status, rule.LastUpdatedBy)
+	if err != nil {
+		return err
+	}
+	rule.ID, err = result.LastInsertId()
+	return err
+}
+
+func (r *MySqlPutawayRepository) Update(rule *domain.PutawayRule) error {
+	query := "UPDATE putaway_rules SET name=?, priority=?, product_id=?, product_type=?, store_id=?, zone_id=?, target_type=?, allow_mixed_products=?, allow_mixed_batches=?, prefer_same_product=?, status=?, last_updated_by=?, version=LAST_INSERT_ID(version+1) WHERE id=? AND (? = 0 OR version=?)"
+	result, err := r.conn.GetDB().Exec(query, rule.Name, rule.Priority, rule.ProductID, rule.ProductType, rule.StoreID, rule.ZoneID, rule.TargetType, rule.AllowMixedProducts, rule.AllowMixedBatches, rule.PreferSameProduct, rule.Status, rule.LastUpdatedBy, rule.ID, rule.Version, rule.Version)
+	if err != nil {
+		return err
+	}
+	if err := database.CheckVersionedWrite(result, "putaway rule", rule.ID, rule.Version); err != nil {
+		return err
+	}
+	rule.Version = database.NextVersion(result, rule.Version)
+	return nil
+}
+
+func (r *MySqlPutawayRepository) Delete(ruleID int64, version int64) error {
+	query := "DELETE FROM putaway_rules WHERE id=? AND (? = 0 OR version=?)"
+	result, err := r.conn.GetDB().Exec(query, ruleID, version, version)
+	if err != nil {
+		return err
+	}
+	return database.CheckVersionedWrite(result, "putaway rule", ruleID, version)
+}
+
+func (r *MySqlPutawayRepository) GetById(ruleID int64) (*domain.PutawayRule, error) {
+	query := "SELECT " + putawayRuleColumns + " FROM putaway_rules WHERE id = ?"
+	rule, err := scanPutawayRule(r.conn.GetDB().QueryRow(query, ruleID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, customerrors.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (r *MySqlPutawayRepository) GetTotalCount(filter PutawayRuleFilterOptions) (int, error) {
+	query, args := r.buildFilterQuery("SELECT COUNT(*) FROM putaway_rules", filter)
+
+	var count int
+	if err := r.conn.GetDB().QueryRow(query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *MySqlPutawayRepository) GetAll(page int, pageSize int, sort string, filter PutawayRuleFilterOptions) ([]*domain.PutawayRule, error) {
+	query, args := r.buildFilterQuery("SELECT "+putawayRuleColumns+" FROM putaway_rules", filter)
+	var allowedSortOrders = map[string]bool{
+		"priority ASC":    true,
+		"priority DESC":   true,
+		"name ASC":        true,
+		"name DESC":       true,
+		"updated_at ASC":  true,
+		"updated_at DESC": true,
+	}
+
+	if sort == "" {
+		sort = "priority ASC"
+	}
+	if _, ok := allowedSortOrders[sort]; !ok {
+		return nil, errors.New("invalid sort order")
+	}
+	query += " ORDER BY " + sort + ", id LIMIT ? OFFSET ?"
+	args = append(args, pageSize, page)
+
+	return r.queryRules(query, args...)
+}
+
+// GetActiveRules returns the rules in the order they are tried.
+func (r *MySqlPutawayRepository) GetActiveRules() ([]*domain.PutawayRule, error) {
+	return r.queryRules("SELECT " + putawayRuleColumns + " FROM putaway_rules WHERE status = 'active' ORDER BY priority, id")
+}
+
+func (r *MySqlPutawayRepository) queryRules(query string, args ...interface{}) ([]*domain.PutawayRule, error) {
+	rows, err := r.conn.GetDB().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []*domain.PutawayRule
+	for rows.Next() {
+		rule, err := scanPutawayRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+func scanPutawayRule(row rowScanner) (*domain.PutawayRule, error) {
+	rule := &domain.PutawayRule{}
+	err := row.Scan(&rule.ID, &rule.Name, &rule.Priority, &rule.ProductID, &rule.ProductType, &rule.StoreID, &rule.ZoneID, &rule.TargetType, &rule.AllowMixedProducts, &rule.AllowMixedBatches, &rule.PreferSameProduct, &rule.Status, &rule.CreatedAt, &rule.UpdatedAt, &rule.LastUpdatedBy, &rule.Version)
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// GetInventorySubject returns a lot that is still in stock as the subject of
+// a putaway.
+func (r *MySqlPutawayRepository) GetInventorySubject(inventoryID int64) (*domain.PutawaySubject, error) {
+	lots, err := queryPutawayLots(r.conn.GetDB(), putawayLotQuery+" AND i.id = ?", domain.STOCK_OUT, inventoryID)
+	if err != nil {
+		return nil, err
+	}
+	if len(lots) == 0 {
+		return nil, customerrors.ErrResourceNotFound
+	}
+	subject := &domain.PutawaySubject{InventoryID: inventoryID, Lots: lots, Load: lots[0].Load}
+	if err := r.conn.GetDB().QueryRow("SELECT store_id FROM inventories WHERE id = ?", inventoryID).Scan(&subject.StoreID); err != nil {
+		return nil, err
+	}
+	return subject, nil
+}
+
+// GetContainerSubject returns a container with every lot in or below it as
+// the subject of a putaway.
+func (r *MySqlPutawayRepository) GetContainerSubject(containerID int64) (*domain.PutawaySubject, error) {
+	container, err := scanContainer(r.conn.GetDB().QueryRow("SELECT "+containerColumns+" FROM containers WHERE id = ?", containerID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, customerrors.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	lots, err := queryPutawayLots(r.conn.GetDB(), putawayLotQuery+" AND c.path LIKE ?", domain.STOCK_OUT, container.Path+"%")
+	if err != nil {
+		return nil, err
+	}
+	subject := &domain.PutawaySubject{Container: container, StoreID: container.StoreID, Lots: lots}
+	for _, lot := range lots {
+		subject.Load = subject.Load.Add(lot.Load)
+	}
+	return subject, nil
+}
+
+// GetStock returns every container of the store with the lots held in them.
+func (r *MySqlPutawayRepository) GetStock(storeID int64) (*domain.PutawayStock, error) {
+	rows, err := r.conn.GetDB().Query("SELECT "+containerColumns+" FROM containers WHERE store_id = ? ORDER BY path", storeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stock := &domain.PutawayStock{}
+	for rows.Next() {
+		container, err := scanContainer(rows)
+		if err != nil {
+			return nil, err
+		}
+		stock.Containers = append(stock.Containers, container)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if stock.Lots, err = queryPutawayLots(r.conn.GetDB(), putawayLotQuery+" AND c.store_id = ?", domain.STOCK_OUT, storeID); err != nil {
+		return nil, err
+	}
+	return stock, nil
+}
+
+func queryPutawayLots(q queryer, query string, args ...interface{}) ([]*domain.PutawayLot, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lots []*domain.PutawayLot
+	for rows.Next() {
+		lot := &domain.PutawayLot{}
+		var quantity customtypes.Decimal
+		var unit string
+		if err := rows.Scan(&lot.InventoryID, &lot.ContainerID, &lot.ProductID, &lot.ProductType, &lot.Batch, &quantity, &unit, &lot.Load.Weight, &lot.Load.Volume); err != nil {
+			return nil, err
+		}
+		// Only lots counted in pieces take up unit capacity
+		if domain.IsCountUnit(unit) {
+			lot.Load.Units = quantity
+		}
+		lots = append(lots, lot)
+	}
+	return lots, rows.Err()
+}
+
+// PutInventory moves the lot to its new container after checking, with the
+// target and its ancestors locked, that one of rules still admits it there.
+// The lot is guarded by its version and its serials record the transfer, in
+// the same transaction.
+func (r *MySqlPutawayRepository) PutInventory(inventory *domain.Inventory, rules []*domain.PutawayRule, storeID *int64, actor string) error {
+	var version int64
+	err := database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		lots, err := queryPutawayLots(tx, putawayLotQuery+" AND i.id = ?", domain.STOCK_OUT, inventory.ID)
+		if err != nil {
+			return err
+		}
+		if len(lots) == 0 {
+			return customerrors.ErrResourceNotFound
+		}
+		subject := &domain.PutawaySubject{InventoryID: inventory.ID, StoreID: inventory.StoreID, Lots: lots, Load: lots[0].Load}
+		if err := checkPutawayTarget(tx, subject, *inventory.ContainerID, rules, storeID); err != nil {
+			return err
+		}
+		if version, err = updateInventory(tx, inventory); err != nil {
+			return err
+		}
+		return syncSerials(tx, inventory, domain.SERIAL_EVENT_TRANSFERRED, actor)
+	})
+	if err != nil {
+		return err
+	}
+	inventory.Version = version
+	return nil
+}
+
+// PutContainer moves the container with everything below it into targetID
+// after checking, with both locked, that one of rules still admits it there.
+// It returns the capacity limits that were only warned about.
+func (r *MySqlPutawayRepository) PutContainer(containerID int64, targetID int64, version int64, rules []*domain.PutawayRule, storeID *int64) ([]string, error) {
+	var warnings []string
+	err := database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		container, err := lockContainer(tx, containerID)
+		if err != nil {
+			return err
+		}
+		lots, err := queryPutawayLots(tx, putawayLotQuery+" AND c.path LIKE ?", domain.STOCK_OUT, container.Path+"%")
+		if err != nil {
+			return err
+		}
+		subject := &domain.PutawaySubject{Container: container, StoreID: container.StoreID, Lots: lots}
+		for _, lot := range lots {
+			subject.Load = subject.Load.Add(lot.Load)
+		}
+		if err := checkPutawayTarget(tx, subject, targetID, rules, storeID); err != nil {
+			return err
+		}
+		warnings, err = moveContainer(tx, containerID, &targetID, nil, version)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return warnings, nil
+}
+
+// checkPutawayTarget locks targetID with its ancestors and returns
+// ErrPutawayTargetRejected unless a rule matching the subject admits it
+// there. storeID stands in for the store of stock that is not in one yet.
+func checkPutawayTarget(tx *sql.Tx, subject *domain.PutawaySubject, targetID int64, rules []*domain.PutawayRule, storeID *int64) error {
+	if subject.StoreID == nil {
+		subject.StoreID = storeID
+	}
+	if subject.StoreID == nil {
+		return domain.ErrPutawayStoreRequired
+	}
+	chain, err := loadContainerChain(tx, targetID)
+	if err != nil {
+		return err
+	}
+	target := chain[len(chain)-1]
+	if target.StoreID == nil || *target.StoreID != *subject.StoreID {
+		return domain.ErrPutawayTargetRejected
+	}
+	held, err := queryPutawayLots(tx, putawayLotQuery+" AND c.path LIKE ?", domain.STOCK_OUT, target.Path+"%")
+	if err != nil {
+		return err
+	}
+	// The subject is checked as if it had already left its old place
+	var others []*domain.PutawayLot
+	for _, lot := range held {
+		if !subject.Holds(lot) {
+			others = append(others, lot)
+		}
+	}
+	for _, rule := range rules {
+		if rule.Matches(subject) && rule.Admits(subject, target, others) {
+			return nil
+		}
+	}
+	return domain.ErrPutawayTargetRejected
+}
+
+func (r *MySqlPutawayRepository) buildFilterQuery(baseQuery string, filter PutawayRuleFilterOptions) (string, []interface{}) {
+	var filters []string
+	var args []interface{}
+
+	if filter.StoreID > 0 {
+		filters = append(filters, "store_id = ?")
+		args = append(args, filter.StoreID)
+	}
+	if filter.ProductID > 0 {
+		filters = append(filters, "product_id = ?")
+		args = append(args, filter.ProductID)
+	}
+	if filter.Status != "" {
+		filters = append(filters, "status = ?")
+		args = append(args, filter.Status)
+	}
+
+	if len(filters) > 0 {
+		return baseQuery + " WHERE " + strings.Join(filters, " AND "), args
+	}
+
+	return baseQuery, args
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+type PutawayRepository interface {
+	Create(rule *domain.PutawayRule) error
+	Update(rule *domain.PutawayRule) error
+	Delete(ruleID int64, version int64) error
+	GetById(ruleID int64) (*domain.PutawayRule, error)
+	GetTotalCount(filter PutawayRuleFilterOptions) (int, error)
+	GetAll(page int, pageSize int, sort string, filter PutawayRuleFilterOptions) ([]*domain.PutawayRule, error)
+	GetActiveRules() ([]*domain.PutawayRule, error)
+	GetInventorySubject(inventoryID int64) (*domain.PutawaySubject, error)
+	GetContainerSubject(containerID int64) (*domain.PutawaySubject, error)
+	GetStock(storeID int64) (*domain.PutawayStock, error)
+	PutInventory(inventory *domain.Inventory, rules []*domain.PutawayRule, storeID *int64, actor string) error
+	PutContainer(containerID int64, targetID int64, version int64, rules []*domain.PutawayRule, storeID *int64) ([]string, error)
+}
+
+type PutawayRuleFilterOptions struct {
+	StoreID   customtypes.NullableInt64
+	ProductID customtypes.NullableInt64
+	Status    string
+}
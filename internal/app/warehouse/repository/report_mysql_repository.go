@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+type MySqlReportRepository struct {
+	conn database.Connection
+}
+
+func NewReportRepository(conn database.Connection) ReportRepository {
+	return &MySqlReportRepository{conn: conn}
+}
+
+// GetAgingLots returns the on-hand inventory rows with their product and store
+// details. The last movement of a lot is the latest receipt or issue of the
+// same product in the same store.
+func (r *MySqlReportRepository) GetAgingLots(filter ReportFilterOptions) ([]*domain.AgingLot, error) {
+	query := `SELECT i.id, i.product_id, COALESCE(p.code, ''), COALESCE(p.name, ''), COALESCE(p.type, ''), p.category_id,
+			COALESCE(i.store_id, 0), COALESCE(s.name, ''), i.quantity, i.unit, i.stockin_at,
+			GREATEST(i.stockin_at, COALESCE(m.last_stockout_at, i.stockin_at))
+		FROM inventories i
+		LEFT JOIN products p ON p.id = i.product_id
+		LEFT JOIN stores s ON s.id = i.store_id
+		LEFT JOIN (
+			SELECT product_id, store_id, MAX(stockout_at) AS last_stockout_at
+			FROM inventories WHERE stockout_at IS NOT NULL GROUP BY product_id, store_id
+		) m ON m.product_id = i.product_id AND m.store_id <=> i.store_id`
+
+	filters := []string{"i.status IN (?, ?)"}
+	args := []interface{}{domain.STOCK_IN, domain.STOCK_RESERVED}
+	if filter.StoreID > 0 {
+		filters = append(filters, "i.store_id = ?")
+		args = append(args, filter.StoreID)
+	}
+	if filter.ProductID > 0 {
+		filters = append(filters, "i.product_id = ?")
+		args = append(args, filter.ProductID)
+	}
+	if filter.ProductType != "" {
+		filters = append(filters, "p.type = ?")
+		args = append(args, filter.ProductType)
+	}
+	if filter.CategoryID > 0 {
+		filters = append(filters, "i."+categoryProductsFilter)
+		args = append(args, filter.CategoryID)
+	}
+	query += " WHERE " + strings.Join(filters, " AND ") + " ORDER BY i.stockin_at"
+
+	rows, err := r.conn.GetDB().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lots []*domain.AgingLot
+	for rows.Next() {
+		lot := &domain.AgingLot{}
+		if err := rows.Scan(&lot.InventoryID, &lot.ProductID, &lot.ProductCode, &lot.ProductName, &lot.ProductType, &lot.CategoryID, &lot.StoreID, &lot.StoreName, &lot.Quantity, &lot.Unit, &lot.StockInAt, &lot.LastMovementAt); err != nil {
+			return nil, err
+		}
+		lots = append(lots, lot)
+	}
+	return lots, rows.Err()
+}
+
+// GetUtilizationData loads the containers of storeID, or of every store when
+// it is zero, and the stock held directly in each of them.
+func (r *MySqlReportRepository) GetUtilizationData(storeID int64) (*UtilizationData, error) {
+	data := &UtilizationData{
+		Loads:      make(map[int64]domain.ContainerLoad),
+		StoreNames: make(map[int64]string),
+	}
+	var storeFilter string
+	var args []interface{}
+	if storeID > 0 {
+		storeFilter = " WHERE store_id = ?"
+		args = append(args, storeID)
+	}
+
+	rows, err := r.conn.GetDB().Query("SELECT "+containerColumns+" FROM containers"+storeFilter+" ORDER BY path", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		container, err := scanContainer(rows)
+		if err != nil {
+			return nil, err
+		}
+		data.Containers = append(data.Containers, container)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	columns, loadArgs := loadColumns()
+	query := `SELECT i.container_id, ` + columns + ` FROM inventories i
+		JOIN containers c ON c.id = i.container_id JOIN products p ON p.id = i.product_id
+		WHERE i.status <> ?`
+	loadArgs = append(loadArgs, domain.STOCK_OUT)
+	if storeID > 0 {
+		query += " AND c.store_id = ?"
+		loadArgs = append(loadArgs, storeID)
+	}
+	query += " GROUP BY i.container_id"
+	loadRows, err := r.conn.GetDB().Query(query, loadArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer loadRows.Close()
+	for loadRows.Next() {
+		var containerID int64
+		var load domain.ContainerLoad
+		if err := loadRows.Scan(&containerID, &load.Units, &load.Weight, &load.Volume); err != nil {
+			return nil, err
+		}
+		data.Loads[containerID] = load
+	}
+	if err := loadRows.Err(); err != nil {
+		return nil, err
+	}
+
+	storeRows, err := r.conn.GetDB().Query("SELECT id, COALESCE(name, '') FROM stores")
+	if err != nil {
+		return nil, err
+	}
+	defer storeRows.Close()
+	for storeRows.Next() {
+		var id int64
+		var name string
+		if err := storeRows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		data.StoreNames[id] = name
+	}
+	return data, storeRows.Err()
+}
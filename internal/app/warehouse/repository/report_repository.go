@@ -0,0 +1,27 @@
+package repository
+
+import "github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+
+type ReportRepository interface {
+	GetAgingLots(filter ReportFilterOptions) ([]*domain.AgingLot, error)
+	GetUtilizationData(storeID int64) (*UtilizationData, error)
+	GetProductCategories() ([]*domain.ProductCategory, error)
+	GetCategoryStock(storeID int64) ([]*domain.CategoryStockRow, error)
+}
+
+// ReportFilterOptions narrows reports. CategoryID takes in the products whose
+// primary category is the category or any category below it.
+type ReportFilterOptions struct {
+	StoreID     int64
+	ProductID   int64
+	ProductType string
+	CategoryID  int64
+}
+
+// UtilizationData is the container tree with the load held directly in each
+// container, keyed by container id.
+type UtilizationData struct {
+	Containers []*domain.Container
+	Loads      map[int64]domain.ContainerLoad
+	StoreNames map[int64]string
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+type MySqlScanRepository struct {
+	conn database.Connection
+}
+
+func NewScanRepository(conn database.Connection) ScanRepository {
+	return &MySqlScanRepository{conn: conn}
+}
+
+// GetProductByCode matches the product code first and the raw code second.
+func (r *MySqlScanRepository) GetProductByCode(code string) (*domain.ScanProduct, error) {
+	query := "SELECT id, type, code, raw_code, name, unit, serialized, status FROM products WHERE code = ? OR raw_code = ? ORDER BY code = ? DESC, id LIMIT 1"
+	product := &domain.ScanProduct{}
+	err := r.conn.GetDB().QueryRow(query, code, code, code).Scan(&product.ID, &product.Type, &product.Code, &product.RawCode, &product.Name, &product.Unit, &product.Serialized, &product.Status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, customerrors.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return product, nil
+}
+
+func (r *MySqlScanRepository) GetStockLocations(productID int64) ([]*domain.StockLocation, error) {
+	query := `SELECT store_id, rack_id, bin_id, pallet_id, qc_status, SUM(quantity), unit, COUNT(*)
+		FROM inventories
+		WHERE product_id = ? AND status IN (?, ?)
+		GROUP BY store_id, rack_id, bin_id, pallet_id, qc_status, unit
+		ORDER BY store_id, rack_id, bin_id, pallet_id, qc_status`
+	rows, err := r.conn.GetDB().Query(query, productID, domain.STOCK_IN, domain.STOCK_RESERVED)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var locations []*domain.StockLocation
+	for rows.Next() {
+		location := &domain.StockLocation{}
+		if err := rows.Scan(&location.StoreID, &location.RackID, &location.BinID, &location.PalletID, &location.QCStatus, &location.Quantity, &location.Unit, &location.Lots); err != nil {
+			return nil, err
+		}
+		locations = append(locations, location)
+	}
+	return locations, rows.Err()
+}
@@ -0,0 +1,8 @@
+package repository
+
+import "github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+
+type ScanRepository interface {
+	GetProductByCode(code string) (*domain.ScanProduct, error)
+	GetStockLocations(productID int64) ([]*domain.StockLocation, error)
+}
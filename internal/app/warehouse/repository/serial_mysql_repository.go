@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+// Serials take their location from the lot they belong to.
+const serialColumns = "s.id, s.serial, s.product_id, s.inventory_id, s.batch, s.status, i.store_id, i.rack_id, i.bin_id, i.pallet_id, s.created_at, s.updated_at"
+
+const serialFrom = " FROM serial_numbers s LEFT JOIN inventories i ON i.id = s.inventory_id"
+
+// mysqlDuplicateEntry is the server error number for a unique key violation.
+const mysqlDuplicateEntry = 1062
+
+type MySqlSerialRepository struct {
+	conn database.Connection
+}
+
+func NewSerialRepository(conn database.Connection) SerialRepository {
+	return &MySqlSerialRepository{conn: conn}
+}
+
+func (r *MySqlSerialRepository) GetSerialSettings(productID int64) (*domain.SerialSettings, error) {
+	query := "SELECT id, COALESCE(code, ''), COALESCE(unit, ''), serialized, COALESCE(serial_pattern, '') FROM products WHERE id = ?"
+	settings := &domain.SerialSettings{}
+	err := r.conn.GetDB().QueryRow(query, productID).Scan(&settings.ProductID, &settings.ProductCode, &settings.Unit, &settings.Serialized, &settings.Pattern)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, customerrors.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+// NextSequence reserves count consecutive sequence numbers for the product
+// and returns the first one. LAST_INSERT_ID(expr) makes the increment atomic
+// across concurrent receipts.
+func (r *MySqlSerialRepository) NextSequence(productID int64, count int) (int64, error) {
+	var last int64
+	err := database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		query := "INSERT INTO serial_sequences (product_id, last_value) VALUES (?, LAST_INSERT_ID(?)) ON DUPLICATE KEY UPDATE last_value = LAST_INSERT_ID(last_value + ?)"
+		if _, err := tx.Exec(query, productID, count, count); err != nil {
+			return err
+		}
+		return tx.QueryRow("SELECT LAST_INSERT_ID()").Scan(&last)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return last - int64(count) + 1, nil
+}
+
+// Receive creates the lot together with its serials and their receipt events.
+func (r *MySqlSerialRepository) Receive(inventory *domain.Inventory, serials []string, actor string) error {
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		return receiveLot(tx, inventory, serials, actor)
+	})
+}
+
+// receiveLot is shared with repositories that receive a lot as part of a
+// larger transaction. serials may be empty for products without tracking.
+func receiveLot(tx *sql.Tx, inventory *domain.Inventory, serials []string, actor string) error {
+	if err := insertInventory(tx, inventory); err != nil {
+		return err
+	}
+	status := domain.SerialStatusFor(inventory.Status)
+	now := time.Now()
+	for _, serial := range serials {
+		result, err := tx.Exec("INSERT INTO serial_numbers (serial, product_id, inventory_id, batch, status) VALUES (?, ?, ?, ?, ?)", serial, inventory.ProductID, inventory.ID, inventory.Batch, status)
+		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+				return fmt.Errorf("%w: %s", domain.ErrDuplicateSerial, serial)
+			}
+			return err
+		}
+		serialID, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		query := "INSERT INTO serial_events (serial_id, event, inventory_id, status, store_id, occurred_at, actor) VALUES (?, ?, ?, ?, ?, ?, ?)"
+		if _, err := tx.Exec(query, serialID, domain.SERIAL_EVENT_RECEIVED, inventory.ID, status, inventory.StoreID, now, nullIfEmpty(actor)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SyncWithInventory moves every serial of the lot to the status of the lot
+// and records event against each of them.
+func (r *MySqlSerialRepository) SyncWithInventory(inventory *domain.Inventory, event domain.SerialEventType, actor string) error {
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		return syncSerials(tx, inventory, event, actor)
+	})
+}
+
+// syncSerials is shared with repositories that change a lot as part of a
+// larger transaction.
+func syncSerials(tx *sql.Tx, inventory *domain.Inventory, event domain.SerialEventType, actor string) error {
+	status := domain.SerialStatusFor(inventory.Status)
+	if _, err := tx.Exec("UPDATE serial_numbers SET status=? WHERE inventory_id=?", status, inventory.ID); err != nil {
+		return err
+	}
+	query := `INSERT INTO serial_events (serial_id, event, inventory_id, status, store_id, occurred_at, actor)
+		SELECT id, ?, ?, ?, ?, ?, ? FROM serial_numbers WHERE inventory_id = ?`
+	_, err := tx.Exec(query, event, inventory.ID, status, inventory.StoreID, time.Now(), nullIfEmpty(actor), inventory.ID)
+	return err
+}
+
+func (r *MySqlSerialRepository) CountByInventory(inventoryID int64) (int, error) {
+	var count int
+	if err := r.conn.GetDB().QueryRow("SELECT COUNT(*) FROM serial_numbers WHERE inventory_id = ?", inventoryID).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *MySqlSerialRepository) GetBySerial(serial string) (*domain.SerialHistory, error) {
+	query := "SELECT " + serialColumns + serialFrom + " WHERE s.serial = ?"
+	serialNumber, err := r.scanSerial(r.conn.GetDB().QueryRow(query, serial))
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.conn.GetDB().Query("SELECT id, serial_id, event, inventory_id, status, store_id, occurred_at, actor FROM serial_events WHERE serial_id = ? ORDER BY occurred_at, id", serialNumber.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := &domain.SerialHistory{SerialNumber: serialNumber, Events: []*domain.SerialEvent{}}
+	for rows.Next() {
+		event := &domain.SerialEvent{}
+		if err := rows.Scan(&event.ID, &event.SerialID, &event.Event, &event.InventoryID, &event.Status, &event.StoreID, &event.OccurredAt, &event.Actor); err != nil {
+			return nil, err
+		}
+		history.Events = append(history.Events, event)
+	}
+	return history, rows.Err()
+}
+
+func (r *MySqlSerialRepository) GetTotalCount(filter SerialFilterOptions) (int, error) {
+	query, args := r.buildFilterQuery("SELECT COUNT(*)"+serialFrom, filter)
+
+	var count int
+	if err := r.conn.GetDB().QueryRow(query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *MySqlSerialRepository) GetAll(page int, pageSize int, sort string, filter SerialFilterOptions) ([]*domain.SerialNumber, error) {
+	query, args := r.buildFilterQuery("SELECT "+serialColumns+serialFrom, filter)
+	var allowedSortOrders = map[string]bool{
+		"serial ASC":      true,
+		"serial DESC":     true,
+		"created_at ASC":  true,
+		"created_at DESC": true,
+	}
+
+	if sort != "" {
+		if _, ok := allowedSortOrders[sort]; ok {
+			query += " ORDER BY s." + sort
+		} else {
+			return nil, errors.New("invalid sort order")
+		}
+	}
+
+	query += " LIMIT ? OFFSET ?"
+	args = append(args, pageSize, page)
+
+	rows, err := r.conn.GetDB().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var serials []*domain.SerialNumber
+	for rows.Next() {
+		serialNumber, err := r.scanSerial(rows)
+		if err != nil {
+			return nil, err
+		}
+		serials = append(serials, serialNumber)
+	}
+	return serials, rows.Err()
+}
+
+func (r *MySqlSerialRepository) scanSerial(row rowScanner) (*domain.SerialNumber, error) {
+	serialNumber := &domain.SerialNumber{}
+	err := row.Scan(&serialNumber.ID, &serialNumber.Serial, &serialNumber.ProductID, &serialNumber.InventoryID, &serialNumber.Batch, &serialNumber.Status, &serialNumber.StoreID, &serialNumber.RackID, &serialNumber.BinID, &serialNumber.PalletID, &serialNumber.CreatedAt, &serialNumber.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, customerrors.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return serialNumber, nil
+}
+
+func (r *MySqlSerialRepository) buildFilterQuery(baseQuery string, filter SerialFilterOptions) (string, []interface{}) {
+	var filters []string
+	var args []interface{}
+
+	if len(filter.Statuses) > 0 {
+		filters = append(filters, "s.status IN ("+placeholders(len(filter.Statuses))+")")
+		for _, status := range filter.Statuses {
+			args = append(args, status)
+		}
+	}
+	if filter.ProductID > 0 {
+		filters = append(filters, "s.product_id = ?")
+		args = append(args, filter.ProductID)
+	}
+	if filter.InventoryID > 0 {
+		filters = append(filters, "s.inventory_id = ?")
+		args = append(args, filter.InventoryID)
+	}
+	if filter.SerialPrefix != "" {
+		filters = append(filters, "s.serial LIKE ?")
+		args = append(args, escapeLike(filter.SerialPrefix)+"%")
+	}
+
+	if len(filters) > 0 {
+		return baseQuery + " WHERE " + strings.Join(filters, " AND "), args
+	}
+
+	return baseQuery, args
+}
+
+func nullIfEmpty(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,28 @@
+package repository
+
+import "github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+
+type SerialRepository interface {
+	GetSerialSettings(productID int64) (*domain.SerialSettings, error)
+	NextSequence(productID int64, count int) (int64, error)
+	Receive(inventory *domain.Inventory, serials []string, actor string) error
+	SyncWithInventory(inventory *domain.Inventory, event domain.SerialEventType, actor string) error
+	CountByInventory(inventoryID int64) (int, error)
+	GetBySerial(serial string) (*domain.SerialHistory, error)
+	GetTotalCount(filter SerialFilterOptions) (int, error)
+	GetAll(page int, pageSize int, sort string, filter SerialFilterOptions) ([]*domain.SerialNumber, error)
+}
+
+type SerialFilterOptions struct {
+	Statuses     []domain.SerialStatus
+	ProductID    int64
+	InventoryID  int64
+	SerialPrefix string
+}
+
+func (f *SerialFilterOptions) SetStatuses(statusesStr string) {
+	f.Statuses = nil
+	for _, status := range splitList(statusesStr) {
+		f.Statuses = append(f.Statuses, domain.SerialStatus(status))
+	}
+}
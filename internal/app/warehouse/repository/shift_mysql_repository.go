@@ -0,0 +1,359 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+const shiftColumns = "id, store_id, code, name, start_time, end_time, days, supervisor, status, created_at, updated_at, last_updated_by, version"
+
+// queryer is satisfied by both *sql.DB and *sql.Tx, so that the shift
+// calendar can be read on its own and while a lot is stocked in.
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+type MySqlShiftRepository struct {
+	conn database.Connection
+}
+
+func NewShiftRepository(conn database.Connection) ShiftRepository {
+	return &MySqlShiftRepository{conn: conn}
+}
+
+func (r *MySqlShiftRepository) Create(shift *domain.Shift) error {
+	query := "INSERT INTO shifts (store_id, code, name, start_time, end_time, days, supervisor, status, last_updated_by) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := r.conn.GetDB().Exec(query, shift.StoreID, shift.Code, shift.Name, shift.StartTime, shift.EndTime, shift.Days, shift.Supervisor, shift.Status, shift.LastUpdatedBy)
+	if err != nil {
+		return duplicateShiftError(err)
+	}
+	shift.ID, err = result.LastInsertId()
+	return err
+}
+
+func (r *MySqlShiftRepository) Update(shift *domain.Shift) error {
+	query := "UPDATE shifts SET store_id=?, code=?, name=?, start_time=?, end_time=?, days=?, supervisor=?, status=?, last_updated_by=?, version=LAST_INSERT_ID(version+1) WHERE id=? AND (? = 0 OR version=?)"
+	result, err := r.conn.GetDB().Exec(query, shift.StoreID, shift.Code, shift.Name, shift.StartTime, shift.EndTime, shift.Days, shift.Supervisor, shift.Status, shift.LastUpdatedBy, shift.ID, shift.Version, shift.Version)
+	if err != nil {
+		return duplicateShiftError(err)
+	}
+	if err := database.CheckVersionedWrite(result, "shift", shift.ID, shift.Version); err != nil {
+		return err
+	}
+	shift.Version = database.NextVersion(result, shift.Version)
+	return nil
+}
+
+func (r *MySqlShiftRepository) Delete(shiftID int64, version int64) error {
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		query := "DELETE FROM shifts WHERE id=? AND (? = 0 OR version=?)"
+		result, err := tx.Exec(query, shiftID, version, version)
+		if err != nil {
+			return err
+		}
+		if err := database.CheckVersionedWrite(result, "shift", shiftID, version); err != nil {
+			return err
+		}
+		_, err = tx.Exec("DELETE FROM shift_assignments WHERE shift_id = ?", shiftID)
+		return err
+	})
+}
+
+func (r *MySqlShiftRepository) GetById(shiftID int64) (*domain.Shift, error) {
+	query := "SELECT " + shiftColumns + " FROM shifts WHERE id = ?"
+	shift, err := scanShift(r.conn.GetDB().QueryRow(query, shiftID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, customerrors.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return shift, nil
+}
+
+// GetAll lists shifts by store and start time. A store runs a handful of
+// shifts, so the list is not paginated.
+func (r *MySqlShiftRepository) GetAll(filter ShiftFilterOptions) ([]*domain.Shift, error) {
+	var filters []string
+	var args []interface{}
+	if filter.StoreID > 0 {
+		filters = append(filters, "store_id = ?")
+		args = append(args, filter.StoreID)
+	}
+	if filter.Status != "" {
+		filters = append(filters, "status = ?")
+		args = append(args, filter.Status)
+	}
+	query := "SELECT " + shiftColumns + " FROM shifts"
+	if len(filters) > 0 {
+		query += " WHERE " + strings.Join(filters, " AND ")
+	}
+	query += " ORDER BY store_id, start_time, code"
+	return queryShifts(r.conn.GetDB(), query, args...)
+}
+
+func queryShifts(db queryer, query string, args ...interface{}) ([]*domain.Shift, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shifts := []*domain.Shift{}
+	for rows.Next() {
+		shift, err := scanShift(rows)
+		if err != nil {
+			return nil, err
+		}
+		shifts = append(shifts, shift)
+	}
+	return shifts, rows.Err()
+}
+
+func scanShift(row rowScanner) (*domain.Shift, error) {
+	shift := &domain.Shift{}
+	err := row.Scan(&shift.ID, &shift.StoreID, &shift.Code, &shift.Name, &shift.StartTime, &shift.EndTime, &shift.Days, &shift.Supervisor, &shift.Status, &shift.CreatedAt, &shift.UpdatedAt, &shift.LastUpdatedBy, &shift.Version)
+	if err != nil {
+		return nil, err
+	}
+	return shift, nil
+}
+
+func duplicateShiftError(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+		return domain.ErrDuplicateShift
+	}
+	return err
+}
+
+func (r *MySqlShiftRepository) CreateHoliday(holiday *domain.ShiftHoliday) error {
+	query := "INSERT INTO shift_holidays (store_id, holiday_date, name) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE name = VALUES(name), id = LAST_INSERT_ID(id)"
+	result, err := r.conn.GetDB().Exec(query, holiday.StoreID, holiday.Date, holiday.Name)
+	if err != nil {
+		return err
+	}
+	holiday.ID, err = result.LastInsertId()
+	return err
+}
+
+func (r *MySqlShiftRepository) DeleteHoliday(holidayID int64) error {
+	result, err := r.conn.GetDB().Exec("DELETE FROM shift_holidays WHERE id = ?", holidayID)
+	if err != nil {
+		return err
+	}
+	return checkDeleted(result)
+}
+
+// GetHolidays lists the holidays of the store between from and to, either of
+// which may be empty.
+func (r *MySqlShiftRepository) GetHolidays(storeID int64, from string, to string) ([]*domain.ShiftHoliday, error) {
+	query, args := dateRangeQuery("SELECT id, store_id, holiday_date, name FROM shift_holidays WHERE store_id = ?", "holiday_date", storeID, from, to)
+	rows, err := r.conn.GetDB().Query(query+" ORDER BY holiday_date", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	holidays := []*domain.ShiftHoliday{}
+	for rows.Next() {
+		holiday := &domain.ShiftHoliday{}
+		var date time.Time
+		if err := rows.Scan(&holiday.ID, &holiday.StoreID, &date, &holiday.Name); err != nil {
+			return nil, err
+		}
+		holiday.Date = date.Format(domain.ShiftDateLayout)
+		holidays = append(holidays, holiday)
+	}
+	return holidays, rows.Err()
+}
+
+// SaveAssignment names the supervisor of the shift on a date, replacing the
+// one assigned before.
+func (r *MySqlShiftRepository) SaveAssignment(assignment *domain.ShiftAssignment) error {
+	assignment.AssignedAt = time.Now()
+	query := `INSERT INTO shift_assignments (shift_id, shift_date, supervisor, assigned_by, assigned_at) VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE supervisor = VALUES(supervisor), assigned_by = VALUES(assigned_by), assigned_at = VALUES(assigned_at), id = LAST_INSERT_ID(id)`
+	result, err := r.conn.GetDB().Exec(query, assignment.ShiftID, assignment.Date, assignment.Supervisor, assignment.AssignedBy, assignment.AssignedAt)
+	if err != nil {
+		return err
+	}
+	assignment.ID, err = result.LastInsertId()
+	return err
+}
+
+func (r *MySqlShiftRepository) DeleteAssignment(shiftID int64, date string) error {
+	result, err := r.conn.GetDB().Exec("DELETE FROM shift_assignments WHERE shift_id = ? AND shift_date = ?", shiftID, date)
+	if err != nil {
+		return err
+	}
+	return checkDeleted(result)
+}
+
+func (r *MySqlShiftRepository) GetAssignments(shiftID int64, from string, to string) ([]*domain.ShiftAssignment, error) {
+	query, args := dateRangeQuery("SELECT id, shift_id, shift_date, supervisor, assigned_by, assigned_at FROM shift_assignments WHERE shift_id = ?", "shift_date", shiftID, from, to)
+	rows, err := r.conn.GetDB().Query(query+" ORDER BY shift_date", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assignments := []*domain.ShiftAssignment{}
+	for rows.Next() {
+		assignment := &domain.ShiftAssignment{}
+		var date time.Time
+		if err := rows.Scan(&assignment.ID, &assignment.ShiftID, &date, &assignment.Supervisor, &assignment.AssignedBy, &assignment.AssignedAt); err != nil {
+			return nil, err
+		}
+		assignment.Date = date.Format(domain.ShiftDateLayout)
+		assignments = append(assignments, assignment)
+	}
+	return assignments, rows.Err()
+}
+
+func (r *MySqlShiftRepository) GetCalendar(storeID int64, at time.Time) (*domain.ShiftCalendar, error) {
+	return loadShiftCalendar(r.conn.GetDB(), storeID, at)
+}
+
+// loadShiftCalendar reads the active shifts of the store with the holidays
+// and supervisor assignments of the day of at and the day before, which is
+// all a shift running at at can have started on.
+func loadShiftCalendar(db queryer, storeID int64, at time.Time) (*domain.ShiftCalendar, error) {
+	shifts, err := queryShifts(db, "SELECT "+shiftColumns+" FROM shifts WHERE store_id = ? AND status = 'active' ORDER BY start_time, code", storeID)
+	if err != nil {
+		return nil, err
+	}
+	calendar := &domain.ShiftCalendar{
+		Shifts:      shifts,
+		Holidays:    make(map[string]bool),
+		Supervisors: make(map[int64]map[string]string),
+	}
+	if len(shifts) == 0 {
+		return calendar, nil
+	}
+
+	at = at.In(time.Local)
+	from, to := at.AddDate(0, 0, -1).Format(domain.ShiftDateLayout), at.Format(domain.ShiftDateLayout)
+	rows, err := db.Query("SELECT holiday_date FROM shift_holidays WHERE store_id = ? AND holiday_date BETWEEN ? AND ?", storeID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var date time.Time
+		if err := rows.Scan(&date); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		calendar.Holidays[date.Format(domain.ShiftDateLayout)] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT a.shift_id, a.shift_date, a.supervisor FROM shift_assignments a JOIN shifts s ON s.id = a.shift_id
+		WHERE s.store_id = ? AND a.shift_date BETWEEN ? AND ?`
+	rows, err = db.Query(query, storeID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var shiftID int64
+		var date time.Time
+		var supervisor string
+		if err := rows.Scan(&shiftID, &date, &supervisor); err != nil {
+			return nil, err
+		}
+		if calendar.Supervisors[shiftID] == nil {
+			calendar.Supervisors[shiftID] = make(map[string]string)
+		}
+		calendar.Supervisors[shiftID][date.Format(domain.ShiftDateLayout)] = supervisor
+	}
+	return calendar, rows.Err()
+}
+
+// applyShiftCalendar books a lot being stocked in to the shift running at its
+// stock in time, and records overrides of the calendar once the lot has an
+// id. An override must be approved by an authenticated user running the
+// store.
+func applyShiftCalendar(tx *sql.Tx, inventory *domain.Inventory) (func() error, error) {
+	noop := func() error { return nil }
+	if inventory.StoreID == nil {
+		return noop, nil
+	}
+	at := inventory.StockInAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+	calendar, err := loadShiftCalendar(tx, *inventory.StoreID, at)
+	if err != nil {
+		return nil, err
+	}
+	resolution, overridden, err := calendar.Apply(inventory, at)
+	if err != nil || !overridden {
+		return noop, err
+	}
+
+	override := inventory.ShiftOverride
+	err = tx.QueryRow("SELECT id FROM users WHERE username = ? AND status = 'active'", override.Approver).Scan(&override.ApprovedBy)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrShiftOverrideNotAuthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var approvers int
+	query := `SELECT (SELECT COUNT(*) FROM stores WHERE id = ? AND owner_id = ?) + (SELECT COUNT(*) FROM store_members WHERE store_id = ? AND user_id = ?)`
+	if err := tx.QueryRow(query, *inventory.StoreID, override.ApprovedBy, *inventory.StoreID, override.ApprovedBy).Scan(&approvers); err != nil {
+		return nil, err
+	}
+	if approvers == 0 {
+		return nil, domain.ErrShiftOverrideNotAuthorized
+	}
+
+	var expectedShift, expectedSupervisor sql.NullString
+	if resolution != nil {
+		expectedShift = sql.NullString{String: resolution.Shift.Code, Valid: true}
+		expectedSupervisor = sql.NullString{String: resolution.Supervisor, Valid: resolution.Supervisor != ""}
+	}
+	return func() error {
+		query := `INSERT INTO shift_overrides (inventory_id, store_id, calendar_shift, calendar_supervisor, shift, supervisor, approved_by, reason, overridden_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		_, err := tx.Exec(query, inventory.ID, *inventory.StoreID, expectedShift, expectedSupervisor, inventory.Shift, inventory.Supervisor, override.ApprovedBy, override.Reason, time.Now())
+		return err
+	}, nil
+}
+
+func dateRangeQuery(baseQuery string, column string, id int64, from string, to string) (string, []interface{}) {
+	query := baseQuery
+	args := []interface{}{id}
+	if from != "" {
+		query += " AND " + column + " >= ?"
+		args = append(args, from)
+	}
+	if to != "" {
+		query += " AND " + column + " <= ?"
+		args = append(args, to)
+	}
+	return query, args
+}
+
+func checkDeleted(result sql.Result) error {
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return customerrors.ErrResourceNotFound
+	}
+	return nil
+}
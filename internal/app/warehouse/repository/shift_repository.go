@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+)
+
+type ShiftRepository interface {
+	Create(shift *domain.Shift) error
+	Update(shift *domain.Shift) error
+	Delete(shiftID int64, version int64) error
+	GetById(shiftID int64) (*domain.Shift, error)
+	GetAll(filter ShiftFilterOptions) ([]*domain.Shift, error)
+	CreateHoliday(holiday *domain.ShiftHoliday) error
+	DeleteHoliday(holidayID int64) error
+	GetHolidays(storeID int64, from string, to string) ([]*domain.ShiftHoliday, error)
+	SaveAssignment(assignment *domain.ShiftAssignment) error
+	DeleteAssignment(shiftID int64, date string) error
+	GetAssignments(shiftID int64, from string, to string) ([]*domain.ShiftAssignment, error)
+	GetCalendar(storeID int64, at time.Time) (*domain.ShiftCalendar, error)
+}
+
+type ShiftFilterOptions struct {
+	StoreID int64
+	Status  string
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+const stockAlertColumns = "id, stock_level_id, product_id, store_id, status, on_hand, reserved, available, reorder_point, suggested_quantity, unit, raised_at, acknowledged_at, acknowledged_by, resolved_at, recovered_at, updated_at"
+
+type MySqlStockAlertRepository struct {
+	conn database.Connection
+}
+
+func NewStockAlertRepository(conn database.Connection) StockAlertRepository {
+	return &MySqlStockAlertRepository{conn: conn}
+}
+
+func (r *MySqlStockAlertRepository) Create(alert *domain.StockAlert) error {
+	query := "INSERT INTO stock_alerts (stock_level_id, product_id, store_id, status, on_hand, reserved, available, reorder_point, suggested_quantity, unit, raised_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := r.conn.GetDB().Exec(query, alert.StockLevelID, alert.ProductID, alert.StoreID, alert.Status, alert.OnHand, alert.Reserved, alert.Available, alert.ReorderPoint, alert.SuggestedQuantity, alert.Unit, alert.RaisedAt)
+	if err != nil {
+		return err
+	}
+	alert.ID, err = result.LastInsertId()
+	return err
+}
+
+func (r *MySqlStockAlertRepository) Update(alert *domain.StockAlert) error {
+	query := "UPDATE stock_alerts SET status=?, on_hand=?, reserved=?, available=?, reorder_point=?, suggested_quantity=?, unit=?, acknowledged_at=?, acknowledged_by=?, resolved_at=?, recovered_at=? WHERE id=?"
+	_, err := r.conn.GetDB().Exec(query, alert.Status, alert.OnHand, alert.Reserved, alert.Available, alert.ReorderPoint, alert.SuggestedQuantity, alert.Unit, alert.AcknowledgedAt, alert.AcknowledgedBy, alert.ResolvedAt, alert.RecoveredAt, alert.ID)
+	return err
+}
+
+func (r *MySqlStockAlertRepository) GetById(alertID int64) (*domain.StockAlert, error) {
+	query := "SELECT " + stockAlertColumns + " FROM stock_alerts WHERE id = ?"
+	return r.scanAlert(r.conn.GetDB().QueryRow(query, alertID))
+}
+
+// GetUnrecoveredByStockLevel returns the alert of the stock level that stock
+// has not yet recovered from, whether it is still active or was resolved by
+// hand.
+func (r *MySqlStockAlertRepository) GetUnrecoveredByStockLevel(stockLevelID int64) (*domain.StockAlert, error) {
+	query := "SELECT " + stockAlertColumns + " FROM stock_alerts WHERE stock_level_id = ? AND recovered_at IS NULL ORDER BY id DESC LIMIT 1"
+	return r.scanAlert(r.conn.GetDB().QueryRow(query, stockLevelID))
+}
+
+func (r *MySqlStockAlertRepository) GetTotalCount(filter StockAlertFilterOptions) (int, error) {
+	query, args := r.buildFilterQuery("SELECT COUNT(*) FROM stock_alerts", filter)
+
+	var count int
+	if err := r.conn.GetDB().QueryRow(query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *MySqlStockAlertRepository) GetAll(page int, pageSize int, sort string, filter StockAlertFilterOptions) ([]*domain.StockAlert, error) {
+	query, args := r.buildFilterQuery("SELECT "+stockAlertColumns+" FROM stock_alerts", filter)
+	var allowedSortOrders = map[string]bool{
+		"raised_at ASC":  true,
+		"raised_at DESC": true,
+		"available ASC":  true,
+		"available DESC": true,
+		"status ASC":     true,
+		"status DESC":    true,
+	}
+
+	if sort != "" {
+		if _, ok := allowedSortOrders[sort]; ok {
+			query += " ORDER BY " + sort
+		} else {
+			return nil, errors.New("invalid sort order")
+		}
+	}
+
+	query += " LIMIT ? OFFSET ?"
+	args = append(args, pageSize, page)
+
+	rows, err := r.conn.GetDB().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var alerts []*domain.StockAlert
+	for rows.Next() {
+		alert, err := r.scanAlert(rows)
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func (r *MySqlStockAlertRepository) scanAlert(row rowScanner) (*domain.StockAlert, error) {
+	alert := &domain.StockAlert{}
+	err := row.Scan(&alert.ID, &alert.StockLevelID, &alert.ProductID, &alert.StoreID, &alert.Status, &alert.OnHand, &alert.Reserved, &alert.Available, &alert.ReorderPoint, &alert.SuggestedQuantity, &alert.Unit, &alert.RaisedAt, &alert.AcknowledgedAt, &alert.AcknowledgedBy, &alert.ResolvedAt, &alert.RecoveredAt, &alert.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, customerrors.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return alert, nil
+}
+
+func (r *MySqlStockAlertRepository) buildFilterQuery(baseQuery string, filter StockAlertFilterOptions) (string, []interface{}) {
+	var filters []string
+	var args []interface{}
+
+	if len(filter.Statuses) > 0 {
+		filters = append(filters, "status IN ("+placeholders(len(filter.Statuses))+")")
+		for _, status := range filter.Statuses {
+			args = append(args, status)
+		}
+	}
+	if filter.ProductID > 0 {
+		filters = append(filters, "product_id = ?")
+		args = append(args, filter.ProductID)
+	}
+	if filter.StoreID > 0 {
+		filters = append(filters, "store_id = ?")
+		args = append(args, filter.StoreID)
+	}
+
+	if len(filters) > 0 {
+		return baseQuery + " WHERE " + strings.Join(filters, " AND "), args
+	}
+
+	return baseQuery, args
+}
@@ -0,0 +1,25 @@
+package repository
+
+import "github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+
+type StockAlertRepository interface {
+	Create(alert *domain.StockAlert) error
+	Update(alert *domain.StockAlert) error
+	GetById(alertID int64) (*domain.StockAlert, error)
+	GetUnrecoveredByStockLevel(stockLevelID int64) (*domain.StockAlert, error)
+	GetTotalCount(filter StockAlertFilterOptions) (int, error)
+	GetAll(page int, pageSize int, sort string, filter StockAlertFilterOptions) ([]*domain.StockAlert, error)
+}
+
+type StockAlertFilterOptions struct {
+	Statuses  []domain.StockAlertStatus
+	ProductID int64
+	StoreID   int64
+}
+
+func (f *StockAlertFilterOptions) SetStatuses(statusesStr string) {
+	f.Statuses = nil
+	for _, status := range splitList(statusesStr) {
+		f.Statuses = append(f.Statuses, domain.StockAlertStatus(status))
+	}
+}
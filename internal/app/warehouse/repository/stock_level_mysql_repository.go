@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+type MySqlStockLevelRepository struct {
+	conn database.Connection
+}
+
+func NewStockLevelRepository(conn database.Connection) StockLevelRepository {
+	return &MySqlStockLevelRepository{conn: conn}
+}
+
+func (r *MySqlStockLevelRepository) Create(stockLevel *domain.StockLevel) error {
+	query := "INSERT INTO stock_levels (product_id, store_id, min_quantity, max_quantity, reorder_point, unit, status, last_updated_by) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+	_, err := r.conn.GetDB().Exec(query, stockLevel.ProductID, stockLevel.StoreID, stockLevel.MinQuantity, stockLevel.MaxQuantity, stockLevel.ReorderPoint, stockLevel.Unit, stockLevel.Status, stockLevel.LastUpdatedBy)
+	return err
+}
+
+func (r *MySqlStockLevelRepository) Update(stockLevel *domain.StockLevel) error {
+	query := "UPDATE stock_levels SET product_id=?, store_id=?, min_quantity=?, max_quantity=?, reorder_point=?, unit=?, status=?, last_updated_by=?, version=LAST_INSERT_ID(version+1) WHERE id=? AND (? = 0 OR version=?)"
+	result, err := r.conn.GetDB().Exec(query, stockLevel.ProductID, stockLevel.StoreID, stockLevel.MinQuantity, stockLevel.MaxQuantity, stockLevel.ReorderPoint, stockLevel.Unit, stockLevel.Status, stockLevel.LastUpdatedBy, stockLevel.ID, stockLevel.Version, stockLevel.Version)
+	if err != nil {
+		return err
+	}
+	if err := database.CheckVersionedWrite(result, "stock level", stockLevel.ID, stockLevel.Version); err != nil {
+		return err
+	}
+	stockLevel.Version = database.NextVersion(result, stockLevel.Version)
+	return nil
+}
+
+func (r *MySqlStockLevelRepository) Delete(stockLevelID int64, version int64) error {
+	query := "DELETE FROM stock_levels WHERE id=? AND (? = 0 OR version=?)"
+	result, err := r.conn.GetDB().Exec(query, stockLevelID, version, version)
+	if err != nil {
+		return err
+	}
+	return database.CheckVersionedWrite(result, "stock level", stockLevelID, version)
+}
+
+func (r *MySqlStockLevelRepository) GetById(stockLevelID int64) (*domain.StockLevel, error) {
+	query := "SELECT id, product_id, store_id, min_quantity, max_quantity, reorder_point, unit, status, created_at, updated_at, last_updated_by, version FROM stock_levels WHERE id = ?"
+	row := r.conn.GetDB().QueryRow(query, stockLevelID)
+	stockLevel := domain.NewStockLevelWithDefaults()
+	err := row.Scan(&stockLevel.ID, &stockLevel.ProductID, &stockLevel.StoreID, &stockLevel.MinQuantity, &stockLevel.MaxQuantity, &stockLevel.ReorderPoint, &stockLevel.Unit, &stockLevel.Status, &stockLevel.CreatedAt, &stockLevel.UpdatedAt, &stockLevel.LastUpdatedBy, &stockLevel.Version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, customerrors.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return stockLevel, nil
+}
+
+func (r *MySqlStockLevelRepository) GetTotalCount(filter StockLevelFilterOptions) (int, error) {
+	query, args := r.buildFilterQuery("SELECT COUNT(*) FROM stock_levels", filter)
+
+	var count int
+	if err := r.conn.GetDB().QueryRow(query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *MySqlStockLevelRepository) GetAll(page int, pageSize int, sort string, filter StockLevelFilterOptions) ([]*domain.StockLevel, error) {
+	query, args := r.buildFilterQuery("SELECT id, product_id, store_id, min_quantity, max_quantity, reorder_point, unit, status, created_at, updated_at, last_updated_by, version FROM stock_levels", filter)
+	var allowedSortOrders = map[string]bool{
+		"product_id ASC":     true,
+		"product_id DESC":    true,
+		"store_id ASC":       true,
+		"store_id DESC":      true,
+		"reorder_point ASC":  true,
+		"reorder_point DESC": true,
+		"updated_at ASC":     true,
+		"updated_at DESC":    true,
+	}
+
+	if sort != "" {
+		if _, ok := allowedSortOrders[sort]; ok {
+			query += " ORDER BY " + sort
+		} else {
+			return nil, errors.New("invalid sort order")
+		}
+	}
+
+	query += " LIMIT ? OFFSET ?"
+	args = append(args, pageSize, page)
+
+	rows, err := r.conn.GetDB().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stockLevels []*domain.StockLevel
+	for rows.Next() {
+		stockLevel := domain.NewStockLevelWithDefaults()
+		if err := rows.Scan(&stockLevel.ID, &stockLevel.ProductID, &stockLevel.StoreID, &stockLevel.MinQuantity, &stockLevel.MaxQuantity, &stockLevel.ReorderPoint, &stockLevel.Unit, &stockLevel.Status, &stockLevel.CreatedAt, &stockLevel.UpdatedAt, &stockLevel.LastUpdatedBy, &stockLevel.Version); err != nil {
+			return nil, err
+		}
+		stockLevels = append(stockLevels, stockLevel)
+	}
+	return stockLevels, nil
+}
+
+// GetPositions returns every active stock level with its on-hand and reserved
+// quantities aggregated from the inventories of the same product and store.
+// The quantities are summed per unit so that only lots in the unit of the
+// level are counted.
+func (r *MySqlStockLevelRepository) GetPositions() ([]*domain.StockLevelPosition, error) {
+	query := `SELECT l.id, l.product_id, l.store_id, l.min_quantity, l.max_quantity, l.reorder_point, l.unit, l.status, l.created_at, l.updated_at, l.last_updated_by, l.version,
+			COALESCE(i.unit, ''),
+			COALESCE(SUM(CASE WHEN i.status IN (?, ?) THEN i.quantity ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN i.status = ? THEN i.quantity ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN i.status = ? AND i.qc_status <> ? THEN i.quantity ELSE 0 END), 0)
+		FROM stock_levels l
+		LEFT JOIN inventories i ON i.product_id = l.product_id AND i.store_id = l.store_id
+		WHERE l.status = 'active'
+		GROUP BY l.id, i.unit
+		ORDER BY l.id`
+	rows, err := r.conn.GetDB().Query(query, domain.STOCK_IN, domain.STOCK_RESERVED, domain.STOCK_RESERVED, domain.STOCK_IN, domain.QC_RELEASED)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var positions []*domain.StockLevelPosition
+	for rows.Next() {
+		stockLevel := domain.NewStockLevelWithDefaults()
+		var unit string
+		var onHand, reserved, held customtypes.Decimal
+		if err := rows.Scan(&stockLevel.ID, &stockLevel.ProductID, &stockLevel.StoreID, &stockLevel.MinQuantity, &stockLevel.MaxQuantity, &stockLevel.ReorderPoint, &stockLevel.Unit, &stockLevel.Status, &stockLevel.CreatedAt, &stockLevel.UpdatedAt, &stockLevel.LastUpdatedBy, &stockLevel.Version, &unit, &onHand, &reserved, &held); err != nil {
+			return nil, err
+		}
+		if len(positions) == 0 || positions[len(positions)-1].Level.ID != stockLevel.ID {
+			positions = append(positions, &domain.StockLevelPosition{Level: stockLevel})
+		}
+		positions[len(positions)-1].AddStock(unit, onHand, reserved, held)
+	}
+	return positions, rows.Err()
+}
+
+func (r *MySqlStockLevelRepository) buildFilterQuery(baseQuery string, filter StockLevelFilterOptions) (string, []interface{}) {
+	var filters []string
+	var args []interface{}
+
+	if filter.ProductID > 0 {
+		filters = append(filters, "product_id = ?")
+		args = append(args, filter.ProductID)
+	}
+	if filter.StoreID > 0 {
+		filters = append(filters, "store_id = ?")
+		args = append(args, filter.StoreID)
+	}
+	if filter.Status != "" {
+		filters = append(filters, "status = ?")
+		args = append(args, filter.Status)
+	}
+
+	if len(filters) > 0 {
+		return baseQuery + " WHERE " + strings.Join(filters, " AND "), args
+	}
+
+	return baseQuery, args
+}
@@ -0,0 +1,19 @@
+package repository
+
+import "github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+
+type StockLevelRepository interface {
+	Create(stockLevel *domain.StockLevel) error
+	Update(stockLevel *domain.StockLevel) error
+	Delete(stockLevelID int64, version int64) error
+	GetById(stockLevelID int64) (*domain.StockLevel, error)
+	GetTotalCount(filter StockLevelFilterOptions) (int, error)
+	GetAll(page int, pageSize int, sort string, filter StockLevelFilterOptions) ([]*domain.StockLevel, error)
+	GetPositions() ([]*domain.StockLevelPosition, error)
+}
+
+type StockLevelFilterOptions struct {
+	ProductID int64
+	StoreID   int64
+	Status    string
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+const positionKeyColumns = "product_id, store_id, container_id, rack_id, bin_id, pallet_id, unit"
+
+// positionQuantities turns the state of a lot into its contribution to the
+// on hand, reserved and held quantities of its location.
+const positionQuantities = `CASE WHEN status IN (?, ?) THEN quantity ELSE 0 END AS on_hand,
+	CASE WHEN status = ? THEN quantity ELSE 0 END AS reserved,
+	CASE WHEN status = ? AND qc_status <> ? THEN quantity ELSE 0 END AS held`
+
+func positionQuantityArgs() []interface{} {
+	return []interface{}{domain.STOCK_IN, domain.STOCK_RESERVED, domain.STOCK_RESERVED, domain.STOCK_IN, domain.QC_RELEASED}
+}
+
+type MySqlStockPositionRepository struct {
+	conn database.Connection
+}
+
+func NewStockPositionRepository(conn database.Connection) StockPositionRepository {
+	return &MySqlStockPositionRepository{conn: conn}
+}
+
+func (r *MySqlStockPositionRepository) GetCurrent(filter StockPositionFilterOptions) ([]*domain.StockPosition, error) {
+	source := "SELECT " + positionKeyColumns + ", " + positionQuantities + " FROM inventories"
+	return r.queryPositions(source, positionQuantityArgs(), filter)
+}
+
+// GetAsOf reconstructs the positions at asOf from the inventory ledger. The
+// latest daily snapshot taken at or before asOf is used as a starting point,
+// so only lots that changed after it have to be replayed: their state at the
+// snapshot is subtracted and their state at asOf is added.
+func (r *MySqlStockPositionRepository) GetAsOf(asOf time.Time, filter StockPositionFilterOptions) ([]*domain.StockPosition, *time.Time, error) {
+	var snapshotAt sql.NullTime
+	err := r.conn.GetDB().QueryRow("SELECT MAX(snapshot_at) FROM stock_snapshots WHERE snapshot_at <= ?", asOf).Scan(&snapshotAt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !snapshotAt.Valid {
+		source, args := latestStateQuery("", asOf, nil)
+		positions, err := r.queryPositions(source, args, filter)
+		return positions, nil, err
+	}
+
+	subtract, subtractArgs := latestStateQuery("-", snapshotAt.Time, &asOf)
+	add, addArgs := latestStateQuery("", asOf, &snapshotAt.Time)
+	source := "SELECT " + positionKeyColumns + ", on_hand, reserved, held FROM stock_snapshots WHERE snapshot_at = ?" +
+		" UNION ALL " + subtract + " UNION ALL " + add
+	args := append([]interface{}{snapshotAt.Time}, subtractArgs...)
+	args = append(args, addArgs...)
+
+	positions, err := r.queryPositions(source, args, filter)
+	return positions, &snapshotAt.Time, err
+}
+
+// latestStateQuery selects the last recorded state of every lot at or before
+// at, with its quantities multiplied by sign. When since is given only lots
+// that changed between since and at (in either order) are included.
+func latestStateQuery(sign string, at time.Time, since *time.Time) (string, []interface{}) {
+	latest := "SELECT inventory_id, MAX(id) AS id FROM inventory_movements WHERE occurred_at <= ?"
+	latestArgs := []interface{}{at}
+	if since != nil {
+		from, to := *since, at
+		if from.After(to) {
+			from, to = to, from
+		}
+		latest += " AND inventory_id IN (SELECT inventory_id FROM inventory_movements WHERE occurred_at > ? AND occurred_at <= ?)"
+		latestArgs = append(latestArgs, from, to)
+	}
+	latest += " GROUP BY inventory_id"
+
+	query := `SELECT m.product_id, m.store_id, m.container_id, m.rack_id, m.bin_id, m.pallet_id, m.unit,
+		` + sign + `(CASE WHEN m.status IN (?, ?) THEN m.quantity ELSE 0 END),
+		` + sign + `(CASE WHEN m.status = ? THEN m.quantity ELSE 0 END),
+		` + sign + `(CASE WHEN m.status = ? AND m.qc_status <> ? THEN m.quantity ELSE 0 END)
+		FROM inventory_movements m JOIN (` + latest + `) latest ON latest.id = m.id
+		WHERE m.movement <> ?`
+	args := append(positionQuantityArgs(), latestArgs...)
+	args = append(args, domain.MOVEMENT_DELETED)
+	return query, args
+}
+
+func (r *MySqlStockPositionRepository) queryPositions(source string, args []interface{}, filter StockPositionFilterOptions) ([]*domain.StockPosition, error) {
+	query := "SELECT " + positionKeyColumns + ", SUM(on_hand), SUM(reserved), SUM(held) FROM (" + source + ") positions"
+
+	var filters []string
+	if filter.ProductID > 0 {
+		filters = append(filters, "product_id = ?")
+		args = append(args, filter.ProductID)
+	}
+	if filter.StoreID > 0 {
+		filters = append(filters, "store_id = ?")
+		args = append(args, filter.StoreID)
+	}
+	if filter.ContainerID > 0 {
+		filters = append(filters, "container_id = ?")
+		args = append(args, filter.ContainerID)
+	}
+	if filter.CategoryID > 0 {
+		filters = append(filters, categoryProductsFilter)
+		args = append(args, filter.CategoryID)
+	}
+	if len(filters) > 0 {
+		query += " WHERE " + strings.Join(filters, " AND ")
+	}
+	query += " GROUP BY " + positionKeyColumns +
+		" HAVING SUM(on_hand) <> 0 OR SUM(reserved) <> 0 OR SUM(held) <> 0" +
+		" ORDER BY product_id, store_id, container_id, rack_id, bin_id, pallet_id"
+
+	rows, err := r.conn.GetDB().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	positions := []*domain.StockPosition{}
+	for rows.Next() {
+		position := &domain.StockPosition{}
+		if err := rows.Scan(&position.ProductID, &position.StoreID, &position.ContainerID, &position.RackID, &position.BinID, &position.PalletID, &position.Unit, &position.OnHand, &position.Reserved, &position.Held); err != nil {
+			return nil, err
+		}
+		position.CalculateAvailable()
+		positions = append(positions, position)
+	}
+	return positions, rows.Err()
+}
+
+func (r *MySqlStockPositionRepository) GetLatestSnapshotAt() (*time.Time, error) {
+	return r.queryTime("SELECT MAX(snapshot_at) FROM stock_snapshots")
+}
+
+func (r *MySqlStockPositionRepository) GetFirstMovementAt() (*time.Time, error) {
+	return r.queryTime("SELECT MIN(occurred_at) FROM inventory_movements")
+}
+
+func (r *MySqlStockPositionRepository) queryTime(query string) (*time.Time, error) {
+	var value sql.NullTime
+	if err := r.conn.GetDB().QueryRow(query).Scan(&value); err != nil {
+		return nil, err
+	}
+	if !value.Valid {
+		return nil, nil
+	}
+	return &value.Time, nil
+}
+
+// SaveSnapshot replaces the snapshot taken at snapshotAt, so a day can be
+// materialized again safely.
+func (r *MySqlStockPositionRepository) SaveSnapshot(snapshotAt time.Time, positions []*domain.StockPosition) error {
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		if _, err := tx.Exec("DELETE FROM stock_snapshots WHERE snapshot_at = ?", snapshotAt); err != nil {
+			return err
+		}
+		query := "INSERT INTO stock_snapshots (snapshot_at, " + positionKeyColumns + ", on_hand, reserved, held) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+		for _, position := range positions {
+			if _, err := tx.Exec(query, snapshotAt, position.ProductID, position.StoreID, position.ContainerID, position.RackID, position.BinID, position.PalletID, position.Unit, position.OnHand, position.Reserved, position.Held); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
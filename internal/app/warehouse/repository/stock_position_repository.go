@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+)
+
+type StockPositionRepository interface {
+	GetCurrent(filter StockPositionFilterOptions) ([]*domain.StockPosition, error)
+	GetAsOf(asOf time.Time, filter StockPositionFilterOptions) ([]*domain.StockPosition, *time.Time, error)
+	GetLatestSnapshotAt() (*time.Time, error)
+	GetFirstMovementAt() (*time.Time, error)
+	SaveSnapshot(snapshotAt time.Time, positions []*domain.StockPosition) error
+}
+
+// StockPositionFilterOptions narrows positions. ContainerID keeps the stock
+// held directly in that container; CategoryID takes in the products whose
+// primary category is the category or any category below it.
+type StockPositionFilterOptions struct {
+	ProductID   int64
+	StoreID     int64
+	ContainerID int64
+	CategoryID  int64
+	AsOf        *time.Time
+}
+
+// SetAsOf accepts an RFC 3339 instant or a date, which means the end of that
+// day.
+func (f *StockPositionFilterOptions) SetAsOf(asOfStr string) (err error) {
+	f.AsOf, err = parseTimeBound(asOfStr, true)
+	return err
+}
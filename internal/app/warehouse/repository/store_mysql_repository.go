@@ -4,12 +4,21 @@ import (
 	"database/sql"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
 	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
 	"github.com/vamika-digital/wms-api-server/pkg/database"
 )
 
+// Stores are read together with the details of their owner from the user
+// directory.
+const (
+	storeColumns = "s.id, s.name, s.location, s.status, s.created_at, s.updated_at, s.last_updated_by, s.owner_id, u.name, u.staff_id, u.email, s.version"
+	storeTables  = "stores s LEFT JOIN users u ON u.id = s.owner_id"
+)
+
 type MySqlStoreRepository struct {
 	conn database.Connection
 }
@@ -19,42 +28,245 @@ func NewStoreRepository(conn database.Connection) StoreRepository {
 }
 
 func (r *MySqlStoreRepository) Create(store *domain.Store) error {
-	query := "INSERT INTO stores (name, location, status, last_updated_by, owner_id) VALUES (?, ?, ?, ?, ?)"
-	_, err := r.conn.GetDB().Exec(query, store.Name, store.Location, store.Status, store.LastUpdatedBy, store.Owner.ID)
-	return err
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		ownerID := store.OwnerID()
+		if ownerID > 0 {
+			if err := checkStoreUser(tx, ownerID); err != nil {
+				return err
+			}
+		}
+		query := "INSERT INTO stores (name, location, status, last_updated_by, owner_id) VALUES (?, ?, ?, ?, ?)"
+		result, err := tx.Exec(query, store.Name, store.Location, store.Status, store.LastUpdatedBy, customtypes.NullableInt64(ownerID))
+		if err != nil {
+			return err
+		}
+		if store.ID, err = result.LastInsertId(); err != nil {
+			return err
+		}
+		if ownerID == 0 {
+			return nil
+		}
+		return recordOwnerChange(tx, store.ID, 0, ownerID, "", store.LastUpdatedBy)
+	})
 }
 
+// Update also hands the store over when the owner changed, which is recorded
+// in its ownership history.
 func (r *MySqlStoreRepository) Update(store *domain.Store) error {
-	query := "UPDATE stores SET name=?, location=?, status=?, last_updated_by=?, owner_id=? WHERE id=?"
-	_, err := r.conn.GetDB().Exec(query, store.Name, store.Location, store.Status, store.LastUpdatedBy, store.Owner.ID, store.ID)
+	version := store.Version
+	err := database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		previousOwnerID, err := lockStoreOwner(tx, store.ID)
+		if err != nil {
+			return err
+		}
+		ownerID := store.OwnerID()
+		if ownerID > 0 && ownerID != previousOwnerID {
+			if err := checkStoreUser(tx, ownerID); err != nil {
+				return err
+			}
+		}
+
+		query := "UPDATE stores SET name=?, location=?, status=?, last_updated_by=?, owner_id=?, version=LAST_INSERT_ID(version+1) WHERE id=? AND (? = 0 OR version=?)"
+		result, err := tx.Exec(query, store.Name, store.Location, store.Status, store.LastUpdatedBy, customtypes.NullableInt64(ownerID), store.ID, store.Version, store.Version)
+		if err != nil {
+			return err
+		}
+		if err := database.CheckVersionedWrite(result, "store", store.ID, store.Version); err != nil {
+			return err
+		}
+		version = database.NextVersion(result, store.Version)
+		if ownerID == previousOwnerID {
+			return nil
+		}
+		return recordOwnerChange(tx, store.ID, previousOwnerID, ownerID, "", store.LastUpdatedBy)
+	})
+	if err != nil {
+		return err
+	}
+	store.Version = version
+	return nil
+}
+
+// ChangeOwner hands the store over to form.OwnerID and records who did it and
+// why.
+func (r *MySqlStoreRepository) ChangeOwner(storeID int64, form *domain.StoreOwnerForm, version int64) error {
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		previousOwnerID, err := lockStoreOwner(tx, storeID)
+		if err != nil {
+			return err
+		}
+		if form.OwnerID > 0 && form.OwnerID != previousOwnerID {
+			if err := checkStoreUser(tx, form.OwnerID); err != nil {
+				return err
+			}
+		}
+
+		query := "UPDATE stores SET owner_id=?, last_updated_by=?, version=version+1 WHERE id=? AND (? = 0 OR version=?)"
+		result, err := tx.Exec(query, customtypes.NullableInt64(form.OwnerID), form.ChangedBy, storeID, version, version)
+		if err != nil {
+			return err
+		}
+		if err := database.CheckVersionedWrite(result, "store", storeID, version); err != nil {
+			return err
+		}
+		if form.OwnerID == previousOwnerID {
+			return nil
+		}
+		return recordOwnerChange(tx, storeID, previousOwnerID, form.OwnerID, form.Reason, form.ChangedBy)
+	})
+}
+
+// lockStoreOwner returns the current owner of the store, or 0 when it has none
+// or does not exist; a missing store is reported by the versioned write.
+func lockStoreOwner(tx *sql.Tx, storeID int64) (int64, error) {
+	var ownerID customtypes.NullableInt64
+	err := tx.QueryRow("SELECT owner_id FROM stores WHERE id = ? FOR UPDATE", storeID).Scan(&ownerID)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, err
+	}
+	return int64(ownerID), nil
+}
+
+// checkStoreUser makes sure a user about to own or help run a store exists
+// and is active.
+func checkStoreUser(tx *sql.Tx, userID int64) error {
+	var status sql.NullString
+	err := tx.QueryRow("SELECT status FROM users WHERE id = ?", userID).Scan(&status)
+	if err == sql.ErrNoRows || (err == nil && status.String != "active") {
+		return domain.ErrStoreUserUnavailable
+	}
 	return err
 }
 
-func (r *MySqlStoreRepository) Delete(storeID int64) error {
-	query := "DELETE FROM stores WHERE id=?"
-	_, err := r.conn.GetDB().Exec(query, storeID)
+func recordOwnerChange(tx *sql.Tx, storeID int64, fromOwnerID int64, toOwnerID int64, reason customtypes.NullableString, changedBy customtypes.NullableString) error {
+	query := "INSERT INTO store_owner_history (store_id, from_owner_id, to_owner_id, reason, changed_by, changed_at) VALUES (?, ?, ?, ?, ?, ?)"
+	_, err := tx.Exec(query, storeID, customtypes.NullableInt64(fromOwnerID), customtypes.NullableInt64(toOwnerID), reason, changedBy, time.Now())
 	return err
 }
 
+func (r *MySqlStoreRepository) Delete(storeID int64, version int64) error {
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		query := "DELETE FROM stores WHERE id=? AND (? = 0 OR version=?)"
+		result, err := tx.Exec(query, storeID, version, version)
+		if err != nil {
+			return err
+		}
+		if err := database.CheckVersionedWrite(result, "store", storeID, version); err != nil {
+			return err
+		}
+		_, err = tx.Exec("DELETE FROM store_members WHERE store_id = ?", storeID)
+		return err
+	})
+}
+
 func (r *MySqlStoreRepository) GetById(storeID int64) (*domain.Store, error) {
-	query := "SELECT id, name, location, status, created_at, updated_at, last_updated_by, owner_id FROM stores WHERE id = ?"
-	row := r.conn.GetDB().QueryRow(query, storeID)
-	store := domain.NewStoreWithDefaults()
-	err := row.Scan(&store.ID, &store.Name, &store.Location, &store.Status, &store.CreatedAt, &store.UpdatedAt, &store.LastUpdatedBy, &store.Owner.ID)
+	query := "SELECT " + storeColumns + " FROM " + storeTables + " WHERE s.id = ?"
+	store, err := scanStore(r.conn.GetDB().QueryRow(query, storeID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, customerrors.ErrResourceNotFound
 		}
 		return nil, err
 	}
+	if store.Members, err = r.GetMembers(storeID); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+func scanStore(row rowScanner) (*domain.Store, error) {
+	store := domain.NewStoreWithDefaults()
+	err := row.Scan(&store.ID, &store.Name, &store.Location, &store.Status, &store.CreatedAt, &store.UpdatedAt, &store.LastUpdatedBy, &store.Owner.ID, &store.Owner.Name, &store.Owner.StaffID, &store.Owner.Email, &store.Version)
+	if err != nil {
+		return nil, err
+	}
 	if store.Owner.ID <= 0 {
 		store.Owner = nil
 	}
 	return store, nil
 }
 
+// SaveMember adds the user to the managers and deputies of the store, or
+// changes the role the user already has there.
+func (r *MySqlStoreRepository) SaveMember(member *domain.StoreMember) error {
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		var stores int
+		if err := tx.QueryRow("SELECT COUNT(*) FROM stores WHERE id = ?", member.StoreID).Scan(&stores); err != nil {
+			return err
+		}
+		if stores == 0 {
+			return customerrors.ErrResourceNotFound
+		}
+		if err := checkStoreUser(tx, member.UserID); err != nil {
+			return err
+		}
+		member.AssignedAt = time.Now()
+		query := `INSERT INTO store_members (store_id, user_id, role, assigned_by, assigned_at) VALUES (?, ?, ?, ?, ?)
+			ON DUPLICATE KEY UPDATE role = VALUES(role), assigned_by = VALUES(assigned_by), assigned_at = VALUES(assigned_at)`
+		_, err := tx.Exec(query, member.StoreID, member.UserID, member.Role, member.AssignedBy, member.AssignedAt)
+		return err
+	})
+}
+
+func (r *MySqlStoreRepository) RemoveMember(storeID int64, userID int64) error {
+	result, err := r.conn.GetDB().Exec("DELETE FROM store_members WHERE store_id = ? AND user_id = ?", storeID, userID)
+	if err != nil {
+		return err
+	}
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		return customerrors.ErrResourceNotFound
+	}
+	return nil
+}
+
+// GetMembers lists the managers and deputies of the store, managers first.
+func (r *MySqlStoreRepository) GetMembers(storeID int64) ([]*domain.StoreMember, error) {
+	query := `SELECT m.store_id, m.user_id, m.role, u.name, u.staff_id, u.email, m.assigned_by, m.assigned_at
+		FROM store_members m LEFT JOIN users u ON u.id = m.user_id
+		WHERE m.store_id = ? ORDER BY m.role = ? DESC, u.name, m.user_id`
+	rows, err := r.conn.GetDB().Query(query, storeID, domain.STORE_MANAGER)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []*domain.StoreMember{}
+	for rows.Next() {
+		member := &domain.StoreMember{}
+		if err := rows.Scan(&member.StoreID, &member.UserID, &member.Role, &member.Name, &member.StaffID, &member.Email, &member.AssignedBy, &member.AssignedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+// GetOwnerHistory returns the owners the store was handed to, oldest first.
+func (r *MySqlStoreRepository) GetOwnerHistory(storeID int64) ([]*domain.StoreOwnerChange, error) {
+	query := "SELECT id, store_id, from_owner_id, to_owner_id, reason, changed_by, changed_at FROM store_owner_history WHERE store_id = ? ORDER BY changed_at, id"
+	rows, err := r.conn.GetDB().Query(query, storeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []*domain.StoreOwnerChange{}
+	for rows.Next() {
+		change := &domain.StoreOwnerChange{}
+		if err := rows.Scan(&change.ID, &change.StoreID, &change.FromOwnerID, &change.ToOwnerID, &change.Reason, &change.ChangedBy, &change.ChangedAt); err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	return changes, rows.Err()
+}
+
 func (r *MySqlStoreRepository) GetTotalCount(filter StoreFilterOptions) (int, error) {
-	query, args := r.buildFilterQuery("SELECT COUNT(*) FROM stores", filter)
+	query, args := r.buildFilterQuery("SELECT COUNT(*) FROM stores s", filter)
 
 	var count int
 	if err := r.conn.GetDB().QueryRow(query, args...).Scan(&count); err != nil {
@@ -65,14 +277,12 @@ func (r *MySqlStoreRepository) GetTotalCount(filter StoreFilterOptions) (int, er
 }
 
 func (r *MySqlStoreRepository) GetAll(page int, pageSize int, sort string, filter StoreFilterOptions) ([]*domain.Store, error) {
-	query, args := r.buildFilterQuery("SELECT id, name, location, status, created_at, updated_at, last_updated_by, owner_id FROM stores", filter)
+	query, args := r.buildFilterQuery("SELECT "+storeColumns+" FROM "+storeTables, filter)
 	var allowedSortOrders = map[string]bool{
 		"location ASC":  true,
 		"location DESC": true,
 		"name ASC":      true,
 		"name DESC":     true,
-		"code ASC":      true,
-		"code DESC":     true,
 		"status ASC":    true,
 		"status DESC":   true,
 		"owner_id ASC":  true,
@@ -81,7 +291,7 @@ func (r *MySqlStoreRepository) GetAll(page int, pageSize int, sort string, filte
 
 	if sort != "" {
 		if _, ok := allowedSortOrders[sort]; ok {
-			query += " ORDER BY " + sort
+			query += " ORDER BY s." + sort
 		} else {
 			return nil, errors.New("invalid sort order")
 		}
@@ -98,13 +308,10 @@ func (r *MySqlStoreRepository) GetAll(page int, pageSize int, sort string, filte
 
 	var stores []*domain.Store
 	for rows.Next() {
-		store := domain.NewStoreWithDefaults()
-		if err := rows.Scan(&store.ID, &store.Name, &store.Location, &store.Status, &store.CreatedAt, &store.UpdatedAt, &store.LastUpdatedBy, &store.Owner.ID); err != nil {
+		store, err := scanStore(rows)
+		if err != nil {
 			return nil, err
 		}
-		if store.Owner.ID <= 0 {
-			store.Owner = nil
-		}
 		stores = append(stores, store)
 	}
 	return stores, nil
@@ -115,19 +322,19 @@ func (r *MySqlStoreRepository) buildFilterQuery(baseQuery string, filter StoreFi
 	var args []interface{}
 
 	if filter.Name != "" {
-		filters = append(filters, "name LIKE ?")
+		filters = append(filters, "s.name LIKE ?")
 		args = append(args, "%"+filter.Name+"%")
 	}
 	if filter.Location != "" {
-		filters = append(filters, "location LIKE ?")
+		filters = append(filters, "s.location LIKE ?")
 		args = append(args, "%"+filter.Location+"%")
 	}
 	if filter.Status != "" {
-		filters = append(filters, "status = ?")
+		filters = append(filters, "s.status = ?")
 		args = append(args, filter.Status)
 	}
 	if filter.OwnerID > 0 {
-		filters = append(filters, "owner_id = ?")
+		filters = append(filters, "s.owner_id = ?")
 		args = append(args, filter.OwnerID)
 	}
 
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
 )
@@ -9,7 +10,13 @@ import (
 type StoreRepository interface {
 	Create(store *domain.Store) error
 	Update(store *domain.Store) error
-	Delete(storeID int64) error
+	Delete(storeID int64, version int64) error
+	ChangeOwner(storeID int64, form *domain.StoreOwnerForm, version int64) error
+	GetOwnerHistory(storeID int64) ([]*domain.StoreOwnerChange, error)
+	SaveMember(member *domain.StoreMember) error
+	RemoveMember(storeID int64, userID int64) error
+	GetMembers(storeID int64) ([]*domain.StoreMember, error)
+	GetSummary(storeID int64, dayStart time.Time, expiringBefore time.Time) (*domain.StoreSummary, error)
 	GetById(storeID int64) (*domain.Store, error)
 	GetTotalCount(filter StoreFilterOptions) (int, error)
 	GetAll(page int, pageSize int, sort string, filter StoreFilterOptions) ([]*domain.Store, error)
@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+// GetSummary builds the summary of the store from a fixed set of aggregate
+// queries, each served by an index on the store, so the cost does not grow
+// with the number of products or containers listed. They run in one
+// transaction to read a consistent snapshot. Today starts at dayStart, and
+// lots count as expiring until expiringBefore.
+func (r *MySqlStoreRepository) GetSummary(storeID int64, dayStart time.Time, expiringBefore time.Time) (*domain.StoreSummary, error) {
+	now := time.Now()
+	summary := domain.NewStoreSummary(storeID, now)
+	err := database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		err := tx.QueryRow("SELECT COALESCE(name, '') FROM stores WHERE id = ?", storeID).Scan(&summary.StoreName)
+		if err == sql.ErrNoRows {
+			return customerrors.ErrResourceNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := summarizeOnHand(tx, summary); err != nil {
+			return err
+		}
+		if err := summarizeMovements(tx, summary, dayStart); err != nil {
+			return err
+		}
+		if err := summarizeContainers(tx, summary); err != nil {
+			return err
+		}
+		if err := summarizeLowStock(tx, summary); err != nil {
+			return err
+		}
+		return summarizeExpiring(tx, summary, now, expiringBefore)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// summarizeOnHand totals the stock by product type and unit, and the open
+// reservations.
+func summarizeOnHand(tx *sql.Tx, summary *domain.StoreSummary) error {
+	query := `SELECT COALESCE(p.type, ''), COALESCE(i.unit, ''), COUNT(*), SUM(i.quantity),
+			SUM(CASE WHEN i.status = ? THEN i.quantity ELSE 0 END),
+			SUM(CASE WHEN i.status = ? AND i.qc_status <> ? THEN i.quantity ELSE 0 END),
+			SUM(CASE WHEN i.status = ? THEN 1 ELSE 0 END)
+		FROM inventories i LEFT JOIN products p ON p.id = i.product_id
+		WHERE i.store_id = ? AND i.status IN (?, ?)
+		GROUP BY COALESCE(p.type, ''), COALESCE(i.unit, '')
+		ORDER BY 1, 2`
+	rows, err := tx.Query(query, domain.STOCK_RESERVED, domain.STOCK_IN, domain.QC_RELEASED, domain.STOCK_RESERVED, summary.StoreID, domain.STOCK_IN, domain.STOCK_RESERVED)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		stock := &domain.ProductTypeStock{}
+		var reservedLots int
+		if err := rows.Scan(&stock.ProductType, &stock.Unit, &stock.Lots, &stock.OnHand, &stock.Reserved, &stock.Held, &reservedLots); err != nil {
+			return err
+		}
+		stock.Available = stock.OnHand.Sub(stock.Reserved).Sub(stock.Held)
+		summary.OnHand = append(summary.OnHand, stock)
+		if reservedLots > 0 {
+			summary.Reservations.Add(stock.Unit, reservedLots, stock.Reserved)
+		}
+	}
+	return rows.Err()
+}
+
+// summarizeMovements totals today's receipts and issues. Receipts are lots
+// created in or received into the store; issues are lots stocked out and
+// stock dispatched to other stores.
+func summarizeMovements(tx *sql.Tx, summary *domain.StoreSummary, dayStart time.Time) error {
+	queries := []struct {
+		totals *domain.StockTotals
+		query  string
+		args   []interface{}
+	}{
+		{&summary.Receipts, `SELECT unit, COUNT(DISTINCT inventory_id), SUM(quantity) FROM inventory_movements
+			WHERE store_id = ? AND occurred_at >= ? AND movement IN (?, ?) AND status <> ? GROUP BY unit`,
+			[]interface{}{summary.StoreID, dayStart, domain.MOVEMENT_CREATED, domain.MOVEMENT_RECEIVED, domain.STOCK_OUT}},
+		{&summary.Issues, `SELECT COALESCE(unit, ''), COUNT(*), SUM(quantity) FROM inventories
+			WHERE store_id = ? AND status = ? AND stockout_at >= ? GROUP BY COALESCE(unit, '')`,
+			[]interface{}{summary.StoreID, domain.STOCK_OUT, dayStart}},
+		{&summary.Issues, `SELECT l.unit, COUNT(*), SUM(l.quantity) FROM transfer_order_lines l JOIN transfer_orders t ON t.id = l.transfer_id
+			WHERE t.from_store_id = ? AND t.dispatched_at >= ? AND t.status <> ? GROUP BY l.unit`,
+			[]interface{}{summary.StoreID, dayStart, domain.TRANSFER_CANCELLED}},
+	}
+	for _, q := range queries {
+		if err := scanStockTotals(tx, q.totals, q.query, q.args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanStockTotals(tx *sql.Tx, totals *domain.StockTotals, query string, args ...interface{}) error {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var unit string
+		var lots int
+		var quantity customtypes.Decimal
+		if err := rows.Scan(&unit, &lots, &quantity); err != nil {
+			return err
+		}
+		totals.Add(unit, lots, quantity)
+	}
+	return rows.Err()
+}
+
+// summarizeContainers counts the containers of the store by type. A
+// container is in use when a lot in stock sits in it or in any container
+// below it. Retired containers are left out.
+func summarizeContainers(tx *sql.Tx, summary *domain.StoreSummary) error {
+	query := `SELECT c.type, COUNT(*),
+			SUM(CASE WHEN EXISTS (
+				SELECT 1 FROM containers h JOIN inventories i ON i.container_id = h.id
+				WHERE h.path LIKE CONCAT(c.path, '%') AND i.store_id = c.store_id AND i.status <> ?
+			) THEN 1 ELSE 0 END)
+		FROM containers c
+		WHERE c.store_id = ? AND c.status <> ?
+		GROUP BY c.type
+		ORDER BY c.type`
+	rows, err := tx.Query(query, domain.STOCK_OUT, summary.StoreID, domain.CONTAINER_RETIRED)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		usage := &domain.ContainerUsage{}
+		if err := rows.Scan(&usage.Type, &usage.Total, &usage.InUse); err != nil {
+			return err
+		}
+		usage.Empty = usage.Total - usage.InUse
+		summary.Containers = append(summary.Containers, usage)
+	}
+	return rows.Err()
+}
+
+// summarizeLowStock counts the alerts still open for the store and lists those
+// furthest below their reorder point.
+func summarizeLowStock(tx *sql.Tx, summary *domain.StoreSummary) error {
+	query := "SELECT status, COUNT(*) FROM stock_alerts WHERE store_id = ? AND status IN (?, ?) GROUP BY status"
+	rows, err := tx.Query(query, summary.StoreID, domain.ALERT_OPEN, domain.ALERT_ACKNOWLEDGED)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var status domain.StockAlertStatus
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return err
+		}
+		if status == domain.ALERT_OPEN {
+			summary.LowStock.Open = count
+		} else {
+			summary.LowStock.Acknowledged = count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	query = `SELECT a.id, a.product_id, COALESCE(p.code, ''), a.status, a.available, a.reorder_point, a.unit, a.raised_at
+		FROM stock_alerts a LEFT JOIN products p ON p.id = a.product_id
+		WHERE a.store_id = ? AND a.status IN (?, ?)
+		ORDER BY a.available - a.reorder_point, a.raised_at LIMIT ?`
+	alertRows, err := tx.Query(query, summary.StoreID, domain.ALERT_OPEN, domain.ALERT_ACKNOWLEDGED, domain.StoreSummaryListLimit)
+	if err != nil {
+		return err
+	}
+	defer alertRows.Close()
+	for alertRows.Next() {
+		alert := &domain.LowStockAlert{}
+		if err := alertRows.Scan(&alert.AlertID, &alert.ProductID, &alert.ProductCode, &alert.Status, &alert.Available, &alert.ReorderPoint, &alert.Unit, &alert.RaisedAt); err != nil {
+			return err
+		}
+		summary.LowStock.Alerts = append(summary.LowStock.Alerts, alert)
+	}
+	return alertRows.Err()
+}
+
+// summarizeExpiring counts the lots on hand that have expired or expire
+// before expiringBefore, and lists the first of them to expire.
+func summarizeExpiring(tx *sql.Tx, summary *domain.StoreSummary, now time.Time, expiringBefore time.Time) error {
+	query := `SELECT COALESCE(SUM(CASE WHEN expires_at < ? THEN 1 ELSE 0 END), 0), COALESCE(SUM(CASE WHEN expires_at >= ? THEN 1 ELSE 0 END), 0)
+		FROM inventories WHERE store_id = ? AND status IN (?, ?) AND expires_at < ?`
+	err := tx.QueryRow(query, now, now, summary.StoreID, domain.STOCK_IN, domain.STOCK_RESERVED, expiringBefore).Scan(&summary.Expiring.Expired, &summary.Expiring.Expiring)
+	if err != nil {
+		return err
+	}
+
+	query = `SELECT i.id, i.product_id, COALESCE(p.code, ''), COALESCE(i.batch, ''), i.container_id, i.quantity, COALESCE(i.unit, ''), i.expires_at
+		FROM inventories i LEFT JOIN products p ON p.id = i.product_id
+		WHERE i.store_id = ? AND i.status IN (?, ?) AND i.expires_at < ?
+		ORDER BY i.expires_at, i.id LIMIT ?`
+	rows, err := tx.Query(query, summary.StoreID, domain.STOCK_IN, domain.STOCK_RESERVED, expiringBefore, domain.StoreSummaryListLimit)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		lot := &domain.ExpiringLot{}
+		if err := rows.Scan(&lot.InventoryID, &lot.ProductID, &lot.ProductCode, &lot.Batch, &lot.ContainerID, &lot.Quantity, &lot.Unit, &lot.ExpiresAt); err != nil {
+			return err
+		}
+		summary.Expiring.Lots = append(summary.Expiring.Lots, lot)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,447 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+	"github.com/vamika-digital/wms-api-server/pkg/database"
+)
+
+const (
+	transferColumns     = "id, from_store_id, to_store_id, status, vehicle, remarks, dispatched_at, dispatched_by, received_at, cancelled_at, cancel_reason, created_at, updated_at, last_updated_by, version"
+	transferLineColumns = "id, transfer_id, inventory_id, product_id, batch, quantity, unit, source_container_id, transit_inventory_id, received_quantity, to_container_id, discrepancy, discrepancy_reason, received_at, received_by"
+)
+
+type MySqlTransferRepository struct {
+	conn database.Connection
+}
+
+func NewTransferRepository(conn database.Connection) TransferRepository {
+	return &MySqlTransferRepository{conn: conn}
+}
+
+func (r *MySqlTransferRepository) Create(order *domain.TransferOrder) error {
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		query := "INSERT INTO transfer_orders (from_store_id, to_store_id, status, vehicle, remarks, last_updated_by) VALUES (?, ?, ?, ?, ?, ?)"
+		result, err := tx.Exec(query, order.FromStoreID, order.ToStoreID, order.Status, order.Vehicle, order.Remarks, order.LastUpdatedBy)
+		if err != nil {
+			return err
+		}
+		if order.ID, err = result.LastInsertId(); err != nil {
+			return err
+		}
+		return insertTransferLines(tx, order)
+	})
+}
+
+// Update replaces the header and lines of a draft order.
+func (r *MySqlTransferRepository) Update(order *domain.TransferOrder) error {
+	version := order.Version
+	err := database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		query := "UPDATE transfer_orders SET from_store_id=?, to_store_id=?, vehicle=?, remarks=?, last_updated_by=?, version=LAST_INSERT_ID(version+1) WHERE id=? AND status=? AND (? = 0 OR version=?)"
+		result, err := tx.Exec(query, order.FromStoreID, order.ToStoreID, order.Vehicle, order.Remarks, order.LastUpdatedBy, order.ID, domain.TRANSFER_DRAFT, order.Version, order.Version)
+		if err != nil {
+			return err
+		}
+		if err := database.CheckVersionedWrite(result, "transfer order", order.ID, order.Version); err != nil {
+			return err
+		}
+		version = database.NextVersion(result, order.Version)
+		if _, err := tx.Exec("DELETE FROM transfer_order_lines WHERE transfer_id = ?", order.ID); err != nil {
+			return err
+		}
+		return insertTransferLines(tx, order)
+	})
+	if err != nil {
+		return err
+	}
+	order.Version = version
+	return nil
+}
+
+func (r *MySqlTransferRepository) Delete(orderID int64, version int64) error {
+	return database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		result, err := tx.Exec("DELETE FROM transfer_orders WHERE id=? AND status=? AND (? = 0 OR version=?)", orderID, domain.TRANSFER_DRAFT, version, version)
+		if err != nil {
+			return err
+		}
+		if err := database.CheckVersionedWrite(result, "transfer order", orderID, version); err != nil {
+			return err
+		}
+		_, err = tx.Exec("DELETE FROM transfer_order_lines WHERE transfer_id = ?", orderID)
+		return err
+	})
+}
+
+// insertTransferLines writes the lines of the order, taking the product,
+// batch and unit of each line from its lot, which must be stock of the source
+// store at this point.
+func insertTransferLines(tx *sql.Tx, order *domain.TransferOrder) error {
+	for _, line := range order.Lines {
+		var status domain.InventoryType
+		var storeID *int64
+		query := "SELECT status, store_id, product_id, batch, unit FROM inventories WHERE id = ?"
+		err := tx.QueryRow(query, line.InventoryID).Scan(&status, &storeID, &line.ProductID, &line.Batch, &line.Unit)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("%w: lot %d does not exist", domain.ErrTransferLotUnavailable, line.InventoryID)
+		}
+		if err != nil {
+			return err
+		}
+		if status != domain.STOCK_IN || storeID == nil || *storeID != order.FromStoreID {
+			return fmt.Errorf("%w: lot %d is not in stock at store %d", domain.ErrTransferLotUnavailable, line.InventoryID, order.FromStoreID)
+		}
+
+		line.TransferID = order.ID
+		query = "INSERT INTO transfer_order_lines (transfer_id, inventory_id, product_id, batch, quantity, unit) VALUES (?, ?, ?, ?, ?, ?)"
+		result, err := tx.Exec(query, line.TransferID, line.InventoryID, line.ProductID, line.Batch, line.Quantity, line.Unit)
+		if err != nil {
+			return err
+		}
+		if line.ID, err = result.LastInsertId(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Dispatch takes the stock of every line out of the source store and puts it
+// in transit. A lot sent in full travels itself; otherwise the quantity is
+// split off into a new lot that keeps the stock in date of its source, so
+// that ageing and FIFO are not reset by the journey.
+func (r *MySqlTransferRepository) Dispatch(order *domain.TransferOrder) error {
+	return r.transition(order, func(tx *sql.Tx) error {
+		for _, line := range order.Lines {
+			if err := dispatchTransferLine(tx, order, line); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func dispatchTransferLine(tx *sql.Tx, order *domain.TransferOrder, line *domain.TransferLine) error {
+	var status domain.InventoryType
+	var qcStatus domain.QCStatus
+	var storeID, containerID *int64
+	var productID int64
+	var quantity customtypes.Decimal
+	query := "SELECT status, qc_status, store_id, container_id, product_id, quantity FROM inventories WHERE id = ? FOR UPDATE"
+	err := tx.QueryRow(query, line.InventoryID).Scan(&status, &qcStatus, &storeID, &containerID, &productID, &quantity)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: lot %d does not exist", domain.ErrTransferLotUnavailable, line.InventoryID)
+	}
+	if err != nil {
+		return err
+	}
+	if status != domain.STOCK_IN || qcStatus != domain.QC_RELEASED || storeID == nil || *storeID != order.FromStoreID || productID != line.ProductID {
+		return fmt.Errorf("%w: lot %d is not released stock of store %d", domain.ErrTransferLotUnavailable, line.InventoryID, order.FromStoreID)
+	}
+	if quantity.Cmp(line.Quantity) < 0 {
+		return fmt.Errorf("%w: lot %d holds %s, %s to dispatch", domain.ErrTransferLotUnavailable, line.InventoryID, quantity, line.Quantity)
+	}
+	serials, err := countSerials(tx, line.InventoryID)
+	if err != nil {
+		return err
+	}
+
+	transitID := line.InventoryID
+	if quantity.Cmp(line.Quantity) == 0 {
+		query := "UPDATE inventories SET status=?, store_id=NULL, container_id=NULL, pallet_id=NULL, bin_id=NULL, rack_id=NULL, version=version+1 WHERE id=?"
+		if _, err := tx.Exec(query, domain.STOCK_IN_TRANSIT, line.InventoryID); err != nil {
+			return err
+		}
+	} else {
+		if serials > 0 {
+			return fmt.Errorf("%w: lot %d has serial numbers and must be transferred whole", domain.ErrTransferLotUnavailable, line.InventoryID)
+		}
+		if _, err := tx.Exec("UPDATE inventories SET quantity=?, version=version+1 WHERE id=?", quantity.Sub(line.Quantity), line.InventoryID); err != nil {
+			return err
+		}
+		if err := recordMovement(tx, line.InventoryID, domain.MOVEMENT_DISPATCHED); err != nil {
+			return err
+		}
+		query := `INSERT INTO inventories (status, qc_status, product_id, batch, machine, shift, supervisor, quantity, unit, stockin_at, expires_at)
+			SELECT ?, qc_status, product_id, batch, machine, shift, supervisor, ?, unit, stockin_at, expires_at FROM inventories WHERE id = ?`
+		result, err := tx.Exec(query, domain.STOCK_IN_TRANSIT, line.Quantity, line.InventoryID)
+		if err != nil {
+			return err
+		}
+		if transitID, err = result.LastInsertId(); err != nil {
+			return err
+		}
+	}
+	if err := recordMovement(tx, transitID, domain.MOVEMENT_DISPATCHED); err != nil {
+		return err
+	}
+	if serials > 0 {
+		lot := &domain.Inventory{ID: transitID, Status: domain.STOCK_IN_TRANSIT}
+		if err := syncSerials(tx, lot, domain.SERIAL_EVENT_TRANSFERRED, string(order.DispatchedBy)); err != nil {
+			return err
+		}
+	}
+
+	line.SourceContainerID, line.TransitInventoryID = containerID, &transitID
+	_, err = tx.Exec("UPDATE transfer_order_lines SET source_container_id=?, transit_inventory_id=? WHERE id=?", line.SourceContainerID, line.TransitInventoryID, line.ID)
+	return err
+}
+
+// Receive books the received lines into the destination store. A line
+// received short or over keeps its lot at the received quantity, and a line
+// of which nothing arrived stocks its lot out; the difference stays on the
+// line with its reason. Capacity warnings of the receiving containers are
+// added to the order.
+func (r *MySqlTransferRepository) Receive(order *domain.TransferOrder, lines []*domain.TransferLine) error {
+	return r.transition(order, func(tx *sql.Tx) error {
+		for _, line := range lines {
+			lot, err := placeTransitLot(tx, line, order.ToStoreID, line.ToContainerID, line.ReceivedQuantity, string(line.ReceivedBy), domain.MOVEMENT_RECEIVED)
+			if err != nil {
+				return err
+			}
+			order.Warnings = append(order.Warnings, lot.Warnings...)
+			query := "UPDATE transfer_order_lines SET received_quantity=?, to_container_id=?, discrepancy=?, discrepancy_reason=?, received_at=?, received_by=? WHERE id=?"
+			if _, err := tx.Exec(query, line.ReceivedQuantity, line.ToContainerID, line.Discrepancy, line.DiscrepancyReason, line.ReceivedAt, line.ReceivedBy, line.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Cancel calls off the order. The stock of a dispatched order goes back to
+// the store and container it left; a lot split off on dispatch comes back as
+// a lot of its own.
+func (r *MySqlTransferRepository) Cancel(order *domain.TransferOrder) error {
+	return r.transition(order, func(tx *sql.Tx) error {
+		for _, line := range order.Lines {
+			if line.TransitInventoryID == nil {
+				continue
+			}
+			if _, err := placeTransitLot(tx, line, order.FromStoreID, line.SourceContainerID, nil, string(order.LastUpdatedBy), domain.MOVEMENT_UPDATED); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// placeTransitLot books the lot in transit of the line into the store,
+// optionally in a container. quantity replaces the quantity of the lot when
+// given; a lot left without stock is stocked out.
+func placeTransitLot(tx *sql.Tx, line *domain.TransferLine, storeID int64, containerID *int64, quantity *customtypes.Decimal, actor string, movement domain.MovementType) (*domain.Inventory, error) {
+	lot := domain.NewInventoryWithDefaults()
+	query := "SELECT id, status, product_id, quantity, unit FROM inventories WHERE id = ? FOR UPDATE"
+	err := tx.QueryRow(query, *line.TransitInventoryID).Scan(&lot.ID, &lot.Status, &lot.ProductID, &lot.Quantity, &lot.Unit)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: lot %d does not exist", domain.ErrTransferLotUnavailable, *line.TransitInventoryID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if lot.Status != domain.STOCK_IN_TRANSIT {
+		return nil, fmt.Errorf("%w: lot %d is no longer in transit", domain.ErrTransferLotUnavailable, lot.ID)
+	}
+	if quantity != nil {
+		if err := quantity.ValidateForUnit(lot.Unit); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", domain.ErrInvalidTransfer, line.ID, err)
+		}
+		lot.Quantity = *quantity
+	}
+	serials, err := countSerials(tx, lot.ID)
+	if err != nil {
+		return nil, err
+	}
+	if serials > 0 {
+		if err := domain.ValidateSerialCount(lot.Quantity, serials); err != nil {
+			return nil, fmt.Errorf("%w: lot %d", err, lot.ID)
+		}
+	}
+
+	lot.StoreID = &storeID
+	if lot.Quantity.IsZero() {
+		now := time.Now()
+		lot.Status, lot.StockOutAt = domain.STOCK_OUT, &now
+	} else {
+		lot.Status, lot.ContainerID = domain.STOCK_IN, containerID
+		if err := resolveLocation(tx, lot); err != nil {
+			return nil, err
+		}
+		if lot.StoreID == nil || *lot.StoreID != storeID {
+			return nil, fmt.Errorf("%w: container %d is not in store %d", domain.ErrLocationMismatch, *containerID, storeID)
+		}
+	}
+	query = "UPDATE inventories SET status=?, store_id=?, container_id=?, pallet_id=?, bin_id=?, rack_id=?, quantity=?, stockout_at=?, version=version+1 WHERE id=?"
+	if _, err := tx.Exec(query, lot.Status, lot.StoreID, lot.ContainerID, lot.PalletID, lot.BinID, lot.RackID, lot.Quantity, lot.StockOutAt, lot.ID); err != nil {
+		return nil, err
+	}
+	if err := recordMovement(tx, lot.ID, movement); err != nil {
+		return nil, err
+	}
+	if serials > 0 {
+		if err := syncSerials(tx, lot, domain.SERIAL_EVENT_TRANSFERRED, actor); err != nil {
+			return nil, err
+		}
+	}
+	return lot, nil
+}
+
+// transition writes the new status of the order and runs apply in the same
+// transaction, so that the stock moves with the order or not at all.
+func (r *MySqlTransferRepository) transition(order *domain.TransferOrder, apply func(tx *sql.Tx) error) error {
+	version := order.Version
+	err := database.WithTx(r.conn.GetDB(), func(tx *sql.Tx) error {
+		query := `UPDATE transfer_orders SET status=?, vehicle=?, dispatched_at=?, dispatched_by=?, received_at=?, cancelled_at=?, cancel_reason=?, last_updated_by=?, version=LAST_INSERT_ID(version+1)
+			WHERE id=? AND (? = 0 OR version=?)`
+		result, err := tx.Exec(query, order.Status, order.Vehicle, order.DispatchedAt, order.DispatchedBy, order.ReceivedAt, order.CancelledAt, order.CancelReason, order.LastUpdatedBy, order.ID, order.Version, order.Version)
+		if err != nil {
+			return err
+		}
+		if err := database.CheckVersionedWrite(result, "transfer order", order.ID, order.Version); err != nil {
+			return err
+		}
+		version = database.NextVersion(result, order.Version)
+		return apply(tx)
+	})
+	if err != nil {
+		return err
+	}
+	order.Version = version
+	return nil
+}
+
+func countSerials(tx *sql.Tx, inventoryID int64) (int, error) {
+	var count int
+	err := tx.QueryRow("SELECT COUNT(*) FROM serial_numbers WHERE inventory_id = ?", inventoryID).Scan(&count)
+	return count, err
+}
+
+func (r *MySqlTransferRepository) GetById(orderID int64) (*domain.TransferOrder, error) {
+	query := "SELECT " + transferColumns + " FROM transfer_orders WHERE id = ?"
+	order, err := r.scanTransfer(r.conn.GetDB().QueryRow(query, orderID))
+	if err != nil {
+		return nil, err
+	}
+	if order.Lines, err = r.getLines(order.ID); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (r *MySqlTransferRepository) GetTotalCount(filter TransferFilterOptions) (int, error) {
+	query, args := r.buildFilterQuery("SELECT COUNT(*) FROM transfer_orders", filter)
+
+	var count int
+	if err := r.conn.GetDB().QueryRow(query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetAll lists orders without their lines; fetch a single order to see them.
+func (r *MySqlTransferRepository) GetAll(page int, pageSize int, sort string, filter TransferFilterOptions) ([]*domain.TransferOrder, error) {
+	query, args := r.buildFilterQuery("SELECT "+transferColumns+" FROM transfer_orders", filter)
+	var allowedSortOrders = map[string]bool{
+		"created_at ASC":     true,
+		"created_at DESC":    true,
+		"dispatched_at ASC":  true,
+		"dispatched_at DESC": true,
+		"received_at ASC":    true,
+		"received_at DESC":   true,
+		"status ASC":         true,
+		"status DESC":        true,
+	}
+
+	if sort != "" {
+		if _, ok := allowedSortOrders[sort]; ok {
+			query += " ORDER BY " + sort
+		} else {
+			return nil, errors.New("invalid sort order")
+		}
+	}
+
+	query += " LIMIT ? OFFSET ?"
+	args = append(args, pageSize, page)
+
+	rows, err := r.conn.GetDB().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []*domain.TransferOrder
+	for rows.Next() {
+		order, err := r.scanTransfer(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	return orders, rows.Err()
+}
+
+func (r *MySqlTransferRepository) getLines(orderID int64) ([]*domain.TransferLine, error) {
+	rows, err := r.conn.GetDB().Query("SELECT "+transferLineColumns+" FROM transfer_order_lines WHERE transfer_id = ? ORDER BY id", orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lines := []*domain.TransferLine{}
+	for rows.Next() {
+		line := &domain.TransferLine{}
+		err := rows.Scan(&line.ID, &line.TransferID, &line.InventoryID, &line.ProductID, &line.Batch, &line.Quantity, &line.Unit, &line.SourceContainerID, &line.TransitInventoryID, &line.ReceivedQuantity, &line.ToContainerID, &line.Discrepancy, &line.DiscrepancyReason, &line.ReceivedAt, &line.ReceivedBy)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+	return lines, rows.Err()
+}
+
+func (r *MySqlTransferRepository) scanTransfer(row rowScanner) (*domain.TransferOrder, error) {
+	order := &domain.TransferOrder{}
+	err := row.Scan(&order.ID, &order.FromStoreID, &order.ToStoreID, &order.Status, &order.Vehicle, &order.Remarks, &order.DispatchedAt, &order.DispatchedBy, &order.ReceivedAt, &order.CancelledAt, &order.CancelReason, &order.CreatedAt, &order.UpdatedAt, &order.LastUpdatedBy, &order.Version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, customerrors.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return order, nil
+}
+
+func (r *MySqlTransferRepository) buildFilterQuery(baseQuery string, filter TransferFilterOptions) (string, []interface{}) {
+	var filters []string
+	var args []interface{}
+
+	if len(filter.Statuses) > 0 {
+		filters = append(filters, "status IN ("+placeholders(len(filter.Statuses))+")")
+		for _, status := range filter.Statuses {
+			args = append(args, status)
+		}
+	}
+	if filter.FromStoreID > 0 {
+		filters = append(filters, "from_store_id = ?")
+		args = append(args, filter.FromStoreID)
+	}
+	if filter.ToStoreID > 0 {
+		filters = append(filters, "to_store_id = ?")
+		args = append(args, filter.ToStoreID)
+	}
+	if filter.StoreID > 0 {
+		filters = append(filters, "(from_store_id = ? OR to_store_id = ?)")
+		args = append(args, filter.StoreID, filter.StoreID)
+	}
+
+	if len(filters) > 0 {
+		return baseQuery + " WHERE " + strings.Join(filters, " AND "), args
+	}
+
+	return baseQuery, args
+}
@@ -0,0 +1,31 @@
+package repository
+
+import "github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+
+type TransferRepository interface {
+	Create(order *domain.TransferOrder) error
+	Update(order *domain.TransferOrder) error
+	Delete(orderID int64, version int64) error
+	Dispatch(order *domain.TransferOrder) error
+	Receive(order *domain.TransferOrder, lines []*domain.TransferLine) error
+	Cancel(order *domain.TransferOrder) error
+	GetById(orderID int64) (*domain.TransferOrder, error)
+	GetTotalCount(filter TransferFilterOptions) (int, error)
+	GetAll(page int, pageSize int, sort string, filter TransferFilterOptions) ([]*domain.TransferOrder, error)
+}
+
+// TransferFilterOptions selects orders by status and store. StoreID matches
+// orders leaving or arriving at the store.
+type TransferFilterOptions struct {
+	Statuses    []domain.TransferStatus
+	FromStoreID int64
+	ToStoreID   int64
+	StoreID     int64
+}
+
+func (f *TransferFilterOptions) SetStatuses(statusesStr string) {
+	f.Statuses = nil
+	for _, status := range splitList(statusesStr) {
+		f.Statuses = append(f.Statuses, domain.TransferStatus(status))
+	}
+}
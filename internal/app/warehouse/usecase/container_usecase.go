@@ -7,8 +7,16 @@ import (
 
 type ContainerUseCase interface {
 	CreateContainer(container *domain.Container) error
+	GenerateContainers(form *domain.GenerateContainersForm) ([]*domain.Container, error)
+	PreviewLayout(spec *domain.LayoutSpec) (*domain.LayoutPlan, error)
+	ApplyLayout(spec *domain.LayoutSpec, dryRun bool) (*domain.LayoutPlan, error)
 	UpdateContainer(container *domain.Container) error
-	DeleteContainer(containerID int64) error
+	DeleteContainer(containerID int64, version int64) error
+	MoveContainer(containerID int64, parentID *int64, storeID *int64, version int64) (*domain.Container, error)
+	ChangeContainerStatus(containerID int64, form *domain.ContainerStatusForm, version int64) (*domain.Container, error)
+	GetContainerHistory(containerID int64, page int, pageSize int, filter repository.ContainerEventFilterOptions) ([]*domain.ContainerEvent, int, error)
+	GetContainerHistoryByCode(code string, page int, pageSize int, filter repository.ContainerEventFilterOptions) ([]*domain.ContainerEvent, int, error)
 	GetContainerByID(containerID int64) (*domain.Container, error)
+	GetContainerTree(containerID int64) (*domain.Container, error)
 	GetAllContainers(page int, pageSize int, sort string, filter repository.ContainerFilterOptions) ([]*domain.Container, int, error)
 }
@@ -17,24 +17,152 @@ func (u *ContainerUseCaseImpl) CreateContainer(container *domain.Container) erro
 	return u.Repo.Create(container)
 }
 
+// GenerateContainers creates a batch of containers numbered by the series of
+// their type, typically pallets whose labels are printed ahead of use.
+func (u *ContainerUseCaseImpl) GenerateContainers(form *domain.GenerateContainersForm) ([]*domain.Container, error) {
+	return u.Repo.Generate(form)
+}
+
+// PreviewLayout lists the racks and bins a layout expands to without looking
+// at what the store already holds.
+func (u *ContainerUseCaseImpl) PreviewLayout(spec *domain.LayoutSpec) (*domain.LayoutPlan, error) {
+	return spec.Plan()
+}
+
+// ApplyLayout creates the racks and bins of a layout that do not exist yet.
+// With dryRun nothing is written, but the plan reports what would be created
+// and what already exists.
+func (u *ContainerUseCaseImpl) ApplyLayout(spec *domain.LayoutSpec, dryRun bool) (*domain.LayoutPlan, error) {
+	plan, err := spec.Plan()
+	if err != nil {
+		return nil, err
+	}
+	if err := u.Repo.ApplyLayout(plan, dryRun); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// UpdateContainer keeps the place of the container in the tree and its
+// status, which only change through MoveContainer and ChangeContainerStatus.
+// A new type must still fit the parent and the children of the container.
 func (u *ContainerUseCaseImpl) UpdateContainer(container *domain.Container) error {
 	// Check for an existing container with the specified ID
-	// existingContainer, err := u.Repo.GetById(container.ID)
-	_, err := u.Repo.GetById(container.ID)
+	existingContainer, err := u.Repo.GetById(container.ID)
 	if err != nil {
 		return err
 	}
+	container.ParentID = existingContainer.ParentID
+	container.StoreID = existingContainer.StoreID
+	container.Path = existingContainer.Path
+	container.Status = existingContainer.Status
+
+	if container.Type != existingContainer.Type {
+		var parent *domain.Container
+		if container.ParentID != nil {
+			if parent, err = u.Repo.GetById(*container.ParentID); err != nil {
+				return err
+			}
+		}
+		if err := container.ValidateParent(parent); err != nil {
+			return err
+		}
+		children, err := u.Repo.GetChildren(container.ID)
+		if err != nil {
+			return err
+		}
+		for _, child := range children {
+			if err := child.ValidateParent(container); err != nil {
+				return err
+			}
+		}
+	}
 	return u.Repo.Update(container)
 }
 
-func (u *ContainerUseCaseImpl) DeleteContainer(containerID int64) error {
-	return u.Repo.Delete(containerID)
+func (u *ContainerUseCaseImpl) DeleteContainer(containerID int64, version int64) error {
+	return u.Repo.Delete(containerID, version)
+}
+
+// MoveContainer places the container and everything below it in parentID, or
+// directly in storeID when parentID is nil. One of them is required, as the
+// lots inside must always end up in a store.
+func (u *ContainerUseCaseImpl) MoveContainer(containerID int64, parentID *int64, storeID *int64, version int64) (*domain.Container, error) {
+	if parentID == nil && storeID == nil {
+		return nil, domain.ErrContainerMoveTarget
+	}
+	warnings, err := u.Repo.Move(containerID, parentID, storeID, version)
+	if err != nil {
+		return nil, err
+	}
+	container, err := u.Repo.GetById(containerID)
+	if err != nil {
+		return nil, err
+	}
+	container.Warnings = warnings
+	return container, nil
+}
+
+// ChangeContainerStatus moves the container along its lifecycle and returns
+// it in its new state.
+func (u *ContainerUseCaseImpl) ChangeContainerStatus(containerID int64, form *domain.ContainerStatusForm, version int64) (*domain.Container, error) {
+	if err := u.Repo.ChangeStatus(containerID, form, version); err != nil {
+		return nil, err
+	}
+	return u.Repo.GetById(containerID)
+}
+
+// GetContainerHistory returns a page of the timeline of the container:
+// where it was moved, the stock that came and went, its status changes and
+// its printed labels.
+func (u *ContainerUseCaseImpl) GetContainerHistory(containerID int64, page int, pageSize int, filter repository.ContainerEventFilterOptions) ([]*domain.ContainerEvent, int, error) {
+	if _, err := u.Repo.GetById(containerID); err != nil {
+		return nil, 0, err
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	events, err := u.Repo.GetEvents(containerID, page, pageSize, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := u.Repo.GetEventCount(containerID, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+func (u *ContainerUseCaseImpl) GetContainerHistoryByCode(code string, page int, pageSize int, filter repository.ContainerEventFilterOptions) ([]*domain.ContainerEvent, int, error) {
+	container, err := u.Repo.GetByCode(code)
+	if err != nil {
+		return nil, 0, err
+	}
+	return u.GetContainerHistory(container.ID, page, pageSize, filter)
 }
 
 func (u *ContainerUseCaseImpl) GetContainerByID(containerID int64) (*domain.Container, error) {
 	return u.Repo.GetById(containerID)
 }
 
+// GetContainerTree returns the container with all its descendants nested
+// under it.
+func (u *ContainerUseCaseImpl) GetContainerTree(containerID int64) (*domain.Container, error) {
+	container, err := u.Repo.GetById(containerID)
+	if err != nil {
+		return nil, err
+	}
+	descendants, err := u.Repo.GetDescendants(container)
+	if err != nil {
+		return nil, err
+	}
+	return domain.BuildContainerTree(container, descendants), nil
+}
+
 func (u *ContainerUseCaseImpl) GetAllContainers(page int, pageSize int, sort string, filter repository.ContainerFilterOptions) ([]*domain.Container, int, error) {
 	if page < 1 {
 		page = 1
@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+)
+
+type InspectionUseCase interface {
+	CreateInspectionParameter(parameter *domain.InspectionParameter) error
+	UpdateInspectionParameter(parameter *domain.InspectionParameter) error
+	DeleteInspectionParameter(parameterID int64, version int64) error
+	GetInspectionParameterByID(parameterID int64) (*domain.InspectionParameter, error)
+	GetAllInspectionParameters(page int, pageSize int, sort string, filter repository.InspectionParameterFilterOptions) ([]*domain.InspectionParameter, int, error)
+
+	OpenInspection(inventoryID int64, inspector string) (*domain.Inspection, error)
+	RecordInspectionResults(inspectionID int64, results []*domain.InspectionResult, recordedBy string) (*domain.Inspection, error)
+	ReleaseInspection(inspectionID int64, inspector string, remarks string) (*domain.Inspection, error)
+	RejectInspection(inspectionID int64, inspector string, remarks string, quarantineStoreID int64) (*domain.Inspection, error)
+	GetInspectionByID(inspectionID int64) (*domain.Inspection, error)
+	GetAllInspections(page int, pageSize int, sort string, filter repository.InspectionFilterOptions) ([]*domain.Inspection, int, error)
+
+	HoldInventory(inventoryID int64) (*domain.Inventory, error)
+	QuarantineInventory(inventoryID int64, storeID int64) (*domain.Inventory, error)
+}
@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+type InspectionUseCaseImpl struct {
+	Repo                     repository.InspectionRepository
+	ParameterRepo            repository.InspectionParameterRepository
+	InventoryRepo            repository.InventoryRepository
+	DefaultQuarantineStoreID int64
+}
+
+func NewInspectionUseCase(repo repository.InspectionRepository, parameterRepo repository.InspectionParameterRepository, inventoryRepo repository.InventoryRepository, defaultQuarantineStoreID int64) InspectionUseCase {
+	return &InspectionUseCaseImpl{Repo: repo, ParameterRepo: parameterRepo, InventoryRepo: inventoryRepo, DefaultQuarantineStoreID: defaultQuarantineStoreID}
+}
+
+func (u *InspectionUseCaseImpl) CreateInspectionParameter(parameter *domain.InspectionParameter) error {
+	return u.ParameterRepo.Create(parameter)
+}
+
+func (u *InspectionUseCaseImpl) UpdateInspectionParameter(parameter *domain.InspectionParameter) error {
+	// Check for an existing parameter with the specified ID
+	_, err := u.ParameterRepo.GetById(parameter.ID)
+	if err != nil {
+		return err
+	}
+	return u.ParameterRepo.Update(parameter)
+}
+
+func (u *InspectionUseCaseImpl) DeleteInspectionParameter(parameterID int64, version int64) error {
+	return u.ParameterRepo.Delete(parameterID, version)
+}
+
+func (u *InspectionUseCaseImpl) GetInspectionParameterByID(parameterID int64) (*domain.InspectionParameter, error) {
+	return u.ParameterRepo.GetById(parameterID)
+}
+
+func (u *InspectionUseCaseImpl) GetAllInspectionParameters(page int, pageSize int, sort string, filter repository.InspectionParameterFilterOptions) ([]*domain.InspectionParameter, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	parameters, err := u.ParameterRepo.GetAll((page-1)*pageSize, pageSize, sort, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Fetch the total count of parameters matching the filter
+	total, err := u.ParameterRepo.GetTotalCount(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return parameters, total, nil
+}
+
+// OpenInspection starts an inspection of a lot and puts the lot back on
+// pending inspection if it was released or held.
+func (u *InspectionUseCaseImpl) OpenInspection(inventoryID int64, inspector string) (*domain.Inspection, error) {
+	inventory, err := u.InventoryRepo.GetById(inventoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = u.Repo.GetOpenByInventory(inventoryID)
+	if err == nil {
+		return nil, domain.ErrInspectionPending
+	}
+	if !errors.Is(err, customerrors.ErrResourceNotFound) {
+		return nil, err
+	}
+
+	if inventory.QCStatus != domain.QC_PENDING_INSPECTION {
+		if inventory.Status == domain.STOCK_RESERVED {
+			return nil, domain.ErrInvalidQCTransition
+		}
+		if err := inventory.ChangeQCStatus(domain.QC_PENDING_INSPECTION); err != nil {
+			return nil, err
+		}
+		if err := u.InventoryRepo.Update(inventory); err != nil {
+			return nil, err
+		}
+	}
+
+	inspection := &domain.Inspection{
+		InventoryID: inventory.ID,
+		ProductID:   inventory.ProductID,
+		Batch:       inventory.Batch,
+		Status:      domain.INSPECTION_OPEN,
+		CreatedAt:   time.Now(),
+		Results:     []*domain.InspectionResult{},
+	}
+	inspection.Inspector = customtypes.NullableString(inspector)
+	if err := u.Repo.Create(inspection); err != nil {
+		return nil, err
+	}
+	return inspection, nil
+}
+
+// RecordInspectionResults evaluates each measured value against its
+// parameter. Recording a parameter again supersedes the earlier result.
+func (u *InspectionUseCaseImpl) RecordInspectionResults(inspectionID int64, results []*domain.InspectionResult, recordedBy string) (*domain.Inspection, error) {
+	inspection, err := u.Repo.GetById(inspectionID)
+	if err != nil {
+		return nil, err
+	}
+	if inspection.Status != domain.INSPECTION_OPEN {
+		return nil, domain.ErrInspectionClosed
+	}
+
+	parameters, err := u.ParameterRepo.GetActiveByProduct(inspection.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*domain.InspectionParameter, len(parameters))
+	for _, parameter := range parameters {
+		byID[parameter.ID] = parameter
+	}
+
+	now := time.Now()
+	for _, result := range results {
+		parameter, ok := byID[result.ParameterID]
+		if !ok {
+			return nil, errors.New("parameter is not an active inspection parameter of the product")
+		}
+		passed, err := parameter.Evaluate(result.Value)
+		if err != nil {
+			return nil, err
+		}
+		result.Name = string(parameter.Name)
+		result.Passed = passed
+		result.RecordedAt = now
+		result.RecordedBy = recordedBy
+	}
+	for _, result := range results {
+		if err := u.Repo.AddResult(inspection.ID, result); err != nil {
+			return nil, err
+		}
+		inspection.Results = append(inspection.Results, result)
+	}
+	return inspection, nil
+}
+
+// ReleaseInspection signs the lot off as good. Every mandatory parameter must
+// have a passing result.
+func (u *InspectionUseCaseImpl) ReleaseInspection(inspectionID int64, inspector string, remarks string) (*domain.Inspection, error) {
+	inspection, inventory, err := u.getOpenInspection(inspectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters, err := u.ParameterRepo.GetActiveByProduct(inspection.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if !inspection.CanRelease(parameters) {
+		return nil, domain.ErrInspectionFailed
+	}
+
+	if err := inspection.Decide(domain.INSPECTION_RELEASED, inspector, remarks, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := inventory.ChangeQCStatus(domain.QC_RELEASED); err != nil {
+		return nil, err
+	}
+	if err := u.Repo.Decide(inspection, inventory, "", inspector); err != nil {
+		return nil, err
+	}
+	return inspection, nil
+}
+
+// RejectInspection signs the lot off as bad. The lot is moved to the given
+// quarantine store, or to the configured default when none is given.
+func (u *InspectionUseCaseImpl) RejectInspection(inspectionID int64, inspector string, remarks string, quarantineStoreID int64) (*domain.Inspection, error) {
+	inspection, inventory, err := u.getOpenInspection(inspectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := inspection.Decide(domain.INSPECTION_REJECTED, inspector, remarks, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := inventory.ChangeQCStatus(domain.QC_REJECTED); err != nil {
+		return nil, err
+	}
+	if quarantineStoreID <= 0 {
+		quarantineStoreID = u.DefaultQuarantineStoreID
+	}
+	var event domain.SerialEventType
+	if quarantineStoreID > 0 {
+		if err := inventory.MoveToQuarantine(quarantineStoreID); err != nil {
+			return nil, err
+		}
+		event = domain.SERIAL_EVENT_QUARANTINED
+	}
+	if err := u.Repo.Decide(inspection, inventory, event, inspector); err != nil {
+		return nil, err
+	}
+	return inspection, nil
+}
+
+func (u *InspectionUseCaseImpl) GetInspectionByID(inspectionID int64) (*domain.Inspection, error) {
+	return u.Repo.GetById(inspectionID)
+}
+
+func (u *InspectionUseCaseImpl) GetAllInspections(page int, pageSize int, sort string, filter repository.InspectionFilterOptions) ([]*domain.Inspection, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	inspections, err := u.Repo.GetAll((page-1)*pageSize, pageSize, sort, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Fetch the total count of inspections matching the filter
+	total, err := u.Repo.GetTotalCount(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return inspections, total, nil
+}
+
+// HoldInventory blocks a lot from allocation until it is inspected again.
+func (u *InspectionUseCaseImpl) HoldInventory(inventoryID int64) (*domain.Inventory, error) {
+	inventory, err := u.InventoryRepo.GetById(inventoryID)
+	if err != nil {
+		return nil, err
+	}
+	if inventory.Status == domain.STOCK_RESERVED {
+		return nil, domain.ErrInvalidQCTransition
+	}
+	if err := inventory.ChangeQCStatus(domain.QC_ON_HOLD); err != nil {
+		return nil, err
+	}
+	return inventory, u.InventoryRepo.Update(inventory)
+}
+
+func (u *InspectionUseCaseImpl) QuarantineInventory(inventoryID int64, storeID int64) (*domain.Inventory, error) {
+	inventory, err := u.InventoryRepo.GetById(inventoryID)
+	if err != nil {
+		return nil, err
+	}
+	if storeID <= 0 {
+		storeID = u.DefaultQuarantineStoreID
+	}
+	if err := inventory.MoveToQuarantine(storeID); err != nil {
+		return nil, err
+	}
+	return inventory, u.InventoryRepo.UpdateWithSerialEvent(inventory, domain.SERIAL_EVENT_QUARANTINED, "")
+}
+
+func (u *InspectionUseCaseImpl) getOpenInspection(inspectionID int64) (*domain.Inspection, *domain.Inventory, error) {
+	inspection, err := u.Repo.GetById(inspectionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if inspection.Status != domain.INSPECTION_OPEN {
+		return nil, nil, domain.ErrInspectionClosed
+	}
+	inventory, err := u.InventoryRepo.GetById(inspection.InventoryID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return inspection, inventory, nil
+}
@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/utility/spreadsheet"
+)
+
+type InventoryImportUseCase interface {
+	CreateImport(fileName string, format string, table *spreadsheet.Table, createdBy string) (*domain.InventoryImport, error)
+	GetImportByID(importID int64) (*domain.InventoryImport, error)
+	GetAllImports(page int, pageSize int, sort string, filter repository.InventoryImportFilterOptions) ([]*domain.InventoryImport, int, error)
+	ResumeImport(importID int64) (*domain.InventoryImport, error)
+	ProcessImports() error
+}
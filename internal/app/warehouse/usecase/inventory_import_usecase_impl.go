@@ -0,0 +1,297 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/internal/utility/spreadsheet"
+)
+
+// importChunkSize is the number of rows validated between checkpoints.
+const importChunkSize = 500
+
+type InventoryImportUseCaseImpl struct {
+	Repo          repository.InventoryImportRepository
+	ScanRepo      repository.ScanRepository
+	ContainerRepo repository.ContainerRepository
+	ParameterRepo repository.InspectionParameterRepository
+}
+
+func NewInventoryImportUseCase(repo repository.InventoryImportRepository, scanRepo repository.ScanRepository, containerRepo repository.ContainerRepository, parameterRepo repository.InspectionParameterRepository) InventoryImportUseCase {
+	return &InventoryImportUseCaseImpl{Repo: repo, ScanRepo: scanRepo, ContainerRepo: containerRepo, ParameterRepo: parameterRepo}
+}
+
+// CreateImport stages the rows of an uploaded file. Validation and commit run
+// in the background through ProcessImports.
+func (u *InventoryImportUseCaseImpl) CreateImport(fileName string, format string, table *spreadsheet.Table, createdBy string) (*domain.InventoryImport, error) {
+	rows, err := domain.ParseImportRows(table)
+	if err != nil {
+		return nil, err
+	}
+
+	inventoryImport := &domain.InventoryImport{
+		FileName:  fileName,
+		Format:    format,
+		Status:    domain.IMPORT_PENDING,
+		CreatedBy: createdBy,
+		Rows:      rows,
+	}
+	if err := u.Repo.Create(inventoryImport); err != nil {
+		return nil, err
+	}
+	return u.Repo.GetById(inventoryImport.ID)
+}
+
+func (u *InventoryImportUseCaseImpl) GetImportByID(importID int64) (*domain.InventoryImport, error) {
+	return u.Repo.GetById(importID)
+}
+
+func (u *InventoryImportUseCaseImpl) GetAllImports(page int, pageSize int, sort string, filter repository.InventoryImportFilterOptions) ([]*domain.InventoryImport, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	imports, err := u.Repo.GetAll((page-1)*pageSize, pageSize, sort, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Fetch the total count of imports matching the filter
+	total, err := u.Repo.GetTotalCount(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return imports, total, nil
+}
+
+// ResumeImport queues an import that stopped on an infrastructure error. Rows
+// that were already validated are not checked again.
+func (u *InventoryImportUseCaseImpl) ResumeImport(importID int64) (*domain.InventoryImport, error) {
+	inventoryImport, err := u.Repo.GetById(importID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case inventoryImport.Status == domain.IMPORT_COMPLETED:
+		return nil, domain.ErrImportCompleted
+	case inventoryImport.ErrorCount > 0:
+		return nil, domain.ErrImportHasErrors
+	}
+	if err := u.Repo.SetStatus(importID, domain.IMPORT_PENDING, ""); err != nil {
+		return nil, err
+	}
+	return u.Repo.GetById(importID)
+}
+
+// ProcessImports runs every unfinished import to completion. An import that
+// fails is marked FAILED and does not stop the others.
+func (u *InventoryImportUseCaseImpl) ProcessImports() error {
+	importIDs, err := u.Repo.GetResumableIDs()
+	if err != nil {
+		return err
+	}
+	for _, importID := range importIDs {
+		if err := u.processImport(importID); err != nil {
+			log.Printf("inventory import %d failed: %v", importID, err)
+			if setErr := u.Repo.SetStatus(importID, domain.IMPORT_FAILED, err.Error()); setErr != nil {
+				return setErr
+			}
+		}
+	}
+	return nil
+}
+
+func (u *InventoryImportUseCaseImpl) processImport(importID int64) error {
+	if err := u.Repo.SetStatus(importID, domain.IMPORT_VALIDATING, ""); err != nil {
+		return err
+	}
+
+	validator := &importValidator{UseCase: u, ImportID: importID}
+	for {
+		rows, err := u.Repo.GetUnvalidatedRows(importID, importChunkSize)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			break
+		}
+		for _, row := range rows {
+			if err := validator.validate(row); err != nil {
+				return err
+			}
+		}
+		if err := u.Repo.SaveValidatedRows(importID, rows); err != nil {
+			return err
+		}
+	}
+
+	inventoryImport, err := u.Repo.GetById(importID)
+	if err != nil {
+		return err
+	}
+	if inventoryImport.ErrorCount > 0 {
+		return u.Repo.SetStatus(importID, domain.IMPORT_FAILED, fmt.Sprintf("%d of %d rows have errors, nothing was imported", inventoryImport.ErrorCount, inventoryImport.TotalRows))
+	}
+
+	if err := u.Repo.SetStatus(importID, domain.IMPORT_COMMITTING, ""); err != nil {
+		return err
+	}
+	return u.Repo.Commit(importID)
+}
+
+// importValidator resolves the rows of one import, caching lookups since
+// opening stock files repeat the same products, stores and containers.
+type importValidator struct {
+	UseCase    *InventoryImportUseCaseImpl
+	ImportID   int64
+	products   map[string]*domain.ScanProduct
+	stores     map[string]int64
+	containers map[string]*domain.Container
+	qcStatuses map[int64]domain.QCStatus
+	// newContainers are the containers to create from rows of this run
+	newContainers map[string]string
+}
+
+// validate fills in the resolved ids of row, or its error. Only lookup
+// failures other than "not found" are returned.
+func (v *importValidator) validate(row *domain.InventoryImportRow) error {
+	if v.products == nil {
+		v.products = make(map[string]*domain.ScanProduct)
+		v.stores = make(map[string]int64)
+		v.containers = make(map[string]*domain.Container)
+		v.qcStatuses = make(map[int64]domain.QCStatus)
+		v.newContainers = make(map[string]string)
+	}
+
+	message, err := v.resolve(row)
+	if err != nil {
+		return err
+	}
+	row.Error = message
+	return nil
+}
+
+func (v *importValidator) resolve(row *domain.InventoryImportRow) (string, error) {
+	if row.ProductCode == "" {
+		return "product_code is required", nil
+	}
+	product, ok := v.products[row.ProductCode]
+	if !ok {
+		var err error
+		product, err = v.UseCase.ScanRepo.GetProductByCode(row.ProductCode)
+		if err != nil && !errors.Is(err, customerrors.ErrResourceNotFound) {
+			return "", err
+		}
+		v.products[row.ProductCode] = product
+	}
+	if product == nil {
+		return "unknown product " + row.ProductCode, nil
+	}
+	if product.Serialized {
+		return "product " + row.ProductCode + " is serialized and must be received with its serial numbers", nil
+	}
+	row.ProductID = product.ID
+
+	if row.Unit == "" {
+		row.Unit = string(product.Unit)
+	} else if !strings.EqualFold(row.Unit, string(product.Unit)) {
+		return fmt.Sprintf("unit %s does not match product unit %s", row.Unit, product.Unit), nil
+	}
+	if _, err := row.ParsedQuantity(); err != nil {
+		return err.Error(), nil
+	}
+
+	if row.Store == "" {
+		return "store is required", nil
+	}
+	storeID, ok := v.stores[row.Store]
+	if !ok {
+		var err error
+		storeID, err = v.UseCase.Repo.GetStoreID(row.Store)
+		if err != nil && !errors.Is(err, customerrors.ErrResourceNotFound) {
+			return "", err
+		}
+		v.stores[row.Store] = storeID
+	}
+	if storeID == 0 {
+		return "unknown store " + row.Store, nil
+	}
+	row.StoreID = storeID
+
+	if message, err := v.resolveContainer(row); message != "" || err != nil {
+		return message, err
+	}
+
+	qcStatus, ok := v.qcStatuses[product.ID]
+	if !ok {
+		parameters, err := v.UseCase.ParameterRepo.GetActiveByProduct(product.ID)
+		if err != nil {
+			return "", err
+		}
+		qcStatus = domain.InitialQCStatus(parameters)
+		v.qcStatuses[product.ID] = qcStatus
+	}
+	row.QCStatus = qcStatus
+	return "", nil
+}
+
+// resolveContainer links the row to an existing container, or checks that
+// the container the import will create is given one type throughout.
+func (v *importValidator) resolveContainer(row *domain.InventoryImportRow) (string, error) {
+	if row.ContainerCode == "" {
+		row.ContainerType = ""
+		return "", nil
+	}
+
+	container, ok := v.containers[row.ContainerCode]
+	if !ok {
+		var err error
+		container, err = v.UseCase.ContainerRepo.GetByCode(row.ContainerCode)
+		if err != nil && !errors.Is(err, customerrors.ErrResourceNotFound) {
+			return "", err
+		}
+		v.containers[row.ContainerCode] = container
+	}
+
+	if container != nil {
+		if row.ContainerType != "" && row.ContainerType != string(container.Type) {
+			return fmt.Sprintf("container %s is a %s, not a %s", row.ContainerCode, container.Type, row.ContainerType), nil
+		}
+		if !container.Status.AcceptsStock() {
+			return "container " + row.ContainerCode + " is " + string(container.Status), nil
+		}
+		if container.StoreID != nil && *container.StoreID != row.StoreID {
+			return "container " + row.ContainerCode + " is in another store", nil
+		}
+		row.ContainerID = &container.ID
+		row.ContainerType = string(container.Type)
+		return "", nil
+	}
+
+	containerType, err := row.ContainerKind()
+	if err != nil {
+		return err.Error(), nil
+	}
+	row.ContainerType = string(containerType)
+	existingType, ok := v.newContainers[row.ContainerCode]
+	if !ok {
+		// Rows validated before a restart are only known to the database
+		if existingType, err = v.UseCase.Repo.GetNewContainerType(v.ImportID, row.ContainerCode); err != nil {
+			return "", err
+		}
+	}
+	if existingType != "" && existingType != row.ContainerType {
+		return fmt.Sprintf("container %s is created as a %s by an earlier row", row.ContainerCode, existingType), nil
+	}
+	v.newContainers[row.ContainerCode] = row.ContainerType
+	return "", nil
+}
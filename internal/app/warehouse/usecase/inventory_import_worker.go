@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// InventoryImportWorker processes uploaded imports in the background. It
+// also picks up imports left unfinished by a previous server run.
+type InventoryImportWorker struct {
+	UseCase  InventoryImportUseCase
+	Interval time.Duration
+	wake     chan struct{}
+}
+
+func NewInventoryImportWorker(useCase InventoryImportUseCase, interval time.Duration) *InventoryImportWorker {
+	return &InventoryImportWorker{UseCase: useCase, Interval: interval, wake: make(chan struct{}, 1)}
+}
+
+// Notify starts processing without waiting for the next interval.
+func (j *InventoryImportWorker) Notify() {
+	select {
+	case j.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run processes imports on startup, on Notify and every Interval until ctx is
+// cancelled. A zero Interval only reacts to Notify.
+func (j *InventoryImportWorker) Run(ctx context.Context) {
+	var tick <-chan time.Time
+	if j.Interval > 0 {
+		ticker := time.NewTicker(j.Interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		if err := j.UseCase.ProcessImports(); err != nil {
+			log.Printf("inventory import processing failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+		case <-j.wake:
+		}
+	}
+}
@@ -7,9 +7,14 @@ import (
 
 type InventoryUseCase interface {
 	CreateInventoryForRawMaterial(inventory *domain.InventoryFormRawMaterial) error
+	CreateInventoryForFinishedGoods(form *domain.InventoryFormFinishedGoods) (*domain.Inventory, error)
 	CreateInventory(inventory *domain.Inventory) error
 	UpdateInventory(inventory *domain.Inventory) error
-	DeleteInventory(inventoryID int64) error
+	DeleteInventory(inventoryID int64, version int64) error
 	GetInventoryByID(inventoryID int64) (*domain.Inventory, error)
 	GetAllInventories(page int, pageSize int, sort string, filter repository.InventoryFilterOptions) ([]*domain.Inventory, int, error)
+	GetSerialHistory(serial string) (*domain.SerialHistory, error)
+	GetAllSerials(page int, pageSize int, sort string, filter repository.SerialFilterOptions) ([]*domain.SerialNumber, int, error)
+	GetAllConsumptions(page int, pageSize int, sort string, filter repository.ConsumptionFilterOptions) ([]*domain.Consumption, int, error)
+	GetInventoriesByCursor(pageSize int, sort string, cursor string, filter repository.InventoryFilterOptions) ([]*domain.Inventory, string, error)
 }
@@ -1,17 +1,27 @@
 package usecase
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
 	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
 )
 
 type InventoryUseCaseImpl struct {
-	Repo          repository.InventoryRepository
-	RepoContainer repository.ContainerRepository
+	Repo              repository.InventoryRepository
+	RepoContainer     repository.ContainerRepository
+	ParameterRepo     repository.InspectionParameterRepository
+	SerialRepo        repository.SerialRepository
+	ProductionRepo    repository.ProductionRepository
+	BackflushStoreIDs []int64
 }
 
-func NewInventoryUseCase(repo repository.InventoryRepository) InventoryUseCase {
-	return &InventoryUseCaseImpl{Repo: repo}
+func NewInventoryUseCase(repo repository.InventoryRepository, parameterRepo repository.InspectionParameterRepository, serialRepo repository.SerialRepository, productionRepo repository.ProductionRepository, backflushStoreIDs []int64) InventoryUseCase {
+	return &InventoryUseCaseImpl{Repo: repo, ParameterRepo: parameterRepo, SerialRepo: serialRepo, ProductionRepo: productionRepo, BackflushStoreIDs: backflushStoreIDs}
 }
 
 func (u *InventoryUseCaseImpl) CreateInventoryForRawMaterial(inventory *domain.InventoryFormRawMaterial) error {
@@ -28,22 +38,241 @@ func (u *InventoryUseCaseImpl) CreateInventoryForRawMaterial(inventory *domain.I
 	return nil
 }
 
+// CreateInventoryForFinishedGoods receives a finished goods lot. Serials of
+// serialized products are taken from the form or generated from the product
+// pattern, and the lot quantity always equals the number of serials. With
+// Backflush set, the BOM components of the lot are consumed in the same
+// transaction.
+func (u *InventoryUseCaseImpl) CreateInventoryForFinishedGoods(form *domain.InventoryFormFinishedGoods) (*domain.Inventory, error) {
+	settings, err := u.SerialRepo.GetSerialSettings(form.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := domain.NewInventoryWithDefaults()
+	inventory.ProductID = form.ProductID
+	inventory.Batch = form.Batch
+	inventory.StoreID = form.StoreID
+	inventory.ContainerID = form.ContainerID
+	inventory.RackID = form.RackID
+	inventory.BinID = form.BinID
+	inventory.PalletID = form.PalletID
+	inventory.Machine = form.Machine
+	inventory.Shift = form.Shift
+	inventory.Supervisor = form.Supervisor
+	inventory.ShiftOverride = form.ShiftOverride
+	inventory.Quantity = form.Quantity
+	inventory.ExpiresAt = form.ExpiresAt
+	inventory.Unit = settings.Unit
+	inventory.StockInAt = time.Now()
+
+	if !settings.Serialized {
+		if len(form.Serials) > 0 || form.GenerateSerials {
+			return nil, errors.New("product is not serialized")
+		}
+		if form.Backflush {
+			if err := u.applyQCStatus(inventory); err != nil {
+				return nil, err
+			}
+			return inventory, u.receiveWithBackflush(inventory, nil, form)
+		}
+		return inventory, u.CreateInventory(inventory)
+	}
+
+	serials := form.Serials
+	if form.GenerateSerials {
+		if len(serials) > 0 {
+			return nil, errors.New("serials cannot be both scanned and generated")
+		}
+		if form.Quantity.Sign() <= 0 || form.Quantity.Precision() > 0 {
+			return nil, errors.New("quantity must be a whole number of units to generate serials")
+		}
+		if form.Quantity.Cmp(customtypes.NewDecimalFromInt(domain.MaxGeneratedSerials)) > 0 {
+			return nil, domain.ErrTooManySerials
+		}
+		count := int(form.Quantity.IntPart())
+		start, err := u.SerialRepo.NextSequence(settings.ProductID, count)
+		if err != nil {
+			return nil, err
+		}
+		serials, err = domain.GenerateSerials(settings.Pattern, settings, form.Batch, inventory.StockInAt, start, count)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(serials) == 0 {
+		return nil, domain.ErrSerialsRequired
+	}
+	seen := make(map[string]bool, len(serials))
+	for i, serial := range serials {
+		serial = strings.TrimSpace(serial)
+		if serial == "" {
+			return nil, errors.New("serial numbers cannot be empty")
+		}
+		if seen[serial] {
+			return nil, fmt.Errorf("%w: %s", domain.ErrDuplicateSerial, serial)
+		}
+		seen[serial] = true
+		serials[i] = serial
+	}
+	if inventory.Quantity.IsZero() {
+		inventory.Quantity = customtypes.NewDecimalFromInt(int64(len(serials)))
+	}
+	if err := domain.ValidateSerialCount(inventory.Quantity, len(serials)); err != nil {
+		return nil, err
+	}
+	if err := u.applyQCStatus(inventory); err != nil {
+		return nil, err
+	}
+	if form.Backflush {
+		return inventory, u.receiveWithBackflush(inventory, serials, form)
+	}
+	return inventory, u.SerialRepo.Receive(inventory, serials, form.ReceivedBy)
+}
+
+// receiveWithBackflush consumes the components of the produced quantity from
+// the stores named in the form, else the configured line-side stores, else
+// the store the lot is received into.
+func (u *InventoryUseCaseImpl) receiveWithBackflush(inventory *domain.Inventory, serials []string, form *domain.InventoryFormFinishedGoods) error {
+	storeIDs := form.BackflushStoreIDs
+	if len(storeIDs) == 0 {
+		storeIDs = u.BackflushStoreIDs
+	}
+	if len(storeIDs) == 0 && inventory.StoreID != nil {
+		storeIDs = []int64{*inventory.StoreID}
+	}
+	if len(storeIDs) == 0 {
+		return errors.New("no store to backflush components from")
+	}
+
+	components, err := u.ProductionRepo.GetBackflushComponents(inventory.ProductID, inventory.StockInAt)
+	if err != nil {
+		return err
+	}
+	if len(components) == 0 {
+		return errors.New("product has no bill of materials to backflush")
+	}
+	requirements := make([]*domain.BackflushRequirement, 0, len(components))
+	for _, component := range components {
+		required, err := component.Required(inventory.Quantity)
+		if err != nil {
+			return err
+		}
+		requirements = append(requirements, &domain.BackflushRequirement{
+			ComponentID:   component.ComponentID,
+			ComponentCode: component.ComponentCode,
+			Quantity:      required,
+			Unit:          component.Unit,
+		})
+	}
+
+	_, err = u.ProductionRepo.ReceiveWithBackflush(inventory, serials, form.ReceivedBy, requirements, storeIDs)
+	return err
+}
+
+// CreateInventory puts lots of products that have mandatory inspection
+// parameters on QC hold until an inspector releases them. Serialized products
+// must be received through CreateInventoryForFinishedGoods.
 func (u *InventoryUseCaseImpl) CreateInventory(inventory *domain.Inventory) error {
+	settings, err := u.SerialRepo.GetSerialSettings(inventory.ProductID)
+	if err != nil {
+		return err
+	}
+	if settings.Serialized {
+		return domain.ErrSerialsRequired
+	}
+	if err := u.applyQCStatus(inventory); err != nil {
+		return err
+	}
 	return u.Repo.Create(inventory)
 }
 
+// applyQCStatus derives the QC status of a new lot from the inspection
+// parameters of its product; a status sent by the client is not trusted.
+func (u *InventoryUseCaseImpl) applyQCStatus(inventory *domain.Inventory) error {
+	parameters, err := u.ParameterRepo.GetActiveByProduct(inventory.ProductID)
+	if err != nil {
+		return err
+	}
+	inventory.QCStatus = domain.InitialQCStatus(parameters)
+	if err := inventory.ValidateQCStatus(); err != nil {
+		return err
+	}
+	if !inventory.IsReleased() && inventory.Status != domain.STOCK_IN {
+		return domain.ErrInventoryNotReleased
+	}
+	return nil
+}
+
+// UpdateInventory keeps the QC status of the stored lot, which only changes
+// through the inspection workflow.
 func (u *InventoryUseCaseImpl) UpdateInventory(inventory *domain.Inventory) error {
 	// Check for an existing inventory with the specified ID
-	// existingInventory, err := u.Repo.GetById(inventory.ID)
-	_, err := u.Repo.GetById(inventory.ID)
+	existingInventory, err := u.Repo.GetById(inventory.ID)
+	if err != nil {
+		return err
+	}
+	if err := existingInventory.ValidateTransition(inventory.Status); err != nil {
+		return err
+	}
+	inventory.QCStatus = existingInventory.QCStatus
+
+	serials, err := u.SerialRepo.CountByInventory(inventory.ID)
 	if err != nil {
 		return err
 	}
-	return u.Repo.Update(inventory)
+	var event domain.SerialEventType
+	if serials > 0 {
+		if err := domain.ValidateSerialCount(inventory.Quantity, serials); err != nil {
+			return err
+		}
+		event = domain.SerialEventFor(existingInventory, inventory)
+	}
+	return u.Repo.UpdateWithSerialEvent(inventory, event, inventory.Supervisor)
+}
+
+func (u *InventoryUseCaseImpl) DeleteInventory(inventoryID int64, version int64) error {
+	inventory, err := u.Repo.GetById(inventoryID)
+	if err != nil {
+		return err
+	}
+	if inventory.Status == domain.STOCK_IN_TRANSIT {
+		return domain.ErrInventoryInTransit
+	}
+	serials, err := u.SerialRepo.CountByInventory(inventoryID)
+	if err != nil {
+		return err
+	}
+	if serials > 0 {
+		return domain.ErrInventoryHasSerials
+	}
+	return u.Repo.Delete(inventoryID, version)
+}
+
+func (u *InventoryUseCaseImpl) GetSerialHistory(serial string) (*domain.SerialHistory, error) {
+	return u.SerialRepo.GetBySerial(serial)
 }
 
-func (u *InventoryUseCaseImpl) DeleteInventory(inventoryID int64) error {
-	return u.Repo.Delete(inventoryID)
+func (u *InventoryUseCaseImpl) GetAllSerials(page int, pageSize int, sort string, filter repository.SerialFilterOptions) ([]*domain.SerialNumber, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	serials, err := u.SerialRepo.GetAll((page-1)*pageSize, pageSize, sort, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Fetch the total count of serials matching the filter
+	total, err := u.SerialRepo.GetTotalCount(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return serials, total, nil
 }
 
 func (u *InventoryUseCaseImpl) GetInventoryByID(inventoryID int64) (*domain.Inventory, error) {
@@ -71,3 +300,31 @@ func (u *InventoryUseCaseImpl) GetAllInventories(page int, pageSize int, sort st
 
 	return inventories, total, nil
 }
+
+func (u *InventoryUseCaseImpl) GetInventoriesByCursor(pageSize int, sort string, cursor string, filter repository.InventoryFilterOptions) ([]*domain.Inventory, string, error) {
+	if pageSize < 1 || pageSize > 1000 {
+		pageSize = 100
+	}
+	return u.Repo.GetAllByCursor(pageSize, sort, cursor, filter)
+}
+
+func (u *InventoryUseCaseImpl) GetAllConsumptions(page int, pageSize int, sort string, filter repository.ConsumptionFilterOptions) ([]*domain.Consumption, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	consumptions, err := u.ProductionRepo.GetAll((page-1)*pageSize, pageSize, sort, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := u.ProductionRepo.GetTotalCount(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return consumptions, total, nil
+}
@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/utility/label"
+)
+
+type LabelUseCase interface {
+	CreateLabelTemplate(labelTemplate *domain.LabelTemplate) error
+	UpdateLabelTemplate(labelTemplate *domain.LabelTemplate) error
+	DeleteLabelTemplate(labelTemplateID int64, version int64) error
+	GetLabelTemplateByID(labelTemplateID int64) (*domain.LabelTemplate, error)
+	GetAllLabelTemplates(page int, pageSize int, sort string, filter repository.LabelTemplateFilterOptions) ([]*domain.LabelTemplate, int, error)
+
+	GetContainerLabel(containerID int64, templateName string) (*label.Layout, *label.Data, error)
+	RecordContainerLabelPrinted(containerID int64, templateName string) error
+	GetInventoryLabel(inventoryID int64, templateName string) (*label.Layout, *label.Data, error)
+	GetProductLabel(productID int64, templateName string) (*label.Layout, *label.Data, error)
+}
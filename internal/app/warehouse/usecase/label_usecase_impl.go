@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+	"github.com/vamika-digital/wms-api-server/internal/utility/gs1"
+	"github.com/vamika-digital/wms-api-server/internal/utility/label"
+)
+
+var ErrLabelTemplateKind = errors.New("label template is for a different kind of label")
+
+type LabelUseCaseImpl struct {
+	Repo          repository.LabelRepository
+	ContainerRepo repository.ContainerRepository
+	InventoryRepo repository.InventoryRepository
+}
+
+func NewLabelUseCase(repo repository.LabelRepository, containerRepo repository.ContainerRepository, inventoryRepo repository.InventoryRepository) LabelUseCase {
+	return &LabelUseCaseImpl{Repo: repo, ContainerRepo: containerRepo, InventoryRepo: inventoryRepo}
+}
+
+func (u *LabelUseCaseImpl) CreateLabelTemplate(labelTemplate *domain.LabelTemplate) error {
+	return u.Repo.Create(labelTemplate)
+}
+
+func (u *LabelUseCaseImpl) UpdateLabelTemplate(labelTemplate *domain.LabelTemplate) error {
+	// Check for an existing label template with the specified ID
+	_, err := u.Repo.GetById(labelTemplate.ID)
+	if err != nil {
+		return err
+	}
+	return u.Repo.Update(labelTemplate)
+}
+
+func (u *LabelUseCaseImpl) DeleteLabelTemplate(labelTemplateID int64, version int64) error {
+	return u.Repo.Delete(labelTemplateID, version)
+}
+
+func (u *LabelUseCaseImpl) GetLabelTemplateByID(labelTemplateID int64) (*domain.LabelTemplate, error) {
+	return u.Repo.GetById(labelTemplateID)
+}
+
+func (u *LabelUseCaseImpl) GetAllLabelTemplates(page int, pageSize int, sort string, filter repository.LabelTemplateFilterOptions) ([]*domain.LabelTemplate, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	labelTemplates, err := u.Repo.GetAll((page-1)*pageSize, pageSize, sort, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Fetch the total count of label templates matching the filter
+	total, err := u.Repo.GetTotalCount(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return labelTemplates, total, nil
+}
+
+func (u *LabelUseCaseImpl) GetContainerLabel(containerID int64, templateName string) (*label.Layout, *label.Data, error) {
+	layout, err := u.getLayout(domain.LABEL_CONTAINER, templateName)
+	if err != nil {
+		return nil, nil, err
+	}
+	container, err := u.ContainerRepo.GetById(containerID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data := &label.Data{Fields: map[string]string{
+		"ID":      strconv.FormatInt(container.ID, 10),
+		"Type":    string(container.Type),
+		"Code":    string(container.Code),
+		"Name":    string(container.Name),
+		"Address": string(container.Address),
+	}}
+	return layout, data, nil
+}
+
+// RecordContainerLabelPrinted adds a print of the label of the container to
+// its timeline. It is called once the label has been rendered for printing.
+func (u *LabelUseCaseImpl) RecordContainerLabelPrinted(containerID int64, templateName string) error {
+	if templateName == "" {
+		templateName = string(domain.DefaultLabelTemplates[domain.LABEL_CONTAINER].Name)
+	}
+	return u.ContainerRepo.RecordEvent(&domain.ContainerEvent{
+		ContainerID: containerID,
+		Event:       domain.CONTAINER_LABEL_PRINTED,
+		Note:        customtypes.NullableString(templateName),
+	})
+}
+
+// GetInventoryLabel prints a lot with its batch, quantity and expiry encoded
+// as GS1 application identifiers.
+func (u *LabelUseCaseImpl) GetInventoryLabel(inventoryID int64, templateName string) (*label.Layout, *label.Data, error) {
+	layout, err := u.getLayout(domain.LABEL_INVENTORY, templateName)
+	if err != nil {
+		return nil, nil, err
+	}
+	inventory, err := u.InventoryRepo.GetById(inventoryID)
+	if err != nil {
+		return nil, nil, err
+	}
+	product, err := u.Repo.GetProduct(inventory.ProductID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data := &label.Data{Fields: map[string]string{
+		"ID":          strconv.FormatInt(inventory.ID, 10),
+		"Batch":       inventory.Batch,
+		"Quantity":    inventory.Quantity.String(),
+		"Unit":        inventory.Unit,
+		"Status":      string(inventory.Status),
+		"QCStatus":    string(inventory.QCStatus),
+		"StockInAt":   inventory.StockInAt.Format("2006-01-02"),
+		"ProductCode": product.Code,
+		"ProductName": product.Name,
+	}}
+	if inventory.Batch != "" {
+		data.GS1 = append(data.GS1, gs1.Batch(inventory.Batch))
+	}
+	quantity, err := gs1.Quantity(inventory.Quantity, inventory.Unit)
+	if err != nil {
+		return nil, nil, err
+	}
+	data.GS1 = append(data.GS1, quantity)
+	if inventory.ExpiresAt != nil {
+		data.Fields["ExpiresAt"] = inventory.ExpiresAt.Format("2006-01-02")
+		data.GS1 = append(data.GS1, gs1.Expiry(*inventory.ExpiresAt))
+	}
+	return layout, data, nil
+}
+
+func (u *LabelUseCaseImpl) GetProductLabel(productID int64, templateName string) (*label.Layout, *label.Data, error) {
+	layout, err := u.getLayout(domain.LABEL_PRODUCT, templateName)
+	if err != nil {
+		return nil, nil, err
+	}
+	product, err := u.Repo.GetProduct(productID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data := &label.Data{Fields: map[string]string{
+		"ID":          strconv.FormatInt(product.ID, 10),
+		"Type":        product.Type,
+		"Code":        product.Code,
+		"Name":        product.Name,
+		"Description": product.Description,
+		"Unit":        product.Unit,
+	}}
+	return layout, data, nil
+}
+
+// getLayout returns the named template, or the built-in default of kind when
+// no name is given.
+func (u *LabelUseCaseImpl) getLayout(kind domain.LabelKind, templateName string) (*label.Layout, error) {
+	if templateName == "" {
+		return domain.DefaultLabelTemplates[kind].Layout(), nil
+	}
+	labelTemplate, err := u.Repo.GetByName(templateName)
+	if err != nil {
+		return nil, err
+	}
+	if labelTemplate.Kind != kind {
+		return nil, ErrLabelTemplateKind
+	}
+	return labelTemplate.Layout(), nil
+}
@@ -0,0 +1,11 @@
+package usecase
+
+import "github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+
+type NumberSeriesUseCase interface {
+	CreateNumberSeries(series *domain.NumberSeries) error
+	UpdateNumberSeries(series *domain.NumberSeries) error
+	DeleteNumberSeries(seriesID int64, version int64) error
+	GetNumberSeriesByID(seriesID int64) (*domain.NumberSeries, error)
+	GetAllNumberSeries() ([]*domain.NumberSeries, error)
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+)
+
+type NumberSeriesUseCaseImpl struct {
+	Repo repository.NumberSeriesRepository
+}
+
+func NewNumberSeriesUseCase(repo repository.NumberSeriesRepository) NumberSeriesUseCase {
+	return &NumberSeriesUseCaseImpl{Repo: repo}
+}
+
+func (u *NumberSeriesUseCaseImpl) CreateNumberSeries(series *domain.NumberSeries) error {
+	return u.Repo.Create(series)
+}
+
+func (u *NumberSeriesUseCaseImpl) UpdateNumberSeries(series *domain.NumberSeries) error {
+	// Check for an existing number series with the specified ID
+	_, err := u.Repo.GetById(series.ID)
+	if err != nil {
+		return err
+	}
+	return u.Repo.Update(series)
+}
+
+func (u *NumberSeriesUseCaseImpl) DeleteNumberSeries(seriesID int64, version int64) error {
+	return u.Repo.Delete(seriesID, version)
+}
+
+func (u *NumberSeriesUseCaseImpl) GetNumberSeriesByID(seriesID int64) (*domain.NumberSeries, error) {
+	return u.Repo.GetById(seriesID)
+}
+
+func (u *NumberSeriesUseCaseImpl) GetAllNumberSeries() ([]*domain.NumberSeries, error) {
+	return u.Repo.GetAll()
+}
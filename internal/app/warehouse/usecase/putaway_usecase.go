@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+)
+
+type PutawayUseCase interface {
+	CreatePutawayRule(rule *domain.PutawayRule) error
+	UpdatePutawayRule(rule *domain.PutawayRule) error
+	DeletePutawayRule(ruleID int64, version int64) error
+	GetPutawayRuleByID(ruleID int64) (*domain.PutawayRule, error)
+	GetAllPutawayRules(page int, pageSize int, sort string, filter repository.PutawayRuleFilterOptions) ([]*domain.PutawayRule, int, error)
+
+	Suggest(request *domain.PutawayRequest) ([]*domain.PutawayCandidate, error)
+	Confirm(confirmation *domain.PutawayConfirmation) (*domain.PutawayResult, error)
+}
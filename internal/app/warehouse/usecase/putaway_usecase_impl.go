@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+)
+
+type PutawayUseCaseImpl struct {
+	Repo          repository.PutawayRepository
+	ContainerRepo repository.ContainerRepository
+	InventoryRepo repository.InventoryRepository
+}
+
+func NewPutawayUseCase(repo repository.PutawayRepository, containerRepo repository.ContainerRepository, inventoryRepo repository.InventoryRepository) PutawayUseCase {
+	return &PutawayUseCaseImpl{Repo: repo, ContainerRepo: containerRepo, InventoryRepo: inventoryRepo}
+}
+
+func (u *PutawayUseCaseImpl) CreatePutawayRule(rule *domain.PutawayRule) error {
+	return u.Repo.Create(rule)
+}
+
+func (u *PutawayUseCaseImpl) UpdatePutawayRule(rule *domain.PutawayRule) error {
+	// Check for an existing putaway rule with the specified ID
+	_, err := u.Repo.GetById(rule.ID)
+	if err != nil {
+		return err
+	}
+	return u.Repo.Update(rule)
+}
+
+func (u *PutawayUseCaseImpl) DeletePutawayRule(ruleID int64, version int64) error {
+	return u.Repo.Delete(ruleID, version)
+}
+
+func (u *PutawayUseCaseImpl) GetPutawayRuleByID(ruleID int64) (*domain.PutawayRule, error) {
+	return u.Repo.GetById(ruleID)
+}
+
+func (u *PutawayUseCaseImpl) GetAllPutawayRules(page int, pageSize int, sort string, filter repository.PutawayRuleFilterOptions) ([]*domain.PutawayRule, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	rules, err := u.Repo.GetAll((page-1)*pageSize, pageSize, sort, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Fetch the total count of putaway rules matching the filter
+	total, err := u.Repo.GetTotalCount(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return rules, total, nil
+}
+
+// Suggest ranks the containers the stock of the request may go to. Every
+// matching rule contributes its candidates in priority order.
+func (u *PutawayUseCaseImpl) Suggest(request *domain.PutawayRequest) ([]*domain.PutawayCandidate, error) {
+	limit := request.Limit
+	if limit < 1 || limit > 100 {
+		limit = domain.DefaultPutawaySuggestions
+	}
+	return u.suggest(request, limit)
+}
+
+func (u *PutawayUseCaseImpl) suggest(request *domain.PutawayRequest, limit int) ([]*domain.PutawayCandidate, error) {
+	var subject *domain.PutawaySubject
+	var err error
+	switch {
+	case request.ContainerID > 0 && request.InventoryID > 0:
+		return nil, domain.ErrPutawaySubject
+	case request.ContainerID > 0:
+		subject, err = u.Repo.GetContainerSubject(request.ContainerID)
+	case request.InventoryID > 0:
+		subject, err = u.Repo.GetInventorySubject(request.InventoryID)
+	default:
+		return nil, domain.ErrPutawaySubject
+	}
+	if err != nil {
+		return nil, err
+	}
+	if subject.StoreID == nil {
+		subject.StoreID = request.StoreID
+	}
+	if subject.StoreID == nil {
+		return nil, domain.ErrPutawayStoreRequired
+	}
+
+	rules, err := u.Repo.GetActiveRules()
+	if err != nil {
+		return nil, err
+	}
+	stock, err := u.Repo.GetStock(*subject.StoreID)
+	if err != nil {
+		return nil, err
+	}
+	var suggestions [][]*domain.PutawayCandidate
+	for _, rule := range rules {
+		if rule.Matches(subject) {
+			suggestions = append(suggestions, rule.Suggest(subject, stock))
+		}
+	}
+	return domain.RankPutawayCandidates(suggestions, limit), nil
+}
+
+// Confirm moves the stock to the chosen target. The rules are checked again
+// in the transaction of the move, with the target locked, since stock may
+// have moved since the suggestion.
+func (u *PutawayUseCaseImpl) Confirm(confirmation *domain.PutawayConfirmation) (*domain.PutawayResult, error) {
+	if (confirmation.ContainerID > 0) == (confirmation.InventoryID > 0) {
+		return nil, domain.ErrPutawaySubject
+	}
+	rules, err := u.Repo.GetActiveRules()
+	if err != nil {
+		return nil, err
+	}
+
+	if confirmation.ContainerID > 0 {
+		warnings, err := u.Repo.PutContainer(confirmation.ContainerID, confirmation.TargetID, confirmation.Version, rules, confirmation.StoreID)
+		if err != nil {
+			return nil, err
+		}
+		container, err := u.ContainerRepo.GetById(confirmation.ContainerID)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.PutawayResult{Container: container, Warnings: warnings}, nil
+	}
+
+	inventory, err := u.InventoryRepo.GetById(confirmation.InventoryID)
+	if err != nil {
+		return nil, err
+	}
+	if confirmation.Version > 0 {
+		inventory.Version = confirmation.Version
+	}
+	inventory.ContainerID = &confirmation.TargetID
+	if err := u.Repo.PutInventory(inventory, rules, confirmation.StoreID, confirmation.MovedBy); err != nil {
+		return nil, err
+	}
+	return &domain.PutawayResult{Inventory: inventory, Warnings: inventory.Warnings}, nil
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+)
+
+type ReportUseCase interface {
+	GetInventoryAging(options AgingReportOptions) (*domain.AgingReport, error)
+	GetUtilization(level string, storeID int64) (*domain.UtilizationReport, error)
+	GetStockByCategory(storeID int64) (*domain.CategoryStockReport, error)
+}
+
+type AgingReportOptions struct {
+	Buckets        []domain.AgingBucket
+	GroupBy        []string
+	SlowMovingDays int
+	AsOf           time.Time
+	Filter         repository.ReportFilterOptions
+	// CategoryLevel rolls the category grouping up to the ancestors at that
+	// level, 1 being the root categories. Zero keeps the primary category.
+	CategoryLevel int
+}
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+type ReportUseCaseImpl struct {
+	Repo repository.ReportRepository
+}
+
+func NewReportUseCase(repo repository.ReportRepository) ReportUseCase {
+	return &ReportUseCaseImpl{Repo: repo}
+}
+
+// GetUtilization reports the capacity used by every rack, zone or store,
+// optionally limited to one store.
+func (u *ReportUseCaseImpl) GetUtilization(level string, storeID int64) (*domain.UtilizationReport, error) {
+	if level == "" {
+		level = domain.UTILIZATION_LEVEL_RACK
+	}
+	data, err := u.Repo.GetUtilizationData(storeID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.BuildUtilizationReport(level, data.Containers, data.Loads, data.StoreNames)
+}
+
+// GetStockByCategory rolls the stock on hand of storeID, or of every store
+// when it is zero, up the product category tree.
+func (u *ReportUseCaseImpl) GetStockByCategory(storeID int64) (*domain.CategoryStockReport, error) {
+	categories, err := u.Repo.GetProductCategories()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := u.Repo.GetCategoryStock(storeID)
+	if err != nil {
+		return nil, err
+	}
+	report := domain.BuildCategoryStockReport(categories, rows)
+	report.AsOf = time.Now()
+	if storeID > 0 {
+		report.StoreID = &storeID
+	}
+	return report, nil
+}
+
+func (u *ReportUseCaseImpl) GetInventoryAging(options AgingReportOptions) (*domain.AgingReport, error) {
+	if len(options.GroupBy) == 0 {
+		options.GroupBy = []string{domain.AGING_GROUP_PRODUCT, domain.AGING_GROUP_STORE}
+	}
+	grouping := map[string]bool{}
+	for _, group := range options.GroupBy {
+		switch group {
+		case domain.AGING_GROUP_PRODUCT, domain.AGING_GROUP_STORE, domain.AGING_GROUP_PRODUCT_TYPE, domain.AGING_GROUP_CATEGORY:
+			grouping[group] = true
+		default:
+			return nil, errors.New("invalid group_by: " + group)
+		}
+	}
+	if len(options.Buckets) == 0 {
+		options.Buckets, _ = domain.ParseAgingBuckets("")
+	}
+	if options.AsOf.IsZero() {
+		options.AsOf = time.Now()
+	}
+
+	lots, err := u.Repo.GetAgingLots(options.Filter)
+	if err != nil {
+		return nil, err
+	}
+	categories := map[int64]*domain.ProductCategory{}
+	if grouping[domain.AGING_GROUP_CATEGORY] {
+		tree, err := u.Repo.GetProductCategories()
+		if err != nil {
+			return nil, err
+		}
+		for _, category := range tree {
+			categories[category.ID] = category
+		}
+		for _, lot := range lots {
+			if lot.CategoryID == nil {
+				continue
+			}
+			if category, ok := categories[*lot.CategoryID]; ok {
+				rolledUp := category.AtLevel(options.CategoryLevel)
+				lot.CategoryID = &rolledUp
+			}
+		}
+	}
+
+	report := &domain.AgingReport{
+		AsOf:           options.AsOf,
+		GroupBy:        options.GroupBy,
+		CategoryLevel:  options.CategoryLevel,
+		Buckets:        options.Buckets,
+		SlowMovingDays: options.SlowMovingDays,
+		Rows:           []*domain.AgingReportRow{},
+	}
+	rowsByKey := map[string]*domain.AgingReportRow{}
+	for _, lot := range lots {
+		// Quantities in different units are never added together
+		key := lot.Unit
+		if grouping[domain.AGING_GROUP_PRODUCT] {
+			key += fmt.Sprintf("|p%d", lot.ProductID)
+		}
+		if grouping[domain.AGING_GROUP_STORE] {
+			key += fmt.Sprintf("|s%d", lot.StoreID)
+		}
+		if grouping[domain.AGING_GROUP_PRODUCT_TYPE] {
+			key += "|t" + lot.ProductType
+		}
+		if grouping[domain.AGING_GROUP_CATEGORY] && lot.CategoryID != nil {
+			key += fmt.Sprintf("|c%d", *lot.CategoryID)
+		}
+
+		row, ok := rowsByKey[key]
+		if !ok {
+			row = newAgingReportRow(lot, grouping, len(options.Buckets))
+			if grouping[domain.AGING_GROUP_CATEGORY] && lot.CategoryID != nil {
+				row.CategoryID = lot.CategoryID
+				if category, ok := categories[*lot.CategoryID]; ok {
+					row.CategoryName = category.Name
+				}
+			}
+			rowsByKey[key] = row
+			report.Rows = append(report.Rows, row)
+		}
+
+		days := int(options.AsOf.Sub(lot.StockInAt).Hours() / 24)
+		if days < 0 {
+			days = 0
+		}
+		for i, bucket := range options.Buckets {
+			if bucket.Contains(days) {
+				row.Buckets[i] = row.Buckets[i].Add(lot.Quantity)
+				break
+			}
+		}
+		row.TotalQuantity = row.TotalQuantity.Add(lot.Quantity)
+		row.LotCount++
+		if row.OldestStockInAt.IsZero() || lot.StockInAt.Before(row.OldestStockInAt) {
+			row.OldestStockInAt = lot.StockInAt
+		}
+		if lot.LastMovementAt.After(row.LastMovementAt) {
+			row.LastMovementAt = lot.LastMovementAt
+		}
+	}
+
+	if options.SlowMovingDays > 0 {
+		threshold := options.AsOf.AddDate(0, 0, -options.SlowMovingDays)
+		for _, row := range report.Rows {
+			row.SlowMoving = row.LastMovementAt.Before(threshold)
+		}
+	}
+
+	sort.SliceStable(report.Rows, func(i, j int) bool {
+		a, b := report.Rows[i], report.Rows[j]
+		if a.ProductCode != b.ProductCode {
+			return strings.Compare(a.ProductCode, b.ProductCode) < 0
+		}
+		if a.StoreName != b.StoreName {
+			return strings.Compare(a.StoreName, b.StoreName) < 0
+		}
+		if a.CategoryName != b.CategoryName {
+			return strings.Compare(a.CategoryName, b.CategoryName) < 0
+		}
+		return a.ProductType < b.ProductType
+	})
+	return report, nil
+}
+
+func newAgingReportRow(lot *domain.AgingLot, grouping map[string]bool, bucketCount int) *domain.AgingReportRow {
+	row := &domain.AgingReportRow{
+		Unit:    lot.Unit,
+		Buckets: make([]customtypes.Decimal, bucketCount),
+	}
+	if grouping[domain.AGING_GROUP_PRODUCT] {
+		productID := lot.ProductID
+		row.ProductID = &productID
+		row.ProductCode = lot.ProductCode
+		row.ProductName = lot.ProductName
+		row.ProductType = lot.ProductType
+	}
+	if grouping[domain.AGING_GROUP_STORE] {
+		storeID := lot.StoreID
+		row.StoreID = &storeID
+		row.StoreName = lot.StoreName
+	}
+	if grouping[domain.AGING_GROUP_PRODUCT_TYPE] {
+		row.ProductType = lot.ProductType
+	}
+	return row
+}
@@ -0,0 +1,7 @@
+package usecase
+
+import "github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+
+type ScanUseCase interface {
+	Resolve(value string) (*domain.ScanResult, error)
+}
@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+	"github.com/vamika-digital/wms-api-server/internal/utility/gs1"
+)
+
+var ErrEmptyScan = errors.New("scanned value is empty")
+
+// scanContentsLimit caps the lots returned for a container or batch; a
+// handheld screen cannot show more than that anyway.
+const scanContentsLimit = 100
+
+type ScanUseCaseImpl struct {
+	Repo          repository.ScanRepository
+	ContainerRepo repository.ContainerRepository
+	InventoryRepo repository.InventoryRepository
+	SerialRepo    repository.SerialRepository
+}
+
+func NewScanUseCase(repo repository.ScanRepository, containerRepo repository.ContainerRepository, inventoryRepo repository.InventoryRepository, serialRepo repository.SerialRepository) ScanUseCase {
+	return &ScanUseCaseImpl{Repo: repo, ContainerRepo: containerRepo, InventoryRepo: inventoryRepo, SerialRepo: serialRepo}
+}
+
+type scanResolver func(value string) (*domain.ScanResult, error)
+
+// Resolve finds what a scanned value refers to. GS1 payloads are resolved by
+// their serial, SSCC or batch; plain values are tried as an inventory label,
+// container code, product code or raw code, serial and batch, in that order.
+func (u *ScanUseCaseImpl) Resolve(value string) (*domain.ScanResult, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, ErrEmptyScan
+	}
+
+	if gs1.IsElementString(value) {
+		return u.resolveElementString(value)
+	}
+
+	for _, resolve := range []scanResolver{u.resolveInventoryLabel, u.resolveContainer, u.resolveProduct, u.resolveSerial, u.resolveBatch} {
+		result, err := resolve(value)
+		if errors.Is(err, customerrors.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.Value = value
+		return result, nil
+	}
+	return nil, customerrors.ErrResourceNotFound
+}
+
+func (u *ScanUseCaseImpl) resolveElementString(value string) (*domain.ScanResult, error) {
+	elements, err := gs1.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *domain.ScanResult
+	if serial, ok := elements.Get(gs1.AI_SERIAL); ok {
+		result, err = u.resolveSerial(serial)
+	} else if sscc, ok := elements.Get(gs1.AI_SSCC); ok {
+		result, err = u.resolveContainer(sscc)
+	} else if batch, ok := elements.Get(gs1.AI_BATCH); ok {
+		result, err = u.resolveBatch(batch)
+	} else {
+		err = customerrors.ErrResourceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.Value = value
+	result.GS1 = make(map[string]string, len(elements))
+	for _, element := range elements {
+		result.GS1[element.AI] = element.Value
+	}
+	return result, nil
+}
+
+func (u *ScanUseCaseImpl) resolveInventoryLabel(value string) (*domain.ScanResult, error) {
+	if !strings.HasPrefix(value, domain.InventoryLabelPrefix) {
+		return nil, customerrors.ErrResourceNotFound
+	}
+	inventoryID, err := strconv.ParseInt(strings.TrimPrefix(value, domain.InventoryLabelPrefix), 10, 64)
+	if err != nil {
+		return nil, customerrors.ErrResourceNotFound
+	}
+	inventory, err := u.InventoryRepo.GetById(inventoryID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ScanResult{EntityType: domain.SCAN_INVENTORY, Entity: inventory, Actions: domain.InventoryScanActions(inventory)}, nil
+}
+
+func (u *ScanUseCaseImpl) resolveContainer(code string) (*domain.ScanResult, error) {
+	container, err := u.ContainerRepo.GetByCode(code)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := repository.InventoryFilterOptions{
+		Statuses:       []domain.InventoryType{domain.STOCK_IN, domain.STOCK_RESERVED},
+		UnderContainer: code,
+	}
+	contents, err := u.InventoryRepo.GetAll(0, scanContentsLimit, "", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ScanResult{
+		EntityType: domain.SCAN_CONTAINER,
+		Entity:     container,
+		Actions:    domain.ContainerScanActions(container, contents),
+		Contents:   contents,
+	}, nil
+}
+
+func (u *ScanUseCaseImpl) resolveProduct(code string) (*domain.ScanResult, error) {
+	product, err := u.Repo.GetProductByCode(code)
+	if err != nil {
+		return nil, err
+	}
+	locations, err := u.Repo.GetStockLocations(product.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ScanResult{
+		EntityType: domain.SCAN_PRODUCT,
+		Entity:     product,
+		Actions:    domain.ProductScanActions(product, locations),
+		Locations:  locations,
+	}, nil
+}
+
+func (u *ScanUseCaseImpl) resolveSerial(serial string) (*domain.ScanResult, error) {
+	history, err := u.SerialRepo.GetBySerial(serial)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ScanResult{EntityType: domain.SCAN_SERIAL, Entity: history, Actions: domain.SerialScanActions(history.SerialNumber)}, nil
+}
+
+func (u *ScanUseCaseImpl) resolveBatch(batch string) (*domain.ScanResult, error) {
+	lots, err := u.InventoryRepo.GetAll(0, scanContentsLimit, "", repository.InventoryFilterOptions{Batch: batch})
+	if err != nil {
+		return nil, err
+	}
+	if len(lots) == 0 {
+		return nil, customerrors.ErrResourceNotFound
+	}
+
+	return &domain.ScanResult{
+		EntityType: domain.SCAN_BATCH,
+		Entity:     map[string]string{"batch": batch},
+		Actions:    domain.BatchScanActions(lots),
+		Contents:   lots,
+	}, nil
+}
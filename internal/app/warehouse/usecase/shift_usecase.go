@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+)
+
+type ShiftUseCase interface {
+	CreateShift(shift *domain.Shift) error
+	UpdateShift(shift *domain.Shift) error
+	DeleteShift(shiftID int64, version int64) error
+	GetShiftByID(shiftID int64) (*domain.Shift, error)
+	GetAllShifts(filter repository.ShiftFilterOptions) ([]*domain.Shift, error)
+	CreateHoliday(holiday *domain.ShiftHoliday) error
+	DeleteHoliday(holidayID int64) error
+	GetHolidays(storeID int64, from string, to string) ([]*domain.ShiftHoliday, error)
+	AssignSupervisor(assignment *domain.ShiftAssignment) error
+	RemoveAssignment(shiftID int64, date string) error
+	GetAssignments(shiftID int64, from string, to string) ([]*domain.ShiftAssignment, error)
+	ResolveShift(storeID int64, at time.Time) (*domain.ShiftResolution, error)
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+)
+
+type ShiftUseCaseImpl struct {
+	Repo      repository.ShiftRepository
+	StoreRepo repository.StoreRepository
+}
+
+func NewShiftUseCase(repo repository.ShiftRepository, storeRepo repository.StoreRepository) ShiftUseCase {
+	return &ShiftUseCaseImpl{Repo: repo, StoreRepo: storeRepo}
+}
+
+func (u *ShiftUseCaseImpl) CreateShift(shift *domain.Shift) error {
+	if _, err := u.StoreRepo.GetById(shift.StoreID); err != nil {
+		return err
+	}
+	return u.Repo.Create(shift)
+}
+
+func (u *ShiftUseCaseImpl) UpdateShift(shift *domain.Shift) error {
+	if _, err := u.Repo.GetById(shift.ID); err != nil {
+		return err
+	}
+	if _, err := u.StoreRepo.GetById(shift.StoreID); err != nil {
+		return err
+	}
+	return u.Repo.Update(shift)
+}
+
+func (u *ShiftUseCaseImpl) DeleteShift(shiftID int64, version int64) error {
+	return u.Repo.Delete(shiftID, version)
+}
+
+func (u *ShiftUseCaseImpl) GetShiftByID(shiftID int64) (*domain.Shift, error) {
+	return u.Repo.GetById(shiftID)
+}
+
+func (u *ShiftUseCaseImpl) GetAllShifts(filter repository.ShiftFilterOptions) ([]*domain.Shift, error) {
+	return u.Repo.GetAll(filter)
+}
+
+func (u *ShiftUseCaseImpl) CreateHoliday(holiday *domain.ShiftHoliday) error {
+	if _, err := domain.ParseShiftDate(holiday.Date); err != nil {
+		return err
+	}
+	if _, err := u.StoreRepo.GetById(holiday.StoreID); err != nil {
+		return err
+	}
+	return u.Repo.CreateHoliday(holiday)
+}
+
+func (u *ShiftUseCaseImpl) DeleteHoliday(holidayID int64) error {
+	return u.Repo.DeleteHoliday(holidayID)
+}
+
+func (u *ShiftUseCaseImpl) GetHolidays(storeID int64, from string, to string) ([]*domain.ShiftHoliday, error) {
+	if err := validateShiftRange(from, to); err != nil {
+		return nil, err
+	}
+	return u.Repo.GetHolidays(storeID, from, to)
+}
+
+// AssignSupervisor names the supervisor of a shift on a date, replacing the
+// default supervisor of the shift for that date.
+func (u *ShiftUseCaseImpl) AssignSupervisor(assignment *domain.ShiftAssignment) error {
+	date, err := domain.ParseShiftDate(assignment.Date)
+	if err != nil {
+		return err
+	}
+	if assignment.Supervisor == "" {
+		return fmt.Errorf("%w: supervisor is required", domain.ErrInvalidShift)
+	}
+	shift, err := u.Repo.GetById(assignment.ShiftID)
+	if err != nil {
+		return err
+	}
+	if !shift.RunsOn(date.Weekday()) {
+		return fmt.Errorf("%w: shift %s does not run on %s", domain.ErrInvalidShift, shift.Code, assignment.Date)
+	}
+	return u.Repo.SaveAssignment(assignment)
+}
+
+func (u *ShiftUseCaseImpl) RemoveAssignment(shiftID int64, date string) error {
+	if _, err := domain.ParseShiftDate(date); err != nil {
+		return err
+	}
+	return u.Repo.DeleteAssignment(shiftID, date)
+}
+
+func (u *ShiftUseCaseImpl) GetAssignments(shiftID int64, from string, to string) ([]*domain.ShiftAssignment, error) {
+	if err := validateShiftRange(from, to); err != nil {
+		return nil, err
+	}
+	if _, err := u.Repo.GetById(shiftID); err != nil {
+		return nil, err
+	}
+	return u.Repo.GetAssignments(shiftID, from, to)
+}
+
+// ResolveShift returns the shift of the store running at at with its
+// supervisor, or nil when no shift is running.
+func (u *ShiftUseCaseImpl) ResolveShift(storeID int64, at time.Time) (*domain.ShiftResolution, error) {
+	if _, err := u.StoreRepo.GetById(storeID); err != nil {
+		return nil, err
+	}
+	calendar, err := u.Repo.GetCalendar(storeID, at)
+	if err != nil {
+		return nil, err
+	}
+	return calendar.Resolve(at), nil
+}
+
+func validateShiftRange(from string, to string) error {
+	for _, value := range []string{from, to} {
+		if value == "" {
+			continue
+		}
+		if _, err := domain.ParseShiftDate(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
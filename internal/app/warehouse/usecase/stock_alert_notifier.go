@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+)
+
+// StockAlertNotifier is called whenever a new stock alert is raised.
+type StockAlertNotifier interface {
+	NotifyStockAlert(alert *domain.StockAlert) error
+}
+
+type LogStockAlertNotifier struct{}
+
+func (n *LogStockAlertNotifier) NotifyStockAlert(alert *domain.StockAlert) error {
+	log.Printf("stock alert %d: product %d in store %d is at %s, below reorder point %s (suggested reorder %s %s)",
+		alert.ID, alert.ProductID, alert.StoreID, alert.Available, alert.ReorderPoint, alert.SuggestedQuantity, alert.Unit)
+	return nil
+}
+
+// WebhookStockAlertNotifier posts each new alert as JSON to a URL.
+type WebhookStockAlertNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+func NewWebhookStockAlertNotifier(url string) *WebhookStockAlertNotifier {
+	return &WebhookStockAlertNotifier{URL: url, Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (n *WebhookStockAlertNotifier) NotifyStockAlert(alert *domain.StockAlert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	resp, err := n.Client.Post(n.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// MultiStockAlertNotifier fans an alert out to several notifiers.
+type MultiStockAlertNotifier []StockAlertNotifier
+
+func (n MultiStockAlertNotifier) NotifyStockAlert(alert *domain.StockAlert) error {
+	var firstErr error
+	for _, notifier := range n {
+		if err := notifier.NotifyStockAlert(alert); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+)
+
+type StockAlertUseCase interface {
+	GetStockAlertByID(alertID int64) (*domain.StockAlert, error)
+	GetAllStockAlerts(page int, pageSize int, sort string, filter repository.StockAlertFilterOptions) ([]*domain.StockAlert, int, error)
+	AcknowledgeStockAlert(alertID int64, acknowledgedBy string) (*domain.StockAlert, error)
+	ResolveStockAlert(alertID int64) (*domain.StockAlert, error)
+	EvaluateStockLevels() error
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"log"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+)
+
+type StockAlertUseCaseImpl struct {
+	Repo           repository.StockAlertRepository
+	StockLevelRepo repository.StockLevelRepository
+	Notifier       StockAlertNotifier
+}
+
+func NewStockAlertUseCase(repo repository.StockAlertRepository, stockLevelRepo repository.StockLevelRepository, notifier StockAlertNotifier) StockAlertUseCase {
+	return &StockAlertUseCaseImpl{Repo: repo, StockLevelRepo: stockLevelRepo, Notifier: notifier}
+}
+
+func (u *StockAlertUseCaseImpl) GetStockAlertByID(alertID int64) (*domain.StockAlert, error) {
+	return u.Repo.GetById(alertID)
+}
+
+func (u *StockAlertUseCaseImpl) GetAllStockAlerts(page int, pageSize int, sort string, filter repository.StockAlertFilterOptions) ([]*domain.StockAlert, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	alerts, err := u.Repo.GetAll((page-1)*pageSize, pageSize, sort, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Fetch the total count of alerts matching the filter
+	total, err := u.Repo.GetTotalCount(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return alerts, total, nil
+}
+
+func (u *StockAlertUseCaseImpl) AcknowledgeStockAlert(alertID int64, acknowledgedBy string) (*domain.StockAlert, error) {
+	alert, err := u.Repo.GetById(alertID)
+	if err != nil {
+		return nil, err
+	}
+	if err := alert.Acknowledge(acknowledgedBy, time.Now()); err != nil {
+		return nil, err
+	}
+	return alert, u.Repo.Update(alert)
+}
+
+func (u *StockAlertUseCaseImpl) ResolveStockAlert(alertID int64) (*domain.StockAlert, error) {
+	alert, err := u.Repo.GetById(alertID)
+	if err != nil {
+		return nil, err
+	}
+	if err := alert.Resolve(time.Now()); err != nil {
+		return nil, err
+	}
+	return alert, u.Repo.Update(alert)
+}
+
+// EvaluateStockLevels compares every stock level against the current stock.
+// An alert is raised once when available stock drops below the reorder point,
+// kept up to date while it stays low and resolved when stock recovers. An
+// alert resolved by hand is left alone until stock recovers, so that the same
+// shortage is not raised again.
+func (u *StockAlertUseCaseImpl) EvaluateStockLevels() error {
+	positions, err := u.StockLevelRepo.GetPositions()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, position := range positions {
+		alert, err := u.Repo.GetUnrecoveredByStockLevel(position.Level.ID)
+		if err != nil && err != customerrors.ErrResourceNotFound {
+			return err
+		}
+
+		belowReorderPoint := position.Available().Cmp(position.Level.ReorderPoint) < 0
+		switch {
+		case belowReorderPoint && alert == nil:
+			alert = domain.NewStockAlert(position, now)
+			if err := u.Repo.Create(alert); err != nil {
+				return err
+			}
+			if u.Notifier != nil {
+				if err := u.Notifier.NotifyStockAlert(alert); err != nil {
+					log.Printf("failed to send notification for stock alert %d: %v", alert.ID, err)
+				}
+			}
+		case belowReorderPoint && alert.IsActive():
+			alert.Refresh(position)
+			if err := u.Repo.Update(alert); err != nil {
+				return err
+			}
+		case belowReorderPoint:
+			// Resolved by hand and still short: stays resolved until stock recovers.
+		case alert != nil:
+			alert.Recover(position, now)
+			if err := u.Repo.Update(alert); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// StockLevelEvaluator periodically evaluates stock levels in the background.
+type StockLevelEvaluator struct {
+	UseCase  StockAlertUseCase
+	Interval time.Duration
+}
+
+func NewStockLevelEvaluator(useCase StockAlertUseCase, interval time.Duration) *StockLevelEvaluator {
+	return &StockLevelEvaluator{UseCase: useCase, Interval: interval}
+}
+
+// Run evaluates stock levels every Interval until ctx is cancelled.
+func (e *StockLevelEvaluator) Run(ctx context.Context) {
+	if e.Interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(e.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := e.UseCase.EvaluateStockLevels(); err != nil {
+			log.Printf("stock level evaluation failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+)
+
+type StockLevelUseCase interface {
+	CreateStockLevel(stockLevel *domain.StockLevel) error
+	UpdateStockLevel(stockLevel *domain.StockLevel) error
+	DeleteStockLevel(stockLevelID int64, version int64) error
+	GetStockLevelByID(stockLevelID int64) (*domain.StockLevel, error)
+	GetAllStockLevels(page int, pageSize int, sort string, filter repository.StockLevelFilterOptions) ([]*domain.StockLevel, int, error)
+}
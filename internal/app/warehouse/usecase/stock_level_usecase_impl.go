@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+)
+
+type StockLevelUseCaseImpl struct {
+	Repo repository.StockLevelRepository
+}
+
+func NewStockLevelUseCase(repo repository.StockLevelRepository) StockLevelUseCase {
+	return &StockLevelUseCaseImpl{Repo: repo}
+}
+
+func (u *StockLevelUseCaseImpl) CreateStockLevel(stockLevel *domain.StockLevel) error {
+	return u.Repo.Create(stockLevel)
+}
+
+func (u *StockLevelUseCaseImpl) UpdateStockLevel(stockLevel *domain.StockLevel) error {
+	// Check for an existing stock level with the specified ID
+	_, err := u.Repo.GetById(stockLevel.ID)
+	if err != nil {
+		return err
+	}
+	return u.Repo.Update(stockLevel)
+}
+
+func (u *StockLevelUseCaseImpl) DeleteStockLevel(stockLevelID int64, version int64) error {
+	return u.Repo.Delete(stockLevelID, version)
+}
+
+func (u *StockLevelUseCaseImpl) GetStockLevelByID(stockLevelID int64) (*domain.StockLevel, error) {
+	return u.Repo.GetById(stockLevelID)
+}
+
+func (u *StockLevelUseCaseImpl) GetAllStockLevels(page int, pageSize int, sort string, filter repository.StockLevelFilterOptions) ([]*domain.StockLevel, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	stockLevels, err := u.Repo.GetAll((page-1)*pageSize, pageSize, sort, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Fetch the total count of stock levels matching the filter
+	total, err := u.Repo.GetTotalCount(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return stockLevels, total, nil
+}
@@ -0,0 +1,11 @@
+package usecase
+
+import (
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+)
+
+type StockPositionUseCase interface {
+	GetStockPositions(filter repository.StockPositionFilterOptions) (*domain.StockPositionReport, error)
+	TakeDailySnapshots() error
+}
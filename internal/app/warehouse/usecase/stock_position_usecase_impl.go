@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+)
+
+type StockPositionUseCaseImpl struct {
+	Repo repository.StockPositionRepository
+}
+
+func NewStockPositionUseCase(repo repository.StockPositionRepository) StockPositionUseCase {
+	return &StockPositionUseCaseImpl{Repo: repo}
+}
+
+// GetStockPositions returns the current positions, or the positions at
+// filter.AsOf when it is set.
+func (u *StockPositionUseCaseImpl) GetStockPositions(filter repository.StockPositionFilterOptions) (*domain.StockPositionReport, error) {
+	now := time.Now()
+	if filter.AsOf == nil || !filter.AsOf.Before(now) {
+		positions, err := u.Repo.GetCurrent(filter)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.StockPositionReport{AsOf: now, Positions: positions}, nil
+	}
+
+	positions, snapshotAt, err := u.Repo.GetAsOf(*filter.AsOf, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.StockPositionReport{AsOf: *filter.AsOf, SnapshotAt: snapshotAt, Positions: positions}, nil
+}
+
+// TakeDailySnapshots materializes the closing balance of every day that has
+// ended since the last snapshot. Without any snapshot it starts from the day
+// of the first ledger entry.
+func (u *StockPositionUseCaseImpl) TakeDailySnapshots() error {
+	now := time.Now()
+	yesterday := domain.ClosingTime(now.AddDate(0, 0, -1))
+
+	var next time.Time
+	latest, err := u.Repo.GetLatestSnapshotAt()
+	if err != nil {
+		return err
+	}
+	if latest != nil {
+		next = domain.ClosingTime(latest.In(now.Location()).AddDate(0, 0, 1))
+	} else {
+		first, err := u.Repo.GetFirstMovementAt()
+		if err != nil || first == nil {
+			return err
+		}
+		next = domain.ClosingTime(first.In(now.Location()))
+	}
+
+	for ; !next.After(yesterday); next = domain.ClosingTime(next.AddDate(0, 0, 1)) {
+		positions, _, err := u.Repo.GetAsOf(next, repository.StockPositionFilterOptions{})
+		if err != nil {
+			return err
+		}
+		if err := u.Repo.SaveSnapshot(next, positions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// StockSnapshotJob periodically materializes daily closing balances so that
+// point-in-time queries only replay the ledger since the last snapshot.
+type StockSnapshotJob struct {
+	UseCase  StockPositionUseCase
+	Interval time.Duration
+}
+
+func NewStockSnapshotJob(useCase StockPositionUseCase, interval time.Duration) *StockSnapshotJob {
+	return &StockSnapshotJob{UseCase: useCase, Interval: interval}
+}
+
+// Run takes any missing snapshots every Interval until ctx is cancelled.
+func (j *StockSnapshotJob) Run(ctx context.Context) {
+	if j.Interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(j.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.UseCase.TakeDailySnapshots(); err != nil {
+			log.Printf("stock snapshot failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"sync"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+)
+
+type storeSummaryKey struct {
+	storeID    int64
+	withinDays int
+}
+
+// storeSummaryCache keeps store summaries for a short time, so that
+// dashboards polling the same store share one set of queries. A zero ttl
+// disables it.
+type storeSummaryCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[storeSummaryKey]*domain.StoreSummary
+}
+
+func newStoreSummaryCache(ttl time.Duration) *storeSummaryCache {
+	return &storeSummaryCache{ttl: ttl, entries: make(map[storeSummaryKey]*domain.StoreSummary)}
+}
+
+func (c *storeSummaryCache) get(key storeSummaryKey, now time.Time) *domain.StoreSummary {
+	if c.ttl <= 0 {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	summary, ok := c.entries[key]
+	if !ok || now.Sub(summary.GeneratedAt) >= c.ttl {
+		return nil
+	}
+	return summary
+}
+
+// put stores summary and drops the entries that have gone stale, which keeps
+// the cache as small as the set of stores being watched.
+func (c *storeSummaryCache) put(key storeSummaryKey, summary *domain.StoreSummary) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, entry := range c.entries {
+		if summary.GeneratedAt.Sub(entry.GeneratedAt) >= c.ttl {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = summary
+}
@@ -8,7 +8,13 @@ import (
 type StoreUseCase interface {
 	CreateStore(store *domain.Store) error
 	UpdateStore(store *domain.Store) error
-	DeleteStore(storeID int64) error
+	DeleteStore(storeID int64, version int64) error
+	ChangeStoreOwner(storeID int64, form *domain.StoreOwnerForm, version int64) (*domain.Store, error)
+	GetStoreOwnerHistory(storeID int64) ([]*domain.StoreOwnerChange, error)
+	SaveStoreMember(member *domain.StoreMember) error
+	RemoveStoreMember(storeID int64, userID int64) error
+	GetStoreMembers(storeID int64) ([]*domain.StoreMember, error)
 	GetStoreByID(storeID int64) (*domain.Store, error)
+	GetStoreSummary(storeID int64, withinDays int, refresh bool) (*domain.StoreSummary, error)
 	GetAllStores(page int, pageSize int, sort string, filter repository.StoreFilterOptions) ([]*domain.Store, int, error)
 }
@@ -1,16 +1,21 @@
 package usecase
 
 import (
+	"time"
+
 	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
 	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
 )
 
 type StoreUseCaseImpl struct {
-	Repo repository.StoreRepository
+	Repo         repository.StoreRepository
+	summaryCache *storeSummaryCache
 }
 
-func NewStoreUseCase(repo repository.StoreRepository) StoreUseCase {
-	return &StoreUseCaseImpl{Repo: repo}
+// NewStoreUseCase serves store summaries from a cache for summaryTTL after
+// they were built; zero builds every summary afresh.
+func NewStoreUseCase(repo repository.StoreRepository, summaryTTL time.Duration) StoreUseCase {
+	return &StoreUseCaseImpl{Repo: repo, summaryCache: newStoreSummaryCache(summaryTTL)}
 }
 
 func (u *StoreUseCaseImpl) CreateStore(store *domain.Store) error {
@@ -27,14 +32,73 @@ func (u *StoreUseCaseImpl) UpdateStore(store *domain.Store) error {
 	return u.Repo.Update(store)
 }
 
-func (u *StoreUseCaseImpl) DeleteStore(storeID int64) error {
-	return u.Repo.Delete(storeID)
+func (u *StoreUseCaseImpl) DeleteStore(storeID int64, version int64) error {
+	return u.Repo.Delete(storeID, version)
+}
+
+// ChangeStoreOwner hands the store over to another user and returns it with
+// the details of its new owner.
+func (u *StoreUseCaseImpl) ChangeStoreOwner(storeID int64, form *domain.StoreOwnerForm, version int64) (*domain.Store, error) {
+	if err := u.Repo.ChangeOwner(storeID, form, version); err != nil {
+		return nil, err
+	}
+	return u.Repo.GetById(storeID)
+}
+
+func (u *StoreUseCaseImpl) GetStoreOwnerHistory(storeID int64) ([]*domain.StoreOwnerChange, error) {
+	if _, err := u.Repo.GetById(storeID); err != nil {
+		return nil, err
+	}
+	return u.Repo.GetOwnerHistory(storeID)
+}
+
+func (u *StoreUseCaseImpl) SaveStoreMember(member *domain.StoreMember) error {
+	return u.Repo.SaveMember(member)
+}
+
+func (u *StoreUseCaseImpl) RemoveStoreMember(storeID int64, userID int64) error {
+	return u.Repo.RemoveMember(storeID, userID)
+}
+
+func (u *StoreUseCaseImpl) GetStoreMembers(storeID int64) ([]*domain.StoreMember, error) {
+	if _, err := u.Repo.GetById(storeID); err != nil {
+		return nil, err
+	}
+	return u.Repo.GetMembers(storeID)
 }
 
 func (u *StoreUseCaseImpl) GetStoreByID(storeID int64) (*domain.Store, error) {
 	return u.Repo.GetById(storeID)
 }
 
+// GetStoreSummary returns the dashboard of the store, looking withinDays ahead
+// for expiring lots. refresh skips the cache.
+func (u *StoreUseCaseImpl) GetStoreSummary(storeID int64, withinDays int, refresh bool) (*domain.StoreSummary, error) {
+	if withinDays <= 0 {
+		withinDays = domain.DefaultExpiryWindowDays
+	}
+	if withinDays > domain.MaxExpiryWindowDays {
+		withinDays = domain.MaxExpiryWindowDays
+	}
+
+	key := storeSummaryKey{storeID: storeID, withinDays: withinDays}
+	now := time.Now()
+	if !refresh {
+		if summary := u.summaryCache.get(key, now); summary != nil {
+			return summary, nil
+		}
+	}
+	year, month, day := now.Date()
+	dayStart := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+	summary, err := u.Repo.GetSummary(storeID, dayStart, now.AddDate(0, 0, withinDays))
+	if err != nil {
+		return nil, err
+	}
+	summary.Expiring.WithinDays = withinDays
+	u.summaryCache.put(key, summary)
+	return summary, nil
+}
+
 func (u *StoreUseCaseImpl) GetAllStores(page int, pageSize int, sort string, filter repository.StoreFilterOptions) ([]*domain.Store, int, error) {
 	if page < 1 {
 		page = 1
@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+)
+
+type TransferUseCase interface {
+	CreateTransfer(order *domain.TransferOrder) error
+	UpdateTransfer(order *domain.TransferOrder) error
+	DeleteTransfer(orderID int64, version int64) error
+	DispatchTransfer(orderID int64, form *domain.TransferDispatchForm, version int64) (*domain.TransferOrder, error)
+	ReceiveTransfer(orderID int64, form *domain.TransferReceiptForm, version int64) (*domain.TransferOrder, error)
+	CancelTransfer(orderID int64, form *domain.TransferCancelForm, version int64) (*domain.TransferOrder, error)
+	GetTransferByID(orderID int64) (*domain.TransferOrder, error)
+	GetAllTransfers(page int, pageSize int, sort string, filter repository.TransferFilterOptions) ([]*domain.TransferOrder, int, error)
+}
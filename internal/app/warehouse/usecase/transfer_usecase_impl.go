@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/domain"
+	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/repository"
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+)
+
+type TransferUseCaseImpl struct {
+	Repo      repository.TransferRepository
+	StoreRepo repository.StoreRepository
+}
+
+func NewTransferUseCase(repo repository.TransferRepository, storeRepo repository.StoreRepository) TransferUseCase {
+	return &TransferUseCaseImpl{Repo: repo, StoreRepo: storeRepo}
+}
+
+func (u *TransferUseCaseImpl) CreateTransfer(order *domain.TransferOrder) error {
+	if err := u.checkStores(order); err != nil {
+		return err
+	}
+	order.Status = domain.TRANSFER_DRAFT
+	return u.Repo.Create(order)
+}
+
+// UpdateTransfer replaces the stores and lines of an order that has not been
+// dispatched yet.
+func (u *TransferUseCaseImpl) UpdateTransfer(order *domain.TransferOrder) error {
+	existingOrder, err := u.Repo.GetById(order.ID)
+	if err != nil {
+		return err
+	}
+	if existingOrder.Status != domain.TRANSFER_DRAFT {
+		return domain.ErrTransferNotDraft
+	}
+	if err := u.checkStores(order); err != nil {
+		return err
+	}
+	order.Status = domain.TRANSFER_DRAFT
+	return u.Repo.Update(order)
+}
+
+func (u *TransferUseCaseImpl) DeleteTransfer(orderID int64, version int64) error {
+	order, err := u.Repo.GetById(orderID)
+	if err != nil {
+		return err
+	}
+	if order.Status != domain.TRANSFER_DRAFT {
+		return domain.ErrTransferNotDraft
+	}
+	return u.Repo.Delete(orderID, version)
+}
+
+func (u *TransferUseCaseImpl) DispatchTransfer(orderID int64, form *domain.TransferDispatchForm, version int64) (*domain.TransferOrder, error) {
+	order, err := u.loadForTransition(orderID, version)
+	if err != nil {
+		return nil, err
+	}
+	if err := order.Dispatch(form, time.Now()); err != nil {
+		return nil, err
+	}
+	order.LastUpdatedBy = order.DispatchedBy
+	if err := u.Repo.Dispatch(order); err != nil {
+		return nil, err
+	}
+	return u.Repo.GetById(orderID)
+}
+
+func (u *TransferUseCaseImpl) ReceiveTransfer(orderID int64, form *domain.TransferReceiptForm, version int64) (*domain.TransferOrder, error) {
+	order, err := u.loadForTransition(orderID, version)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := order.Receive(form, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	order.LastUpdatedBy = lines[0].ReceivedBy
+	if err := u.Repo.Receive(order, lines); err != nil {
+		return nil, err
+	}
+	received, err := u.Repo.GetById(orderID)
+	if err != nil {
+		return nil, err
+	}
+	received.Warnings = order.Warnings
+	return received, nil
+}
+
+func (u *TransferUseCaseImpl) CancelTransfer(orderID int64, form *domain.TransferCancelForm, version int64) (*domain.TransferOrder, error) {
+	order, err := u.loadForTransition(orderID, version)
+	if err != nil {
+		return nil, err
+	}
+	if err := order.Cancel(form, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := u.Repo.Cancel(order); err != nil {
+		return nil, err
+	}
+	return u.Repo.GetById(orderID)
+}
+
+// loadForTransition returns the order to move on. The version it was read at
+// guards the transition, unless the client sent the version it saw.
+func (u *TransferUseCaseImpl) loadForTransition(orderID int64, version int64) (*domain.TransferOrder, error) {
+	order, err := u.Repo.GetById(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if version > 0 && version != order.Version {
+		return nil, customerrors.NewVersionConflictError("transfer order", orderID, version)
+	}
+	return order, nil
+}
+
+func (u *TransferUseCaseImpl) checkStores(order *domain.TransferOrder) error {
+	for _, storeID := range []int64{order.FromStoreID, order.ToStoreID} {
+		if _, err := u.StoreRepo.GetById(storeID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *TransferUseCaseImpl) GetTransferByID(orderID int64) (*domain.TransferOrder, error) {
+	return u.Repo.GetById(orderID)
+}
+
+func (u *TransferUseCaseImpl) GetAllTransfers(page int, pageSize int, sort string, filter repository.TransferFilterOptions) ([]*domain.TransferOrder, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	orders, err := u.Repo.GetAll((page-1)*pageSize, pageSize, sort, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Fetch the total count of orders matching the filter
+	total, err := u.Repo.GetTotalCount(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return orders, total, nil
+}
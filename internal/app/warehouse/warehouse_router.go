@@ -1,23 +1,74 @@
 package warehouse
 
 import (
+	"context"
+
 	"github.com/gorilla/mux"
 	"github.com/vamika-digital/wms-api-server/internal/app/warehouse/interfaces/rest"
 	"github.com/vamika-digital/wms-api-server/pkg/database"
 )
 
 type WarehouseModule struct {
-	ContainerModule *rest.ContainerModule
-	StoreModule     *rest.StoreModule
+	ContainerModule       *rest.ContainerModule
+	StoreModule           *rest.StoreModule
+	InventoryModule       *rest.InventoryModule
+	StockLevelModule      *rest.StockLevelModule
+	StockAlertModule      *rest.StockAlertModule
+	ReportModule          *rest.ReportModule
+	InspectionModule      *rest.InspectionModule
+	StockPositionModule   *rest.StockPositionModule
+	LabelModule           *rest.LabelModule
+	ScanModule            *rest.ScanModule
+	InventoryImportModule *rest.InventoryImportModule
+	NumberSeriesModule    *rest.NumberSeriesModule
+	PutawayModule         *rest.PutawayModule
+	ShiftModule           *rest.ShiftModule
+	TransferModule        *rest.TransferModule
 }
 
 func NewWarehouseModule(db database.Connection) *WarehouseModule {
-	containerModule := rest.NewContainerModule(db)
-	storeModule := rest.NewStoreModule(db)
-	return &WarehouseModule{ContainerModule: containerModule, StoreModule: storeModule}
+	return &WarehouseModule{
+		ContainerModule:       rest.NewContainerModule(db),
+		StoreModule:           rest.NewStoreModule(db),
+		InventoryModule:       rest.NewInventoryModule(db),
+		StockLevelModule:      rest.NewStockLevelModule(db),
+		StockAlertModule:      rest.NewStockAlertModule(db),
+		ReportModule:          rest.NewReportModule(db),
+		InspectionModule:      rest.NewInspectionModule(db),
+		StockPositionModule:   rest.NewStockPositionModule(db),
+		LabelModule:           rest.NewLabelModule(db),
+		ScanModule:            rest.NewScanModule(db),
+		InventoryImportModule: rest.NewInventoryImportModule(db),
+		NumberSeriesModule:    rest.NewNumberSeriesModule(db),
+		PutawayModule:         rest.NewPutawayModule(db),
+		ShiftModule:           rest.NewShiftModule(db),
+		TransferModule:        rest.NewTransferModule(db),
+	}
 }
 
 func (w *WarehouseModule) RegisterRoutes(r *mux.Router) {
 	w.ContainerModule.RegisterRoutes(r.PathPrefix("/warehouse").Subrouter())
 	w.StoreModule.RegisterRoutes(r.PathPrefix("/warehouse").Subrouter())
+	w.InventoryModule.RegisterRoutes(r.PathPrefix("/warehouse").Subrouter())
+	w.StockLevelModule.RegisterRoutes(r.PathPrefix("/warehouse").Subrouter())
+	w.StockAlertModule.RegisterRoutes(r.PathPrefix("/warehouse").Subrouter())
+	w.ReportModule.RegisterRoutes(r.PathPrefix("/warehouse").Subrouter())
+	w.InspectionModule.RegisterRoutes(r.PathPrefix("/warehouse").Subrouter())
+	w.StockPositionModule.RegisterRoutes(r.PathPrefix("/warehouse").Subrouter())
+	w.LabelModule.RegisterRoutes(r.PathPrefix("/warehouse").Subrouter())
+	w.InventoryImportModule.RegisterRoutes(r.PathPrefix("/warehouse").Subrouter())
+	w.NumberSeriesModule.RegisterRoutes(r.PathPrefix("/warehouse").Subrouter())
+	w.PutawayModule.RegisterRoutes(r.PathPrefix("/warehouse").Subrouter())
+	w.ShiftModule.RegisterRoutes(r.PathPrefix("/warehouse").Subrouter())
+	w.TransferModule.RegisterRoutes(r.PathPrefix("/warehouse").Subrouter())
+	// Scanning spans every module, so it lives at /secure/scan
+	w.ScanModule.RegisterRoutes(r)
+}
+
+// StartBackgroundJobs launches the periodic warehouse jobs. They stop when ctx
+// is cancelled.
+func (w *WarehouseModule) StartBackgroundJobs(ctx context.Context) {
+	go w.StockAlertModule.Evaluator.Run(ctx)
+	go w.StockPositionModule.SnapshotJob.Run(ctx)
+	go w.InventoryImportModule.Worker.Run(ctx)
 }
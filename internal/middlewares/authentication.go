@@ -1 +1,33 @@
 package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/vamika-digital/wms-api-server/config"
+)
+
+var ErrUnauthenticated = errors.New("a valid access token is required")
+
+// AuthenticatedUsername returns the username the bearer token in the
+// Authorization header was issued to.
+func AuthenticatedUsername(r *http.Request) (string, error) {
+	tokenString, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || tokenString == "" {
+		return "", ErrUnauthenticated
+	}
+
+	claims := &jwt.StandardClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrUnauthenticated
+		}
+		return []byte(config.AppConfig.Auth.SecretKey), nil
+	})
+	if err != nil || !token.Valid || claims.Subject == "" {
+		return "", ErrUnauthenticated
+	}
+	return claims.Subject, nil
+}
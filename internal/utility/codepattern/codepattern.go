@@ -0,0 +1,98 @@
+// Package codepattern expands the {NAME} and {NAME:arg} placeholders of the
+// patterns that generate codes and serial numbers. Token names are case
+// insensitive.
+package codepattern
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MaxPadWidth caps the zero padding of a token such as {SEQ:6}.
+const MaxPadWidth = 10
+
+// Sequence is the name of the token that holds a running number.
+const Sequence = "SEQ"
+
+var (
+	ErrInvalidPadWidth = errors.New("invalid pad width")
+	ErrMissingSequence = errors.New("pattern must contain a {SEQ} or {SEQ:n} token")
+)
+
+var tokenPattern = regexp.MustCompile(`\{([A-Za-z_]+)(?::([^{}]*))?\}`)
+
+// Token is one placeholder of a pattern.
+type Token struct {
+	Text   string // as written in the pattern
+	Name   string // upper-cased
+	Arg    string
+	HasArg bool
+}
+
+func newToken(match []string) Token {
+	return Token{
+		Text:   match[0],
+		Name:   strings.ToUpper(match[1]),
+		Arg:    match[2],
+		HasArg: strings.Contains(match[0], ":"),
+	}
+}
+
+// Parse lists the tokens of pattern in order.
+func Parse(pattern string) []Token {
+	var tokens []Token
+	for _, match := range tokenPattern.FindAllStringSubmatch(pattern, -1) {
+		tokens = append(tokens, newToken(match))
+	}
+	return tokens
+}
+
+// Expand replaces every token of pattern with what replace returns for it.
+// replace returns token.Text to leave a token as it is.
+func Expand(pattern string, replace func(token Token) string) string {
+	return tokenPattern.ReplaceAllStringFunc(pattern, func(text string) string {
+		return replace(newToken(tokenPattern.FindStringSubmatch(text)))
+	})
+}
+
+// Width is the zero padding asked for by the argument of a token, or 0 when
+// it has none.
+func (t Token) Width() (int, error) {
+	if !t.HasArg {
+		return 0, nil
+	}
+	width, err := strconv.Atoi(t.Arg)
+	if err != nil || width < 1 || width > MaxPadWidth {
+		return 0, fmt.Errorf("%w: %s must pad to 1 to %d digits", ErrInvalidPadWidth, t.Text, MaxPadWidth)
+	}
+	return width, nil
+}
+
+// Pad writes value zero padded to the width of the token. The width must
+// have been checked with Width or ValidateSequence.
+func (t Token) Pad(value int64) string {
+	width, _ := t.Width()
+	return fmt.Sprintf("%0*d", width, value)
+}
+
+// ValidateSequence checks that pattern has a {SEQ} token and that every
+// {SEQ:n} token pads to a valid width.
+func ValidateSequence(pattern string) error {
+	found := false
+	for _, token := range Parse(pattern) {
+		if token.Name != Sequence {
+			continue
+		}
+		if _, err := token.Width(); err != nil {
+			return err
+		}
+		found = true
+	}
+	if !found {
+		return ErrMissingSequence
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package codepattern
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExpand(t *testing.T) {
+	got := Expand("{Store}-{seq:4}-{SEQ}-{other}", func(token Token) string {
+		switch token.Name {
+		case "STORE":
+			return "7"
+		case Sequence:
+			return token.Pad(42)
+		default:
+			return token.Text
+		}
+	})
+	if want := "7-0042-42-{other}"; got != want {
+		t.Errorf("Expand = %q, want %q", got, want)
+	}
+}
+
+func TestValidateSequence(t *testing.T) {
+	tests := []struct {
+		pattern string
+		err     error
+	}{
+		{pattern: "{SEQ}"},
+		{pattern: "P-{seq:6}"},
+		{pattern: "{SEQ:10}"},
+		{pattern: "{CODE}", err: ErrMissingSequence},
+		{pattern: "{SEQ", err: ErrMissingSequence},
+		{pattern: "{SEQ:11}", err: ErrInvalidPadWidth},
+		{pattern: "{SEQ:999999999}", err: ErrInvalidPadWidth},
+		{pattern: "{SEQ:0}", err: ErrInvalidPadWidth},
+		{pattern: "{SEQ:}", err: ErrInvalidPadWidth},
+		{pattern: "{SEQ:abc}", err: ErrInvalidPadWidth},
+		{pattern: "{SEQ:4}-{SEQ:abc}", err: ErrInvalidPadWidth},
+	}
+	for _, test := range tests {
+		err := ValidateSequence(test.pattern)
+		if !errors.Is(err, test.err) {
+			t.Errorf("ValidateSequence(%q) = %v, want %v", test.pattern, err, test.err)
+		}
+	}
+}
@@ -1,5 +1,30 @@
 package customerrors
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var ErrResourceNotFound = errors.New("resource not found")
+
+var ErrVersionConflict = errors.New("resource version conflict")
+
+// VersionConflictError is returned by repositories when a conditional update
+// or delete did not match the stored version of the resource.
+type VersionConflictError struct {
+	Resource string
+	ID       int64
+	Version  int64
+}
+
+func NewVersionConflictError(resource string, id int64, version int64) *VersionConflictError {
+	return &VersionConflictError{Resource: resource, ID: id, Version: version}
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%s %d has been modified since version %d", e.Resource, e.ID, e.Version)
+}
+
+func (e *VersionConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
@@ -0,0 +1,501 @@
+package customtypes
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MaxDecimalScale is the largest number of fractional digits a Decimal keeps.
+// It matches the scale of the DECIMAL(18,6) quantity columns.
+const MaxDecimalScale int32 = 6
+
+// MaxDecimalIntegerDigits is the largest number of digits before the point
+// of a parsed Decimal, which keeps every value within the quantity columns.
+const MaxDecimalIntegerDigits = 12
+
+// DefaultUnitScale is used for units that have no configured precision.
+const DefaultUnitScale int32 = 3
+
+var (
+	ErrInvalidDecimal  = errors.New("invalid decimal value")
+	ErrDecimalOverflow = errors.New("decimal value out of range")
+)
+
+var pow10 = [...]int64{1, 10, 100, 1000, 10000, 100000, 1000000, 1e7, 1e8, 1e9, 1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18}
+
+// Decimal is an exact fixed-point number holding units of 10^-scale.
+// The zero value is 0.
+type Decimal struct {
+	units int64
+	scale int32
+}
+
+func NewDecimal(units int64, scale int32) Decimal {
+	if scale < 0 {
+		scale = 0
+	}
+	if scale > MaxDecimalScale {
+		return Decimal{units: units, scale: scale}.Round(MaxDecimalScale)
+	}
+	return Decimal{units: units, scale: scale}
+}
+
+func NewDecimalFromInt(value int64) Decimal {
+	return Decimal{units: value}
+}
+
+// ParseDecimal parses a plain decimal string such as "12", "-0.125" or "3.".
+// Exponent notation is not accepted so that values are never rounded silently,
+// and values with more than MaxDecimalIntegerDigits digits before the point
+// are rejected with ErrDecimalOverflow. Requests, imports and rows read from
+// the database all go through it.
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Decimal{}, ErrInvalidDecimal
+	}
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+	if intPart == "" && fracPart == "" {
+		return Decimal{}, ErrInvalidDecimal
+	}
+	// Trailing zeros carry no precision, so "1.500000000" is still acceptable.
+	fracPart = strings.TrimRight(fracPart, "0")
+	if int32(len(fracPart)) > MaxDecimalScale {
+		return Decimal{}, fmt.Errorf("%w: more than %d fractional digits", ErrInvalidDecimal, MaxDecimalScale)
+	}
+	digits := intPart + fracPart
+	if digits == "" {
+		digits = "0"
+	}
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return Decimal{}, ErrInvalidDecimal
+		}
+	}
+	if len(strings.TrimLeft(intPart, "0")) > MaxDecimalIntegerDigits {
+		return Decimal{}, fmt.Errorf("%w: more than %d digits before the decimal point", ErrDecimalOverflow, MaxDecimalIntegerDigits)
+	}
+	units, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("%w: out of range", ErrInvalidDecimal)
+	}
+	if negative {
+		units = -units
+	}
+	return Decimal{units: units, scale: int32(len(fracPart))}, nil
+}
+
+// inRange reports whether d has at most MaxDecimalIntegerDigits digits
+// before the point.
+func (d Decimal) inRange() bool {
+	limit := pow10[MaxDecimalIntegerDigits+d.scale]
+	return d.units < limit && d.units > -limit
+}
+
+// MustParseDecimal is like ParseDecimal but panics on error. It is meant for
+// constants in code.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+func (d Decimal) IsZero() bool {
+	return d.units == 0
+}
+
+func (d Decimal) Sign() int {
+	switch {
+	case d.units > 0:
+		return 1
+	case d.units < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{units: -d.units, scale: d.scale}
+}
+
+func (d Decimal) Abs() Decimal {
+	if d.units < 0 {
+		return d.Neg()
+	}
+	return d
+}
+
+// rescaled returns the units of d expressed with a larger scale. ok is false
+// when they do not fit in an int64.
+func (d Decimal) rescaled(scale int32) (units int64, ok bool) {
+	factor := pow10[scale-d.scale]
+	if d.units > math.MaxInt64/factor || d.units < math.MinInt64/factor {
+		return 0, false
+	}
+	return d.units * factor, true
+}
+
+// add returns d + other at the larger of their scales, or with fewer
+// fractional digits when the exact sum does not fit in an int64. That only
+// happens far beyond the range of the quantity columns, where the dropped
+// digits carry no meaning.
+func (d Decimal) add(other Decimal) (Decimal, error) {
+	scale := d.scale
+	if other.scale > scale {
+		scale = other.scale
+	}
+	for ; scale >= 0; scale-- {
+		a, okA := d.Round(scale).rescaled(scale)
+		b, okB := other.Round(scale).rescaled(scale)
+		if !okA || !okB {
+			continue
+		}
+		sum := a + b
+		if (a >= 0) == (b >= 0) && (sum >= 0) != (a >= 0) {
+			continue
+		}
+		return Decimal{units: sum, scale: scale}, nil
+	}
+	return Decimal{}, ErrDecimalOverflow
+}
+
+// Add returns d + other. Every Decimal is parsed, or multiplied, to at most
+// MaxDecimalIntegerDigits digits before the point, and add gives up
+// fractional digits before it fails, so a sum only overflows beyond ±9.2e18:
+// more than nine million values of the largest size added together. No
+// request or report adds that many, which is why Add does not return an
+// error; it panics with ErrDecimalOverflow should that ever change.
+func (d Decimal) Add(other Decimal) Decimal {
+	sum, err := d.add(other)
+	if err != nil {
+		panic(err)
+	}
+	return sum
+}
+
+// Sub returns d - other. Like Add, it cannot overflow for values within the
+// quantity columns.
+func (d Decimal) Sub(other Decimal) Decimal {
+	if other.units == math.MinInt64 {
+		panic(ErrDecimalOverflow)
+	}
+	return d.Add(other.Neg())
+}
+
+// Mul multiplies two decimals. The product is worked out in 128 bits and
+// digits beyond MaxDecimalScale are rounded half away from zero, so only a
+// result with more than MaxDecimalIntegerDigits digits before the point is an
+// error.
+func (d Decimal) Mul(other Decimal) (Decimal, error) {
+	scale := d.scale + other.scale
+	divisor := uint64(1)
+	if scale > MaxDecimalScale {
+		divisor = uint64(pow10[scale-MaxDecimalScale])
+		scale = MaxDecimalScale
+	}
+	hi, lo := bits.Mul64(absUnits(d.units), absUnits(other.units))
+	if hi >= divisor {
+		return Decimal{}, ErrDecimalOverflow
+	}
+	quotient, remainder := bits.Div64(hi, lo, divisor)
+	if remainder >= divisor-remainder {
+		quotient++
+	}
+	if quotient > math.MaxInt64 {
+		return Decimal{}, ErrDecimalOverflow
+	}
+	units := int64(quotient)
+	if (d.units < 0) != (other.units < 0) {
+		units = -units
+	}
+	product := Decimal{units: units, scale: scale}
+	if !product.inRange() {
+		return Decimal{}, ErrDecimalOverflow
+	}
+	return product, nil
+}
+
+func (d Decimal) MulInt(n int64) (Decimal, error) {
+	return d.Mul(NewDecimalFromInt(n))
+}
+
+func absUnits(units int64) uint64 {
+	if units < 0 {
+		return uint64(-units)
+	}
+	return uint64(units)
+}
+
+// Cmp returns -1, 0 or +1 depending on whether d is less than, equal to or
+// greater than other.
+func (d Decimal) Cmp(other Decimal) int {
+	scale := d.scale
+	if other.scale > scale {
+		scale = other.scale
+	}
+	a, okA := d.rescaled(scale)
+	b, okB := other.rescaled(scale)
+	// A value too large to rescale is further from zero than any value
+	// already at that scale, so its sign decides.
+	switch {
+	case !okA:
+		return d.Sign()
+	case !okB:
+		return -other.Sign()
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+func MinDecimal(a, b Decimal) Decimal {
+	if a.Cmp(b) <= 0 {
+		return a
+	}
+	return b
+}
+
+func MaxDecimal(a, b Decimal) Decimal {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
+
+// Round returns d with at most scale fractional digits, rounding half away
+// from zero.
+func (d Decimal) Round(scale int32) Decimal {
+	if scale < 0 {
+		scale = 0
+	}
+	if scale >= d.scale {
+		return d
+	}
+	factor := int64(1)
+	for i := scale; i < d.scale; i++ {
+		factor *= 10
+	}
+	units := d.units / factor
+	remainder := d.units % factor
+	if remainder*2 >= factor {
+		units++
+	} else if remainder*2 <= -factor {
+		units--
+	}
+	return Decimal{units: units, scale: scale}
+}
+
+// Ceil returns the smallest value with the given scale that is not less than d.
+func (d Decimal) Ceil(scale int32) Decimal {
+	rounded := d.Truncate(scale)
+	if rounded.Cmp(d) < 0 {
+		rounded = rounded.Add(Decimal{units: 1, scale: rounded.scale})
+	}
+	return rounded
+}
+
+// Truncate drops fractional digits beyond scale.
+func (d Decimal) Truncate(scale int32) Decimal {
+	if scale < 0 {
+		scale = 0
+	}
+	if scale >= d.scale {
+		return d
+	}
+	factor := int64(1)
+	for i := scale; i < d.scale; i++ {
+		factor *= 10
+	}
+	return Decimal{units: d.units / factor, scale: scale}
+}
+
+// Precision returns the number of significant fractional digits in d.
+func (d Decimal) Precision() int32 {
+	units, scale := d.units, d.scale
+	for scale > 0 && units%10 == 0 {
+		units /= 10
+		scale--
+	}
+	return scale
+}
+
+// IntPart returns the integer part of d, dropping any fraction.
+func (d Decimal) IntPart() int64 {
+	return d.Truncate(0).units
+}
+
+// Float64 is intended for presentation only, never for further arithmetic.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+func (d Decimal) String() string {
+	units := d.units
+	sign := ""
+	if units < 0 {
+		sign = "-"
+		units = -units
+	}
+	digits := strconv.FormatInt(units, 10)
+	if d.scale == 0 {
+		return sign + digits
+	}
+	for int32(len(digits)) <= d.scale {
+		digits = "0" + digits
+	}
+	point := int32(len(digits)) - d.scale
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+func (d *Decimal) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*d = Decimal{}
+		return nil
+	case []byte:
+		parsed, err := ParseDecimal(string(v))
+		if err != nil {
+			return err
+		}
+		*d = parsed
+		return nil
+	case string:
+		parsed, err := ParseDecimal(v)
+		if err != nil {
+			return err
+		}
+		*d = parsed
+		return nil
+	case int64:
+		*d = NewDecimalFromInt(v)
+		return nil
+	case float64:
+		parsed, err := ParseDecimal(strconv.FormatFloat(v, 'f', int(MaxDecimalScale), 64))
+		if err != nil {
+			return err
+		}
+		*d = parsed
+		return nil
+	default:
+		return fmt.Errorf("decimal: unsupported type %T", value)
+	}
+}
+
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		*d = Decimal{}
+		return nil
+	}
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+var (
+	unitScalesMu sync.RWMutex
+	unitScales   = map[string]int32{
+		"pcs": 0,
+		"nos": 0,
+		"kg":  3,
+		"g":   0,
+		"m":   2,
+		"l":   3,
+	}
+)
+
+// SetUnitScale configures how many fractional digits quantities in unit may
+// carry.
+func SetUnitScale(unit string, scale int32) {
+	if scale < 0 {
+		scale = 0
+	}
+	if scale > MaxDecimalScale {
+		scale = MaxDecimalScale
+	}
+	unitScalesMu.Lock()
+	defer unitScalesMu.Unlock()
+	unitScales[strings.ToLower(strings.TrimSpace(unit))] = scale
+}
+
+func UnitScale(unit string) int32 {
+	unitScalesMu.RLock()
+	defer unitScalesMu.RUnlock()
+	if scale, ok := unitScales[strings.ToLower(strings.TrimSpace(unit))]; ok {
+		return scale
+	}
+	return DefaultUnitScale
+}
+
+// ValidateForUnit rejects values that are more precise than unit allows, such
+// as 1.5 pcs or 0.0001 kg, and values too large for the quantity columns.
+func (d Decimal) ValidateForUnit(unit string) error {
+	if !d.inRange() {
+		return fmt.Errorf("%w: quantity %s has more than %d digits before the decimal point", ErrDecimalOverflow, d, MaxDecimalIntegerDigits)
+	}
+	scale := UnitScale(unit)
+	if d.Precision() > scale {
+		return fmt.Errorf("quantity %s exceeds the precision of unit %q (%d decimal places)", d, unit, scale)
+	}
+	return nil
+}
+
+// RequiredQuantity returns the quantity of a component consumed by making
+// quantity units of its parent, quantityPer each plus scrapFactor of it lost,
+// rounded up to the precision of unit.
+func RequiredQuantity(quantity, quantityPer, scrapFactor Decimal, unit string) (Decimal, error) {
+	required, err := quantity.Mul(quantityPer)
+	if err != nil {
+		return Decimal{}, err
+	}
+	if required, err = required.Mul(NewDecimalFromInt(1).Add(scrapFactor)); err != nil {
+		return Decimal{}, err
+	}
+	return required.Ceil(UnitScale(unit)), nil
+}
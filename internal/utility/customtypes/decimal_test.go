@@ -0,0 +1,217 @@
+package customtypes
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		err   bool
+	}{
+		{input: "12", want: "12"},
+		{input: "-0.125", want: "-0.125"},
+		{input: "+3.", want: "3"},
+		{input: ".5", want: "0.5"},
+		{input: "1.500000000", want: "1.5"},
+		{input: "0.1234567", err: true},
+		{input: "1e3", err: true},
+		{input: "", err: true},
+		{input: "-", err: true},
+		{input: "-000999999999999.999999", want: "-999999999999.999999"},
+		{input: "1000000000000", err: true},
+		{input: "99999999999999999999", err: true},
+	}
+	for _, test := range tests {
+		got, err := ParseDecimal(test.input)
+		if test.err {
+			if err == nil {
+				t.Errorf("ParseDecimal(%q) = %s, want an error", test.input, got)
+			}
+			continue
+		}
+		if err != nil || got.String() != test.want {
+			t.Errorf("ParseDecimal(%q) = %s, %v, want %s", test.input, got, err, test.want)
+		}
+	}
+}
+
+func TestDecimalAddSub(t *testing.T) {
+	tests := []struct {
+		a, b     Decimal
+		sum      string
+		diff     string
+		overflow bool
+	}{
+		{a: MustParseDecimal("1.5"), b: MustParseDecimal("2.25"), sum: "3.75", diff: "-0.75"},
+		{a: MustParseDecimal("-1"), b: MustParseDecimal("0.000001"), sum: "-0.999999", diff: "-1.000001"},
+		{a: MustParseDecimal("999999999999.999999"), b: MustParseDecimal("0.000001"), sum: "1000000000000.000000", diff: "999999999999.999998"},
+		// The exact sum does not fit with six digits, so fractional digits
+		// are given up rather than wrapping around.
+		{a: Decimal{units: 5000000000000000001, scale: 6}, b: Decimal{units: 5000000000000000001, scale: 6}, sum: "10000000000000.00000", diff: "0"},
+		{a: Decimal{units: math.MaxInt64}, b: NewDecimalFromInt(1), overflow: true},
+	}
+	for _, test := range tests {
+		a, b := test.a, test.b
+		if test.overflow {
+			if _, err := a.add(b); !errors.Is(err, ErrDecimalOverflow) {
+				t.Errorf("%s + %s: err = %v, want ErrDecimalOverflow", a, b, err)
+			}
+			continue
+		}
+		if got := a.Add(b).String(); got != test.sum {
+			t.Errorf("%s + %s = %s, want %s", a, b, got, test.sum)
+		}
+		if got := a.Sub(b); !got.Equal(MustParseDecimal(test.diff)) {
+			t.Errorf("%s - %s = %s, want %s", a, b, got, test.diff)
+		}
+	}
+}
+
+func TestDecimalSumOfLargestValues(t *testing.T) {
+	largest := MustParseDecimal("999999999999.999999")
+	sum := Decimal{}
+	for i := 0; i < 100000; i++ {
+		sum = sum.Add(largest)
+	}
+	// Beyond nine values the sum gives up fractional digits instead of
+	// overflowing.
+	if got, want := sum.String(), "100000000000000000.0"; got != want {
+		t.Errorf("sum of 100000 largest values = %s, want %s", got, want)
+	}
+}
+
+func TestDecimalMul(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		want     string
+		overflow bool
+	}{
+		{a: "1.5", b: "2", want: "3"},
+		{a: "-0.001", b: "0.001", want: "-0.000001"},
+		{a: "0.000005", b: "0.1", want: "0.000001"},
+		{a: "0.000004", b: "0.1", want: "0"},
+		{a: "0.123456", b: "0.123456", want: "0.015241"},
+		{a: "-0.123456", b: "0.5", want: "-0.061728"},
+		// Both operands carry six digits, so the raw product of their units
+		// is far beyond an int64 although the result is not.
+		{a: "100000.000001", b: "100000.000001", want: "10000000000.2"},
+		{a: "99999999999.999999", b: "1.05", want: "104999999999.999999"},
+		{a: "999999999999.999999", b: "1.05", overflow: true},
+		{a: "999999999999", b: "999999999999", overflow: true},
+	}
+	for _, test := range tests {
+		a, b := MustParseDecimal(test.a), MustParseDecimal(test.b)
+		got, err := a.Mul(b)
+		if test.overflow {
+			if !errors.Is(err, ErrDecimalOverflow) {
+				t.Errorf("%s * %s = %s, %v, want ErrDecimalOverflow", a, b, got, err)
+			}
+			continue
+		}
+		if err != nil || !got.Equal(MustParseDecimal(test.want)) {
+			t.Errorf("%s * %s = %s, %v, want %s", a, b, got, err, test.want)
+		}
+	}
+}
+
+func TestDecimalCmp(t *testing.T) {
+	largest := Decimal{units: math.MaxInt64}
+	tests := []struct {
+		a, b Decimal
+		want int
+	}{
+		{a: MustParseDecimal("1.10"), b: MustParseDecimal("1.1"), want: 0},
+		{a: MustParseDecimal("9"), b: MustParseDecimal("10"), want: -1},
+		{a: MustParseDecimal("-0.000001"), b: Decimal{}, want: -1},
+		// 9.2e18 cannot be rescaled to six digits, but still compares.
+		{a: largest, b: MustParseDecimal("0.000001"), want: 1},
+		{a: largest.Neg(), b: MustParseDecimal("0.5"), want: -1},
+		{a: MustParseDecimal("0.5"), b: largest, want: -1},
+	}
+	for _, test := range tests {
+		if got := test.a.Cmp(test.b); got != test.want {
+			t.Errorf("Cmp(%s, %s) = %d, want %d", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestDecimalRounding(t *testing.T) {
+	tests := []struct {
+		value    string
+		scale    int32
+		round    string
+		ceil     string
+		truncate string
+	}{
+		{value: "1.2345", scale: 2, round: "1.23", ceil: "1.24", truncate: "1.23"},
+		{value: "1.235", scale: 2, round: "1.24", ceil: "1.24", truncate: "1.23"},
+		{value: "-1.235", scale: 2, round: "-1.24", ceil: "-1.23", truncate: "-1.23"},
+		{value: "2", scale: 0, round: "2", ceil: "2", truncate: "2"},
+		{value: "0.001", scale: 0, round: "0", ceil: "1", truncate: "0"},
+	}
+	for _, test := range tests {
+		value := MustParseDecimal(test.value)
+		if got := value.Round(test.scale).String(); got != test.round {
+			t.Errorf("Round(%s, %d) = %s, want %s", value, test.scale, got, test.round)
+		}
+		if got := value.Ceil(test.scale).String(); got != test.ceil {
+			t.Errorf("Ceil(%s, %d) = %s, want %s", value, test.scale, got, test.ceil)
+		}
+		if got := value.Truncate(test.scale).String(); got != test.truncate {
+			t.Errorf("Truncate(%s, %d) = %s, want %s", value, test.scale, got, test.truncate)
+		}
+	}
+}
+
+func TestValidateForUnit(t *testing.T) {
+	tests := []struct {
+		value string
+		unit  string
+		ok    bool
+	}{
+		{value: "2", unit: "pcs", ok: true},
+		{value: "1.5", unit: "pcs", ok: false},
+		{value: "0.001", unit: "kg", ok: true},
+		{value: "0.0001", unit: "KG", ok: false},
+		{value: "1.250", unit: "m", ok: true},
+		{value: "0.123", unit: "unknown", ok: true},
+	}
+	for _, test := range tests {
+		err := MustParseDecimal(test.value).ValidateForUnit(test.unit)
+		if (err == nil) != test.ok {
+			t.Errorf("ValidateForUnit(%s, %s) = %v, want ok %v", test.value, test.unit, err, test.ok)
+		}
+	}
+	sum := MustParseDecimal("999999999999").Add(MustParseDecimal("1"))
+	if err := sum.ValidateForUnit("pcs"); !errors.Is(err, ErrDecimalOverflow) {
+		t.Errorf("ValidateForUnit(%s, pcs) = %v, want ErrDecimalOverflow", sum, err)
+	}
+}
+
+func TestRequiredQuantity(t *testing.T) {
+	tests := []struct {
+		quantity    string
+		quantityPer string
+		scrap       string
+		unit        string
+		want        string
+	}{
+		{quantity: "10", quantityPer: "2", scrap: "0", unit: "pcs", want: "20"},
+		{quantity: "10", quantityPer: "0.25", scrap: "0.02", unit: "kg", want: "2.55"},
+		{quantity: "3", quantityPer: "1", scrap: "0.1", unit: "pcs", want: "4"},
+		{quantity: "1", quantityPer: "0.0001", scrap: "0", unit: "kg", want: "0.001"},
+	}
+	for _, test := range tests {
+		got, err := RequiredQuantity(MustParseDecimal(test.quantity), MustParseDecimal(test.quantityPer), MustParseDecimal(test.scrap), test.unit)
+		if err != nil || !got.Equal(MustParseDecimal(test.want)) {
+			t.Errorf("RequiredQuantity(%s x %s + %s %s) = %s, %v, want %s", test.quantity, test.quantityPer, test.scrap, test.unit, got, err, test.want)
+		}
+	}
+	if _, err := RequiredQuantity(MustParseDecimal("999999999999"), MustParseDecimal("2"), MustParseDecimal("0"), "pcs"); !errors.Is(err, ErrDecimalOverflow) {
+		t.Errorf("overflowing requirement: %v, want ErrDecimalOverflow", err)
+	}
+}
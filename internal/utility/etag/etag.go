@@ -0,0 +1,88 @@
+package etag
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+)
+
+var ErrInvalidIfMatch = errors.New("invalid If-Match header")
+
+// Format renders a resource version as a strong entity tag.
+func Format(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// Set writes the ETag header for the given resource version.
+func Set(w http.ResponseWriter, version int64) {
+	if version > 0 {
+		w.Header().Set("ETag", Format(version))
+	}
+}
+
+// ParseIfMatch returns the version requested through the If-Match header.
+// A missing header or "*" yields 0, which means the write is unconditional.
+func ParseIfMatch(r *http.Request) (int64, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+	// Only a single entity tag is meaningful since each resource has one version.
+	if i := strings.Index(value, ","); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	value = strings.TrimPrefix(value, "W/")
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		return 0, ErrInvalidIfMatch
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, ErrInvalidIfMatch
+	}
+	return version, nil
+}
+
+// WritePreconditionFailed responds with 412 and the current representation
+// of the resource so the client can merge its changes and retry.
+func WritePreconditionFailed(w http.ResponseWriter, version int64, current interface{}) {
+	Set(w, version)
+	w.WriteHeader(http.StatusPreconditionFailed)
+	if current != nil {
+		json.NewEncoder(w).Encode(current)
+	}
+}
+
+// Current loads the version and representation a resource has now, so that a
+// write that lost a version race can be answered with them.
+type Current func() (version int64, resource interface{}, err error)
+
+// WriteError answers a failed write: a version conflict with 412 and the
+// representation returned by current, a missing resource with 404 and an
+// error found in statuses with the status it maps to. Anything else is a 500.
+func WriteError(w http.ResponseWriter, err error, current Current, statuses map[error]int) {
+	switch {
+	case errors.Is(err, customerrors.ErrVersionConflict):
+		version, resource, getErr := current()
+		if getErr != nil {
+			http.Error(w, getErr.Error(), http.StatusNotFound)
+			return
+		}
+		WritePreconditionFailed(w, version, resource)
+		return
+	case errors.Is(err, customerrors.ErrResourceNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	for target, status := range statuses {
+		if errors.Is(err, target) {
+			http.Error(w, err.Error(), status)
+			return
+		}
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
@@ -0,0 +1,158 @@
+// Package gs1 builds GS1-128 element strings from application identifiers.
+package gs1
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+const (
+	AI_SSCC        = "00"
+	AI_GTIN        = "01"
+	AI_BATCH       = "10"
+	AI_EXPIRY      = "17"
+	AI_SERIAL      = "21"
+	AI_COUNT       = "30"
+	AI_NET_WEIGHT  = "310" // followed by the number of decimals, e.g. 3103
+	AI_NET_LENGTH  = "311"
+	AI_NET_VOLUME  = "315"
+	FNC1           = 'ñ'
+	maxVariableLen = 20
+)
+
+// fixedLengths lists the data length of application identifiers that never
+// need a separator.
+var fixedLengths = map[string]int{
+	AI_SSCC:   18,
+	AI_GTIN:   14,
+	AI_EXPIRY: 6,
+}
+
+// charset82 is the GS1 AI encodable character set 82, the characters allowed
+// in the data of alphanumeric application identifiers.
+const charset82 = "!\"%&'()*+,-./0123456789:;<=>?ABCDEFGHIJKLMNOPQRSTUVWXYZ_abcdefghijklmnopqrstuvwxyz"
+
+var ErrInvalidElement = errors.New("invalid gs1 element")
+
+type Element struct {
+	AI    string
+	Value string
+}
+
+func (e Element) fixed() bool {
+	_, ok := fixedLengths[e.AI]
+	return ok || len(e.AI) == 4 && strings.HasPrefix(e.AI, "31")
+}
+
+func (e Element) validate() error {
+	if length, ok := fixedLengths[e.AI]; ok && len(e.Value) != length {
+		return fmt.Errorf("%w: AI (%s) needs %d characters", ErrInvalidElement, e.AI, length)
+	}
+	if e.Value == "" || !e.fixed() && utf8.RuneCountInString(e.Value) > maxVariableLen {
+		return fmt.Errorf("%w: AI (%s) has an invalid length", ErrInvalidElement, e.AI)
+	}
+	for _, r := range e.Value {
+		if !strings.ContainsRune(charset82, r) {
+			return fmt.Errorf("%w: AI (%s) contains %q", ErrInvalidElement, e.AI, r)
+		}
+	}
+	return nil
+}
+
+// ElementString is an ordered set of elements. Fixed length elements are
+// placed first so that only the variable length ones need separators.
+type ElementString []Element
+
+func (s ElementString) sorted() ElementString {
+	sorted := append(ElementString{}, s...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].fixed() && !sorted[j].fixed()
+	})
+	return sorted
+}
+
+func (s ElementString) Validate() error {
+	if len(s) == 0 {
+		return fmt.Errorf("%w: no elements", ErrInvalidElement)
+	}
+	for _, element := range s {
+		if err := element.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Encode returns the barcode data: a leading FNC1 followed by the elements,
+// with FNC1 after every variable length element except the last.
+func (s ElementString) Encode() string {
+	sorted := s.sorted()
+	var b strings.Builder
+	b.WriteRune(FNC1)
+	for i, element := range sorted {
+		b.WriteString(element.AI)
+		b.WriteString(element.Value)
+		if !element.fixed() && i < len(sorted)-1 {
+			b.WriteRune(FNC1)
+		}
+	}
+	return b.String()
+}
+
+// HumanReadable returns the interpretation printed below the barcode, such
+// as "(17)260331(10)B1234".
+func (s ElementString) HumanReadable() string {
+	var b strings.Builder
+	for _, element := range s.sorted() {
+		b.WriteString("(" + element.AI + ")" + element.Value)
+	}
+	return b.String()
+}
+
+func Batch(batch string) Element {
+	return Element{AI: AI_BATCH, Value: batch}
+}
+
+func Expiry(at time.Time) Element {
+	return Element{AI: AI_EXPIRY, Value: at.Format("060102")}
+}
+
+// Quantity encodes a count for whole units and a net measure such as
+// (3103) for 1.250 kg for weighed, measured or liquid units.
+func Quantity(quantity customtypes.Decimal, unit string) (Element, error) {
+	if quantity.Sign() < 0 {
+		return Element{}, fmt.Errorf("%w: negative quantity", ErrInvalidElement)
+	}
+	var prefix string
+	switch strings.ToLower(unit) {
+	case "kg":
+		prefix = AI_NET_WEIGHT
+	case "m":
+		prefix = AI_NET_LENGTH
+	case "l":
+		prefix = AI_NET_VOLUME
+	default:
+		if quantity.Precision() > 0 {
+			return Element{}, fmt.Errorf("%w: count must be a whole number", ErrInvalidElement)
+		}
+		return Element{AI: AI_COUNT, Value: fmt.Sprintf("%d", quantity.IntPart())}, nil
+	}
+
+	decimals := quantity.Precision()
+	if decimals > 5 {
+		decimals = 5
+	}
+	units := quantity.Round(decimals)
+	digits := strings.Replace(units.String(), ".", "", 1)
+	digits = strings.TrimLeft(digits, "0")
+	if len(digits) > 6 {
+		return Element{}, fmt.Errorf("%w: quantity %s does not fit in 6 digits", ErrInvalidElement, quantity)
+	}
+	return Element{AI: fmt.Sprintf("%s%d", prefix, decimals), Value: strings.Repeat("0", 6-len(digits)) + digits}, nil
+}
@@ -0,0 +1,106 @@
+package gs1
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+func TestElementStringEncode(t *testing.T) {
+	expiry := Expiry(time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC))
+	tests := []struct {
+		name          string
+		elements      ElementString
+		encoded       string
+		humanReadable string
+	}{
+		{
+			name:          "fixed length elements come first",
+			elements:      ElementString{Batch("B1234"), expiry},
+			encoded:       "ñ17260331" + "10B1234",
+			humanReadable: "(17)260331(10)B1234",
+		},
+		{
+			name:          "separator between variable length elements only",
+			elements:      ElementString{Batch("B1"), {AI: AI_SERIAL, Value: "S-9"}, expiry},
+			encoded:       "ñ17260331" + "10B1ñ" + "21S-9",
+			humanReadable: "(17)260331(10)B1(21)S-9",
+		},
+	}
+	for _, test := range tests {
+		if err := test.elements.Validate(); err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		}
+		if got := test.elements.Encode(); got != test.encoded {
+			t.Errorf("%s: Encode() = %q, want %q", test.name, got, test.encoded)
+		}
+		if got := test.elements.HumanReadable(); got != test.humanReadable {
+			t.Errorf("%s: HumanReadable() = %q, want %q", test.name, got, test.humanReadable)
+		}
+	}
+}
+
+func TestElementValidate(t *testing.T) {
+	tests := []struct {
+		element Element
+		ok      bool
+	}{
+		{element: Batch("AB-12/x_y"), ok: true},
+		{element: Batch(`a"%&'*+,:;<=>?!`), ok: true},
+		{element: Batch(""), ok: false},
+		{element: Batch("B 1"), ok: false},
+		{element: Batch("B#1"), ok: false},
+		{element: Batch("B$1"), ok: false},
+		{element: Batch("B@1"), ok: false},
+		{element: Batch("B^1"), ok: false},
+		{element: Batch(`B\1`), ok: false},
+		{element: Batch("B[1]"), ok: false},
+		{element: Batch("B`1"), ok: false},
+		{element: Batch("B~1"), ok: false},
+		{element: Batch("Bé1"), ok: false},
+		{element: Batch("123456789012345678901"), ok: false},
+		{element: Element{AI: AI_GTIN, Value: "09501101530003"}, ok: true},
+		{element: Element{AI: AI_GTIN, Value: "0950110153000"}, ok: false},
+	}
+	for _, test := range tests {
+		err := test.element.validate()
+		if (err == nil) != test.ok {
+			t.Errorf("validate(%s %q) = %v, want ok %v", test.element.AI, test.element.Value, err, test.ok)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidElement) {
+			t.Errorf("validate(%s %q) = %v, want ErrInvalidElement", test.element.AI, test.element.Value, err)
+		}
+	}
+}
+
+func TestQuantity(t *testing.T) {
+	tests := []struct {
+		quantity string
+		unit     string
+		ai       string
+		value    string
+		err      bool
+	}{
+		{quantity: "12", unit: "pcs", ai: "30", value: "12"},
+		{quantity: "1.25", unit: "kg", ai: "3102", value: "000125"},
+		{quantity: "3", unit: "M", ai: "3110", value: "000003"},
+		{quantity: "0.5", unit: "l", ai: "3151", value: "000005"},
+		{quantity: "1.5", unit: "pcs", err: true},
+		{quantity: "-1", unit: "kg", err: true},
+		{quantity: "1234567", unit: "kg", err: true},
+	}
+	for _, test := range tests {
+		element, err := Quantity(customtypes.MustParseDecimal(test.quantity), test.unit)
+		if test.err {
+			if err == nil {
+				t.Errorf("Quantity(%s %s) = %v, want an error", test.quantity, test.unit, element)
+			}
+			continue
+		}
+		if err != nil || element.AI != test.ai || element.Value != test.value {
+			t.Errorf("Quantity(%s %s) = (%s)%s, %v, want (%s)%s", test.quantity, test.unit, element.AI, element.Value, err, test.ai, test.value)
+		}
+	}
+}
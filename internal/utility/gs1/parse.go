@@ -0,0 +1,126 @@
+package gs1
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GroupSeparator is how scanners transmit FNC1 inside the payload.
+const GroupSeparator = '\x1d'
+
+// symbologyIdentifiers are the AIM prefixes scanners put in front of GS1
+// payloads, e.g. "]C1" for GS1-128.
+var symbologyIdentifiers = []string{"]C1", "]e0", "]d2", "]Q3"}
+
+// IsElementString reports whether a scanned value looks like GS1 data rather
+// than a plain code.
+func IsElementString(payload string) bool {
+	for _, prefix := range symbologyIdentifiers {
+		if strings.HasPrefix(payload, prefix) {
+			return true
+		}
+	}
+	return strings.HasPrefix(payload, string(FNC1)) || strings.HasPrefix(payload, string(GroupSeparator)) || strings.HasPrefix(payload, "(")
+}
+
+// Parse reads a scanned GS1 payload. It accepts the raw form with FNC1 or
+// group separators, with or without a symbology identifier, and the human
+// readable form "(01)...(10)...".
+func Parse(payload string) (ElementString, error) {
+	for _, prefix := range symbologyIdentifiers {
+		payload = strings.TrimPrefix(payload, prefix)
+	}
+	if strings.HasPrefix(payload, "(") {
+		return parseHumanReadable(payload)
+	}
+
+	payload = strings.NewReplacer(string(FNC1), string(GroupSeparator)).Replace(payload)
+	payload = strings.TrimLeft(payload, string(GroupSeparator))
+
+	var elements ElementString
+	for payload != "" {
+		ai, err := readAI(payload)
+		if err != nil {
+			return nil, err
+		}
+		payload = payload[len(ai):]
+
+		element := Element{AI: ai}
+		if element.fixed() {
+			length := 6
+			if fixed, ok := fixedLengths[ai]; ok {
+				length = fixed
+			}
+			if len(payload) < length {
+				return nil, fmt.Errorf("%w: AI (%s) needs %d characters", ErrInvalidElement, ai, length)
+			}
+			element.Value, payload = payload[:length], payload[length:]
+		} else if end := strings.IndexRune(payload, GroupSeparator); end >= 0 {
+			element.Value, payload = payload[:end], payload[end:]
+		} else {
+			element.Value, payload = payload, ""
+		}
+		payload = strings.TrimLeft(payload, string(GroupSeparator))
+
+		if err := element.validate(); err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+	}
+	return elements, elements.Validate()
+}
+
+func parseHumanReadable(payload string) (ElementString, error) {
+	var elements ElementString
+	for payload != "" {
+		end := strings.IndexByte(payload, ')')
+		if payload[0] != '(' || end < 0 {
+			return nil, fmt.Errorf("%w: malformed %q", ErrInvalidElement, payload)
+		}
+		element := Element{AI: payload[1:end]}
+		payload = payload[end+1:]
+		if next := strings.IndexByte(payload, '('); next >= 0 {
+			element.Value, payload = payload[:next], payload[next:]
+		} else {
+			element.Value, payload = payload, ""
+		}
+		if _, err := readAI(element.AI); err != nil {
+			return nil, err
+		}
+		if err := element.validate(); err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+	}
+	return elements, elements.Validate()
+}
+
+// readAI returns the application identifier at the start of data. Only the
+// identifiers this package can produce are recognised.
+func readAI(data string) (string, error) {
+	if strings.HasPrefix(data, "31") && len(data) >= 4 {
+		switch data[:3] {
+		case AI_NET_WEIGHT, AI_NET_LENGTH, AI_NET_VOLUME:
+			if data[3] >= '0' && data[3] <= '5' {
+				return data[:4], nil
+			}
+		}
+	}
+	if len(data) >= 2 {
+		switch data[:2] {
+		case AI_SSCC, AI_GTIN, AI_BATCH, AI_EXPIRY, AI_SERIAL, AI_COUNT:
+			return data[:2], nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown application identifier in %q", ErrInvalidElement, data)
+}
+
+// Get returns the value of the first element with the given AI.
+func (s ElementString) Get(ai string) (string, bool) {
+	for _, element := range s {
+		if element.AI == ai {
+			return element.Value, true
+		}
+	}
+	return "", false
+}
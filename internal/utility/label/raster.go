@@ -0,0 +1,140 @@
+package label
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// Rasterize draws the label as a grayscale image at the layout resolution.
+func Rasterize(layout *Layout, data *Data) (*image.Gray, error) {
+	img := image.NewGray(image.Rect(0, 0, layout.dots(layout.Width), layout.dots(layout.Height)))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	for _, element := range layout.Elements {
+		value, humanReadable, err := data.content(element)
+		if err != nil {
+			return nil, err
+		}
+		x, y, height := layout.dots(element.X), layout.dots(element.Y), layout.dots(element.Height)
+
+		if element.Type == ELEMENT_TEXT {
+			drawText(img, value, x, y, height)
+			continue
+		}
+		if value == "" {
+			continue
+		}
+
+		code, err := encodeBarcode(element.Symbology, value)
+		if err != nil {
+			return nil, err
+		}
+		textHeight := 0
+		if element.HumanReadable && element.Symbology != QR {
+			textHeight = height / 5
+		}
+		width := layout.dots(element.Width)
+		if element.Symbology == QR || width <= 0 {
+			width = height - textHeight
+		}
+		scaled, err := scaleBarcode(code, width, height-textHeight)
+		if err != nil {
+			return nil, err
+		}
+		draw.Draw(img, scaled.Bounds().Add(image.Pt(x, y)), scaled, scaled.Bounds().Min, draw.Src)
+		if textHeight > 0 {
+			drawText(img, humanReadable, x, y+height-textHeight, textHeight)
+		}
+	}
+	return img, nil
+}
+
+func WritePNG(w io.Writer, layout *Layout, data *Data) error {
+	img, err := Rasterize(layout, data)
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, img)
+}
+
+func encodeBarcode(symbology Symbology, value string) (barcode.Barcode, error) {
+	if symbology == QR {
+		return qr.Encode(value, qr.M, qr.Auto)
+	}
+	return code128.Encode(value)
+}
+
+// scaleBarcode scales by whole modules so bars stay crisp, growing the
+// requested size when it is narrower than one dot per module.
+func scaleBarcode(code barcode.Barcode, width int, height int) (barcode.Barcode, error) {
+	bounds := code.Bounds()
+	if width < bounds.Dx() {
+		width = bounds.Dx()
+	}
+	if code.Metadata().Dimensions == 2 && height < bounds.Dy() {
+		height = bounds.Dy()
+	}
+	if height < 1 {
+		height = 1
+	}
+	modules := width / bounds.Dx()
+	if code.Metadata().Dimensions == 2 {
+		if rows := height / bounds.Dy(); rows < modules {
+			modules = rows
+		}
+		return barcode.Scale(code, bounds.Dx()*modules, bounds.Dy()*modules)
+	}
+	return barcode.Scale(code, bounds.Dx()*modules, height)
+}
+
+// drawText renders text with the built-in bitmap face, scaled by a whole
+// factor to approximate the requested height in dots.
+func drawText(dst *image.Gray, text string, x int, y int, height int) {
+	if text == "" {
+		return
+	}
+	face := basicfont.Face7x13
+	scale := height / face.Height
+	if scale < 1 {
+		scale = 1
+	}
+
+	width := font.MeasureString(face, text).Ceil()
+	src := image.NewGray(image.Rect(0, 0, width, face.Height))
+	draw.Draw(src, src.Bounds(), image.White, image.Point{}, draw.Src)
+	drawer := &font.Drawer{
+		Dst:  src,
+		Src:  image.NewUniform(color.Black),
+		Face: face,
+		Dot:  fixed.P(0, face.Ascent),
+	}
+	drawer.DrawString(text)
+
+	bounds := dst.Bounds()
+	for sy := 0; sy < face.Height; sy++ {
+		for sx := 0; sx < width; sx++ {
+			value := src.GrayAt(sx, sy)
+			if value.Y == 0xff {
+				continue
+			}
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					point := image.Pt(x+sx*scale+dx, y+sy*scale+dy)
+					if point.In(bounds) {
+						dst.SetGray(point.X, point.Y, value)
+					}
+				}
+			}
+		}
+	}
+}
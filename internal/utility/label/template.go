@@ -0,0 +1,156 @@
+// Package label renders label layouts to PNG, PDF and ZPL.
+package label
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"text/template"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/gs1"
+)
+
+type ElementType string
+
+const (
+	ELEMENT_TEXT    ElementType = "text"
+	ELEMENT_BARCODE ElementType = "barcode"
+)
+
+type Symbology string
+
+const (
+	CODE128 Symbology = "code128"
+	GS1_128 Symbology = "gs1-128"
+	QR      Symbology = "qr"
+)
+
+const (
+	FormatPNG = "png"
+	FormatPDF = "pdf"
+	FormatZPL = "zpl"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported label format")
+
+func ContentType(format string) string {
+	switch format {
+	case FormatPNG:
+		return "image/png"
+	case FormatPDF:
+		return "application/pdf"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
+
+// Element is a text or barcode placed on the label. Positions and sizes are
+// in millimetres from the top left corner. Value is a text/template evaluated
+// against the label fields, e.g. "{{.Code}}"; GS1-128 barcodes are built from
+// the GS1 elements of the label instead.
+type Element struct {
+	Type          ElementType `json:"type"`
+	Symbology     Symbology   `json:"symbology,omitempty"`
+	X             float64     `json:"x"`
+	Y             float64     `json:"y"`
+	Width         float64     `json:"width,omitempty"`
+	Height        float64     `json:"height"`
+	Value         string      `json:"value,omitempty"`
+	HumanReadable bool        `json:"human_readable,omitempty"`
+}
+
+// Elements is stored as a JSON column.
+type Elements []Element
+
+func (e *Elements) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*e = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, e)
+	case string:
+		return json.Unmarshal([]byte(v), e)
+	default:
+		return fmt.Errorf("elements: unsupported type %T", value)
+	}
+}
+
+func (e Elements) Value() (driver.Value, error) {
+	data, err := json.Marshal(e)
+	return string(data), err
+}
+
+// Layout describes the size of a label and what is printed on it.
+type Layout struct {
+	Width    float64  `json:"width"`
+	Height   float64  `json:"height"`
+	DPI      int      `json:"dpi"`
+	Elements Elements `json:"elements"`
+}
+
+func (l *Layout) Validate() error {
+	if l.Width <= 0 || l.Height <= 0 {
+		return errors.New("label width and height are required")
+	}
+	if l.DPI < 72 || l.DPI > 600 {
+		return errors.New("label dpi must be between 72 and 600")
+	}
+	if len(l.Elements) == 0 {
+		return errors.New("label needs at least one element")
+	}
+	for _, element := range l.Elements {
+		switch element.Type {
+		case ELEMENT_TEXT:
+		case ELEMENT_BARCODE:
+			switch element.Symbology {
+			case CODE128, GS1_128, QR:
+			default:
+				return fmt.Errorf("unsupported symbology %q", element.Symbology)
+			}
+		default:
+			return fmt.Errorf("unsupported element type %q", element.Type)
+		}
+		if element.Height <= 0 {
+			return errors.New("element height is required")
+		}
+		if element.Type == ELEMENT_TEXT || element.Symbology != GS1_128 {
+			if _, err := template.New("").Parse(element.Value); err != nil {
+				return fmt.Errorf("invalid element value: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (l *Layout) dots(mm float64) int {
+	return int(mm*float64(l.DPI)/25.4 + 0.5)
+}
+
+// Data is what a label is printed from.
+type Data struct {
+	Fields map[string]string
+	GS1    gs1.ElementString
+}
+
+// content returns the text or barcode data of element along with the text
+// printed under a barcode.
+func (d *Data) content(element Element) (string, string, error) {
+	if element.Type == ELEMENT_BARCODE && element.Symbology == GS1_128 {
+		if err := d.GS1.Validate(); err != nil {
+			return "", "", err
+		}
+		return d.GS1.Encode(), d.GS1.HumanReadable(), nil
+	}
+	tmpl, err := template.New("").Option("missingkey=zero").Parse(element.Value)
+	if err != nil {
+		return "", "", err
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, d.Fields); err != nil {
+		return "", "", err
+	}
+	return b.String(), b.String(), nil
+}
@@ -0,0 +1,71 @@
+package label
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// zplEscaper hex-encodes the characters that ZPL treats as commands or
+// escapes inside field data, which ^FH enables.
+var zplEscaper = strings.NewReplacer("_", "_5F", "^", "_5E", "~", "_7E", "\\", "_5C")
+
+// WriteZPL writes the label as ZPL II for Zebra compatible printers.
+func WriteZPL(w io.Writer, layout *Layout, data *Data) error {
+	var b strings.Builder
+	b.WriteString("^XA\n")
+	fmt.Fprintf(&b, "^PW%d\n^LL%d\n^CI28\n", layout.dots(layout.Width), layout.dots(layout.Height))
+
+	for _, element := range layout.Elements {
+		value, humanReadable, err := data.content(element)
+		if err != nil {
+			return err
+		}
+		if value == "" {
+			continue
+		}
+		x, y, height := layout.dots(element.X), layout.dots(element.Y), layout.dots(element.Height)
+		interpretation := "N"
+		if element.HumanReadable {
+			interpretation = "Y"
+		}
+
+		fmt.Fprintf(&b, "^FO%d,%d", x, y)
+		switch {
+		case element.Type == ELEMENT_TEXT:
+			fmt.Fprintf(&b, "^A0N,%d,%d^FH^FD%s^FS\n", height, height, zplEscaper.Replace(value))
+		case element.Symbology == QR:
+			magnification := height / 30
+			if magnification < 1 {
+				magnification = 1
+			}
+			if magnification > 10 {
+				magnification = 10
+			}
+			fmt.Fprintf(&b, "^BQN,2,%d^FH^FDMA,%s^FS\n", magnification, zplEscaper.Replace(value))
+		case element.Symbology == GS1_128:
+			// Mode D inserts FNC1 itself from the parenthesised AIs.
+			fmt.Fprintf(&b, "^BY2^BCN,%d,%s,N,N,D^FH^FD%s^FS\n", height, interpretation, zplEscaper.Replace(humanReadable))
+		default:
+			fmt.Fprintf(&b, "^BY2^BCN,%d,%s,N,N^FH^FD%s^FS\n", height, interpretation, zplEscaper.Replace(value))
+		}
+	}
+
+	b.WriteString("^XZ\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Write renders the label in format.
+func Write(w io.Writer, format string, layout *Layout, data *Data) error {
+	switch format {
+	case FormatPNG:
+		return WritePNG(w, layout, data)
+	case FormatPDF:
+		return WritePDF(w, layout, data)
+	case FormatZPL:
+		return WriteZPL(w, layout, data)
+	default:
+		return ErrUnsupportedFormat
+	}
+}
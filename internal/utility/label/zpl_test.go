@@ -0,0 +1,21 @@
+package label
+
+import "testing"
+
+func TestZPLEscaper(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "B-1234", want: "B-1234"},
+		{value: "^XZ^XA", want: "_5EXZ_5EXA"},
+		{value: "~JR", want: "_7EJR"},
+		{value: `a\b`, want: "a_5Cb"},
+		{value: "_5E", want: "_5F5E"},
+	}
+	for _, test := range tests {
+		if got := zplEscaper.Replace(test.value); got != test.want {
+			t.Errorf("escape(%q) = %q, want %q", test.value, got, test.want)
+		}
+	}
+}
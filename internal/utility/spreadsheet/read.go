@@ -0,0 +1,292 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// MaxReadRows guards against files that would not fit in memory.
+const MaxReadRows = 200000
+
+// MaxReadColumns is the widest sheet Excel can hold (column XFD).
+const MaxReadColumns = 16384
+
+// maxPartSize bounds the uncompressed size of every XLSX part that is read,
+// allowing a generous 2 KiB of XML per row, so a small compressed upload
+// cannot expand without limit.
+const maxPartSize = MaxReadRows * 2048
+
+var (
+	ErrTooManyRows    = fmt.Errorf("spreadsheet has more than %d rows", MaxReadRows)
+	ErrTooManyColumns = fmt.Errorf("spreadsheet has more than %d columns", MaxReadColumns)
+	ErrPartTooLarge   = fmt.Errorf("xlsx part is larger than %d bytes", maxPartSize)
+)
+
+// Table is a sheet as read back from a file. Every cell is text; the first
+// row of the file becomes Headers.
+type Table struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// Read parses the first sheet of a CSV or XLSX file.
+func Read(r io.ReaderAt, size int64, format string) (*Table, error) {
+	switch format {
+	case FormatCSV:
+		return ReadCSV(io.NewSectionReader(r, 0, size))
+	case FormatXLSX:
+		return ReadXLSX(r, size)
+	default:
+		return nil, fmt.Errorf("unsupported spreadsheet format %q", format)
+	}
+}
+
+// FormatOf guesses the format from a file name.
+func FormatOf(fileName string) string {
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".xlsx":
+		return FormatXLSX
+	case ".csv":
+		return FormatCSV
+	default:
+		return ""
+	}
+}
+
+func ReadCSV(r io.Reader) (*Table, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	return newTable(records)
+}
+
+func newTable(records [][]string) (*Table, error) {
+	if len(records) == 0 {
+		return nil, errors.New("spreadsheet is empty")
+	}
+	if len(records) > MaxReadRows+1 {
+		return nil, ErrTooManyRows
+	}
+	headers := records[0]
+	if len(headers) > 0 {
+		// Excel saves CSV files with a byte order mark
+		headers[0] = strings.TrimPrefix(headers[0], "\ufeff")
+	}
+	return &Table{Headers: headers, Rows: records[1:]}, nil
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RelationshipID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRichText struct {
+	Text string `xml:",chardata"`
+}
+
+type xlsxString struct {
+	Text string         `xml:"t"`
+	Runs []xlsxRichText `xml:"r>t"`
+}
+
+func (s xlsxString) String() string {
+	if len(s.Runs) == 0 {
+		return s.Text
+	}
+	var b strings.Builder
+	for _, run := range s.Runs {
+		b.WriteString(run.Text)
+	}
+	return b.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxString `xml:"si"`
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Number int `xml:"r,attr"`
+		Cells  []struct {
+			Ref    string     `xml:"r,attr"`
+			Type   string     `xml:"t,attr"`
+			Value  string     `xml:"v"`
+			Inline xlsxString `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// ReadXLSX reads the first worksheet of a workbook. Formulas are read as
+// their cached values and dates as the serial numbers Excel stores.
+func ReadXLSX(r io.ReaderAt, size int64) (*Table, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var shared xlsxSharedStrings
+	if file, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeXMLPart(file, &shared); err != nil {
+			return nil, err
+		}
+	}
+
+	var worksheet xlsxWorksheet
+	file, ok := files[sheetPath]
+	if !ok {
+		return nil, errors.New("xlsx worksheet not found: " + sheetPath)
+	}
+	if err := decodeXMLPart(file, &worksheet); err != nil {
+		return nil, err
+	}
+
+	var records [][]string
+	for _, row := range worksheet.Rows {
+		if row.Number > MaxReadRows+1 {
+			return nil, ErrTooManyRows
+		}
+		// Rows may be sparse; keep the file row numbers intact
+		for row.Number > len(records)+1 {
+			records = append(records, nil)
+		}
+		if len(records) > MaxReadRows+1 {
+			return nil, ErrTooManyRows
+		}
+		var record []string
+		for i, cell := range row.Cells {
+			column := i
+			if cell.Ref != "" {
+				if column, err = columnIndex(cell.Ref); err != nil {
+					return nil, err
+				}
+			}
+			if column >= MaxReadColumns {
+				return nil, ErrTooManyColumns
+			}
+			for len(record) <= column {
+				record = append(record, "")
+			}
+			switch cell.Type {
+			case "s":
+				index, err := strconv.Atoi(cell.Value)
+				if err != nil || index < 0 || index >= len(shared.Items) {
+					return nil, errors.New("xlsx cell " + cell.Ref + " refers to a missing shared string")
+				}
+				record[column] = shared.Items[index].String()
+			case "inlineStr":
+				record[column] = cell.Inline.String()
+			default:
+				record[column] = cell.Value
+			}
+		}
+		records = append(records, record)
+	}
+	return newTable(records)
+}
+
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	workbookFile, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", errors.New("not an xlsx workbook")
+	}
+	var workbook xlsxWorkbook
+	if err := decodeXMLPart(workbookFile, &workbook); err != nil {
+		return "", err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", errors.New("xlsx workbook has no sheets")
+	}
+
+	relsFile, ok := files["xl/_rels/workbook.xml.rels"]
+	if !ok {
+		return "xl/worksheets/sheet1.xml", nil
+	}
+	var rels xlsxRelationships
+	if err := decodeXMLPart(relsFile, &rels); err != nil {
+		return "", err
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID == workbook.Sheets[0].RelationshipID {
+			if strings.HasPrefix(rel.Target, "/") {
+				return strings.TrimPrefix(rel.Target, "/"), nil
+			}
+			return path.Join("xl", rel.Target), nil
+		}
+	}
+	return "", errors.New("xlsx first sheet not found")
+}
+
+func decodeXMLPart(file *zip.File, v interface{}) error {
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	return xml.NewDecoder(&partReader{reader: io.LimitReader(reader, maxPartSize+1)}).Decode(v)
+}
+
+// partReader fails with ErrPartTooLarge once more than maxPartSize bytes have
+// been read, rather than letting the decoder see a truncated document.
+type partReader struct {
+	reader io.Reader
+	read   int64
+}
+
+func (r *partReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+	if r.read > maxPartSize {
+		return n, ErrPartTooLarge
+	}
+	return n, err
+}
+
+// columnIndex converts a cell reference such as "AB12" to its zero based
+// column index (27).
+func columnIndex(ref string) (int, error) {
+	index := 0
+	letters := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		index = index*26 + int(r-'A'+1)
+		if index > MaxReadColumns {
+			return 0, ErrTooManyColumns
+		}
+		letters++
+	}
+	if letters == 0 {
+		return 0, errors.New("invalid xlsx cell reference " + ref)
+	}
+	return index - 1, nil
+}
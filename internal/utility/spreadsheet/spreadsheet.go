@@ -0,0 +1,122 @@
+// Package spreadsheet reads and writes tabular data as CSV or XLSX without
+// external dependencies.
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+
+	ContentTypeCSV  = "text/csv; charset=utf-8"
+	ContentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// Sheet is a single table. Cells may be strings, integers, floats, decimals,
+// booleans, times or nil.
+type Sheet struct {
+	Name    string
+	Headers []string
+	Rows    [][]interface{}
+}
+
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return ContentTypeXLSX
+	}
+	return ContentTypeCSV
+}
+
+// Write renders the sheet in the given format.
+func Write(w io.Writer, format string, sheet *Sheet) error {
+	switch format {
+	case FormatCSV:
+		return WriteCSV(w, sheet)
+	case FormatXLSX:
+		return WriteXLSX(w, sheet)
+	default:
+		return fmt.Errorf("unsupported spreadsheet format %q", format)
+	}
+}
+
+func WriteCSV(w io.Writer, sheet *Sheet) error {
+	writer := csv.NewWriter(w)
+	headers := make([]string, len(sheet.Headers))
+	for i, header := range sheet.Headers {
+		headers[i] = escapeFormula(header)
+	}
+	if err := writer.Write(headers); err != nil {
+		return err
+	}
+	for _, row := range sheet.Rows {
+		record := make([]string, len(row))
+		for i, cell := range row {
+			value, numeric := formatCell(cell)
+			if !numeric {
+				value = escapeFormula(value)
+			}
+			record[i] = value
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// escapeFormula prefixes text that a spreadsheet application would evaluate
+// as a formula with a quote, so that a product name such as "=HYPERLINK(...)"
+// opens as the text it is. Numbers are written as they are.
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// formatCell returns the textual value of a cell and whether it is numeric.
+func formatCell(cell interface{}) (string, bool) {
+	switch v := cell.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, false
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case customtypes.Decimal:
+		return v.String(), true
+	case bool:
+		if v {
+			return "TRUE", false
+		}
+		return "FALSE", false
+	case time.Time:
+		if v.IsZero() {
+			return "", false
+		}
+		return v.Format("2006-01-02 15:04:05"), false
+	case *time.Time:
+		if v == nil {
+			return "", false
+		}
+		return formatCell(*v)
+	case fmt.Stringer:
+		return v.String(), false
+	default:
+		return fmt.Sprint(v), false
+	}
+}
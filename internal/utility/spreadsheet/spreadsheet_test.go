@@ -0,0 +1,149 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customtypes"
+)
+
+func TestWriteCSVEscapesFormulas(t *testing.T) {
+	sheet := &Sheet{
+		Headers: []string{"Code", "=Name"},
+		Rows: [][]interface{}{
+			{"=HYPERLINK(\"http://x\")", customtypes.MustParseDecimal("-5")},
+			{"+1", int64(-3)},
+			{"-2", "@SUM(A1)"},
+			{"\tTab", "plain - text"},
+			{nil, ""},
+		},
+	}
+	var b bytes.Buffer
+	if err := WriteCSV(&b, sheet); err != nil {
+		t.Fatal(err)
+	}
+	want := "Code,'=Name\n" +
+		"\"'=HYPERLINK(\"\"http://x\"\")\",-5\n" +
+		"'+1,-3\n" +
+		"'-2,'@SUM(A1)\n" +
+		"'\tTab,plain - text\n" +
+		",\n"
+	if got := b.String(); got != want {
+		t.Errorf("WriteCSV wrote\n%q\nwant\n%q", got, want)
+	}
+}
+
+// xlsxWith builds a minimal workbook whose first sheet holds sheetData.
+func xlsxWith(t *testing.T, sheetData string) *bytes.Reader {
+	var b bytes.Buffer
+	archive := zip.NewWriter(&b)
+	parts := map[string]string{
+		"xl/workbook.xml":          `<workbook><sheets><sheet/></sheets></workbook>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData>` + sheetData + `</sheetData></worksheet>`,
+	}
+	for name, content := range parts {
+		part, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(part, content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(b.Bytes())
+}
+
+func TestReadXLSX(t *testing.T) {
+	tests := []struct {
+		name      string
+		sheetData string
+		rows      int
+		err       error
+	}{
+		{
+			name:      "sparse rows and cells",
+			sheetData: `<row r="1"><c r="A1" t="inlineStr"><is><t>Code</t></is></c></row><row r="3"><c r="C3"><v>7</v></c></row>`,
+			rows:      2,
+		},
+		{
+			name:      "row number beyond the limit",
+			sheetData: `<row r="1000000000"><c r="A1000000000"><v>1</v></c></row>`,
+			err:       ErrTooManyRows,
+		},
+		{
+			name:      "column beyond XFD",
+			sheetData: `<row r="1"><c r="XFE1"><v>1</v></c></row>`,
+			err:       ErrTooManyColumns,
+		},
+		{
+			name:      "column reference that would overflow",
+			sheetData: `<row r="1"><c r="ZZZZZZZZZZZZZZZ1"><v>1</v></c></row>`,
+			err:       ErrTooManyColumns,
+		},
+	}
+	for _, test := range tests {
+		file := xlsxWith(t, test.sheetData)
+		table, err := ReadXLSX(file, file.Size())
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("%s: err = %v, want %v", test.name, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if len(table.Rows) != test.rows {
+			t.Errorf("%s: read %d rows, want %d", test.name, len(table.Rows), test.rows)
+		}
+	}
+}
+
+func TestColumnIndex(t *testing.T) {
+	tests := []struct {
+		ref   string
+		index int
+		err   bool
+	}{
+		{ref: "A1", index: 0},
+		{ref: "AB12", index: 27},
+		{ref: "XFD1", index: MaxReadColumns - 1},
+		{ref: "XFE1", err: true},
+		{ref: "12", err: true},
+	}
+	for _, test := range tests {
+		index, err := columnIndex(test.ref)
+		if test.err {
+			if err == nil {
+				t.Errorf("columnIndex(%q) = %d, want an error", test.ref, index)
+			}
+			continue
+		}
+		if err != nil || index != test.index {
+			t.Errorf("columnIndex(%q) = %d, %v, want %d", test.ref, index, err, test.index)
+		}
+	}
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+func TestPartReaderStopsAtLimit(t *testing.T) {
+	reader := &partReader{reader: io.LimitReader(zeroReader{}, maxPartSize+1)}
+	if _, err := io.Copy(io.Discard, reader); !errors.Is(err, ErrPartTooLarge) {
+		t.Errorf("reading past the part limit: %v, want ErrPartTooLarge", err)
+	}
+}
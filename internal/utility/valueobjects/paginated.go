@@ -7,3 +7,10 @@ type PaginatedResponse struct {
 	PageSize   int         `json:"page_size"`
 	TotalPages int         `json:"total_pages"`
 }
+
+type CursorPaginatedResponse struct {
+	Data       interface{} `json:"data"`
+	PageSize   int         `json:"page_size"`
+	NextCursor string      `json:"next_cursor"`
+	HasMore    bool        `json:"has_more"`
+}
@@ -0,0 +1,23 @@
+package database
+
+import "database/sql"
+
+// Execer is satisfied by both *sql.DB and *sql.Tx so that a write can be
+// shared between plain and transactional code paths.
+type Execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// WithTx runs fn inside a transaction. The transaction is committed when fn
+// returns nil and rolled back otherwise.
+func WithTx(db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,38 @@
+package database
+
+import (
+	"database/sql"
+
+	"github.com/vamika-digital/wms-api-server/internal/utility/customerrors"
+)
+
+// CheckVersionedWrite inspects the result of an UPDATE or DELETE guarded by
+// "version=?" and reports a version conflict when no row matched. A version
+// of 0 means the write was unconditional and is never treated as a conflict.
+func CheckVersionedWrite(result sql.Result, resource string, id int64, version int64) error {
+	if version <= 0 {
+		return nil
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return customerrors.NewVersionConflictError(resource, id, version)
+	}
+	return nil
+}
+
+// NextVersion returns the version a row holds after an UPDATE that sets
+// "version=LAST_INSERT_ID(version+1)". MySQL hands the new value back with the
+// result, so it is known for unconditional writes as well and the caller can
+// always return a fresh ETag.
+func NextVersion(result sql.Result, version int64) int64 {
+	if next, err := result.LastInsertId(); err == nil && next > 0 {
+		return next
+	}
+	if version > 0 {
+		return version + 1
+	}
+	return version
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -58,6 +59,10 @@ func (s *Server) Run() {
 	s.ProductModule.RegisterRoutes(r.PathPrefix("/secure").Subrouter())
 	s.WarehouseModule.RegisterRoutes(r.PathPrefix("/secure").Subrouter())
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.WarehouseModule.StartBackgroundJobs(ctx)
+
 	log.Printf("Server started on %s:%d", s.Address, s.Port)
 	server := &http.Server{
 		Addr:    fmt.Sprintf("%s:%d", s.Address, s.Port),